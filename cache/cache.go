@@ -3,7 +3,6 @@ package cache
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"kasen/config"
@@ -32,10 +31,6 @@ func init() {
 		Password: redisConfig.Passwd,
 	})
 
-	if _, err := Redis.Ping(context.Background()).Result(); err != nil {
-		log.Fatalln(err)
-	}
-
 	ProjectCache = &LRU{gcache.New(512).LRU().Expiration(time.Duration(cacheConfig.DefaultTTL)).Build()}
 	ChapterCache = &LRU{gcache.New(1024).LRU().Expiration(cacheConfig.DefaultTTL).Build()}
 	CoverCache = &LRU{gcache.New(1024).LRU().Expiration(cacheConfig.DefaultTTL).Build()}
@@ -43,3 +38,9 @@ func init() {
 	StatsCache = &LRU{gcache.New(4096).LRU().Expiration(cacheConfig.DefaultTTL).Build()}
 	TemplatesCache = &LRU{gcache.New(512).LRU().Expiration(5 * time.Minute).Build()}
 }
+
+// ConnectRedis checks the connection to Redis,
+// it must be called before using Redis.
+func ConnectRedis() error {
+	return Redis.Ping(context.Background()).Err()
+}
@@ -9,6 +9,7 @@ import (
 	"os/user"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +32,12 @@ type Config struct {
 	Service
 	Cache
 	Directories
+	Storage
+	Image
+	Jobs
+	Trash
+	OIDC
+	Mail
 }
 
 type Meta struct {
@@ -59,6 +66,18 @@ type Redis struct {
 type Security struct {
 	JWTSessionSecret []byte
 	JWTRefreshSecret []byte
+
+	JWTAlgorithm        string
+	KeyRotationInterval time.Duration
+	KeyGracePeriod      time.Duration
+
+	// LoginDelayAfter is the number of failed logins of an account after
+	// which each login is delayed, twice as long after each failure.
+	LoginDelayAfter int
+	// LoginMaxAttempts is the number of failed logins of an account
+	// after which it's locked for LoginLockout.
+	LoginMaxAttempts int
+	LoginLockout     time.Duration
 }
 
 type Server struct {
@@ -69,6 +88,15 @@ type Service struct {
 	DisableRegistration bool `json:"disableRegistration"`
 	CoverMaxFileSize    int  `json:"coverMaxFileSize"`
 	PageMaxFileSize     int  `json:"pageMaxFileSize"`
+
+	// ArchiveMaxPages and ArchiveMaxSize limit the number of pages and
+	// the uncompressed size of the archives of pages.
+	ArchiveMaxPages int   `json:"archiveMaxPages"`
+	ArchiveMaxSize  int64 `json:"archiveMaxSize"`
+
+	// TwoFactorPermissions are the permissions which
+	// require two-factor authentication to be enabled.
+	TwoFactorPermissions []string `json:"twoFactorPermissions"`
 }
 
 type Cache struct {
@@ -80,6 +108,61 @@ type Directories struct {
 	Root string
 }
 
+type Storage struct {
+	Driver          string
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKey       string
+	SecretKey       string
+	PathStyle       bool
+	PresignRedirect bool
+	PresignExpiry   time.Duration
+}
+
+type Image struct {
+	Workers   int
+	QueueSize int
+	MaxRSS    int64
+	Timeout   time.Duration
+}
+
+type Jobs struct {
+	Workers      int
+	PollInterval time.Duration
+	MaxAttempts  int
+	Backoff      time.Duration
+
+	ScheduleInterval time.Duration
+}
+
+type Trash struct {
+	Retention         time.Duration
+	RevisionRetention time.Duration
+	PurgeInterval     time.Duration
+}
+
+type OIDC struct {
+	Enabled      bool
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	LinkByEmail  bool
+}
+
+type Mail struct {
+	Transport string
+	From      string
+	Host      string
+	Port      int
+	User      string
+	Passwd    string
+	Security  string
+	Dir       string
+}
+
 //go:embed config.ini
 var buf []byte
 
@@ -96,7 +179,13 @@ func init() {
 	p := flag.String("config", "", "Path to config file")
 	m := flag.String("mode", "production", "App mode")
 
-	flag.Parse()
+	// Test binaries parse their flags once the tests start,
+	// so the config file is given by KASEN_CONFIG instead.
+	if strings.HasSuffix(strings.TrimSuffix(os.Args[0], ".exe"), ".test") {
+		*p = os.Getenv("KASEN_CONFIG")
+	} else {
+		flag.Parse()
+	}
 
 	path = *p
 	if len(path) == 0 {
@@ -170,6 +259,14 @@ func init() {
 		Security: Security{
 			JWTSessionSecret: []byte(file.Section("security").Key("jwt_session_secret").String()),
 			JWTRefreshSecret: []byte(file.Section("security").Key("jwt_refresh_secret").String()),
+
+			JWTAlgorithm:        file.Section("security").Key("jwt_algorithm").In("HS256", []string{"HS256", "EdDSA", "RS256"}),
+			KeyRotationInterval: time.Duration(file.Section("security").Key("key_rotation_interval").MustInt(2592000000000000)),
+			KeyGracePeriod:      time.Duration(file.Section("security").Key("key_grace_period").MustInt(2592000000000000)),
+
+			LoginDelayAfter:  file.Section("security").Key("login_delay_after").MustInt(3),
+			LoginMaxAttempts: file.Section("security").Key("login_max_attempts").MustInt(10),
+			LoginLockout:     time.Duration(file.Section("security").Key("login_lockout").MustInt(3600000000000)),
 		},
 
 		Server: Server{
@@ -180,6 +277,11 @@ func init() {
 			DisableRegistration: file.Section("service").Key("disable_registration").MustBool(true),
 			CoverMaxFileSize:    file.Section("service").Key("cover_max_file_size").MustInt(10485760),
 			PageMaxFileSize:     file.Section("service").Key("page_max_file_size").MustInt(20971520),
+
+			ArchiveMaxPages: file.Section("service").Key("archive_max_pages").MustInt(500),
+			ArchiveMaxSize:  file.Section("service").Key("archive_max_size").MustInt64(1073741824),
+
+			TwoFactorPermissions: file.Section("service").Key("two_factor_permissions").Strings(","),
 		},
 
 		Cache: Cache{
@@ -190,6 +292,60 @@ func init() {
 		Directories: Directories{
 			Root: file.Section("directories").Key("root").MustString("/var/lib/kasen"),
 		},
+
+		Storage: Storage{
+			Driver:          file.Section("storage").Key("driver").MustString("local"),
+			Endpoint:        file.Section("storage").Key("endpoint").String(),
+			Region:          file.Section("storage").Key("region").MustString("us-east-1"),
+			Bucket:          file.Section("storage").Key("bucket").String(),
+			AccessKey:       file.Section("storage").Key("access_key").String(),
+			SecretKey:       file.Section("storage").Key("secret_key").String(),
+			PathStyle:       file.Section("storage").Key("path_style").MustBool(true),
+			PresignRedirect: file.Section("storage").Key("presign_redirect").MustBool(true),
+			PresignExpiry:   time.Duration(file.Section("storage").Key("presign_expiry").MustInt(3600000000000)),
+		},
+
+		Image: Image{
+			Workers:   file.Section("image").Key("workers").MustInt(2),
+			QueueSize: file.Section("image").Key("queue_size").MustInt(64),
+			MaxRSS:    file.Section("image").Key("max_rss").MustInt64(536870912),
+			Timeout:   time.Duration(file.Section("image").Key("timeout").MustInt(60000000000)),
+		},
+		Jobs: Jobs{
+			Workers:      file.Section("jobs").Key("workers").MustInt(2),
+			PollInterval: time.Duration(file.Section("jobs").Key("poll_interval").MustInt(5000000000)),
+			MaxAttempts:  file.Section("jobs").Key("max_attempts").MustInt(5),
+			Backoff:      time.Duration(file.Section("jobs").Key("backoff").MustInt(30000000000)),
+
+			ScheduleInterval: time.Duration(file.Section("jobs").Key("schedule_interval").MustInt(30000000000)),
+		},
+
+		Trash: Trash{
+			Retention:         time.Duration(file.Section("trash").Key("retention").MustInt(2592000000000000)),
+			RevisionRetention: time.Duration(file.Section("trash").Key("revision_retention").MustInt(2592000000000000)),
+			PurgeInterval:     time.Duration(file.Section("trash").Key("purge_interval").MustInt(3600000000000)),
+		},
+
+		OIDC: OIDC{
+			Enabled:      file.Section("oidc").Key("enabled").MustBool(false),
+			Name:         file.Section("oidc").Key("name").MustString("OpenID Connect"),
+			Issuer:       file.Section("oidc").Key("issuer").String(),
+			ClientID:     file.Section("oidc").Key("client_id").String(),
+			ClientSecret: file.Section("oidc").Key("client_secret").String(),
+			Scopes:       file.Section("oidc").Key("scopes").Strings(" "),
+			LinkByEmail:  file.Section("oidc").Key("link_by_email").MustBool(true),
+		},
+
+		Mail: Mail{
+			Transport: file.Section("mail").Key("transport").MustString("log"),
+			From:      file.Section("mail").Key("from").MustString("Kasen <noreply@localhost>"),
+			Host:      file.Section("mail").Key("host").MustString("localhost"),
+			Port:      file.Section("mail").Key("port").MustInt(587),
+			User:      file.Section("mail").Key("user").String(),
+			Passwd:    file.Section("mail").Key("passwd").String(),
+			Security:  file.Section("mail").Key("security").MustString("starttls"),
+			Dir:       file.Section("mail").Key("dir").String(),
+		},
 	}
 
 	if len(*m) > 0 {
@@ -327,6 +483,78 @@ func SetDirectories(v Directories) {
 	config.Directories = v
 }
 
+func GetStorage() Storage {
+	config.RLock()
+	defer config.RUnlock()
+	return config.Storage
+}
+
+func SetStorage(v Storage) {
+	config.Lock()
+	defer config.Unlock()
+	config.Storage = v
+}
+
+func GetImage() Image {
+	config.RLock()
+	defer config.RUnlock()
+	return config.Image
+}
+
+func SetImage(v Image) {
+	config.Lock()
+	defer config.Unlock()
+	config.Image = v
+}
+
+func GetJobs() Jobs {
+	config.RLock()
+	defer config.RUnlock()
+	return config.Jobs
+}
+
+func SetJobs(v Jobs) {
+	config.Lock()
+	defer config.Unlock()
+	config.Jobs = v
+}
+
+func GetTrash() Trash {
+	config.RLock()
+	defer config.RUnlock()
+	return config.Trash
+}
+
+func SetTrash(v Trash) {
+	config.Lock()
+	defer config.Unlock()
+	config.Trash = v
+}
+
+func GetOIDC() OIDC {
+	config.RLock()
+	defer config.RUnlock()
+	return config.OIDC
+}
+
+func SetOIDC(v OIDC) {
+	config.Lock()
+	defer config.Unlock()
+	config.OIDC = v
+}
+
+func GetMail() Mail {
+	config.RLock()
+	defer config.RUnlock()
+	return config.Mail
+}
+
+func SetMail(v Mail) {
+	config.Lock()
+	defer config.Unlock()
+	config.Mail = v
+}
+
 func Save() error {
 	config.Lock()
 	defer config.Unlock()
@@ -353,17 +581,68 @@ func Save() error {
 
 	config.Section("security").Key("jwt_session_secret").SetValue(string(config.Security.JWTSessionSecret))
 	config.Section("security").Key("jwt_refresh_secret").SetValue(string(config.Security.JWTRefreshSecret))
+	config.Section("security").Key("jwt_algorithm").SetValue(config.Security.JWTAlgorithm)
+	config.Section("security").Key("key_rotation_interval").SetValue(strconv.Itoa(int(config.Security.KeyRotationInterval)))
+	config.Section("security").Key("key_grace_period").SetValue(strconv.Itoa(int(config.Security.KeyGracePeriod)))
+	config.Section("security").Key("login_delay_after").SetValue(strconv.Itoa(config.Security.LoginDelayAfter))
+	config.Section("security").Key("login_max_attempts").SetValue(strconv.Itoa(config.Security.LoginMaxAttempts))
+	config.Section("security").Key("login_lockout").SetValue(strconv.Itoa(int(config.Security.LoginLockout)))
 
 	config.Section("server").Key("port").SetValue(strconv.Itoa(config.Server.Port))
 
 	config.Section("service").Key("disable_registration").SetValue(strconv.FormatBool(config.Service.DisableRegistration))
 	config.Section("service").Key("cover_max_file_size").SetValue(strconv.Itoa(config.Service.CoverMaxFileSize))
 	config.Section("service").Key("page_max_file_size").SetValue(strconv.Itoa(config.Service.PageMaxFileSize))
+	config.Section("service").Key("archive_max_pages").SetValue(strconv.Itoa(config.Service.ArchiveMaxPages))
+	config.Section("service").Key("archive_max_size").SetValue(strconv.FormatInt(config.Service.ArchiveMaxSize, 10))
+	config.Section("service").Key("two_factor_permissions").SetValue(strings.Join(config.Service.TwoFactorPermissions, ","))
 
 	config.Section("cache").Key("default_ttl").SetValue(strconv.Itoa(int(config.Cache.DefaultTTL)))
 	config.Section("cache").Key("templates_ttl").SetValue(strconv.Itoa(int(config.Cache.TemplatesTTL)))
 
 	config.Section("directories").Key("root").SetValue(config.Directories.Root)
 
+	config.Section("storage").Key("driver").SetValue(config.Storage.Driver)
+	config.Section("storage").Key("endpoint").SetValue(config.Storage.Endpoint)
+	config.Section("storage").Key("region").SetValue(config.Storage.Region)
+	config.Section("storage").Key("bucket").SetValue(config.Storage.Bucket)
+	config.Section("storage").Key("access_key").SetValue(config.Storage.AccessKey)
+	config.Section("storage").Key("secret_key").SetValue(config.Storage.SecretKey)
+	config.Section("storage").Key("path_style").SetValue(strconv.FormatBool(config.Storage.PathStyle))
+	config.Section("storage").Key("presign_redirect").SetValue(strconv.FormatBool(config.Storage.PresignRedirect))
+	config.Section("storage").Key("presign_expiry").SetValue(strconv.Itoa(int(config.Storage.PresignExpiry)))
+
+	config.Section("image").Key("workers").SetValue(strconv.Itoa(config.Image.Workers))
+	config.Section("image").Key("queue_size").SetValue(strconv.Itoa(config.Image.QueueSize))
+	config.Section("image").Key("max_rss").SetValue(strconv.FormatInt(config.Image.MaxRSS, 10))
+	config.Section("image").Key("timeout").SetValue(strconv.Itoa(int(config.Image.Timeout)))
+
+	config.Section("jobs").Key("workers").SetValue(strconv.Itoa(config.Jobs.Workers))
+	config.Section("jobs").Key("poll_interval").SetValue(strconv.Itoa(int(config.Jobs.PollInterval)))
+	config.Section("jobs").Key("max_attempts").SetValue(strconv.Itoa(config.Jobs.MaxAttempts))
+	config.Section("jobs").Key("backoff").SetValue(strconv.Itoa(int(config.Jobs.Backoff)))
+	config.Section("jobs").Key("schedule_interval").SetValue(strconv.Itoa(int(config.Jobs.ScheduleInterval)))
+
+	config.Section("trash").Key("retention").SetValue(strconv.Itoa(int(config.Trash.Retention)))
+	config.Section("trash").Key("revision_retention").SetValue(strconv.Itoa(int(config.Trash.RevisionRetention)))
+	config.Section("trash").Key("purge_interval").SetValue(strconv.Itoa(int(config.Trash.PurgeInterval)))
+
+	config.Section("oidc").Key("enabled").SetValue(strconv.FormatBool(config.OIDC.Enabled))
+	config.Section("oidc").Key("name").SetValue(config.OIDC.Name)
+	config.Section("oidc").Key("issuer").SetValue(config.OIDC.Issuer)
+	config.Section("oidc").Key("client_id").SetValue(config.OIDC.ClientID)
+	config.Section("oidc").Key("client_secret").SetValue(config.OIDC.ClientSecret)
+	config.Section("oidc").Key("scopes").SetValue(strings.Join(config.OIDC.Scopes, " "))
+	config.Section("oidc").Key("link_by_email").SetValue(strconv.FormatBool(config.OIDC.LinkByEmail))
+
+	config.Section("mail").Key("transport").SetValue(config.Mail.Transport)
+	config.Section("mail").Key("from").SetValue(config.Mail.From)
+	config.Section("mail").Key("host").SetValue(config.Mail.Host)
+	config.Section("mail").Key("port").SetValue(strconv.Itoa(config.Mail.Port))
+	config.Section("mail").Key("user").SetValue(config.Mail.User)
+	config.Section("mail").Key("passwd").SetValue(config.Mail.Passwd)
+	config.Section("mail").Key("security").SetValue(config.Mail.Security)
+	config.Section("mail").Key("dir").SetValue(config.Mail.Dir)
+
 	return config.SaveTo(path)
 }
@@ -0,0 +1,51 @@
+package constants
+
+const (
+	RoleAdmin    = "Admin"
+	RoleEditor   = "Editor"
+	RoleUploader = "Uploader"
+	RoleQC       = "QC"
+)
+
+// Roles of the members of a scanlation group.
+const (
+	ScanlationGroupLeader = "leader"
+	ScanlationGroupMember = "member"
+)
+
+var Roles = []string{
+	RoleAdmin,
+	RoleEditor,
+	RoleUploader,
+	RoleQC,
+}
+
+// DefaultRoles are the roles created if they don't exist yet,
+// they can be edited or deleted afterwards.
+var DefaultRoles = map[string][]string{
+	RoleAdmin: Perms,
+	RoleEditor: append(append([]string{}, PermsProject...),
+		PermEditUser,
+		PermDeleteUser,
+	),
+	RoleUploader: {
+		PermCreateProject,
+		PermUploadCover,
+		PermSetCover,
+		PermCreateChapter,
+		PermEditChapter,
+		PermLockChapter,
+		PermPublishChapter,
+		PermUnlockChapter,
+		PermUnpublishChapter,
+		PermEditUser,
+		PermDeleteUser,
+	},
+	RoleQC: {
+		PermEditChapters,
+		PermLockChapters,
+		PermUnlockChapters,
+		PermEditUser,
+		PermDeleteUser,
+	},
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func CreateAccessToken(c *server.Context) {
+	// Access tokens can't be used to create other tokens, which
+	// would outlive them.
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	draft := services.AccessTokenDraft{}
+	c.BindJSON(&draft)
+
+	token, err := services.CreateAccessToken(c.GetUser(), draft)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to create access token", err)
+		return
+	}
+	c.JSON(http.StatusCreated, token)
+}
+
+func GetAccessTokens(c *server.Context) {
+	tokens, err := services.GetAccessTokens(c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get access tokens", err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+func DeleteAccessToken(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := services.DeleteAccessToken(c.GetUser(), id); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete access token", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
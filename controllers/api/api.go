@@ -12,9 +12,10 @@ func Init() {
 		PATCH  = server.PATCH
 		DELETE = server.DELETE
 
-		WithAuthorization = server.WithAuthorization
-		WithPermissions   = server.WithPermissions
-		WithRateLimit     = server.WithRateLimit
+		WithAuthorization      = server.WithAuthorization
+		WithPermissions        = server.WithPermissions
+		WithProjectPermissions = server.WithProjectPermissions
+		WithRateLimit          = server.WithRateLimit
 	)
 
 	PATCH("/api/config/meta",
@@ -32,6 +33,71 @@ func Init() {
 		WithPermissions(PermManage),
 		RefreshTemplates)
 
+	GET("/api/audit",
+		WithPermissions(PermManage),
+		GetAuditLogs)
+	GET("/api/login_failures",
+		WithPermissions(PermManage),
+		GetLoginFailures)
+
+	POST("/api/role",
+		WithPermissions(PermManage),
+		CreateRole)
+	GET("/api/roles",
+		WithPermissions(PermEditUsers, PermManage),
+		GetRoles)
+	GET("/api/role/:id",
+		WithPermissions(PermEditUsers, PermManage),
+		GetRole)
+	PATCH("/api/role/:id",
+		WithPermissions(PermManage),
+		UpdateRole)
+	DELETE("/api/role/:id",
+		WithPermissions(PermManage),
+		DeleteRole)
+
+	POST("/api/invite",
+		WithPermissions(PermManage),
+		CreateInvite)
+	GET("/api/invites",
+		WithPermissions(PermManage),
+		GetInvites)
+	DELETE("/api/invite/:id",
+		WithPermissions(PermManage),
+		DeleteInvite)
+
+	POST("/api/webhook",
+		WithPermissions(PermManage),
+		CreateWebhook)
+	GET("/api/webhooks",
+		WithPermissions(PermManage),
+		GetWebhooks)
+	GET("/api/webhook/:id",
+		WithPermissions(PermManage),
+		GetWebhook)
+	PATCH("/api/webhook/:id",
+		WithPermissions(PermManage),
+		UpdateWebhook)
+	DELETE("/api/webhook/:id",
+		WithPermissions(PermManage),
+		DeleteWebhook)
+	GET("/api/webhook/:id/deliveries",
+		WithPermissions(PermManage),
+		GetWebhookDeliveries)
+	POST("/api/webhook/:id/deliveries/:deliveryId",
+		WithPermissions(PermManage),
+		RedeliverWebhook)
+
+	GET("/api/jobs",
+		WithAuthorization(nil),
+		GetJobs)
+	GET("/api/jobs/:id",
+		WithAuthorization(nil),
+		GetJob)
+	DELETE("/api/jobs/:id",
+		WithAuthorization(nil),
+		CancelJob)
+
 	POST("/api/author",
 		WithPermissions(PermCreateProject, PermEditProject),
 		CreateAuthor)
@@ -46,10 +112,10 @@ func Init() {
 		GetAuthors)
 
 	POST("/api/project/:id/chapter",
-		WithPermissions(PermCreateChapter),
+		WithProjectPermissions(PermCreateChapter),
 		CreateChapter)
 	DELETE("/api/chapter/:id",
-		WithPermissions(PermDeleteChapter, PermDeleteChapters),
+		WithProjectPermissions(PermDeleteChapter, PermDeleteChapters),
 		DeleteChapter)
 	GET("/api/chapter/:id",
 		WithRateLimit("api-global", "5-S"),
@@ -64,30 +130,54 @@ func Init() {
 		WithRateLimit("api-global", "5-S"),
 		GetChaptersByProject)
 	PATCH("/api/chapter/:id/lock",
-		WithPermissions(PermLockChapter, PermLockChapters),
+		WithProjectPermissions(PermLockChapter, PermLockChapters),
 		LockChapter)
+	PATCH("/api/chapter/:id/restore",
+		WithProjectPermissions(PermDeleteChapter, PermDeleteChapters),
+		RestoreChapter)
 	PATCH("/api/chapter/:id/publish",
-		WithPermissions(PermPublishChapter, PermPublishChapters),
+		WithProjectPermissions(PermPublishChapter, PermPublishChapters),
 		PublishChapter)
+	PATCH("/api/chapter/:id/schedule",
+		WithProjectPermissions(PermPublishChapter, PermPublishChapters),
+		ScheduleChapter)
 	PATCH("/api/chapter/:id/unlock",
-		WithPermissions(PermUnlockChapter, PermUnlockChapters),
+		WithProjectPermissions(PermUnlockChapter, PermUnlockChapters),
 		UnlockChapter)
 	PATCH("/api/chapter/:id/unpublish",
-		WithPermissions(PermUnpublishChapter, PermUnpublishChapters),
+		WithProjectPermissions(PermUnpublishChapter, PermUnpublishChapters),
 		UnpublishChapter)
+	PATCH("/api/chapter/:id/unschedule",
+		WithProjectPermissions(PermPublishChapter, PermPublishChapters),
+		UnscheduleChapter)
 	PATCH("/api/chapter/:id",
-		WithPermissions(PermEditChapter, PermEditChapters),
+		WithProjectPermissions(PermEditChapter, PermEditChapters),
 		UpdateChapter)
 
 	DELETE("/api/chapter/:id/pages/:fileName",
-		WithPermissions(PermCreateChapter, PermEditChapter),
+		WithProjectPermissions(PermCreateChapter, PermEditChapter),
 		DeletePage)
 	GET("/api/chapter/:id/pages",
 		WithRateLimit("api-global", "5-S"),
 		GetPages)
 	POST("/api/chapter/:id/pages",
-		WithPermissions(PermCreateChapter, PermEditChapter),
+		WithProjectPermissions(PermCreateChapter, PermEditChapter),
 		UploadPage)
+	POST("/api/chapter/:id/pages/archive",
+		WithProjectPermissions(PermCreateChapter, PermEditChapter),
+		UploadPagesArchive)
+	POST("/api/chapter/:id/pages/md",
+		WithProjectPermissions(PermCreateChapter, PermEditChapter),
+		ImportPagesMd)
+	GET("/api/chapter/:id/revisions",
+		WithProjectPermissions(PermEditChapter, PermEditChapters),
+		GetChapterRevisions)
+	GET("/api/chapter/:id/revision/:rid/diff",
+		WithProjectPermissions(PermEditChapter, PermEditChapters),
+		DiffChapterRevision)
+	POST("/api/chapter/:id/revision/:rid/rollback",
+		WithProjectPermissions(PermEditChapter, PermEditChapters),
+		RollbackChapter)
 
 	GET("/api/project/exists",
 		WithAuthorization(nil),
@@ -110,19 +200,38 @@ func Init() {
 	PATCH("/api/project/:id/lock",
 		WithPermissions(PermLockProject),
 		LockProject)
+	PATCH("/api/project/:id/restore",
+		WithPermissions(PermDeleteProject),
+		RestoreProject)
 	PATCH("/api/project/:id/publish",
 		WithPermissions(PermPublishProject),
 		PublishProject)
+	PATCH("/api/project/:id/schedule",
+		WithPermissions(PermPublishProject),
+		ScheduleProject)
 	PATCH("/api/project/:id/unlock",
 		WithPermissions(PermUnlockProject),
 		UnlockProject)
 	PATCH("/api/project/:id/unpublish",
 		WithPermissions(PermUnpublishProject),
 		UnpublishProject)
+	PATCH("/api/project/:id/unschedule",
+		WithPermissions(PermPublishProject),
+		UnscheduleProject)
 	PATCH("/api/project/:id",
 		WithPermissions(PermEditProject),
 		UpdateProject)
 
+	GET("/api/project/:id/members",
+		WithPermissions(PermEditProject, PermManage),
+		GetProjectMembers)
+	PATCH("/api/project/:id/member/:uid",
+		WithPermissions(PermEditProject, PermManage),
+		SetProjectMember)
+	DELETE("/api/project/:id/member/:uid",
+		WithPermissions(PermEditProject, PermManage),
+		RemoveProjectMember)
+
 	DELETE("/api/project/:id/cover/:cid",
 		WithPermissions(PermDeleteCover),
 		DeleteCover)
@@ -135,10 +244,17 @@ func Init() {
 	PATCH("/api/project/:id/cover/:cid",
 		WithPermissions(PermSetCover),
 		SetCover)
+	PATCH("/api/project/:id/cover/:cid/restore",
+		WithPermissions(PermDeleteCover),
+		RestoreCover)
 	POST("/api/project/:id/cover",
 		WithPermissions(PermUploadCover),
 		UploadCover)
 
+	GET("/api/trash",
+		WithPermissions(PermDeleteProject, PermDeleteChapters, PermDeleteCover),
+		GetTrash)
+
 	POST("/api/scanlation_group",
 		WithPermissions(PermCreateChapter, PermEditChapter),
 		CreateScanlationGroup)
@@ -151,6 +267,15 @@ func Init() {
 	GET("/api/scanlation_groups",
 		WithRateLimit("api-global", "5-S"),
 		GetScanlationGroups)
+	GET("/api/scanlation_group/:identifier/members",
+		WithAuthorization(nil),
+		GetScanlationGroupMembers)
+	PATCH("/api/scanlation_group/:identifier/member/:uid",
+		WithAuthorization(nil),
+		SetScanlationGroupMember)
+	DELETE("/api/scanlation_group/:identifier/member/:uid",
+		WithAuthorization(nil),
+		RemoveScanlationGroupMember)
 
 	POST("/api/tag",
 		WithPermissions(PermCreateProject, PermEditProject),
@@ -174,6 +299,51 @@ func Init() {
 	GET("/api/user",
 		WithAuthorization(nil),
 		GetUser)
+	GET("/api/user/tokens",
+		WithAuthorization(nil),
+		GetAccessTokens)
+	POST("/api/user/token",
+		WithAuthorization(nil),
+		CreateAccessToken)
+	DELETE("/api/user/token/:id",
+		WithAuthorization(nil),
+		DeleteAccessToken)
+	POST("/api/user/totp",
+		WithAuthorization(nil),
+		EnrollTOTP)
+	PATCH("/api/user/totp",
+		WithAuthorization(nil),
+		EnableTOTP)
+	DELETE("/api/user/totp",
+		WithAuthorization(nil),
+		DisableTOTP)
+	DELETE("/api/user/:id/totp",
+		WithPermissions(PermManage),
+		DisableTOTPById)
+	POST("/api/user/recovery_codes",
+		WithAuthorization(nil),
+		RegenerateRecoveryCodes)
+	GET("/api/user/sessions",
+		WithAuthorization(nil),
+		GetSessions)
+	DELETE("/api/user/sessions",
+		WithAuthorization(nil),
+		RevokeSessions)
+	DELETE("/api/user/session/:id",
+		WithAuthorization(nil),
+		RevokeSession)
+	DELETE("/api/user/:id/sessions",
+		WithPermissions(PermManage),
+		RevokeSessionsById)
+	DELETE("/api/user/:id/lock",
+		WithPermissions(PermManage),
+		UnlockUserById)
+	GET("/api/user/identities",
+		WithAuthorization(nil),
+		GetUserIdentities)
+	DELETE("/api/user/identity/:id",
+		WithAuthorization(nil),
+		DeleteUserIdentity)
 	GET("/api/users",
 		WithPermissions(PermEditUsers, PermDeleteUsers, PermManage),
 		GetUsers)
@@ -183,6 +353,9 @@ func Init() {
 	PATCH("/api/user/:id/name",
 		WithPermissions(PermEditUsers),
 		UpdateUserNameById)
+	PATCH("/api/user/email",
+		WithPermissions(PermEditUser),
+		UpdateUserEmail)
 	PATCH("/api/user/password",
 		WithPermissions(PermEditUser),
 		UpdateUserPassword)
@@ -192,6 +365,9 @@ func Init() {
 	PATCH("/api/user/:id/permissions",
 		WithPermissions(PermManage),
 		UpdateUserPermissions)
+	PATCH("/api/user/:id/roles",
+		WithPermissions(PermManage),
+		UpdateUserRoles)
 
 	GET("/api/stats/pages",
 		WithPermissions(PermManage),
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func GetAuditLogs(c *server.Context) {
+	opts := services.GetAuditLogsOptions{}
+	c.BindQuery(&opts)
+
+	result := services.GetAuditLogs(opts)
+	if result.Err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get audit log", result.Err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -48,7 +48,7 @@ func CreateAuthor(c *server.Context) {
 }
 
 func DeleteAuthor(c *server.Context) {
-	if err := services.DeleteAuthorBySlugOrName(c.Param("identifier")); err != nil {
+	if err := services.DeleteAuthorBySlugOrName(c.Param("identifier"), c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete author", err)
 		return
 	}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"kasen/constants"
 	"kasen/server"
@@ -56,7 +57,7 @@ func GetChapter(c *server.Context) {
 
 	if opts.IncludesDrafts {
 		user := c.GetUser()
-		if user == nil || !user.HasPermissions(constants.PermsChapter...) {
+		if user == nil || !user.HasAnyProjectPermissions(constants.PermsChapter...) {
 			c.Status(http.StatusForbidden)
 			return
 		}
@@ -91,7 +92,7 @@ func GetChapters(c *server.Context) {
 
 	if opts.IncludesDrafts {
 		user := c.GetUser()
-		if user == nil || !user.HasPermissions(constants.PermsChapter...) {
+		if user == nil || !user.HasAnyProjectPermissions(constants.PermsChapter...) {
 			c.Status(http.StatusForbidden)
 			return
 		}
@@ -117,7 +118,7 @@ func GetChaptersByProject(c *server.Context) {
 
 	if opts.IncludesDrafts {
 		user := c.GetUser()
-		if user == nil || !user.HasPermissions(constants.PermsChapter...) {
+		if user == nil || !user.HasProjectPermissions(id, constants.PermsChapter...) {
 			c.Status(http.StatusForbidden)
 			return
 		}
@@ -162,6 +163,29 @@ func PublishChapter(c *server.Context) {
 	c.JSON(http.StatusOK, chapter)
 }
 
+func ScheduleChapter(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	payload := SchedulePayload{}
+	c.BindJSON(&payload)
+
+	if payload.ScheduledAt <= 0 {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	chapter, err := services.ScheduleChapter(id, time.Unix(payload.ScheduledAt, 0), c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to schedule chapter", err)
+		return
+	}
+	c.JSON(http.StatusOK, chapter)
+}
+
 func UnlockChapter(c *server.Context) {
 	id, err := c.ParamInt64("id")
 	if err != nil {
@@ -192,6 +216,21 @@ func UnpublishChapter(c *server.Context) {
 	c.JSON(http.StatusOK, chapter)
 }
 
+func UnscheduleChapter(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	chapter, err := services.UnscheduleChapter(id, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to unschedule chapter", err)
+		return
+	}
+	c.JSON(http.StatusOK, chapter)
+}
+
 func UpdateChapter(c *server.Context) {
 	id, err := c.ParamInt64("id")
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"mime/multipart"
 	"net/http"
 
+	"kasen/errs"
 	"kasen/server"
 	"kasen/services"
 )
@@ -95,3 +96,55 @@ func UploadPage(c *server.Context) {
 	}
 	c.JSON(http.StatusOK, pages)
 }
+
+func UploadPagesArchive(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	fh, err := c.FormFile("data")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	pages, err := services.UploadPagesArchiveMultipart(id, fh, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to upload pages", err)
+		return
+	}
+	c.JSON(http.StatusOK, pages)
+}
+
+type ImportPagesMdPayload struct {
+	MdChapterID string `json:"mdChapterId"`
+}
+
+func ImportPagesMd(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	payload := ImportPagesMdPayload{}
+	c.BindJSON(&payload)
+
+	job, err := services.ImportPagesMd(id, payload.MdChapterID, c.GetUser())
+	if err != nil {
+		switch err {
+		case errs.ErrInvalidJobPayload, errs.ErrChapterLocked:
+			c.ErrorJSON(http.StatusBadRequest, "Failed to import pages", err)
+		case errs.ErrChapterNotFound:
+			c.ErrorJSON(http.StatusNotFound, "Failed to import pages", err)
+		case errs.ErrForbidden:
+			c.ErrorJSON(http.StatusForbidden, "Failed to import pages", err)
+		default:
+			c.ErrorJSON(http.StatusInternalServerError, "Failed to import pages", err)
+		}
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func GetChapterRevisions(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	opts := services.GetChapterRevisionsOptions{}
+	c.BindQuery(&opts)
+
+	result := services.GetChapterRevisions(id, opts, c.GetUser())
+	if result.Err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get chapter revisions", result.Err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+type DiffChapterRevisionQuery struct {
+	To int64 `form:"to"`
+}
+
+func DiffChapterRevision(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	rid, err := c.ParamInt64("rid")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	q := DiffChapterRevisionQuery{}
+	c.BindQuery(&q)
+
+	diff, err := services.DiffChapterRevisions(id, rid, q.To, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to diff chapter revisions", err)
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+func RollbackChapter(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	rid, err := c.ParamInt64("rid")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	pages, err := services.RollbackChapter(id, rid, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to roll back chapter", err)
+		return
+	}
+	c.JSON(http.StatusOK, pages)
+}
@@ -16,7 +16,7 @@ func UpdateMeta(c *server.Context) {
 	payload := &config.Meta{}
 	c.BindJSON(payload)
 
-	if err := services.UpdateMeta(payload); err != nil {
+	if err := services.UpdateMeta(payload, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to update meta", err)
 		return
 	}
@@ -27,7 +27,7 @@ func UpdateServiceConfig(c *server.Context) {
 	payload := &config.Service{}
 	c.BindJSON(payload)
 
-	if err := services.UpdateServiceConfig(payload); err != nil {
+	if err := services.UpdateServiceConfig(payload, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to update service config", err)
 		return
 	}
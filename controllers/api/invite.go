@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func CreateInvite(c *server.Context) {
+	draft := services.InviteDraft{}
+	c.BindJSON(&draft)
+
+	invite, err := services.CreateInvite(draft, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to create invite", err)
+		return
+	}
+	c.JSON(http.StatusCreated, invite)
+}
+
+func GetInvites(c *server.Context) {
+	invites, err := services.GetInvites()
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get invites", err)
+		return
+	}
+	c.JSON(http.StatusOK, invites)
+}
+
+func DeleteInvite(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := services.DeleteInvite(id, c.GetUser()); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete invite", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func GetJobs(c *server.Context) {
+	opts := services.GetJobsOptions{}
+	c.BindQuery(&opts)
+
+	result := services.GetJobs(opts, c.GetUser())
+	if result.Err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get jobs", result.Err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func GetJob(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	job, err := services.GetJob(id, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get job", err)
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+func CancelJob(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	job, err := services.CancelJob(id, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to cancel job", err)
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func GetLoginFailures(c *server.Context) {
+	opts := services.GetLoginFailuresOptions{}
+	c.BindQuery(&opts)
+
+	result := services.GetLoginFailures(opts)
+	if result.Err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get failed logins", result.Err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func UnlockUserById(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	user, err := services.GetUser(id)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get user", err)
+		return
+	}
+
+	if err := services.UnlockUser(user, c.GetUser()); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to unlock user", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"kasen/constants"
 	"kasen/server"
@@ -53,7 +54,7 @@ func CreateProject(c *server.Context) {
 	draft := &services.ProjectDraft{}
 	c.BindJSON(draft)
 
-	project, err := services.CreateProject(draft)
+	project, err := services.CreateProject(draft, c.GetUser())
 	if err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to create project", err)
 		return
@@ -68,7 +69,7 @@ func DeleteProject(c *server.Context) {
 		return
 	}
 
-	if err = services.DeleteProject(id); err != nil {
+	if err = services.DeleteProject(id, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete project", err)
 		return
 	}
@@ -143,7 +144,7 @@ func LockProject(c *server.Context) {
 		return
 	}
 
-	project, err := services.LockProject(id)
+	project, err := services.LockProject(id, c.GetUser())
 	if err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to lock project", err)
 		return
@@ -158,7 +159,7 @@ func PublishProject(c *server.Context) {
 		return
 	}
 
-	project, err := services.PublishProject(id)
+	project, err := services.PublishProject(id, c.GetUser())
 	if err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to publish project", err)
 		return
@@ -166,6 +167,29 @@ func PublishProject(c *server.Context) {
 	c.JSON(http.StatusOK, project)
 }
 
+func ScheduleProject(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	payload := SchedulePayload{}
+	c.BindJSON(&payload)
+
+	if payload.ScheduledAt <= 0 {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	project, err := services.ScheduleProject(id, time.Unix(payload.ScheduledAt, 0), c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to schedule project", err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
 func UnlockProject(c *server.Context) {
 	id, err := c.ParamInt64("id")
 	if err != nil {
@@ -173,7 +197,7 @@ func UnlockProject(c *server.Context) {
 		return
 	}
 
-	project, err := services.UnlockProject(id)
+	project, err := services.UnlockProject(id, c.GetUser())
 	if err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to unlock project", err)
 		return
@@ -188,7 +212,7 @@ func UnpublishProject(c *server.Context) {
 		return
 	}
 
-	project, err := services.UnpublishProject(id)
+	project, err := services.UnpublishProject(id, c.GetUser())
 	if err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to unpublish project", err)
 		return
@@ -196,6 +220,21 @@ func UnpublishProject(c *server.Context) {
 	c.JSON(http.StatusOK, project)
 }
 
+func UnscheduleProject(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	project, err := services.UnscheduleProject(id, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to unschedule project", err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
 func UpdateProject(c *server.Context) {
 	id, err := c.ParamInt64("id")
 	if err != nil {
@@ -206,7 +245,7 @@ func UpdateProject(c *server.Context) {
 	draft := &services.ProjectDraft{}
 	c.BindJSON(draft)
 
-	project, err := services.UpdateProject(id, draft)
+	project, err := services.UpdateProject(id, draft, c.GetUser())
 	if err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to update project", err)
 		return
@@ -27,7 +27,7 @@ func DeleteCover(c *server.Context) {
 		return
 	}
 
-	if err := services.DeleteCover(cid); err != nil {
+	if err := services.DeleteCover(cid, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete cover", err)
 		return
 	}
@@ -77,7 +77,7 @@ func SetCover(c *server.Context) {
 		return
 	}
 
-	if err := services.SetCover(id, cid); err != nil {
+	if err := services.SetCover(id, cid, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to set project cover", err)
 		return
 	}
@@ -118,7 +118,7 @@ func UploadCover(c *server.Context) {
 	}
 
 	if payload.IsInitialCover || (payload.SetAsMainCover && c.GetUser().HasPermissions(constants.PermSetCover)) {
-		if err := services.SetCover(id, cover.ID); err != nil {
+		if err := services.SetCover(id, cover.ID, c.GetUser()); err != nil {
 			c.ErrorJSON(http.StatusInternalServerError, "Failed to set project main cover", err)
 			return
 		}
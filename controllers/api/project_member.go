@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func GetProjectMembers(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	members, err := services.GetProjectMembers(id)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get project members", err)
+		return
+	}
+	c.JSON(http.StatusOK, members)
+}
+
+type SetProjectMemberPayload struct {
+	Permissions []string `json:"permissions"`
+}
+
+func SetProjectMember(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	uid, err := c.ParamInt64("uid")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	payload := SetProjectMemberPayload{}
+	c.BindJSON(&payload)
+
+	member, err := services.SetProjectMember(id, uid, payload.Permissions, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to set project member", err)
+		return
+	}
+	c.JSON(http.StatusOK, member)
+}
+
+func RemoveProjectMember(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	uid, err := c.ParamInt64("uid")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := services.RemoveProjectMember(id, uid, c.GetUser()); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to remove project member", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func CreateRole(c *server.Context) {
+	draft := services.RoleDraft{}
+	c.BindJSON(&draft)
+
+	role, err := services.CreateRole(draft, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to create role", err)
+		return
+	}
+	c.JSON(http.StatusCreated, role)
+}
+
+func GetRoles(c *server.Context) {
+	roles, err := services.GetRoles()
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get roles", err)
+		return
+	}
+	c.JSON(http.StatusOK, roles)
+}
+
+func GetRole(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	role, err := services.GetRole(id)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get role", err)
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+func UpdateRole(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	draft := services.RoleDraft{}
+	c.BindJSON(&draft)
+
+	role, err := services.UpdateRole(id, draft, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to update role", err)
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+func DeleteRole(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err = services.DeleteRole(id, c.GetUser()); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete role", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -21,7 +21,7 @@ func CreateScanlationGroup(c *server.Context) {
 }
 
 func DeleteScanlationGroup(c *server.Context) {
-	if err := services.DeleteScanlationGroupBySlugOrName(c.Param("identifier")); err != nil {
+	if err := services.DeleteScanlationGroupBySlugOrName(c.Param("identifier"), c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete scanlation group", err)
 		return
 	}
@@ -63,3 +63,66 @@ func GetScanlationGroups(c *server.Context) {
 	}
 	c.JSON(http.StatusOK, scanlationGroups)
 }
+
+func GetScanlationGroupMembers(c *server.Context) {
+	scanlationGroup, err := services.GetScanlationGroupBySlugOrName(c.Param("identifier"))
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get scanlation group", err)
+		return
+	}
+
+	members, err := services.GetScanlationGroupMembers(scanlationGroup.ID)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get scanlation group members", err)
+		return
+	}
+	c.JSON(http.StatusOK, members)
+}
+
+type SetScanlationGroupMemberPayload struct {
+	Role string `json:"role"`
+}
+
+func SetScanlationGroupMember(c *server.Context) {
+	uid, err := c.ParamInt64("uid")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	payload := SetScanlationGroupMemberPayload{}
+	c.BindJSON(&payload)
+
+	scanlationGroup, err := services.GetScanlationGroupBySlugOrName(c.Param("identifier"))
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get scanlation group", err)
+		return
+	}
+
+	member, err := services.SetScanlationGroupMember(scanlationGroup.ID, uid, payload.Role, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to set scanlation group member", err)
+		return
+	}
+	c.JSON(http.StatusOK, member)
+}
+
+func RemoveScanlationGroupMember(c *server.Context) {
+	uid, err := c.ParamInt64("uid")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	scanlationGroup, err := services.GetScanlationGroupBySlugOrName(c.Param("identifier"))
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get scanlation group", err)
+		return
+	}
+
+	if err := services.RemoveScanlationGroupMember(scanlationGroup.ID, uid, c.GetUser()); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to remove scanlation group member", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func GetSessions(c *server.Context) {
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	sessions, err := services.GetSessions(c.GetUser(), c.SessionID())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get sessions", err)
+		return
+	}
+	c.JSON(http.StatusOK, sessions)
+}
+
+func RevokeSession(c *server.Context) {
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	if err := services.RevokeSession(c.GetUser(), c.Param("id")); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to revoke session", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func RevokeSessions(c *server.Context) {
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	if err := services.RevokeSessions(c.GetUser(), c.GetUser()); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to revoke sessions", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func RevokeSessionsById(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	user, err := services.GetUser(id)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get user", err)
+		return
+	}
+
+	if err := services.RevokeSessions(user, c.GetUser()); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to revoke sessions", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -9,3 +9,7 @@ type GetEntityQueries struct {
 	Slug string `form:"slug"`
 	Name string `form:"name"`
 }
+
+type SchedulePayload struct {
+	ScheduledAt int64 `json:"scheduledAt"`
+}
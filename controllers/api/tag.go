@@ -49,7 +49,7 @@ func CreateTag(c *server.Context) {
 }
 
 func DeleteTag(c *server.Context) {
-	if err := services.DeleteTagBySlugOrName(c.Param("identifier")); err != nil {
+	if err := services.DeleteTagBySlugOrName(c.Param("identifier"), c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete tag", err)
 		return
 	}
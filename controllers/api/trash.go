@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func GetTrash(c *server.Context) {
+	trash, err := services.GetTrash()
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get trash", err)
+		return
+	}
+	c.JSON(http.StatusOK, trash)
+}
+
+func RestoreProject(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	project, err := services.RestoreProject(id, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to restore project", err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+func RestoreChapter(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	chapter, err := services.RestoreChapter(id, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to restore chapter", err)
+		return
+	}
+	c.JSON(http.StatusOK, chapter)
+}
+
+func RestoreCover(c *server.Context) {
+	_, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	cid, err := c.ParamInt64("cid")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	cover, err := services.RestoreCover(cid, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to restore cover", err)
+		return
+	}
+	c.JSON(http.StatusOK, cover)
+}
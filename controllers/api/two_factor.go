@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorCodePayload struct {
+	Code string `json:"code"`
+}
+
+func EnrollTOTP(c *server.Context) {
+	// Two-factor authentication can only be managed from a session,
+	// access tokens bypass it.
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	enrollment, err := services.EnrollTOTP(c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to enroll two-factor authentication", err)
+		return
+	}
+	c.JSON(http.StatusOK, enrollment)
+}
+
+func EnableTOTP(c *server.Context) {
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	payload := TwoFactorCodePayload{}
+	c.BindJSON(&payload)
+
+	codes, err := services.EnableTOTP(c.GetUser(), payload.Code)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to enable two-factor authentication", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recoveryCodes": codes})
+}
+
+func DisableTOTP(c *server.Context) {
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	payload := TwoFactorCodePayload{}
+	c.BindJSON(&payload)
+
+	if err := services.DisableTOTP(c.GetUser(), payload.Code, c.GetUser()); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to disable two-factor authentication", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func DisableTOTPById(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	user, err := services.GetUser(id)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get user", err)
+		return
+	}
+
+	if err := services.DisableTOTP(user, "", c.GetUser()); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to disable two-factor authentication", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func RegenerateRecoveryCodes(c *server.Context) {
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	payload := TwoFactorCodePayload{}
+	c.BindJSON(&payload)
+
+	codes, err := services.RegenerateRecoveryCodes(c.GetUser(), payload.Code)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to regenerate recovery codes", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recoveryCodes": codes})
+}
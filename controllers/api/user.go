@@ -29,7 +29,7 @@ func DeleteUser(c *server.Context) {
 		return
 	}
 
-	if err := services.DeleteUser(user); err != nil {
+	if err := services.DeleteUser(user, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete user", err)
 		return
 	}
@@ -53,7 +53,7 @@ func DeleteUserById(c *server.Context) {
 		return
 	}
 
-	if err := services.DeleteUser(user); err != nil {
+	if err := services.DeleteUser(user, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete user", err)
 		return
 	}
@@ -88,7 +88,7 @@ func UpdateUserName(c *server.Context) {
 	payload := UpdateUserNamePayload{}
 	c.BindJSON(&payload)
 
-	if err := services.UpdateUserName(c.GetUser(), payload.Name); err != nil {
+	if err := services.UpdateUserName(c.GetUser(), payload.Name, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to update user name", err)
 	}
 	c.Status(http.StatusNoContent)
@@ -110,7 +110,7 @@ func UpdateUserNameById(c *server.Context) {
 		return
 	}
 
-	if err := services.UpdateUserName(user, payload.Name); err != nil {
+	if err := services.UpdateUserName(user, payload.Name, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to update user name", err)
 		return
 	}
@@ -118,11 +118,29 @@ func UpdateUserNameById(c *server.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+func UpdateUserEmail(c *server.Context) {
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	payload := services.RequestEmailChangeOptions{}
+	c.BindJSON(&payload)
+
+	if err := services.RequestEmailChange(c.GetUser(), payload); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to update user email", err)
+		return
+	}
+
+	// The email is only changed once the link sent to it is followed.
+	c.Status(http.StatusAccepted)
+}
+
 func UpdateUserPassword(c *server.Context) {
 	payload := services.UpdateUserPasswordOptions{}
 	c.BindJSON(&payload)
 
-	if err := services.UpdateUserPassword(c.GetUser(), payload); err != nil {
+	if err := services.UpdateUserPassword(c.GetUser(), payload, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to update user password", err)
 	}
 	c.Status(http.StatusNoContent)
@@ -144,7 +162,7 @@ func UpdateUserPasswordById(c *server.Context) {
 		return
 	}
 
-	if err := services.UpdateUserPassword(user, payload); err != nil {
+	if err := services.UpdateUserPassword(user, payload, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to update user password", err)
 		return
 	}
@@ -152,10 +170,6 @@ func UpdateUserPasswordById(c *server.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-type UpdateUserPermissionsPayload struct {
-	Permissions []string `json:"permissions"`
-}
-
 func UpdateUserPermissions(c *server.Context) {
 	id, err := c.ParamInt64("id")
 	if err != nil {
@@ -163,8 +177,8 @@ func UpdateUserPermissions(c *server.Context) {
 		return
 	}
 
-	payload := UpdateUserPermissionsPayload{}
-	c.BindJSON(&payload)
+	opts := services.UpdateUserPermissionsOptions{}
+	c.BindJSON(&opts)
 
 	user, err := services.GetUser(id)
 	if err != nil {
@@ -172,9 +186,37 @@ func UpdateUserPermissions(c *server.Context) {
 		return
 	}
 
-	if _, err := services.UpdateUserPermissions(user, payload.Permissions); err != nil {
+	if _, err := services.UpdateUserPermissions(user, opts, c.GetUser()); err != nil {
 		c.ErrorJSON(http.StatusInternalServerError, "Failed to update user permissions", err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+type UpdateUserRolesPayload struct {
+	Roles []int64 `json:"roles"`
+}
+
+func UpdateUserRoles(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	payload := UpdateUserRolesPayload{}
+	c.BindJSON(&payload)
+
+	user, err := services.GetUser(id)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get user", err)
+		return
+	}
+
+	roles, err := services.UpdateUserRoles(user, payload.Roles, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to update user roles", err)
+		return
+	}
+	c.JSON(http.StatusOK, roles)
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func GetUserIdentities(c *server.Context) {
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	identities, err := services.GetUserIdentities(c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get identities", err)
+		return
+	}
+	c.JSON(http.StatusOK, identities)
+}
+
+func DeleteUserIdentity(c *server.Context) {
+	if c.UsesAccessToken() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := services.DeleteUserIdentity(c.GetUser(), id); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete identity", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
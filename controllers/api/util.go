@@ -3,13 +3,22 @@ package api
 import (
 	"net/http"
 
+	"kasen/errs"
 	"kasen/server"
 	"kasen/services"
 )
 
 func RemapSymlinks(c *server.Context) {
-	go services.RemapSymlinks()
-	c.Status(http.StatusOK)
+	job, err := services.CreateJob(services.JobRemapSymlinks, struct{}{}, c.GetUser())
+	if err != nil {
+		if err == errs.ErrInvalidJobKind || err == errs.ErrInvalidJobPayload {
+			c.ErrorJSON(http.StatusBadRequest, "Failed to remap symlinks", err)
+		} else {
+			c.ErrorJSON(http.StatusInternalServerError, "Failed to remap symlinks", err)
+		}
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
 }
 
 func RefreshTemplates(c *server.Context) {
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+
+	"kasen/server"
+	"kasen/services"
+)
+
+func CreateWebhook(c *server.Context) {
+	draft := services.WebhookDraft{}
+	c.BindJSON(&draft)
+
+	webhook, err := services.CreateWebhook(draft, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to create webhook", err)
+		return
+	}
+	c.JSON(http.StatusCreated, webhook)
+}
+
+func GetWebhooks(c *server.Context) {
+	webhooks, err := services.GetWebhooks()
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get webhooks", err)
+		return
+	}
+	c.JSON(http.StatusOK, webhooks)
+}
+
+func GetWebhook(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := services.GetWebhook(id)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get webhook", err)
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+func UpdateWebhook(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	draft := services.WebhookDraft{}
+	c.BindJSON(&draft)
+
+	webhook, err := services.UpdateWebhook(id, draft, c.GetUser())
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to update webhook", err)
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+func DeleteWebhook(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err = services.DeleteWebhook(id, c.GetUser()); err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to delete webhook", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func GetWebhookDeliveries(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	opts := services.GetWebhookDeliveriesOptions{}
+	c.BindQuery(&opts)
+
+	result := services.GetWebhookDeliveries(id, opts)
+	if result.Err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to get webhook deliveries", result.Err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func RedeliverWebhook(c *server.Context) {
+	id, err := c.ParamInt64("id")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	deliveryID, err := c.ParamInt64("deliveryId")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := services.RedeliverWebhook(id, deliveryID)
+	if err != nil {
+		c.ErrorJSON(http.StatusInternalServerError, "Failed to redeliver webhook", err)
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
@@ -20,8 +20,18 @@ func Chapter(c *server.Context) {
 		return
 	}
 
-	if strings.EqualFold(c.Query("download"), "true") {
-		if err := services.DownloadChapter(id, c.Writer); err != nil {
+	var format string
+	switch strings.ToLower(c.Query("download")) {
+	case "true", services.DownloadFormatZip:
+		format = services.DownloadFormatZip
+	case services.DownloadFormatCBZ:
+		format = services.DownloadFormatCBZ
+	case services.DownloadFormatEPUB:
+		format = services.DownloadFormatEPUB
+	}
+
+	if len(format) > 0 {
+		if err := services.DownloadChapter(id, format, c.Writer); err != nil {
 			c.Status(http.StatusInternalServerError)
 		}
 		return
@@ -43,6 +43,20 @@ func Init() {
 		Login,
 	)
 
+	POST("/login/2fa",
+		WithNoAuthorization(WithRedirect("/manage")),
+		WithRateLimit("auth-2fa", "10-H"),
+		WithName("Login"),
+		LoginTwoFactor)
+	GET("/login/oidc",
+		WithRateLimit("auth-oidc", "20-H"),
+		WithName("Login"),
+		OIDCLogin)
+	GET("/login/oidc/callback",
+		WithRateLimit("auth-oidc-callback", "20-H"),
+		WithName("Login"),
+		OIDCCallback)
+
 	GET("/register",
 		WithNoAuthorization(WithRedirect("/manage")),
 		WithName("Register"),
@@ -53,8 +67,36 @@ func Init() {
 		WithName("Register"),
 		Register)
 
+	GET("/verify_email",
+		WithName("Verify Email"),
+		VerifyEmail)
+	GET("/confirm_email",
+		WithName("Confirm Email"),
+		ConfirmEmail)
+
+	GET("/forgot_password",
+		WithNoAuthorization(WithRedirect("/manage")),
+		WithName("Forgot Password"),
+		ForgotPasswordPage)
+	POST("/forgot_password",
+		WithNoAuthorization(WithRedirect("/manage")),
+		WithRateLimit("auth-forgot-password", "5-H"),
+		WithName("Forgot Password"),
+		ForgotPassword)
+	GET("/reset_password",
+		WithNoAuthorization(WithRedirect("/manage")),
+		WithName("Reset Password"),
+		ResetPasswordPage)
+	POST("/reset_password",
+		WithNoAuthorization(WithRedirect("/manage")),
+		WithRateLimit("auth-reset-password", "10-H"),
+		WithName("Reset Password"),
+		ResetPassword)
+
 	GET("/logout", Logout)
 
+	GET("/.well-known/jwks.json", JWKS)
+
 	GET("/manage",
 		WithAuthorization(WithRedirect("/login")),
 		WithName("Manage"),
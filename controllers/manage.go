@@ -1,9 +1,12 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"kasen/config"
+	"kasen/errs"
 	"kasen/server"
 	"kasen/services"
 )
@@ -21,9 +24,18 @@ func LoginPage(c *server.Context) {
 		c.Redirect(http.StatusFound, "/manage")
 		return
 	}
+	setOIDCData(c)
 	c.HTML(http.StatusOK, "login.html")
 }
 
+// setOIDCData sets the name of the identity provider shown
+// on the login page, if the login with it is enabled.
+func setOIDCData(c *server.Context) {
+	if cfg := config.GetOIDC(); cfg.Enabled {
+		c.SetData("oidc", cfg.Name)
+	}
+}
+
 type LoginRequest struct {
 	Email       string `form:"email"`
 	RawPassword string `form:"password"`
@@ -38,28 +50,116 @@ func Login(c *server.Context) {
 	payload := &LoginRequest{}
 	c.Bind(payload)
 
-	rt, st, err := services.Login(services.LoginOptions{
+	rt, st, challenge, err := services.Login(services.LoginOptions{
 		Email:       payload.Email,
 		RawPassword: payload.RawPassword,
+		Client:      c.SessionClient(),
 	})
 	if err != nil {
 		c.SetData("error", err)
+		setOIDCData(c)
 		c.HTML(http.StatusInternalServerError, "login.html")
 		return
+	} else if len(challenge) > 0 {
+		c.SetData("challenge", challenge)
+		c.HTML(http.StatusOK, "two_factor.html")
+		return
 	}
 
 	c.SetTokens(st, rt)
 	c.Redirect(http.StatusFound, "/manage")
 }
 
+type LoginTwoFactorRequest struct {
+	Challenge string `form:"challenge"`
+	Code      string `form:"code"`
+}
+
+func LoginTwoFactor(c *server.Context) {
+	payload := &LoginTwoFactorRequest{}
+	c.Bind(payload)
+
+	rt, st, err := services.LoginTwoFactor(payload.Challenge, payload.Code, c.SessionClient())
+	if err != nil {
+		c.SetData("error", err)
+		if err == errs.ErrTwoFactorChallengeInvalid || err == errs.ErrAccountLocked {
+			setOIDCData(c)
+			c.HTML(http.StatusUnauthorized, "login.html")
+		} else {
+			c.SetData("challenge", payload.Challenge)
+			c.HTML(http.StatusUnauthorized, "two_factor.html")
+		}
+		return
+	}
+
+	c.SetTokens(st, rt)
+	c.Redirect(http.StatusFound, "/manage")
+}
+
+// OIDCLogin redirects to the identity provider, logged in users
+// link the identity to their account instead.
+func OIDCLogin(c *server.Context) {
+	url, binding, err := services.OIDCAuthURL(c.GetUser())
+	if err != nil {
+		c.SetData("error", err)
+		setOIDCData(c)
+		c.HTML(http.StatusInternalServerError, "login.html")
+		return
+	}
+
+	expires := time.Now().Add(services.OIDCLoginExpiration)
+	c.SetCookie("oidc", binding, &expires)
+	c.Redirect(http.StatusFound, url)
+}
+
+func OIDCCallback(c *server.Context) {
+	binding, _ := c.Cookie("oidc")
+	c.SetCookie("oidc", "", nil)
+
+	if desc := c.Query("error"); len(desc) > 0 {
+		if v := c.Query("error_description"); len(v) > 0 {
+			desc = v
+		}
+		c.SetData("error", errors.New(desc))
+		setOIDCData(c)
+		c.HTML(http.StatusUnauthorized, "login.html")
+		return
+	}
+
+	rt, st, challenge, err := services.OIDCCallback(c.Query("state"), binding, c.Query("code"), c.SessionClient())
+	if err != nil {
+		c.SetData("error", err)
+		setOIDCData(c)
+		c.HTML(http.StatusInternalServerError, "login.html")
+		return
+	} else if len(challenge) > 0 {
+		c.SetData("challenge", challenge)
+		c.HTML(http.StatusOK, "two_factor.html")
+		return
+	}
+
+	c.SetTokens(st, rt)
+	c.Redirect(http.StatusFound, "/manage")
+}
+
+// setInviteData sets the invite code of the registration, which is
+// required when the registration is disabled.
+func setInviteData(c *server.Context, invite string) {
+	c.SetData("invite", invite)
+	c.SetData("inviteRequired", config.GetService().DisableRegistration)
+}
+
 func RegisterPage(c *server.Context) {
-	if config.GetService().DisableRegistration {
+	invite := c.Query("invite")
+	if config.GetService().DisableRegistration && len(invite) == 0 {
 		c.Redirect(http.StatusFound, "/login")
 		return
 	} else if c.GetUser() != nil {
 		c.Redirect(http.StatusFound, "/manage")
 		return
 	}
+
+	setInviteData(c, invite)
 	c.HTML(http.StatusOK, "register.html")
 }
 
@@ -67,13 +167,11 @@ type RegisterRequest struct {
 	Name        string `form:"name"`
 	Email       string `form:"email"`
 	RawPassword string `form:"password"`
+	Invite      string `form:"invite"`
 }
 
 func Register(c *server.Context) {
-	if config.GetService().DisableRegistration {
-		c.Redirect(http.StatusFound, "/login")
-		return
-	} else if c.GetUser() != nil {
+	if c.GetUser() != nil {
 		c.Redirect(http.StatusFound, "/manage")
 		return
 	}
@@ -81,21 +179,96 @@ func Register(c *server.Context) {
 	payload := &RegisterRequest{}
 	c.Bind(payload)
 
-	rt, st, err := services.Register(services.CreateUserOptions{
+	user, err := services.Register(services.CreateUserOptions{
 		Name:        payload.Name,
 		Email:       payload.Email,
 		RawPassword: payload.RawPassword,
-	})
+	}, payload.Invite)
 	if err != nil {
 		c.SetData("error", err)
+		setInviteData(c, payload.Invite)
 		c.HTML(http.StatusInternalServerError, "register.html")
 		return
 	}
 
-	c.SetTokens(st, rt)
+	c.SetData("message", "A verification link has been sent to "+user.Email+", follow it to log in.")
+	setOIDCData(c)
+	c.HTML(http.StatusOK, "login.html")
+}
+
+func VerifyEmail(c *server.Context) {
+	if err := services.VerifyEmail(c.Query("token")); err != nil {
+		c.SetData("error", err)
+		c.HTML(http.StatusBadRequest, "error.html")
+		return
+	}
+
+	c.SetData("message", "Your email has been verified, you can now log in.")
+	setOIDCData(c)
+	c.HTML(http.StatusOK, "login.html")
+}
+
+func ConfirmEmail(c *server.Context) {
+	if err := services.ConfirmEmailChange(c.Query("token")); err != nil {
+		c.SetData("error", err)
+		c.HTML(http.StatusBadRequest, "error.html")
+		return
+	}
 	c.Redirect(http.StatusFound, "/manage")
 }
 
+func ForgotPasswordPage(c *server.Context) {
+	c.HTML(http.StatusOK, "forgot_password.html")
+}
+
+type ForgotPasswordRequest struct {
+	Email string `form:"email"`
+}
+
+func ForgotPassword(c *server.Context) {
+	payload := &ForgotPasswordRequest{}
+	c.Bind(payload)
+
+	if err := services.RequestPasswordReset(payload.Email); err != nil {
+		c.SetData("error", err)
+		c.HTML(http.StatusInternalServerError, "forgot_password.html")
+		return
+	}
+
+	c.SetData("message", "If an account uses this email, a password reset link has been sent to it.")
+	c.HTML(http.StatusOK, "forgot_password.html")
+}
+
+func ResetPasswordPage(c *server.Context) {
+	c.SetData("token", c.Query("token"))
+	c.HTML(http.StatusOK, "reset_password.html")
+}
+
+type ResetPasswordRequest struct {
+	Token       string `form:"token"`
+	RawPassword string `form:"password"`
+}
+
+func ResetPassword(c *server.Context) {
+	payload := &ResetPasswordRequest{}
+	c.Bind(payload)
+
+	if err := services.ResetPassword(payload.Token, payload.RawPassword); err != nil {
+		c.SetData("error", err)
+		if err == errs.ErrMailTokenInvalid {
+			c.HTML(http.StatusBadRequest, "forgot_password.html")
+		} else {
+			c.SetData("token", payload.Token)
+			c.HTML(http.StatusBadRequest, "reset_password.html")
+		}
+		return
+	}
+
+	c.SetData("message", "Your password has been reset, you can now log in.")
+	setOIDCData(c)
+	c.HTML(http.StatusOK, "login.html")
+}
+
 func Logout(c *server.Context) {
 	st, _ := c.Cookie("session")
 	rt, _ := c.Cookie("refresh")
@@ -105,3 +278,10 @@ func Logout(c *server.Context) {
 	c.SetTokens(nil, nil)
 	c.Redirect(http.StatusFound, "/")
 }
+
+// JWKS publishes the public keys which verify the session tokens,
+// for other services to authenticate the users of Kasen.
+func JWKS(c *server.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, services.GetJWKS())
+}
@@ -49,6 +49,28 @@ func Project(c *server.Context) {
 		return
 	}
 
+	var format string
+	switch strings.ToLower(c.Query("download")) {
+	case "true", services.DownloadFormatZip:
+		format = services.DownloadFormatZip
+	case services.DownloadFormatCBZ:
+		format = services.DownloadFormatCBZ
+	case services.DownloadFormatEPUB:
+		format = services.DownloadFormatEPUB
+	}
+
+	if len(format) > 0 {
+		opts := services.DownloadProjectOptions{Volume: c.Query("volume"), Format: format}
+		if err := services.DownloadProject(params.ID, opts, c.Writer, c.Request); err != nil {
+			if err == errs.ErrProjectNotFound || err == errs.ErrChapterNotFound {
+				c.Status(http.StatusNotFound)
+			} else {
+				c.Status(http.StatusInternalServerError)
+			}
+		}
+		return
+	}
+
 	templateName := "project.html"
 	if c.TryCache(templateName) {
 		return
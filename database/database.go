@@ -5,7 +5,6 @@ import (
 
 	"database/sql"
 	"fmt"
-	"log"
 
 	"kasen/config"
 
@@ -22,33 +21,36 @@ var WriteDB *Database
 //go:embed schema.sql
 var schema []byte
 
-func init() {
+// ConnectDB connects to the database and migrates its schema,
+// it must be called before using ReadDB and WriteDB.
+func ConnectDB() error {
 	cfg := config.GetDatabase()
 	dsn := fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.Name, cfg.User, cfg.Passwd, cfg.SSLMode)
 
 	readConn, err := sql.Open("pgx", dsn)
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
 
 	if err := readConn.Ping(); err != nil {
-		log.Fatalln(err)
+		return err
 	}
 
 	writeConn, err := sql.Open("pgx", dsn)
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
 
 	if err := writeConn.Ping(); err != nil {
-		log.Fatalln(err)
+		return err
 	}
 
 	if _, err = writeConn.Exec(string(schema)); err != nil && err != sql.ErrNoRows {
-		log.Fatalln(err)
+		return err
 	}
 
 	ReadDB = &Database{readConn}
 	WriteDB = &Database{writeConn}
+	return nil
 }
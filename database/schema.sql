@@ -37,6 +37,19 @@ CREATE TABLE IF NOT EXISTS user_account (
 );
 
 
+ALTER TABLE user_account
+  ADD IF NOT EXISTS revoked_permissions VARCHAR(32)[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE user_account
+  ADD IF NOT EXISTS totp_secret     VARCHAR(64) DEFAULT NULL,
+  ADD IF NOT EXISTS totp_enabled_at TIMESTAMP DEFAULT NULL;
+
+-- Users created before email verification are considered verified.
+ALTER TABLE user_account
+  ADD IF NOT EXISTS email_verified_at TIMESTAMP DEFAULT NOW();
+ALTER TABLE user_account
+  ALTER email_verified_at SET DEFAULT NULL;
+
 CREATE UNIQUE INDEX IF NOT EXISTS user_account_email_uindex ON user_account(email);
 CREATE INDEX IF NOT EXISTS user_account_created_at_index ON user_account(created_at);
 CREATE INDEX IF NOT EXISTS user_account_updated_at_index ON user_account(updated_at);
@@ -54,12 +67,16 @@ ALTER TABLE cover
   ADD IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW(),
   ADD IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
   ADD IF NOT EXISTS project_id BIGINT NOT NULL DEFAULT NULL REFERENCES project(id) ON DELETE CASCADE,
-  ADD IF NOT EXISTS file_name  VARCHAR(255) NOT NULL DEFAULT NULL;
+  ADD IF NOT EXISTS file_name  VARCHAR(255) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS deleted_at TIMESTAMP;
 
-CREATE UNIQUE INDEX IF NOT EXISTS cover_pid_fn_uindex ON cover(project_id, file_name);
+-- Covers in the trash don't hold their file name.
+DROP INDEX IF EXISTS cover_pid_fn_uindex;
+CREATE UNIQUE INDEX IF NOT EXISTS cover_pid_fn_active_uindex ON cover(project_id, file_name) WHERE deleted_at IS NULL;
 CREATE INDEX IF NOT EXISTS cover_created_at_index ON cover(created_at);
 CREATE INDEX IF NOT EXISTS cover_updated_at_index ON cover(updated_at);
 CREATE INDEX IF NOT EXISTS cover_project_id_index ON cover(project_id);
+CREATE INDEX IF NOT EXISTS cover_deleted_at_index ON cover(deleted_at);
 
 ALTER TABLE project 
   ADD IF NOT EXISTS slug              VARCHAR(255) NOT NULL DEFAULT NULL,
@@ -73,15 +90,24 @@ ALTER TABLE project
   ADD IF NOT EXISTS project_status    VARCHAR(32) NOT NULL DEFAULT NULL,
   ADD IF NOT EXISTS series_status     VARCHAR(32) NOT NULL DEFAULT NULL,
   ADD IF NOT EXISTS demographic       VARCHAR(32) DEFAULT NULL,
-  ADD IF NOT EXISTS rating            VARCHAR(32) DEFAULT NULL;
+  ADD IF NOT EXISTS rating            VARCHAR(32) DEFAULT NULL,
+  ADD IF NOT EXISTS reading_direction VARCHAR(32) DEFAULT NULL,
+  ADD IF NOT EXISTS scheduled_at      TIMESTAMP,
+  ADD IF NOT EXISTS deleted_at        TIMESTAMP;
 
-CREATE UNIQUE INDEX IF NOT EXISTS project_slug_uindex ON project(slug);
-CREATE UNIQUE INDEX IF NOT EXISTS project_title_uindex ON project(title);
-CREATE UNIQUE INDEX IF NOT EXISTS project_slug_title_uindex ON project(slug, title);
+-- Projects in the trash don't hold their slug and title.
+DROP INDEX IF EXISTS project_slug_uindex;
+DROP INDEX IF EXISTS project_title_uindex;
+DROP INDEX IF EXISTS project_slug_title_uindex;
+CREATE UNIQUE INDEX IF NOT EXISTS project_slug_active_uindex ON project(slug) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS project_title_active_uindex ON project(title) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS project_slug_title_active_uindex ON project(slug, title) WHERE deleted_at IS NULL;
 CREATE INDEX IF NOT EXISTS project_locked_index ON project(locked);
 CREATE INDEX IF NOT EXISTS project_created_at_index ON project(created_at);
 CREATE INDEX IF NOT EXISTS project_updated_at_index ON project(updated_at);
 CREATE INDEX IF NOT EXISTS project_published_at_index ON project(published_at);
+CREATE INDEX IF NOT EXISTS project_scheduled_at_index ON project(scheduled_at);
+CREATE INDEX IF NOT EXISTS project_deleted_at_index ON project(deleted_at);
 CREATE INDEX IF NOT EXISTS project_title_index ON project(title);
 CREATE INDEX IF NOT EXISTS project_cover_id_index ON project(cover_id);
 CREATE INDEX IF NOT EXISTS project_project_status_index ON project(project_status);
@@ -130,12 +156,16 @@ ALTER TABLE chapter
   ADD IF NOT EXISTS chapter       VARCHAR(8) NOT NULL DEFAULT NULL,
   ADD IF NOT EXISTS volume        VARCHAR(8) DEFAULT NULL,
   ADD IF NOT EXISTS title         VARCHAR(128) DEFAULT NULL,
-  ADD IF NOT EXISTS pages         VARCHAR(255)[] DEFAULT NULL;
+  ADD IF NOT EXISTS pages         VARCHAR(255)[] DEFAULT NULL,
+  ADD IF NOT EXISTS scheduled_at  TIMESTAMP,
+  ADD IF NOT EXISTS deleted_at    TIMESTAMP;
 
 CREATE INDEX IF NOT EXISTS chapter_locked_index ON chapter(locked);
 CREATE INDEX IF NOT EXISTS chapter_created_at_index ON chapter(created_at);
 CREATE INDEX IF NOT EXISTS chapter_updated_at_index ON chapter(updated_at);
 CREATE INDEX IF NOT EXISTS chapter_published_at_index ON chapter(published_at);
+CREATE INDEX IF NOT EXISTS chapter_scheduled_at_index ON chapter(scheduled_at);
+CREATE INDEX IF NOT EXISTS chapter_deleted_at_index ON chapter(deleted_at);
 CREATE INDEX IF NOT EXISTS chapter_project_id_index ON chapter(project_id);
 CREATE INDEX IF NOT EXISTS chapter_uploader_id_index ON chapter(uploader_id);
 
@@ -161,4 +191,241 @@ ALTER TABLE statistics
   ADD CONSTRAINT                statistics_check    CHECK(project_id > 0 OR chapter_id > 0);
 
 CREATE UNIQUE INDEX IF NOT EXISTS statistics_project_id_uindex ON statistics(project_id);
-CREATE UNIQUE INDEX IF NOT EXISTS statistics_chapter_id_uindex ON statistics(chapter_id);
\ No newline at end of file
+CREATE UNIQUE INDEX IF NOT EXISTS statistics_chapter_id_uindex ON statistics(chapter_id);
+
+CREATE TABLE IF NOT EXISTS job (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE job
+  ADD IF NOT EXISTS created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS updated_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS user_id       BIGINT DEFAULT NULL REFERENCES user_account(id) ON DELETE SET NULL,
+  ADD IF NOT EXISTS kind          VARCHAR(32) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS payload       JSONB NOT NULL DEFAULT '{}',
+  ADD IF NOT EXISTS status        VARCHAR(32) NOT NULL DEFAULT 'pending',
+  ADD IF NOT EXISTS progress      INTEGER NOT NULL DEFAULT 0,
+  ADD IF NOT EXISTS total         INTEGER NOT NULL DEFAULT 0,
+  ADD IF NOT EXISTS attempts      INTEGER NOT NULL DEFAULT 0,
+  ADD IF NOT EXISTS max_attempts  INTEGER NOT NULL DEFAULT 1,
+  ADD IF NOT EXISTS run_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS started_at    TIMESTAMP,
+  ADD IF NOT EXISTS finished_at   TIMESTAMP,
+  ADD IF NOT EXISTS error         TEXT DEFAULT NULL,
+  ADD IF NOT EXISTS scopes        VARCHAR(32)[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS job_created_at_index ON job(created_at);
+CREATE INDEX IF NOT EXISTS job_user_id_index ON job(user_id);
+CREATE INDEX IF NOT EXISTS job_kind_index ON job(kind);
+CREATE INDEX IF NOT EXISTS job_status_run_at_index ON job(status, run_at);
+
+CREATE TABLE IF NOT EXISTS webhook (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE webhook
+  ADD IF NOT EXISTS created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS updated_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS url           VARCHAR(2048) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS secret        VARCHAR(128) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS events        VARCHAR(64)[] NOT NULL DEFAULT '{}',
+  ADD IF NOT EXISTS active        BOOLEAN NOT NULL DEFAULT TRUE,
+  ADD IF NOT EXISTS description   VARCHAR(256) DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS webhook_created_at_index ON webhook(created_at);
+CREATE INDEX IF NOT EXISTS webhook_active_index ON webhook(active);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE webhook_delivery
+  ADD IF NOT EXISTS created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS updated_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS webhook_id      BIGINT NOT NULL DEFAULT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+  ADD IF NOT EXISTS event           VARCHAR(64) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS payload         JSONB NOT NULL DEFAULT '{}',
+  ADD IF NOT EXISTS status          VARCHAR(32) NOT NULL DEFAULT 'pending',
+  ADD IF NOT EXISTS attempts        INTEGER NOT NULL DEFAULT 0,
+  ADD IF NOT EXISTS response_status INTEGER DEFAULT NULL,
+  ADD IF NOT EXISTS response_body   TEXT DEFAULT NULL,
+  ADD IF NOT EXISTS error           TEXT DEFAULT NULL,
+  ADD IF NOT EXISTS delivered_at    TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_created_at_index ON webhook_delivery(created_at);
+CREATE INDEX IF NOT EXISTS webhook_delivery_webhook_id_index ON webhook_delivery(webhook_id);
+CREATE INDEX IF NOT EXISTS webhook_delivery_status_index ON webhook_delivery(status);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE audit_log
+  ADD IF NOT EXISTS created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS actor_id      BIGINT DEFAULT NULL REFERENCES user_account(id) ON DELETE SET NULL,
+  ADD IF NOT EXISTS actor_name    VARCHAR(32) DEFAULT NULL,
+  ADD IF NOT EXISTS action        VARCHAR(32) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS target_type   VARCHAR(32) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS target_id     BIGINT DEFAULT NULL,
+  ADD IF NOT EXISTS diff          JSONB NOT NULL DEFAULT '{}',
+  ADD IF NOT EXISTS ip            VARCHAR(64) DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS audit_log_created_at_index ON audit_log(created_at);
+CREATE INDEX IF NOT EXISTS audit_log_actor_id_index ON audit_log(actor_id);
+CREATE INDEX IF NOT EXISTS audit_log_action_index ON audit_log(action);
+CREATE INDEX IF NOT EXISTS audit_log_target_index ON audit_log(target_type, target_id);
+
+CREATE TABLE IF NOT EXISTS chapter_revision (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE chapter_revision
+  ADD IF NOT EXISTS created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS chapter_id  BIGINT NOT NULL DEFAULT NULL REFERENCES chapter(id) ON DELETE CASCADE,
+  ADD IF NOT EXISTS user_id     BIGINT DEFAULT NULL REFERENCES user_account(id) ON DELETE SET NULL,
+  ADD IF NOT EXISTS action      VARCHAR(32) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS pages       VARCHAR(255)[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS chapter_revision_created_at_index ON chapter_revision(created_at);
+CREATE INDEX IF NOT EXISTS chapter_revision_chapter_id_index ON chapter_revision(chapter_id);
+CREATE INDEX IF NOT EXISTS chapter_revision_user_id_index ON chapter_revision(user_id);
+
+CREATE TABLE IF NOT EXISTS role (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE role
+  ADD IF NOT EXISTS created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS updated_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS name        VARCHAR(32) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS permissions VARCHAR(32)[] NOT NULL DEFAULT '{}';
+
+CREATE UNIQUE INDEX IF NOT EXISTS role_name_uindex ON role(name);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+  user_id BIGINT NOT NULL DEFAULT NULL REFERENCES user_account(id) ON DELETE CASCADE,
+  role_id BIGINT NOT NULL DEFAULT NULL REFERENCES role(id) ON DELETE CASCADE,
+  PRIMARY KEY(user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_roles_user_id_index ON user_roles(user_id);
+CREATE INDEX IF NOT EXISTS user_roles_role_id_index ON user_roles(role_id);
+
+CREATE TABLE IF NOT EXISTS project_member (
+  created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+  project_id  BIGINT NOT NULL DEFAULT NULL REFERENCES project(id) ON DELETE CASCADE,
+  user_id     BIGINT NOT NULL DEFAULT NULL REFERENCES user_account(id) ON DELETE CASCADE,
+  permissions VARCHAR(32)[] NOT NULL DEFAULT '{}',
+  PRIMARY KEY(project_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS project_member_project_id_index ON project_member(project_id);
+CREATE INDEX IF NOT EXISTS project_member_user_id_index ON project_member(user_id);
+
+CREATE TABLE IF NOT EXISTS scanlation_group_member (
+  created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+  scanlation_group_id BIGINT NOT NULL DEFAULT NULL REFERENCES scanlation_group(id) ON DELETE CASCADE,
+  user_id             BIGINT NOT NULL DEFAULT NULL REFERENCES user_account(id) ON DELETE CASCADE,
+  role                VARCHAR(16) NOT NULL DEFAULT 'member',
+  PRIMARY KEY(scanlation_group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS scanlation_group_member_scanlation_group_id_index ON scanlation_group_member(scanlation_group_id);
+CREATE INDEX IF NOT EXISTS scanlation_group_member_user_id_index ON scanlation_group_member(user_id);
+
+CREATE TABLE IF NOT EXISTS access_token (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE access_token
+  ADD IF NOT EXISTS created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS user_id       BIGINT NOT NULL DEFAULT NULL REFERENCES user_account(id) ON DELETE CASCADE,
+  ADD IF NOT EXISTS name          VARCHAR(64) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS hash          VARCHAR(64) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS prefix        VARCHAR(16) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS scopes        VARCHAR(32)[] NOT NULL DEFAULT '{}',
+  ADD IF NOT EXISTS expires_at    TIMESTAMP DEFAULT NULL,
+  ADD IF NOT EXISTS last_used_at  TIMESTAMP DEFAULT NULL,
+  ADD IF NOT EXISTS last_used_ip  VARCHAR(64) DEFAULT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS access_token_hash_uindex ON access_token(hash);
+CREATE INDEX IF NOT EXISTS access_token_user_id_index ON access_token(user_id);
+
+CREATE TABLE IF NOT EXISTS user_identity (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE user_identity
+  ADD IF NOT EXISTS created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS user_id       BIGINT NOT NULL DEFAULT NULL REFERENCES user_account(id) ON DELETE CASCADE,
+  ADD IF NOT EXISTS issuer        VARCHAR(255) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS subject       VARCHAR(255) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS email         VARCHAR(255) DEFAULT NULL,
+  ADD IF NOT EXISTS last_login_at TIMESTAMP DEFAULT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_identity_issuer_subject_uindex ON user_identity(issuer, subject);
+CREATE INDEX IF NOT EXISTS user_identity_user_id_index ON user_identity(user_id);
+
+CREATE TABLE IF NOT EXISTS recovery_code (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE recovery_code
+  ADD IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS user_id    BIGINT NOT NULL DEFAULT NULL REFERENCES user_account(id) ON DELETE CASCADE,
+  ADD IF NOT EXISTS hash       VARCHAR(64) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS used_at    TIMESTAMP DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS recovery_code_user_id_index ON recovery_code(user_id);
+
+CREATE TABLE IF NOT EXISTS invite (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE invite
+  ADD IF NOT EXISTS created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS creator_id  BIGINT DEFAULT NULL REFERENCES user_account(id) ON DELETE SET NULL,
+  ADD IF NOT EXISTS hash        VARCHAR(64) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS prefix      VARCHAR(16) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS max_uses    INT NOT NULL DEFAULT 1,
+  ADD IF NOT EXISTS uses        INT NOT NULL DEFAULT 0,
+  ADD IF NOT EXISTS role_id     BIGINT DEFAULT NULL REFERENCES role(id) ON DELETE SET NULL,
+  ADD IF NOT EXISTS permissions VARCHAR(32)[] NOT NULL DEFAULT '{}',
+  ADD IF NOT EXISTS expires_at  TIMESTAMP DEFAULT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS invite_hash_uindex ON invite(hash);
+
+CREATE TABLE IF NOT EXISTS signing_key (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE signing_key
+  ADD IF NOT EXISTS created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS kid         VARCHAR(32) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS purpose     VARCHAR(16) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS algorithm   VARCHAR(16) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS private_key TEXT NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS retired_at  TIMESTAMP DEFAULT NULL,
+  ADD IF NOT EXISTS expires_at  TIMESTAMP DEFAULT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS signing_key_kid_uindex ON signing_key(kid);
+CREATE INDEX IF NOT EXISTS signing_key_purpose_index ON signing_key(purpose);
+
+CREATE TABLE IF NOT EXISTS login_failure (
+  id BIGSERIAL PRIMARY KEY
+);
+
+ALTER TABLE login_failure
+  ADD IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  ADD IF NOT EXISTS user_id    BIGINT DEFAULT NULL REFERENCES user_account(id) ON DELETE SET NULL,
+  ADD IF NOT EXISTS email      VARCHAR(255) NOT NULL DEFAULT NULL,
+  ADD IF NOT EXISTS ip         VARCHAR(64) DEFAULT NULL,
+  ADD IF NOT EXISTS user_agent VARCHAR(256) DEFAULT NULL,
+  ADD IF NOT EXISTS reason     VARCHAR(32) NOT NULL DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS login_failure_created_at_index ON login_failure(created_at);
+CREATE INDEX IF NOT EXISTS login_failure_user_id_index ON login_failure(user_id);
+CREATE INDEX IF NOT EXISTS login_failure_email_index ON login_failure(email);
+CREATE INDEX IF NOT EXISTS login_failure_ip_index ON login_failure(ip);
@@ -6,13 +6,45 @@ var ErrUnknown = errors.New("Unknown error")
 var ErrValidation = errors.New("Validation error")
 var ErrInvalidCredentials = errors.New("Invalid credentials")
 var ErrInvalidToken = errors.New("Invalid token")
+var ErrLoginThrottled = errors.New("Too many failed logins, try again in a moment")
+var ErrAccountLocked = errors.New("Account is locked after too many failed logins, try again later or reset your password")
 
 var ErrForbidden = errors.New("Not enough privileges")
 
+var ErrAccessTokenNotFound = errors.New("Access token does not exist")
+var ErrAccessTokenNameRequired = errors.New("Access token name is required")
+var ErrAccessTokenNameTooLong = errors.New("Access token name must be at most 64 characters")
+var ErrAccessTokenScopeInvalid = errors.New("Access token scopes must be permissions of the user")
+var ErrAccessTokenExpirationInvalid = errors.New("Access token expiration must be in the future")
+
+var ErrSessionNotFound = errors.New("Session does not exist")
+
+var ErrTwoFactorAlreadyEnabled = errors.New("Two-factor authentication is already enabled")
+var ErrTwoFactorNotEnabled = errors.New("Two-factor authentication is not enabled")
+var ErrTwoFactorNotEnrolled = errors.New("Two-factor authentication enrollment has not been started")
+var ErrTwoFactorCodeRequired = errors.New("Two-factor authentication code is required")
+var ErrTwoFactorCodeInvalid = errors.New("Invalid two-factor authentication code")
+var ErrTwoFactorChallengeInvalid = errors.New("Two-factor authentication request is invalid or has expired")
+
+var ErrOIDCDisabled = errors.New("OpenID Connect login is disabled")
+var ErrOIDCProvider = errors.New("Failed to reach the identity provider")
+var ErrOIDCStateInvalid = errors.New("Login request is invalid or has expired")
+var ErrOIDCEmailRequired = errors.New("Identity provider did not return a verified email")
+var ErrIdentityAlreadyLinked = errors.New("Identity is already linked to another user")
+var ErrIdentityNotFound = errors.New("Identity does not exist")
+var ErrRegistrationDisabled = errors.New("Registration is disabled")
+
+var ErrInviteNotFound = errors.New("Invite does not exist")
+var ErrInviteRequired = errors.New("Invite code is required")
+var ErrInviteInvalid = errors.New("Invite code is invalid, expired or has already been used")
+var ErrInviteMaxUsesInvalid = errors.New("Invite uses must be between 1 and 1000")
+var ErrInviteExpirationInvalid = errors.New("Invite expiration must be in the future")
+
 var ErrInvalidProjectStatus = errors.New("Invalid project status")
 var ErrInvalidSeriesStatus = errors.New("Invalid series status")
 var ErrInvalidDemographic = errors.New("Invalid demographic")
 var ErrInvalidRating = errors.New("Invalid rating")
+var ErrInvalidReadingDirection = errors.New("Invalid reading direction")
 
 var ErrMenuAlreadyExists = errors.New("Menu already exists")
 var ErrMenuNotFound = errors.New("Menu not found")
@@ -40,6 +72,9 @@ var ErrEmailRequired = errors.New("Email is required")
 var ErrEmailTooLong = errors.New("Email must be at most 255 characters")
 var ErrEmailInvalid = errors.New("Email is invalid")
 var ErrEmailTaken = errors.New("Email is already taken")
+var ErrEmailNotVerified = errors.New("Email is not verified, check your inbox for the verification email")
+var ErrEmailUnchanged = errors.New("Email is the same as the current one")
+var ErrMailTokenInvalid = errors.New("Link is invalid or has expired")
 var ErrPasswordRequired = errors.New("Password is required")
 var ErrPasswordTooShort = errors.New("Password must be at least 6 characters")
 var ErrCurrentPasswordRequired = errors.New("Current password is required")
@@ -47,11 +82,21 @@ var ErrCurrentPasswordTooShort = errors.New("Current password must be at least 6
 var ErrNewPasswordRequired = errors.New("New password is required")
 var ErrNewPasswordTooShort = errors.New("New password must be at least 6 characters")
 var ErrPermissionRequired = errors.New("Permission is required")
+var ErrPermissionInvalid = errors.New("Permission is invalid")
+
+var ErrRoleAlreadyExists = errors.New("Role already exists")
+var ErrRoleNotFound = errors.New("Role does not exist")
+var ErrRoleNameRequired = errors.New("Role name is required")
+var ErrRoleNameTooLong = errors.New("Role name must be at most 32 characters")
 
 var ErrScanlationGroupAlreadyExists = errors.New("Scanlation group already exists")
 var ErrScanlationGroupNotFound = errors.New("Scanlation group does not exist")
 var ErrScanlationGroupNameRequired = errors.New("Scanlation group name is required")
 var ErrScanlationGroupNameTooLong = errors.New("Scanlation group name must be at most 128 characters")
+var ErrScanlationGroupMemberNotFound = errors.New("Scanlation group member does not exist")
+var ErrScanlationGroupRoleInvalid = errors.New("Scanlation group role must be either leader or member")
+var ErrScanlationGroupLeaderRequired = errors.New("Scanlation group must have a leader")
+var ErrScanlationGroupMembersOnly = errors.New("Scanlation group can only be credited by its members")
 
 var ErrProjectAlreadyExists = errors.New("Project already exists")
 var ErrProjectNotFound = errors.New("Project does not exist")
@@ -62,6 +107,7 @@ var ErrProjectStatusRequired = errors.New("Project status is required")
 var ErrProjectSeriesStatusRequired = errors.New("Project series status is required")
 var ErrProjectLocked = errors.New("Project is locked")
 var ErrProjectMdFetchFailed = errors.New("Failed to fetch project from MangaDex")
+var ErrProjectMemberNotFound = errors.New("Project member does not exist")
 
 var ErrCoverAlreadyExists = errors.New("Cover already exists")
 var ErrCoverNotFound = errors.New("Cover does not exist")
@@ -87,3 +133,27 @@ var ErrPageInvalid = errors.New("Page data is invalid")
 var ErrPageUnkownFormat = errors.New("Page format is unknown")
 var ErrPageUnsupportedFormat = errors.New("Page format is not supported")
 var ErrPageMdFetchFailed = errors.New("Failed to fetch pages from MangaDex")
+
+var ErrArchiveInvalid = errors.New("Archive is invalid")
+var ErrArchiveEmpty = errors.New("Archive does not contain any pages")
+var ErrArchiveTooManyPages = errors.New("Archive contains too many pages")
+var ErrArchiveTooLarge = errors.New("Archive uncompressed size exceeds the limit")
+
+var ErrRevisionNotFound = errors.New("Revision does not exist")
+
+var ErrJobNotFound = errors.New("Job not found")
+var ErrJobFinished = errors.New("Job has already finished")
+var ErrInvalidJobKind = errors.New("Invalid job kind")
+var ErrInvalidJobPayload = errors.New("Invalid job payload")
+
+var ErrChapterPublished = errors.New("Chapter is already published")
+var ErrProjectPublished = errors.New("Project is already published")
+var ErrInvalidScheduleTime = errors.New("Scheduled time must be in the future")
+
+var ErrWebhookNotFound = errors.New("Webhook does not exist")
+var ErrWebhookURLInvalid = errors.New("Webhook URL must be a valid http or https URL of at most 2048 characters")
+var ErrWebhookSecretTooLong = errors.New("Webhook secret must be at most 128 characters")
+var ErrWebhookDescriptionTooLong = errors.New("Webhook description must be at most 256 characters")
+var ErrWebhookEventsRequired = errors.New("Webhook events are required")
+var ErrWebhookEventInvalid = errors.New("Webhook event is invalid")
+var ErrWebhookDeliveryNotFound = errors.New("Webhook delivery does not exist")
@@ -1,53 +1,106 @@
 package main
 
 import (
+	"bufio"
+	"errors"
+	"io"
 	"log"
 	"os"
-	"strconv"
+
+	"kasen/internal/resize"
 
 	"github.com/h2non/bimg"
 )
 
+// The worker reads resize requests from stdin and writes the
+// results to stdout until stdin is closed, see package resize
+// for the protocol.
 func main() {
-	args := os.Args
-	if len(args) <= 1 {
-		return
-	}
+	log.SetPrefix("kasen-image: ")
+
+	bimg.VipsCacheSetMax(0)
+	bimg.VipsCacheSetMaxMem(0)
 
-	input := args[1]
-	width, _ := strconv.Atoi(args[2])
-	height, _ := strconv.Atoi(args[3])
-	crop := args[4]
+	r := bufio.NewReader(os.Stdin)
+	w := bufio.NewWriter(os.Stdout)
 
-	buf, err := os.ReadFile(input)
-	if err != nil {
+	hello := &resize.Hello{}
+	for format, t := range imageTypes {
+		if bimg.IsTypeSupportedSave(t) {
+			hello.Formats = append(hello.Formats, format)
+		}
+	}
+
+	if err := resize.WriteHello(w, hello); err != nil {
+		log.Fatalln(err)
+	} else if err := w.Flush(); err != nil {
 		log.Fatalln(err)
-		return
 	}
 
-	bimg.VipsCacheSetMax(0)
-	bimg.VipsCacheSetMaxMem(0)
+	for {
+		req, err := resize.ReadRequest(r)
+		if err != nil {
+			if err != io.EOF {
+				log.Fatalln(err)
+			}
+			return
+		}
 
-	jpeg, err := bimg.NewImage(buf).Convert(bimg.JPEG)
-	if err != nil {
-		log.Fatalln(err)
-		return
+		out, err := process(req)
+		if err := resize.WriteResponse(w, out, err); err != nil {
+			log.Fatalln(err)
+		}
+
+		if err := w.Flush(); err != nil {
+			log.Fatalln(err)
+		}
 	}
+}
 
-	out, err := bimg.NewImage(jpeg).Process(bimg.Options{
-		Width:         width,
-		Height:        height,
-		StripMetadata: true,
-		Crop:          crop == "true",
-		Quality:       85,
-		Interlace:     true,
-		Interpolator:  bimg.Bicubic,
-	})
+var imageTypes = map[string]bimg.ImageType{
+	resize.FormatAVIF: bimg.AVIF,
+	resize.FormatWebP: bimg.WEBP,
+	resize.FormatJPEG: bimg.JPEG,
+}
+
+var qualities = map[string]int{
+	resize.FormatAVIF: 60,
+	resize.FormatWebP: 80,
+	resize.FormatJPEG: 85,
+}
+
+func process(req *resize.Request) ([]byte, error) {
+	format := req.Format
+	if len(format) == 0 {
+		format = resize.FormatJPEG
+	}
 
+	t, ok := imageTypes[format]
+	if !ok {
+		return nil, errors.New("Unsupported format")
+	}
+
+	buf, err := os.ReadFile(req.Input)
 	if err != nil {
-		log.Fatalln(err)
-		return
+		return nil, err
+	}
+
+	// JPEG has no alpha channel, so the image has to be converted
+	// before it can be processed.
+	if t == bimg.JPEG {
+		if buf, err = bimg.NewImage(buf).Convert(bimg.JPEG); err != nil {
+			return nil, err
+		}
 	}
 
-	os.Stdout.Write(out)
+	return bimg.NewImage(buf).Process(bimg.Options{
+		Width:         req.Width,
+		Height:        req.Height,
+		StripMetadata: true,
+		Crop:          req.Crop,
+		Quality:       qualities[format],
+		Interlace:     t == bimg.JPEG,
+		Interpolator:  bimg.Bicubic,
+		Type:          t,
+	})
 }
@@ -0,0 +1,267 @@
+package resize
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrQueueFull = errors.New("Resize queue is full")
+	ErrTimeout   = errors.New("Resize timed out")
+)
+
+// PoolOptions represents the options of a worker pool.
+type PoolOptions struct {
+	// Path is the path of the worker binary.
+	Path string
+
+	// Workers is the number of worker processes,
+	// which is also the number of concurrent resizes.
+	Workers int
+
+	// QueueSize is the number of requests which can wait for a worker,
+	// requests are rejected with ErrQueueFull once the queue is full.
+	QueueSize int
+
+	// MaxRSS is the resident set size in bytes after which a worker
+	// is restarted, vips does not give memory back to the system.
+	// Zero disables the limit.
+	//
+	// The RSS is read from /proc, so the limit is only enforced on Linux.
+	MaxRSS int64
+
+	// Timeout is the maximum duration of a single request,
+	// the worker is killed if it has been exceeded.
+	Timeout time.Duration
+}
+
+// Pool is a pool of supervised worker processes.
+//
+// Processes are started lazily, restarted when they crash, time out
+// or exceed the RSS limit, and reused for subsequent requests.
+type Pool struct {
+	opts    PoolOptions
+	jobs    chan *job
+	formats atomic.Value
+}
+
+type job struct {
+	req    *Request
+	result chan result
+}
+
+type result struct {
+	out []byte
+	err error
+}
+
+// NewPool creates a worker pool and starts its supervisors.
+func NewPool(opts PoolOptions) *Pool {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+
+	if opts.QueueSize < 0 {
+		opts.QueueSize = 0
+	}
+
+	p := &Pool{
+		opts: opts,
+		jobs: make(chan *job, opts.QueueSize),
+	}
+	p.formats.Store([]string{FormatJPEG})
+
+	if opts.MaxRSS > 0 && runtime.GOOS != "linux" {
+		log.Println("The RSS of image workers can only be limited on Linux, max_rss is ignored")
+	}
+
+	for i := 0; i < opts.Workers; i++ {
+		go p.supervise()
+	}
+	return p
+}
+
+// Do sends the given request to an idle worker and waits for the result.
+func (p *Pool) Do(req *Request) ([]byte, error) {
+	j := &job{req: req, result: make(chan result, 1)}
+
+	select {
+	case p.jobs <- j:
+	default:
+		return nil, ErrQueueFull
+	}
+
+	r := <-j.result
+	return r.out, r.err
+}
+
+// Formats returns the output formats supported by the workers.
+//
+// Only FormatJPEG is returned until a worker has been started.
+func (p *Pool) Formats() []string {
+	return p.formats.Load().([]string)
+}
+
+// Supports checks if the given output format is supported by the workers.
+func (p *Pool) Supports(format string) bool {
+	for _, f := range p.Formats() {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Pool) start() (*worker, error) {
+	w, hello, err := startWorker(p.opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hello.Formats) > 0 {
+		p.formats.Store(hello.Formats)
+	}
+	return w, nil
+}
+
+// supervise processes jobs with a single worker process.
+//
+// The process is started right away, so that the supported
+// formats are known before the first request.
+func (p *Pool) supervise() {
+	w, err := p.start()
+	if err != nil {
+		log.Println("Failed to start image worker:", err)
+	}
+
+	for j := range p.jobs {
+		if w == nil {
+			if w, err = p.start(); err != nil {
+				j.result <- result{err: err}
+				continue
+			}
+		}
+
+		out, err := w.do(j.req, p.opts.Timeout)
+		j.result <- result{out, err}
+
+		var werr *WorkerError
+		if err != nil && !errors.As(err, &werr) {
+			log.Println("Restarting image worker:", err)
+			w.kill()
+			w = nil
+		} else if p.opts.MaxRSS > 0 {
+			if rss := w.rss(); rss > p.opts.MaxRSS {
+				log.Printf("Restarting image worker: RSS %d exceeds the limit of %d bytes\n", rss, p.opts.MaxRSS)
+				w.stop()
+				w = nil
+			}
+		}
+	}
+}
+
+// worker represents a worker process.
+type worker struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	writer *bufio.Writer
+	reader *bufio.Reader
+}
+
+func startWorker(path string) (*worker, *Hello, error) {
+	cmd := exec.Command(path)
+	cmd.Env = os.Environ()
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+
+	w := &worker{
+		cmd:    cmd,
+		stdin:  stdin,
+		writer: bufio.NewWriter(stdin),
+		reader: bufio.NewReader(stdout),
+	}
+
+	hello, err := ReadHello(w.reader)
+	if err != nil {
+		w.kill()
+		return nil, nil, err
+	}
+	return w, hello, nil
+}
+
+func (w *worker) do(req *Request, timeout time.Duration) ([]byte, error) {
+	var timedOut int32
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			atomic.StoreInt32(&timedOut, 1)
+			w.cmd.Process.Kill()
+		})
+		defer timer.Stop()
+	}
+
+	if err := WriteRequest(w.writer, req); err != nil {
+		return nil, err
+	}
+
+	if err := w.writer.Flush(); err != nil {
+		return nil, err
+	}
+
+	out, err := ReadResponse(w.reader)
+	if err != nil && atomic.LoadInt32(&timedOut) == 1 {
+		return nil, ErrTimeout
+	}
+	return out, err
+}
+
+// stop closes the stdin of the worker and waits for it to exit.
+func (w *worker) stop() {
+	w.stdin.Close()
+	w.cmd.Wait()
+}
+
+// kill kills the worker immediately.
+func (w *worker) kill() {
+	w.cmd.Process.Kill()
+	w.stop()
+}
+
+// rss returns the resident set size of the worker in bytes,
+// or zero if it could not be determined, which is always
+// the case on systems without /proc/<pid>/statm.
+func (w *worker) rss() int64 {
+	buf, err := os.ReadFile("/proc/" + strconv.Itoa(w.cmd.Process.Pid) + "/statm")
+	if err != nil {
+		return 0
+	}
+
+	fields := strings.Fields(string(buf))
+	if len(fields) < 2 {
+		return 0
+	}
+
+	pages, _ := strconv.ParseInt(fields[1], 10, 64)
+	return pages * int64(os.Getpagesize())
+}
@@ -0,0 +1,191 @@
+// Package resize implements the protocol spoken between Kasen
+// and the image worker (internal/cmd/image), and a supervised
+// pool of worker processes.
+//
+// Every message is a frame, which is a 4-byte big-endian length
+// followed by the payload. Once started, the worker writes a frame
+// containing a JSON-encoded Hello. A request is a single frame
+// containing a JSON-encoded Request, and a response is a frame
+// containing a JSON-encoded ResponseHeader followed by a frame
+// containing the resized image (empty if the request failed).
+package resize
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// maxFrameSize is the maximum size of a frame, it's large enough
+// for any image allowed by page_max_file_size.
+const maxFrameSize = 256 << 20
+
+var ErrFrameTooLarge = errors.New("Frame too large")
+
+// Output formats, in the order of preference.
+const (
+	FormatAVIF = "avif"
+	FormatWebP = "webp"
+	FormatJPEG = "jpeg"
+)
+
+// Extensions maps the output formats to file extensions.
+var Extensions = map[string]string{
+	FormatAVIF: "avif",
+	FormatWebP: "webp",
+	FormatJPEG: "jpg",
+}
+
+// Hello represents the first message written by the worker.
+type Hello struct {
+	// Formats are the output formats supported by the worker,
+	// which depend on how libvips has been built.
+	Formats []string `json:"formats"`
+}
+
+// Request represents a resize request.
+type Request struct {
+	Input  string `json:"input"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Crop   bool   `json:"crop"`
+
+	// Format is the output format, defaults to FormatJPEG.
+	Format string `json:"format,omitempty"`
+}
+
+// ResponseHeader represents the header of a resize response.
+type ResponseHeader struct {
+	Error string `json:"error,omitempty"`
+}
+
+// WriteFrame writes the given payload as a frame.
+func WriteFrame(w io.Writer, payload []byte) error {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(payload)))
+	if _, err := w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// ReadFrame reads a frame and returns its payload.
+func ReadFrame(r io.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, err
+	}
+
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxFrameSize {
+		return nil, ErrFrameTooLarge
+	}
+
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return payload, nil
+}
+
+// WriteHello writes the given hello.
+func WriteHello(w io.Writer, hello *Hello) error {
+	buf, err := json.Marshal(hello)
+	if err != nil {
+		return err
+	}
+	return WriteFrame(w, buf)
+}
+
+// ReadHello reads a hello.
+func ReadHello(r io.Reader) (*Hello, error) {
+	buf, err := ReadFrame(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hello := &Hello{}
+	if err := json.Unmarshal(buf, hello); err != nil {
+		return nil, err
+	}
+	return hello, nil
+}
+
+// WriteRequest writes the given request.
+func WriteRequest(w io.Writer, req *Request) error {
+	buf, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return WriteFrame(w, buf)
+}
+
+// ReadRequest reads a request.
+func ReadRequest(r io.Reader) (*Request, error) {
+	buf, err := ReadFrame(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &Request{}
+	if err := json.Unmarshal(buf, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// WriteResponse writes the result of a request.
+func WriteResponse(w io.Writer, out []byte, err error) error {
+	header := &ResponseHeader{}
+	if err != nil {
+		header.Error = err.Error()
+		out = nil
+	}
+
+	buf, _ := json.Marshal(header)
+	if err := WriteFrame(w, buf); err != nil {
+		return err
+	}
+	return WriteFrame(w, out)
+}
+
+// ReadResponse reads the result of a request.
+//
+// The returned error is either a *WorkerError if the worker failed
+// to process the request, or an error of the underlying reader.
+func ReadResponse(r io.Reader) ([]byte, error) {
+	buf, err := ReadFrame(r)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &ResponseHeader{}
+	if err := json.Unmarshal(buf, header); err != nil {
+		return nil, err
+	}
+
+	out, err := ReadFrame(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(header.Error) > 0 {
+		return nil, &WorkerError{header.Error}
+	}
+	return out, nil
+}
+
+// WorkerError represents an error returned by the worker,
+// the worker is still usable after returning it.
+type WorkerError struct {
+	Message string
+}
+
+func (e *WorkerError) Error() string {
+	return e.Message
+}
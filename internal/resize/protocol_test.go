@@ -0,0 +1,144 @@
+package resize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func frame(size uint32, payload []byte) []byte {
+	buf := make([]byte, 4, 4+len(payload))
+	binary.BigEndian.PutUint32(buf, size)
+	return append(buf, payload...)
+}
+
+func TestReadFrame(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  []byte
+		err   error
+	}{
+		{"payload", frame(5, []byte("hello")), []byte("hello"), nil},
+		{"empty payload", frame(0, nil), []byte{}, nil},
+		{"too large", frame(maxFrameSize+1, nil), nil, ErrFrameTooLarge},
+		{"truncated payload", frame(5, []byte("hel")), nil, io.ErrUnexpectedEOF},
+		{"missing payload", frame(5, nil), nil, io.ErrUnexpectedEOF},
+		{"truncated size", []byte{0, 0}, nil, io.ErrUnexpectedEOF},
+		{"end of stream", nil, nil, io.EOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadFrame(bytes.NewReader(tt.input))
+			if err != tt.err {
+				t.Fatalf("ReadFrame() error = %v, want %v", err, tt.err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("ReadFrame() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrameRoundTrip(t *testing.T) {
+	payloads := [][]byte{nil, []byte("a"), bytes.Repeat([]byte{0xff}, 1<<16)}
+
+	buf := &bytes.Buffer{}
+	for _, payload := range payloads {
+		if err := WriteFrame(buf, payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, want := range payloads {
+		got, err := ReadFrame(buf)
+		if err != nil {
+			t.Fatalf("ReadFrame(%d) error = %v", i, err)
+		} else if !bytes.Equal(got, want) {
+			t.Errorf("ReadFrame(%d) = %d bytes, want %d bytes", i, len(got), len(want))
+		}
+	}
+
+	if _, err := ReadFrame(buf); err != io.EOF {
+		t.Errorf("ReadFrame() after the last frame error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	hello := &Hello{Formats: []string{FormatJPEG, FormatWebP}}
+	if err := WriteHello(buf, hello); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ReadHello(buf); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got, hello) {
+		t.Errorf("ReadHello() = %+v, want %+v", got, hello)
+	}
+
+	req := &Request{Input: "/data/1.png", Width: 320, Height: 480, Crop: true, Format: FormatAVIF}
+	if err := WriteRequest(buf, req); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ReadRequest(buf); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got, req) {
+		t.Errorf("ReadRequest() = %+v, want %+v", got, req)
+	}
+}
+
+func TestResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		out  []byte
+		err  error
+		want []byte
+	}{
+		{"image", []byte("image"), nil, []byte("image")},
+		{"empty image", nil, nil, []byte{}},
+		{"worker error", []byte("partial image"), errors.New("unsupported image"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := WriteResponse(buf, tt.out, tt.err); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadResponse(buf)
+			if tt.err != nil {
+				var werr *WorkerError
+				if !errors.As(err, &werr) || werr.Message != tt.err.Error() {
+					t.Fatalf("ReadResponse() error = %v, want a worker error %q", err, tt.err)
+				}
+			} else if err != nil {
+				t.Fatalf("ReadResponse() error = %v", err)
+			}
+
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("ReadResponse() = %q, want %q", got, tt.want)
+			}
+			if buf.Len() > 0 {
+				t.Errorf("ReadResponse() left %d bytes unread", buf.Len())
+			}
+		})
+	}
+}
+
+func TestReadResponseTruncated(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteResponse(buf, []byte("image"), nil); err != nil {
+		t.Fatal(err)
+	}
+	input := buf.Bytes()[:buf.Len()-1]
+
+	if _, err := ReadResponse(bytes.NewReader(input)); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadResponse() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
@@ -0,0 +1,35 @@
+package mail
+
+import (
+	"fmt"
+	netmail "net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"kasen/config"
+)
+
+// fileTransport writes messages to a directory as .eml files,
+// which is meant for local testing.
+type fileTransport struct {
+	dir string
+}
+
+func newFileTransport(c config.Mail) *fileTransport {
+	dir := c.Dir
+	if len(dir) == 0 {
+		dir = filepath.Join(config.GetDirectories().Root, "mail")
+	}
+	return &fileTransport{dir}
+}
+
+func (t *fileTransport) Send(from *netmail.Address, to string, msg []byte) error {
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), strings.NewReplacer("/", "_", "\\", "_").Replace(to))
+	return os.WriteFile(filepath.Join(t.dir, name), msg, 0644)
+}
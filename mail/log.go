@@ -0,0 +1,15 @@
+package mail
+
+import (
+	"log"
+	netmail "net/mail"
+)
+
+// logTransport prints messages to the log instead of delivering them,
+// which is meant for local testing.
+type logTransport struct{}
+
+func (t *logTransport) Send(from *netmail.Address, to string, msg []byte) error {
+	log.Printf("Mail from %s to %s:\n%s\n", from.Address, to, msg)
+	return nil
+}
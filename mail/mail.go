@@ -0,0 +1,118 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"log"
+	"mime"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"strings"
+	"text/template"
+	"time"
+
+	"kasen/config"
+
+	"github.com/google/uuid"
+)
+
+// Message represents a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Transport delivers messages.
+type Transport interface {
+	Send(from *netmail.Address, to string, msg []byte) error
+}
+
+//go:embed templates/*.txt
+var templatesFS embed.FS
+
+var templates = template.Must(template.ParseFS(templatesFS, "templates/*.txt"))
+
+var transport Transport
+
+func init() {
+	c := config.GetMail()
+
+	switch c.Transport {
+	case "smtp":
+		transport = &smtpTransport{c}
+	case "file":
+		transport = newFileTransport(c)
+	case "log":
+		transport = &logTransport{}
+	default:
+		log.Fatalf("Unknown mail transport %q\n", c.Transport)
+	}
+}
+
+// Render renders the message of the given template to the given address.
+// Templates define a "<name>.subject" and a "<name>.body" template.
+func Render(name, to string, data interface{}) (*Message, error) {
+	var subject, body bytes.Buffer
+	if err := templates.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return nil, err
+	} else if err := templates.ExecuteTemplate(&body, name+".body", data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
+
+// Send sends the given message with the configured transport.
+func Send(msg *Message) error {
+	from, err := netmail.ParseAddress(config.GetMail().From)
+	if err != nil {
+		return err
+	}
+
+	to, err := netmail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+
+	buf, err := msg.bytes(from, to)
+	if err != nil {
+		return err
+	}
+	return transport.Send(from, to.Address, buf)
+}
+
+// bytes formats the message as per RFC 5322.
+func (msg *Message) bytes(from, to *netmail.Address) ([]byte, error) {
+	var buf bytes.Buffer
+
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+	headers := [][2]string{
+		{"From", from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", msg.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", uuid.NewString(), domain)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+
+	for _, h := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h[0], h[1])
+	}
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(strings.ReplaceAll(msg.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	} else if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,74 @@
+package mail
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"kasen/config"
+)
+
+const smtpTimeout = 30 * time.Second
+
+// smtpTransport delivers messages to an SMTP server.
+type smtpTransport struct {
+	config.Mail
+}
+
+func (t *smtpTransport) Send(from *netmail.Address, to string, msg []byte) error {
+	addr := net.JoinHostPort(t.Host, strconv.Itoa(t.Port))
+	tlsConfig := &tls.Config{ServerName: t.Host}
+
+	var conn net.Conn
+	var err error
+	if t.Security == "tls" {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: smtpTimeout}, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, smtpTimeout)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	c, err := smtp.NewClient(conn, t.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if t.Security == "starttls" {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%s does not support STARTTLS", addr)
+		} else if err := c.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if len(t.User) > 0 {
+		if err := c.Auth(smtp.PlainAuth("", t.User, t.Passwd, t.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	} else if err := c.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	} else if _, err := w.Write(msg); err != nil {
+		return err
+	} else if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
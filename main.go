@@ -4,8 +4,10 @@ import (
 	"log"
 	"os"
 
+	"kasen/cache"
 	"kasen/controllers"
 	"kasen/controllers/api"
+	"kasen/database"
 	"kasen/server"
 	"kasen/services"
 )
@@ -13,6 +15,14 @@ import (
 func init() {
 	os.Setenv("MALLOC_ARENA_MAX", "2")
 
+	if err := database.ConnectDB(); err != nil {
+		log.Fatalln(err)
+	}
+
+	if err := cache.ConnectRedis(); err != nil {
+		log.Fatalln(err)
+	}
+
 	if err := services.MkdirAll(services.GetTempDir()); err != nil {
 		log.Fatalln(err)
 	}
@@ -21,6 +31,7 @@ func init() {
 		log.Fatalln(err)
 	}
 
+	services.CreateDefaultRoles()
 	setup()
 }
 
@@ -31,5 +42,10 @@ func main() {
 	controllers.Init()
 	api.Init()
 
+	services.StartKeyRotation()
+	services.StartImageWorkers()
+	services.StartJobWorkers()
+	services.StartScheduler()
+	services.StartTrashPurger()
 	server.Start()
 }
@@ -0,0 +1,1180 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// AccessToken is an object representing the database table.
+type AccessToken struct {
+	ID         int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt  time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UserID     int64             `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Name       string            `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Hash       string            `boil:"hash" json:"hash" toml:"hash" yaml:"hash"`
+	Prefix     string            `boil:"prefix" json:"prefix" toml:"prefix" yaml:"prefix"`
+	Scopes     types.StringArray `boil:"scopes" json:"scopes" toml:"scopes" yaml:"scopes"`
+	ExpiresAt  null.Time         `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
+	LastUsedAt null.Time         `boil:"last_used_at" json:"last_used_at,omitempty" toml:"last_used_at" yaml:"last_used_at,omitempty"`
+	LastUsedIP null.String       `boil:"last_used_ip" json:"last_used_ip,omitempty" toml:"last_used_ip" yaml:"last_used_ip,omitempty"`
+
+	R *accessTokenR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L accessTokenL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AccessTokenColumns = struct {
+	ID         string
+	CreatedAt  string
+	UserID     string
+	Name       string
+	Hash       string
+	Prefix     string
+	Scopes     string
+	ExpiresAt  string
+	LastUsedAt string
+	LastUsedIP string
+}{
+	ID:         "id",
+	CreatedAt:  "created_at",
+	UserID:     "user_id",
+	Name:       "name",
+	Hash:       "hash",
+	Prefix:     "prefix",
+	Scopes:     "scopes",
+	ExpiresAt:  "expires_at",
+	LastUsedAt: "last_used_at",
+	LastUsedIP: "last_used_ip",
+}
+
+var AccessTokenTableColumns = struct {
+	ID         string
+	CreatedAt  string
+	UserID     string
+	Name       string
+	Hash       string
+	Prefix     string
+	Scopes     string
+	ExpiresAt  string
+	LastUsedAt string
+	LastUsedIP string
+}{
+	ID:         "access_token.id",
+	CreatedAt:  "access_token.created_at",
+	UserID:     "access_token.user_id",
+	Name:       "access_token.name",
+	Hash:       "access_token.hash",
+	Prefix:     "access_token.prefix",
+	Scopes:     "access_token.scopes",
+	ExpiresAt:  "access_token.expires_at",
+	LastUsedAt: "access_token.last_used_at",
+	LastUsedIP: "access_token.last_used_ip",
+}
+
+// Generated where
+
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelpertypes_StringArray struct{ field string }
+
+func (w whereHelpertypes_StringArray) EQ(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_StringArray) NEQ(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_StringArray) LT(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_StringArray) LTE(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_StringArray) GT(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var AccessTokenWhere = struct {
+	ID         whereHelperint64
+	CreatedAt  whereHelpertime_Time
+	UserID     whereHelperint64
+	Name       whereHelperstring
+	Hash       whereHelperstring
+	Prefix     whereHelperstring
+	Scopes     whereHelpertypes_StringArray
+	ExpiresAt  whereHelpernull_Time
+	LastUsedAt whereHelpernull_Time
+	LastUsedIP whereHelpernull_String
+}{
+	ID:         whereHelperint64{field: "\"access_token\".\"id\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"access_token\".\"created_at\""},
+	UserID:     whereHelperint64{field: "\"access_token\".\"user_id\""},
+	Name:       whereHelperstring{field: "\"access_token\".\"name\""},
+	Hash:       whereHelperstring{field: "\"access_token\".\"hash\""},
+	Prefix:     whereHelperstring{field: "\"access_token\".\"prefix\""},
+	Scopes:     whereHelpertypes_StringArray{field: "\"access_token\".\"scopes\""},
+	ExpiresAt:  whereHelpernull_Time{field: "\"access_token\".\"expires_at\""},
+	LastUsedAt: whereHelpernull_Time{field: "\"access_token\".\"last_used_at\""},
+	LastUsedIP: whereHelpernull_String{field: "\"access_token\".\"last_used_ip\""},
+}
+
+// AccessTokenRels is where relationship names are stored.
+var AccessTokenRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// accessTokenR is where relationships are stored.
+type accessTokenR struct {
+	User *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*accessTokenR) NewStruct() *accessTokenR {
+	return &accessTokenR{}
+}
+
+// accessTokenL is where Load methods for each relationship are stored.
+type accessTokenL struct{}
+
+var (
+	accessTokenAllColumns            = []string{"id", "created_at", "user_id", "name", "hash", "prefix", "scopes", "expires_at", "last_used_at", "last_used_ip"}
+	accessTokenColumnsWithoutDefault = []string{"user_id", "expires_at", "last_used_at"}
+	accessTokenColumnsWithDefault    = []string{"id", "created_at", "name", "hash", "prefix", "scopes", "last_used_ip"}
+	accessTokenPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// AccessTokenSlice is an alias for a slice of pointers to AccessToken.
+	// This should almost always be used instead of []AccessToken.
+	AccessTokenSlice []*AccessToken
+	// AccessTokenHook is the signature for custom AccessToken hook methods
+	AccessTokenHook func(boil.Executor, *AccessToken) error
+
+	accessTokenQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	accessTokenType                 = reflect.TypeOf(&AccessToken{})
+	accessTokenMapping              = queries.MakeStructMapping(accessTokenType)
+	accessTokenPrimaryKeyMapping, _ = queries.BindMapping(accessTokenType, accessTokenMapping, accessTokenPrimaryKeyColumns)
+	accessTokenInsertCacheMut       sync.RWMutex
+	accessTokenInsertCache          = make(map[string]insertCache)
+	accessTokenUpdateCacheMut       sync.RWMutex
+	accessTokenUpdateCache          = make(map[string]updateCache)
+	accessTokenUpsertCacheMut       sync.RWMutex
+	accessTokenUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var accessTokenBeforeInsertHooks []AccessTokenHook
+var accessTokenBeforeUpdateHooks []AccessTokenHook
+var accessTokenBeforeDeleteHooks []AccessTokenHook
+var accessTokenBeforeUpsertHooks []AccessTokenHook
+
+var accessTokenAfterInsertHooks []AccessTokenHook
+var accessTokenAfterSelectHooks []AccessTokenHook
+var accessTokenAfterUpdateHooks []AccessTokenHook
+var accessTokenAfterDeleteHooks []AccessTokenHook
+var accessTokenAfterUpsertHooks []AccessTokenHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *AccessToken) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range accessTokenBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *AccessToken) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range accessTokenBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *AccessToken) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range accessTokenBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *AccessToken) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range accessTokenBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *AccessToken) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range accessTokenAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *AccessToken) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range accessTokenAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *AccessToken) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range accessTokenAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *AccessToken) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range accessTokenAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *AccessToken) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range accessTokenAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAccessTokenHook registers your hook function for all future operations.
+func AddAccessTokenHook(hookPoint boil.HookPoint, accessTokenHook AccessTokenHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		accessTokenBeforeInsertHooks = append(accessTokenBeforeInsertHooks, accessTokenHook)
+	case boil.BeforeUpdateHook:
+		accessTokenBeforeUpdateHooks = append(accessTokenBeforeUpdateHooks, accessTokenHook)
+	case boil.BeforeDeleteHook:
+		accessTokenBeforeDeleteHooks = append(accessTokenBeforeDeleteHooks, accessTokenHook)
+	case boil.BeforeUpsertHook:
+		accessTokenBeforeUpsertHooks = append(accessTokenBeforeUpsertHooks, accessTokenHook)
+	case boil.AfterInsertHook:
+		accessTokenAfterInsertHooks = append(accessTokenAfterInsertHooks, accessTokenHook)
+	case boil.AfterSelectHook:
+		accessTokenAfterSelectHooks = append(accessTokenAfterSelectHooks, accessTokenHook)
+	case boil.AfterUpdateHook:
+		accessTokenAfterUpdateHooks = append(accessTokenAfterUpdateHooks, accessTokenHook)
+	case boil.AfterDeleteHook:
+		accessTokenAfterDeleteHooks = append(accessTokenAfterDeleteHooks, accessTokenHook)
+	case boil.AfterUpsertHook:
+		accessTokenAfterUpsertHooks = append(accessTokenAfterUpsertHooks, accessTokenHook)
+	}
+}
+
+// One returns a single accessToken record from the query.
+func (q accessTokenQuery) One(exec boil.Executor) (*AccessToken, error) {
+	o := &AccessToken{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for access_token")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all AccessToken records from the query.
+func (q accessTokenQuery) All(exec boil.Executor) (AccessTokenSlice, error) {
+	var o []*AccessToken
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to AccessToken slice")
+	}
+
+	if len(accessTokenAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all AccessToken records in the query.
+func (q accessTokenQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count access_token rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q accessTokenQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if access_token exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *AccessToken) User(mods ...qm.QueryMod) userAccountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	return query
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (accessTokenL) LoadUser(e boil.Executor, singular bool, maybeAccessToken interface{}, mods queries.Applicator) error {
+	var slice []*AccessToken
+	var object *AccessToken
+
+	if singular {
+		object = maybeAccessToken.(*AccessToken)
+	} else {
+		slice = *maybeAccessToken.(*[]*AccessToken)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &accessTokenR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &accessTokenR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_account`),
+		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(accessTokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userAccountR{}
+		}
+		foreign.R.AccessTokens = append(foreign.R.AccessTokens, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.AccessTokens = append(foreign.R.AccessTokens, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the accessToken to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.AccessTokens.
+func (o *AccessToken) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"access_token\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, accessTokenPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &accessTokenR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userAccountR{
+			AccessTokens: AccessTokenSlice{o},
+		}
+	} else {
+		related.R.AccessTokens = append(related.R.AccessTokens, o)
+	}
+
+	return nil
+}
+
+// AccessTokens retrieves all the records using an executor.
+func AccessTokens(mods ...qm.QueryMod) accessTokenQuery {
+	mods = append(mods, qm.From("\"access_token\""))
+	return accessTokenQuery{NewQuery(mods...)}
+}
+
+// FindAccessToken retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAccessToken(exec boil.Executor, iD int64, selectCols ...string) (*AccessToken, error) {
+	accessTokenObj := &AccessToken{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"access_token\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, accessTokenObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from access_token")
+	}
+
+	if err = accessTokenObj.doAfterSelectHooks(exec); err != nil {
+		return accessTokenObj, err
+	}
+
+	return accessTokenObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AccessToken) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no access_token provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(accessTokenColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	accessTokenInsertCacheMut.RLock()
+	cache, cached := accessTokenInsertCache[key]
+	accessTokenInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			accessTokenAllColumns,
+			accessTokenColumnsWithDefault,
+			accessTokenColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(accessTokenType, accessTokenMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(accessTokenType, accessTokenMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"access_token\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"access_token\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into access_token")
+	}
+
+	if !cached {
+		accessTokenInsertCacheMut.Lock()
+		accessTokenInsertCache[key] = cache
+		accessTokenInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the AccessToken.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AccessToken) Update(exec boil.Executor, columns boil.Columns) error {
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	accessTokenUpdateCacheMut.RLock()
+	cache, cached := accessTokenUpdateCache[key]
+	accessTokenUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			accessTokenAllColumns,
+			accessTokenPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update access_token, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"access_token\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, accessTokenPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(accessTokenType, accessTokenMapping, append(wl, accessTokenPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update access_token row")
+	}
+
+	if !cached {
+		accessTokenUpdateCacheMut.Lock()
+		accessTokenUpdateCache[key] = cache
+		accessTokenUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q accessTokenQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for access_token")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AccessTokenSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accessTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"access_token\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, accessTokenPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in accessToken slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *AccessToken) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no access_token provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(accessTokenColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	accessTokenUpsertCacheMut.RLock()
+	cache, cached := accessTokenUpsertCache[key]
+	accessTokenUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			accessTokenAllColumns,
+			accessTokenColumnsWithDefault,
+			accessTokenColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			accessTokenAllColumns,
+			accessTokenPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert access_token, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(accessTokenPrimaryKeyColumns))
+			copy(conflict, accessTokenPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"access_token\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(accessTokenType, accessTokenMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(accessTokenType, accessTokenMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert access_token")
+	}
+
+	if !cached {
+		accessTokenUpsertCacheMut.Lock()
+		accessTokenUpsertCache[key] = cache
+		accessTokenUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single AccessToken record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AccessToken) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no AccessToken provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), accessTokenPrimaryKeyMapping)
+	sql := "DELETE FROM \"access_token\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from access_token")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q accessTokenQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no accessTokenQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from access_token")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AccessTokenSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(accessTokenBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accessTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"access_token\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, accessTokenPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from accessToken slice")
+	}
+
+	if len(accessTokenAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AccessToken) Reload(exec boil.Executor) error {
+	ret, err := FindAccessToken(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AccessTokenSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AccessTokenSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accessTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"access_token\".* FROM \"access_token\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, accessTokenPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in AccessTokenSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AccessTokenExists checks if the AccessToken row exists.
+func AccessTokenExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"access_token\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if access_token exists")
+	}
+
+	return exists, nil
+}
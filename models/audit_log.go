@@ -0,0 +1,1119 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// AuditLog is an object representing the database table.
+type AuditLog struct {
+	ID         int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt  time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ActorID    null.Int64  `boil:"actor_id" json:"actor_id,omitempty" toml:"actor_id" yaml:"actor_id,omitempty"`
+	ActorName  null.String `boil:"actor_name" json:"actor_name,omitempty" toml:"actor_name" yaml:"actor_name,omitempty"`
+	Action     string      `boil:"action" json:"action" toml:"action" yaml:"action"`
+	TargetType string      `boil:"target_type" json:"target_type" toml:"target_type" yaml:"target_type"`
+	TargetID   null.Int64  `boil:"target_id" json:"target_id,omitempty" toml:"target_id" yaml:"target_id,omitempty"`
+	Diff       types.JSON  `boil:"diff" json:"diff" toml:"diff" yaml:"diff"`
+	IP         null.String `boil:"ip" json:"ip,omitempty" toml:"ip" yaml:"ip,omitempty"`
+
+	R *auditLogR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L auditLogL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AuditLogColumns = struct {
+	ID         string
+	CreatedAt  string
+	ActorID    string
+	ActorName  string
+	Action     string
+	TargetType string
+	TargetID   string
+	Diff       string
+	IP         string
+}{
+	ID:         "id",
+	CreatedAt:  "created_at",
+	ActorID:    "actor_id",
+	ActorName:  "actor_name",
+	Action:     "action",
+	TargetType: "target_type",
+	TargetID:   "target_id",
+	Diff:       "diff",
+	IP:         "ip",
+}
+
+var AuditLogTableColumns = struct {
+	ID         string
+	CreatedAt  string
+	ActorID    string
+	ActorName  string
+	Action     string
+	TargetType string
+	TargetID   string
+	Diff       string
+	IP         string
+}{
+	ID:         "audit_log.id",
+	CreatedAt:  "audit_log.created_at",
+	ActorID:    "audit_log.actor_id",
+	ActorName:  "audit_log.actor_name",
+	Action:     "audit_log.action",
+	TargetType: "audit_log.target_type",
+	TargetID:   "audit_log.target_id",
+	Diff:       "audit_log.diff",
+	IP:         "audit_log.ip",
+}
+
+// Generated where
+
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpertypes_JSON struct{ field string }
+
+func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_JSON) NEQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_JSON) LT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_JSON) LTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_JSON) GT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var AuditLogWhere = struct {
+	ID         whereHelperint64
+	CreatedAt  whereHelpertime_Time
+	ActorID    whereHelpernull_Int64
+	ActorName  whereHelpernull_String
+	Action     whereHelperstring
+	TargetType whereHelperstring
+	TargetID   whereHelpernull_Int64
+	Diff       whereHelpertypes_JSON
+	IP         whereHelpernull_String
+}{
+	ID:         whereHelperint64{field: "\"audit_log\".\"id\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"audit_log\".\"created_at\""},
+	ActorID:    whereHelpernull_Int64{field: "\"audit_log\".\"actor_id\""},
+	ActorName:  whereHelpernull_String{field: "\"audit_log\".\"actor_name\""},
+	Action:     whereHelperstring{field: "\"audit_log\".\"action\""},
+	TargetType: whereHelperstring{field: "\"audit_log\".\"target_type\""},
+	TargetID:   whereHelpernull_Int64{field: "\"audit_log\".\"target_id\""},
+	Diff:       whereHelpertypes_JSON{field: "\"audit_log\".\"diff\""},
+	IP:         whereHelpernull_String{field: "\"audit_log\".\"ip\""},
+}
+
+// AuditLogRels is where relationship names are stored.
+var AuditLogRels = struct {
+	Actor string
+}{
+	Actor: "Actor",
+}
+
+// auditLogR is where relationships are stored.
+type auditLogR struct {
+	Actor *User `boil:"Actor" json:"Actor" toml:"Actor" yaml:"Actor"`
+}
+
+// NewStruct creates a new relationship struct
+func (*auditLogR) NewStruct() *auditLogR {
+	return &auditLogR{}
+}
+
+// auditLogL is where Load methods for each relationship are stored.
+type auditLogL struct{}
+
+var (
+	auditLogAllColumns            = []string{"id", "created_at", "actor_id", "actor_name", "action", "target_type", "target_id", "diff", "ip"}
+	auditLogColumnsWithoutDefault = []string{"actor_id", "target_id"}
+	auditLogColumnsWithDefault    = []string{"id", "created_at", "actor_name", "action", "target_type", "diff", "ip"}
+	auditLogPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// AuditLogSlice is an alias for a slice of pointers to AuditLog.
+	// This should almost always be used instead of []AuditLog.
+	AuditLogSlice []*AuditLog
+	// AuditLogHook is the signature for custom AuditLog hook methods
+	AuditLogHook func(boil.Executor, *AuditLog) error
+
+	auditLogQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	auditLogType                 = reflect.TypeOf(&AuditLog{})
+	auditLogMapping              = queries.MakeStructMapping(auditLogType)
+	auditLogPrimaryKeyMapping, _ = queries.BindMapping(auditLogType, auditLogMapping, auditLogPrimaryKeyColumns)
+	auditLogInsertCacheMut       sync.RWMutex
+	auditLogInsertCache          = make(map[string]insertCache)
+	auditLogUpdateCacheMut       sync.RWMutex
+	auditLogUpdateCache          = make(map[string]updateCache)
+	auditLogUpsertCacheMut       sync.RWMutex
+	auditLogUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var auditLogBeforeInsertHooks []AuditLogHook
+var auditLogBeforeUpdateHooks []AuditLogHook
+var auditLogBeforeDeleteHooks []AuditLogHook
+var auditLogBeforeUpsertHooks []AuditLogHook
+
+var auditLogAfterInsertHooks []AuditLogHook
+var auditLogAfterSelectHooks []AuditLogHook
+var auditLogAfterUpdateHooks []AuditLogHook
+var auditLogAfterDeleteHooks []AuditLogHook
+var auditLogAfterUpsertHooks []AuditLogHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *AuditLog) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range auditLogBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *AuditLog) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range auditLogBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *AuditLog) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range auditLogBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *AuditLog) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range auditLogBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *AuditLog) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range auditLogAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *AuditLog) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range auditLogAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *AuditLog) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range auditLogAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *AuditLog) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range auditLogAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *AuditLog) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range auditLogAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAuditLogHook registers your hook function for all future operations.
+func AddAuditLogHook(hookPoint boil.HookPoint, auditLogHook AuditLogHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		auditLogBeforeInsertHooks = append(auditLogBeforeInsertHooks, auditLogHook)
+	case boil.BeforeUpdateHook:
+		auditLogBeforeUpdateHooks = append(auditLogBeforeUpdateHooks, auditLogHook)
+	case boil.BeforeDeleteHook:
+		auditLogBeforeDeleteHooks = append(auditLogBeforeDeleteHooks, auditLogHook)
+	case boil.BeforeUpsertHook:
+		auditLogBeforeUpsertHooks = append(auditLogBeforeUpsertHooks, auditLogHook)
+	case boil.AfterInsertHook:
+		auditLogAfterInsertHooks = append(auditLogAfterInsertHooks, auditLogHook)
+	case boil.AfterSelectHook:
+		auditLogAfterSelectHooks = append(auditLogAfterSelectHooks, auditLogHook)
+	case boil.AfterUpdateHook:
+		auditLogAfterUpdateHooks = append(auditLogAfterUpdateHooks, auditLogHook)
+	case boil.AfterDeleteHook:
+		auditLogAfterDeleteHooks = append(auditLogAfterDeleteHooks, auditLogHook)
+	case boil.AfterUpsertHook:
+		auditLogAfterUpsertHooks = append(auditLogAfterUpsertHooks, auditLogHook)
+	}
+}
+
+// One returns a single auditLog record from the query.
+func (q auditLogQuery) One(exec boil.Executor) (*AuditLog, error) {
+	o := &AuditLog{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for audit_log")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all AuditLog records from the query.
+func (q auditLogQuery) All(exec boil.Executor) (AuditLogSlice, error) {
+	var o []*AuditLog
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to AuditLog slice")
+	}
+
+	if len(auditLogAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all AuditLog records in the query.
+func (q auditLogQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count audit_log rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q auditLogQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if audit_log exists")
+	}
+
+	return count > 0, nil
+}
+
+// Actor pointed to by the foreign key.
+func (o *AuditLog) Actor(mods ...qm.QueryMod) userAccountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ActorID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	return query
+}
+
+// LoadActor allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (auditLogL) LoadActor(e boil.Executor, singular bool, maybeAuditLog interface{}, mods queries.Applicator) error {
+	var slice []*AuditLog
+	var object *AuditLog
+
+	if singular {
+		object = maybeAuditLog.(*AuditLog)
+	} else {
+		slice = *maybeAuditLog.(*[]*AuditLog)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &auditLogR{}
+		}
+		if !queries.IsNil(object.ActorID) {
+			args = append(args, object.ActorID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &auditLogR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ActorID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.ActorID) {
+				args = append(args, obj.ActorID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_account`),
+		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(auditLogAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Actor = foreign
+		if foreign.R == nil {
+			foreign.R = &userAccountR{}
+		}
+		foreign.R.ActorAuditLogs = append(foreign.R.ActorAuditLogs, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.ActorID, foreign.ID) {
+				local.R.Actor = foreign
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.ActorAuditLogs = append(foreign.R.ActorAuditLogs, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetActor of the auditLog to the related item.
+// Sets o.R.Actor to related.
+// Adds o to related.R.ActorAuditLogs.
+func (o *AuditLog) SetActor(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"audit_log\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"actor_id"}),
+		strmangle.WhereClause("\"", "\"", 2, auditLogPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.ActorID, related.ID)
+	if o.R == nil {
+		o.R = &auditLogR{
+			Actor: related,
+		}
+	} else {
+		o.R.Actor = related
+	}
+
+	if related.R == nil {
+		related.R = &userAccountR{
+			ActorAuditLogs: AuditLogSlice{o},
+		}
+	} else {
+		related.R.ActorAuditLogs = append(related.R.ActorAuditLogs, o)
+	}
+
+	return nil
+}
+
+// RemoveActor relationship.
+// Sets o.R.Actor to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *AuditLog) RemoveActor(exec boil.Executor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.ActorID, nil)
+	if err = o.Update(exec, boil.Whitelist("actor_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Actor = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.ActorAuditLogs {
+		if queries.Equal(o.ActorID, ri.ActorID) {
+			continue
+		}
+
+		ln := len(related.R.ActorAuditLogs)
+		if ln > 1 && i < ln-1 {
+			related.R.ActorAuditLogs[i] = related.R.ActorAuditLogs[ln-1]
+		}
+		related.R.ActorAuditLogs = related.R.ActorAuditLogs[:ln-1]
+		break
+	}
+	return nil
+}
+
+// AuditLogs retrieves all the records using an executor.
+func AuditLogs(mods ...qm.QueryMod) auditLogQuery {
+	mods = append(mods, qm.From("\"audit_log\""))
+	return auditLogQuery{NewQuery(mods...)}
+}
+
+// FindAuditLog retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAuditLog(exec boil.Executor, iD int64, selectCols ...string) (*AuditLog, error) {
+	auditLogObj := &AuditLog{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"audit_log\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, auditLogObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from audit_log")
+	}
+
+	if err = auditLogObj.doAfterSelectHooks(exec); err != nil {
+		return auditLogObj, err
+	}
+
+	return auditLogObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AuditLog) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no audit_log provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(auditLogColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	auditLogInsertCacheMut.RLock()
+	cache, cached := auditLogInsertCache[key]
+	auditLogInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			auditLogAllColumns,
+			auditLogColumnsWithDefault,
+			auditLogColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(auditLogType, auditLogMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(auditLogType, auditLogMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"audit_log\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"audit_log\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into audit_log")
+	}
+
+	if !cached {
+		auditLogInsertCacheMut.Lock()
+		auditLogInsertCache[key] = cache
+		auditLogInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the AuditLog.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AuditLog) Update(exec boil.Executor, columns boil.Columns) error {
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	auditLogUpdateCacheMut.RLock()
+	cache, cached := auditLogUpdateCache[key]
+	auditLogUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			auditLogAllColumns,
+			auditLogPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update audit_log, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"audit_log\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, auditLogPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(auditLogType, auditLogMapping, append(wl, auditLogPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update audit_log row")
+	}
+
+	if !cached {
+		auditLogUpdateCacheMut.Lock()
+		auditLogUpdateCache[key] = cache
+		auditLogUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q auditLogQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for audit_log")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AuditLogSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), auditLogPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"audit_log\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, auditLogPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in auditLog slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *AuditLog) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no audit_log provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(auditLogColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	auditLogUpsertCacheMut.RLock()
+	cache, cached := auditLogUpsertCache[key]
+	auditLogUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			auditLogAllColumns,
+			auditLogColumnsWithDefault,
+			auditLogColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			auditLogAllColumns,
+			auditLogPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert audit_log, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(auditLogPrimaryKeyColumns))
+			copy(conflict, auditLogPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"audit_log\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(auditLogType, auditLogMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(auditLogType, auditLogMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert audit_log")
+	}
+
+	if !cached {
+		auditLogUpsertCacheMut.Lock()
+		auditLogUpsertCache[key] = cache
+		auditLogUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single AuditLog record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AuditLog) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no AuditLog provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), auditLogPrimaryKeyMapping)
+	sql := "DELETE FROM \"audit_log\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from audit_log")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q auditLogQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no auditLogQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from audit_log")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AuditLogSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(auditLogBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), auditLogPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"audit_log\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, auditLogPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from auditLog slice")
+	}
+
+	if len(auditLogAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AuditLog) Reload(exec boil.Executor) error {
+	ret, err := FindAuditLog(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AuditLogSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AuditLogSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), auditLogPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"audit_log\".* FROM \"audit_log\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, auditLogPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in AuditLogSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AuditLogExists checks if the AuditLog row exists.
+func AuditLogExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"audit_log\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if audit_log exists")
+	}
+
+	return exists, nil
+}
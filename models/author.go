@@ -52,52 +52,6 @@ var AuthorTableColumns = struct {
 
 // Generated where
 
-type whereHelperint64 struct{ field string }
-
-func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 var AuthorWhere = struct {
 	ID   whereHelperint64
 	Slug whereHelperstring
@@ -461,10 +415,11 @@ func (authorL) LoadArtistProjects(e boil.Executor, singular bool, maybeAuthor in
 	}
 
 	query := NewQuery(
-		qm.Select("\"project\".id, \"project\".slug, \"project\".locked, \"project\".created_at, \"project\".updated_at, \"project\".published_at, \"project\".title, \"project\".description, \"project\".cover_id, \"project\".project_status, \"project\".series_status, \"project\".demographic, \"project\".rating, \"a\".\"artist_id\""),
+		qm.Select("\"project\".id, \"project\".slug, \"project\".locked, \"project\".created_at, \"project\".updated_at, \"project\".published_at, \"project\".title, \"project\".description, \"project\".cover_id, \"project\".project_status, \"project\".series_status, \"project\".demographic, \"project\".rating, \"project\".reading_direction, \"project\".scheduled_at, \"project\".deleted_at, \"a\".\"artist_id\""),
 		qm.From("\"project\""),
 		qm.InnerJoin("\"project_artists\" as \"a\" on \"project\".\"id\" = \"a\".\"project_id\""),
 		qm.WhereIn("\"a\".\"artist_id\" in ?", args...),
+		qmhelper.WhereIsNull("\"project\".\"deleted_at\""),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -482,7 +437,7 @@ func (authorL) LoadArtistProjects(e boil.Executor, singular bool, maybeAuthor in
 		one := new(Project)
 		var localJoinCol int64
 
-		err = results.Scan(&one.ID, &one.Slug, &one.Locked, &one.CreatedAt, &one.UpdatedAt, &one.PublishedAt, &one.Title, &one.Description, &one.CoverID, &one.ProjectStatus, &one.SeriesStatus, &one.Demographic, &one.Rating, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Slug, &one.Locked, &one.CreatedAt, &one.UpdatedAt, &one.PublishedAt, &one.Title, &one.Description, &one.CoverID, &one.ProjectStatus, &one.SeriesStatus, &one.Demographic, &one.Rating, &one.ReadingDirection, &one.ScheduledAt, &one.DeletedAt, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for project")
 		}
@@ -576,10 +531,11 @@ func (authorL) LoadAuthorProjects(e boil.Executor, singular bool, maybeAuthor in
 	}
 
 	query := NewQuery(
-		qm.Select("\"project\".id, \"project\".slug, \"project\".locked, \"project\".created_at, \"project\".updated_at, \"project\".published_at, \"project\".title, \"project\".description, \"project\".cover_id, \"project\".project_status, \"project\".series_status, \"project\".demographic, \"project\".rating, \"a\".\"author_id\""),
+		qm.Select("\"project\".id, \"project\".slug, \"project\".locked, \"project\".created_at, \"project\".updated_at, \"project\".published_at, \"project\".title, \"project\".description, \"project\".cover_id, \"project\".project_status, \"project\".series_status, \"project\".demographic, \"project\".rating, \"project\".reading_direction, \"project\".scheduled_at, \"project\".deleted_at, \"a\".\"author_id\""),
 		qm.From("\"project\""),
 		qm.InnerJoin("\"project_authors\" as \"a\" on \"project\".\"id\" = \"a\".\"project_id\""),
 		qm.WhereIn("\"a\".\"author_id\" in ?", args...),
+		qmhelper.WhereIsNull("\"project\".\"deleted_at\""),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -597,7 +553,7 @@ func (authorL) LoadAuthorProjects(e boil.Executor, singular bool, maybeAuthor in
 		one := new(Project)
 		var localJoinCol int64
 
-		err = results.Scan(&one.ID, &one.Slug, &one.Locked, &one.CreatedAt, &one.UpdatedAt, &one.PublishedAt, &one.Title, &one.Description, &one.CoverID, &one.ProjectStatus, &one.SeriesStatus, &one.Demographic, &one.Rating, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Slug, &one.Locked, &one.CreatedAt, &one.UpdatedAt, &one.PublishedAt, &one.Title, &one.Description, &one.CoverID, &one.ProjectStatus, &one.SeriesStatus, &one.Demographic, &one.Rating, &one.ReadingDirection, &one.ScheduledAt, &one.DeletedAt, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for project")
 		}
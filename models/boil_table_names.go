@@ -4,29 +4,59 @@
 package models
 
 var TableNames = struct {
+	AccessToken             string
+	AuditLog                string
 	Author                  string
 	Chapter                 string
+	ChapterRevision         string
 	ChapterScanlationGroups string
 	Cover                   string
+	Invite                  string
+	Job                     string
+	LoginFailure            string
 	Project                 string
 	ProjectArtists          string
 	ProjectAuthors          string
+	ProjectMember           string
 	ProjectTags             string
+	RecoveryCode            string
+	Role                    string
 	ScanlationGroup         string
+	ScanlationGroupMember   string
+	SigningKey              string
 	Statistics              string
 	Tag                     string
 	UserAccount             string
+	UserIdentity            string
+	UserRoles               string
+	Webhook                 string
+	WebhookDelivery         string
 }{
+	AccessToken:             "access_token",
+	AuditLog:                "audit_log",
 	Author:                  "author",
 	Chapter:                 "chapter",
+	ChapterRevision:         "chapter_revision",
 	ChapterScanlationGroups: "chapter_scanlation_groups",
 	Cover:                   "cover",
+	Invite:                  "invite",
+	Job:                     "job",
+	LoginFailure:            "login_failure",
 	Project:                 "project",
 	ProjectArtists:          "project_artists",
 	ProjectAuthors:          "project_authors",
+	ProjectMember:           "project_member",
 	ProjectTags:             "project_tags",
+	RecoveryCode:            "recovery_code",
+	Role:                    "role",
 	ScanlationGroup:         "scanlation_group",
+	ScanlationGroupMember:   "scanlation_group_member",
+	SigningKey:              "signing_key",
 	Statistics:              "statistics",
 	Tag:                     "tag",
 	UserAccount:             "user_account",
+	UserIdentity:            "user_identity",
+	UserRoles:               "user_roles",
+	Webhook:                 "webhook",
+	WebhookDelivery:         "webhook_delivery",
 }
@@ -35,6 +35,8 @@ type Chapter struct {
 	Volume      null.String       `boil:"volume" json:"volume,omitempty" toml:"volume" yaml:"volume,omitempty"`
 	Title       null.String       `boil:"title" json:"title,omitempty" toml:"title" yaml:"title,omitempty"`
 	Pages       types.StringArray `boil:"pages" json:"pages,omitempty" toml:"pages" yaml:"pages,omitempty"`
+	ScheduledAt null.Time         `boil:"scheduled_at" json:"scheduled_at,omitempty" toml:"scheduled_at" yaml:"scheduled_at,omitempty"`
+	DeletedAt   null.Time         `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
 
 	R *chapterR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L chapterL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -52,6 +54,8 @@ var ChapterColumns = struct {
 	Volume      string
 	Title       string
 	Pages       string
+	ScheduledAt string
+	DeletedAt   string
 }{
 	ID:          "id",
 	Locked:      "locked",
@@ -64,6 +68,8 @@ var ChapterColumns = struct {
 	Volume:      "volume",
 	Title:       "title",
 	Pages:       "pages",
+	ScheduledAt: "scheduled_at",
+	DeletedAt:   "deleted_at",
 }
 
 var ChapterTableColumns = struct {
@@ -78,6 +84,8 @@ var ChapterTableColumns = struct {
 	Volume      string
 	Title       string
 	Pages       string
+	ScheduledAt string
+	DeletedAt   string
 }{
 	ID:          "chapter.id",
 	Locked:      "chapter.locked",
@@ -90,6 +98,8 @@ var ChapterTableColumns = struct {
 	Volume:      "chapter.volume",
 	Title:       "chapter.title",
 	Pages:       "chapter.pages",
+	ScheduledAt: "chapter.scheduled_at",
+	DeletedAt:   "chapter.deleted_at",
 }
 
 // Generated where
@@ -118,120 +128,6 @@ func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
 func (w whereHelpernull_Bool) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Bool) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelpernull_Time struct{ field string }
-
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpernull_Int64 struct{ field string }
-
-func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpertypes_StringArray struct{ field string }
-
-func (w whereHelpertypes_StringArray) EQ(x types.StringArray) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpertypes_StringArray) NEQ(x types.StringArray) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpertypes_StringArray) LT(x types.StringArray) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertypes_StringArray) LTE(x types.StringArray) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertypes_StringArray) GT(x types.StringArray) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 func (w whereHelpertypes_StringArray) IsNull() qm.QueryMod { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpertypes_StringArray) IsNotNull() qm.QueryMod {
 	return qmhelper.WhereIsNotNull(w.field)
@@ -249,6 +145,8 @@ var ChapterWhere = struct {
 	Volume      whereHelpernull_String
 	Title       whereHelpernull_String
 	Pages       whereHelpertypes_StringArray
+	ScheduledAt whereHelpernull_Time
+	DeletedAt   whereHelpernull_Time
 }{
 	ID:          whereHelperint64{field: "\"chapter\".\"id\""},
 	Locked:      whereHelpernull_Bool{field: "\"chapter\".\"locked\""},
@@ -261,6 +159,8 @@ var ChapterWhere = struct {
 	Volume:      whereHelpernull_String{field: "\"chapter\".\"volume\""},
 	Title:       whereHelpernull_String{field: "\"chapter\".\"title\""},
 	Pages:       whereHelpertypes_StringArray{field: "\"chapter\".\"pages\""},
+	ScheduledAt: whereHelpernull_Time{field: "\"chapter\".\"scheduled_at\""},
+	DeletedAt:   whereHelpernull_Time{field: "\"chapter\".\"deleted_at\""},
 }
 
 // ChapterRels is where relationship names are stored.
@@ -268,11 +168,13 @@ var ChapterRels = struct {
 	Project          string
 	Uploader         string
 	Statistic        string
+	ChapterRevisions string
 	ScanlationGroups string
 }{
 	Project:          "Project",
 	Uploader:         "Uploader",
 	Statistic:        "Statistic",
+	ChapterRevisions: "ChapterRevisions",
 	ScanlationGroups: "ScanlationGroups",
 }
 
@@ -281,6 +183,7 @@ type chapterR struct {
 	Project          *Project             `boil:"Project" json:"Project" toml:"Project" yaml:"Project"`
 	Uploader         *User                `boil:"Uploader" json:"Uploader" toml:"Uploader" yaml:"Uploader"`
 	Statistic        *Statistic           `boil:"Statistic" json:"Statistic" toml:"Statistic" yaml:"Statistic"`
+	ChapterRevisions ChapterRevisionSlice `boil:"ChapterRevisions" json:"ChapterRevisions" toml:"ChapterRevisions" yaml:"ChapterRevisions"`
 	ScanlationGroups ScanlationGroupSlice `boil:"ScanlationGroups" json:"ScanlationGroups" toml:"ScanlationGroups" yaml:"ScanlationGroups"`
 }
 
@@ -293,8 +196,8 @@ func (*chapterR) NewStruct() *chapterR {
 type chapterL struct{}
 
 var (
-	chapterAllColumns            = []string{"id", "locked", "created_at", "updated_at", "published_at", "project_id", "uploader_id", "chapter", "volume", "title", "pages"}
-	chapterColumnsWithoutDefault = []string{"published_at", "project_id", "uploader_id"}
+	chapterAllColumns            = []string{"id", "locked", "created_at", "updated_at", "published_at", "project_id", "uploader_id", "chapter", "volume", "title", "pages", "scheduled_at", "deleted_at"}
+	chapterColumnsWithoutDefault = []string{"published_at", "project_id", "uploader_id", "scheduled_at", "deleted_at"}
 	chapterColumnsWithDefault    = []string{"id", "locked", "created_at", "updated_at", "chapter", "volume", "title", "pages"}
 	chapterPrimaryKeyColumns     = []string{"id"}
 )
@@ -542,6 +445,7 @@ func (q chapterQuery) Exists(exec boil.Executor) (bool, error) {
 func (o *Chapter) Project(mods ...qm.QueryMod) projectQuery {
 	queryMods := []qm.QueryMod{
 		qm.Where("\"id\" = ?", o.ProjectID),
+		qmhelper.WhereIsNull("deleted_at"),
 	}
 
 	queryMods = append(queryMods, mods...)
@@ -556,6 +460,7 @@ func (o *Chapter) Project(mods ...qm.QueryMod) projectQuery {
 func (o *Chapter) Uploader(mods ...qm.QueryMod) userAccountQuery {
 	queryMods := []qm.QueryMod{
 		qm.Where("\"id\" = ?", o.UploaderID),
+		qmhelper.WhereIsNull("deleted_at"),
 	}
 
 	queryMods = append(queryMods, mods...)
@@ -580,6 +485,27 @@ func (o *Chapter) Statistic(mods ...qm.QueryMod) statisticQuery {
 	return query
 }
 
+// ChapterRevisions retrieves all the chapter_revision's ChapterRevisions with an executor.
+func (o *Chapter) ChapterRevisions(mods ...qm.QueryMod) chapterRevisionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"chapter_revision\".\"chapter_id\"=?", o.ID),
+	)
+
+	query := ChapterRevisions(queryMods...)
+	queries.SetFrom(query.Query, "\"chapter_revision\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"chapter_revision\".*"})
+	}
+
+	return query
+}
+
 // ScanlationGroups retrieves all the scanlation_group's ScanlationGroups with an executor.
 func (o *Chapter) ScanlationGroups(mods ...qm.QueryMod) scanlationGroupQuery {
 	var queryMods []qm.QueryMod
@@ -646,6 +572,7 @@ func (chapterL) LoadProject(e boil.Executor, singular bool, maybeChapter interfa
 	query := NewQuery(
 		qm.From(`project`),
 		qm.WhereIn(`project.id in ?`, args...),
+		qmhelper.WhereIsNull(`project.deleted_at`),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -754,6 +681,7 @@ func (chapterL) LoadUploader(e boil.Executor, singular bool, maybeChapter interf
 	query := NewQuery(
 		qm.From(`user_account`),
 		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -915,6 +843,104 @@ func (chapterL) LoadStatistic(e boil.Executor, singular bool, maybeChapter inter
 	return nil
 }
 
+// LoadChapterRevisions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (chapterL) LoadChapterRevisions(e boil.Executor, singular bool, maybeChapter interface{}, mods queries.Applicator) error {
+	var slice []*Chapter
+	var object *Chapter
+
+	if singular {
+		object = maybeChapter.(*Chapter)
+	} else {
+		slice = *maybeChapter.(*[]*Chapter)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &chapterR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &chapterR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`chapter_revision`),
+		qm.WhereIn(`chapter_revision.chapter_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load chapter_revision")
+	}
+
+	var resultSlice []*ChapterRevision
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice chapter_revision")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on chapter_revision")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for chapter_revision")
+	}
+
+	if len(chapterRevisionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ChapterRevisions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &chapterRevisionR{}
+			}
+			foreign.R.Chapter = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ChapterID {
+				local.R.ChapterRevisions = append(local.R.ChapterRevisions, foreign)
+				if foreign.R == nil {
+					foreign.R = &chapterRevisionR{}
+				}
+				foreign.R.Chapter = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadScanlationGroups allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (chapterL) LoadScanlationGroups(e boil.Executor, singular bool, maybeChapter interface{}, mods queries.Applicator) error {
@@ -1226,6 +1252,58 @@ func (o *Chapter) RemoveStatistic(exec boil.Executor, related *Statistic) error
 	return nil
 }
 
+// AddChapterRevisions adds the given related objects to the existing relationships
+// of the chapter, optionally inserting them as new records.
+// Appends related to o.R.ChapterRevisions.
+// Sets related.R.Chapter appropriately.
+func (o *Chapter) AddChapterRevisions(exec boil.Executor, insert bool, related ...*ChapterRevision) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ChapterID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"chapter_revision\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"chapter_id"}),
+				strmangle.WhereClause("\"", "\"", 2, chapterRevisionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ChapterID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &chapterR{
+			ChapterRevisions: related,
+		}
+	} else {
+		o.R.ChapterRevisions = append(o.R.ChapterRevisions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &chapterRevisionR{
+				Chapter: o,
+			}
+		} else {
+			rel.R.Chapter = o
+		}
+	}
+	return nil
+}
+
 // AddScanlationGroups adds the given related objects to the existing relationships
 // of the chapter, optionally inserting them as new records.
 // Appends related to o.R.ScanlationGroups.
@@ -1369,7 +1447,7 @@ func removeScanlationGroupsFromChaptersSlice(o *Chapter, related []*ScanlationGr
 
 // Chapters retrieves all the records using an executor.
 func Chapters(mods ...qm.QueryMod) chapterQuery {
-	mods = append(mods, qm.From("\"chapter\""))
+	mods = append(mods, qm.From("\"chapter\""), qmhelper.WhereIsNull("\"chapter\".\"deleted_at\""))
 	return chapterQuery{NewQuery(mods...)}
 }
 
@@ -1383,7 +1461,7 @@ func FindChapter(exec boil.Executor, iD int64, selectCols ...string) (*Chapter,
 		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
 	}
 	query := fmt.Sprintf(
-		"select %s from \"chapter\" where \"id\"=$1", sel,
+		"select %s from \"chapter\" where \"id\"=$1 and \"deleted_at\" is null", sel,
 	)
 
 	q := queries.Raw(query, iD)
@@ -1726,7 +1804,7 @@ func (o *Chapter) Upsert(exec boil.Executor, updateOnConflict bool, conflictColu
 
 // Delete deletes a single Chapter record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *Chapter) Delete(exec boil.Executor) error {
+func (o *Chapter) Delete(exec boil.Executor, hardDelete bool) error {
 	if o == nil {
 		return errors.New("models: no Chapter provided for delete")
 	}
@@ -1735,8 +1813,26 @@ func (o *Chapter) Delete(exec boil.Executor) error {
 		return err
 	}
 
-	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), chapterPrimaryKeyMapping)
-	sql := "DELETE FROM \"chapter\" WHERE \"id\"=$1"
+	var (
+		sql  string
+		args []interface{}
+	)
+	if hardDelete {
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), chapterPrimaryKeyMapping)
+		sql = "DELETE FROM \"chapter\" WHERE \"id\"=$1"
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		o.DeletedAt = null.TimeFrom(currTime)
+		wl := []string{"deleted_at"}
+		sql = fmt.Sprintf("UPDATE \"chapter\" SET %s WHERE \"id\"=$2",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+		)
+		valueMapping, err := queries.BindMapping(chapterType, chapterMapping, append(wl, chapterPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), valueMapping)
+	}
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
@@ -1755,12 +1851,17 @@ func (o *Chapter) Delete(exec boil.Executor) error {
 }
 
 // DeleteAll deletes all matching rows.
-func (q chapterQuery) DeleteAll(exec boil.Executor) error {
+func (q chapterQuery) DeleteAll(exec boil.Executor, hardDelete bool) error {
 	if q.Query == nil {
 		return errors.New("models: no chapterQuery provided for delete all")
 	}
 
-	queries.SetDelete(q.Query)
+	if hardDelete {
+		queries.SetDelete(q.Query)
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		queries.SetUpdate(q.Query, M{"deleted_at": currTime})
+	}
 
 	_, err := q.Query.Exec(exec)
 	if err != nil {
@@ -1771,7 +1872,7 @@ func (q chapterQuery) DeleteAll(exec boil.Executor) error {
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o ChapterSlice) DeleteAll(exec boil.Executor) error {
+func (o ChapterSlice) DeleteAll(exec boil.Executor, hardDelete bool) error {
 	if len(o) == 0 {
 		return nil
 	}
@@ -1784,15 +1885,32 @@ func (o ChapterSlice) DeleteAll(exec boil.Executor) error {
 		}
 	}
 
-	var args []interface{}
-	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), chapterPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
+	var (
+		sql  string
+		args []interface{}
+	)
+	if hardDelete {
+		for _, obj := range o {
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), chapterPrimaryKeyMapping)
+			args = append(args, pkeyArgs...)
+		}
+		sql = "DELETE FROM \"chapter\" WHERE " +
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, chapterPrimaryKeyColumns, len(o))
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		for _, obj := range o {
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), chapterPrimaryKeyMapping)
+			args = append(args, pkeyArgs...)
+			obj.DeletedAt = null.TimeFrom(currTime)
+		}
+		wl := []string{"deleted_at"}
+		sql = fmt.Sprintf("UPDATE \"chapter\" SET %s WHERE "+
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 2, chapterPrimaryKeyColumns, len(o)),
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+		)
+		args = append([]interface{}{currTime}, args...)
 	}
 
-	sql := "DELETE FROM \"chapter\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, chapterPrimaryKeyColumns, len(o))
-
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
 		fmt.Fprintln(boil.DebugWriter, args)
@@ -1840,7 +1958,8 @@ func (o *ChapterSlice) ReloadAll(exec boil.Executor) error {
 	}
 
 	sql := "SELECT \"chapter\".* FROM \"chapter\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, chapterPrimaryKeyColumns, len(*o))
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, chapterPrimaryKeyColumns, len(*o)) +
+		"and \"deleted_at\" is null"
 
 	q := queries.Raw(sql, args...)
 
@@ -1857,7 +1976,7 @@ func (o *ChapterSlice) ReloadAll(exec boil.Executor) error {
 // ChapterExists checks if the Chapter row exists.
 func ChapterExists(exec boil.Executor, iD int64) (bool, error) {
 	var exists bool
-	sql := "select exists(select 1 from \"chapter\" where \"id\"=$1 limit 1)"
+	sql := "select exists(select 1 from \"chapter\" where \"id\"=$1 and \"deleted_at\" is null limit 1)"
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
@@ -0,0 +1,1222 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// ChapterRevision is an object representing the database table.
+type ChapterRevision struct {
+	ID        int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ChapterID int64             `boil:"chapter_id" json:"chapter_id" toml:"chapter_id" yaml:"chapter_id"`
+	UserID    null.Int64        `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	Action    string            `boil:"action" json:"action" toml:"action" yaml:"action"`
+	Pages     types.StringArray `boil:"pages" json:"pages" toml:"pages" yaml:"pages"`
+
+	R *chapterRevisionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L chapterRevisionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ChapterRevisionColumns = struct {
+	ID        string
+	CreatedAt string
+	ChapterID string
+	UserID    string
+	Action    string
+	Pages     string
+}{
+	ID:        "id",
+	CreatedAt: "created_at",
+	ChapterID: "chapter_id",
+	UserID:    "user_id",
+	Action:    "action",
+	Pages:     "pages",
+}
+
+var ChapterRevisionTableColumns = struct {
+	ID        string
+	CreatedAt string
+	ChapterID string
+	UserID    string
+	Action    string
+	Pages     string
+}{
+	ID:        "chapter_revision.id",
+	CreatedAt: "chapter_revision.created_at",
+	ChapterID: "chapter_revision.chapter_id",
+	UserID:    "chapter_revision.user_id",
+	Action:    "chapter_revision.action",
+	Pages:     "chapter_revision.pages",
+}
+
+// Generated where
+
+var ChapterRevisionWhere = struct {
+	ID        whereHelperint64
+	CreatedAt whereHelpertime_Time
+	ChapterID whereHelperint64
+	UserID    whereHelpernull_Int64
+	Action    whereHelperstring
+	Pages     whereHelpertypes_StringArray
+}{
+	ID:        whereHelperint64{field: "\"chapter_revision\".\"id\""},
+	CreatedAt: whereHelpertime_Time{field: "\"chapter_revision\".\"created_at\""},
+	ChapterID: whereHelperint64{field: "\"chapter_revision\".\"chapter_id\""},
+	UserID:    whereHelpernull_Int64{field: "\"chapter_revision\".\"user_id\""},
+	Action:    whereHelperstring{field: "\"chapter_revision\".\"action\""},
+	Pages:     whereHelpertypes_StringArray{field: "\"chapter_revision\".\"pages\""},
+}
+
+// ChapterRevisionRels is where relationship names are stored.
+var ChapterRevisionRels = struct {
+	Chapter string
+	User    string
+}{
+	Chapter: "Chapter",
+	User:    "User",
+}
+
+// chapterRevisionR is where relationships are stored.
+type chapterRevisionR struct {
+	Chapter *Chapter `boil:"Chapter" json:"Chapter" toml:"Chapter" yaml:"Chapter"`
+	User    *User    `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*chapterRevisionR) NewStruct() *chapterRevisionR {
+	return &chapterRevisionR{}
+}
+
+// chapterRevisionL is where Load methods for each relationship are stored.
+type chapterRevisionL struct{}
+
+var (
+	chapterRevisionAllColumns            = []string{"id", "created_at", "chapter_id", "user_id", "action", "pages"}
+	chapterRevisionColumnsWithoutDefault = []string{"chapter_id", "user_id"}
+	chapterRevisionColumnsWithDefault    = []string{"id", "created_at", "action", "pages"}
+	chapterRevisionPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// ChapterRevisionSlice is an alias for a slice of pointers to ChapterRevision.
+	// This should almost always be used instead of []ChapterRevision.
+	ChapterRevisionSlice []*ChapterRevision
+	// ChapterRevisionHook is the signature for custom ChapterRevision hook methods
+	ChapterRevisionHook func(boil.Executor, *ChapterRevision) error
+
+	chapterRevisionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	chapterRevisionType                 = reflect.TypeOf(&ChapterRevision{})
+	chapterRevisionMapping              = queries.MakeStructMapping(chapterRevisionType)
+	chapterRevisionPrimaryKeyMapping, _ = queries.BindMapping(chapterRevisionType, chapterRevisionMapping, chapterRevisionPrimaryKeyColumns)
+	chapterRevisionInsertCacheMut       sync.RWMutex
+	chapterRevisionInsertCache          = make(map[string]insertCache)
+	chapterRevisionUpdateCacheMut       sync.RWMutex
+	chapterRevisionUpdateCache          = make(map[string]updateCache)
+	chapterRevisionUpsertCacheMut       sync.RWMutex
+	chapterRevisionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var chapterRevisionBeforeInsertHooks []ChapterRevisionHook
+var chapterRevisionBeforeUpdateHooks []ChapterRevisionHook
+var chapterRevisionBeforeDeleteHooks []ChapterRevisionHook
+var chapterRevisionBeforeUpsertHooks []ChapterRevisionHook
+
+var chapterRevisionAfterInsertHooks []ChapterRevisionHook
+var chapterRevisionAfterSelectHooks []ChapterRevisionHook
+var chapterRevisionAfterUpdateHooks []ChapterRevisionHook
+var chapterRevisionAfterDeleteHooks []ChapterRevisionHook
+var chapterRevisionAfterUpsertHooks []ChapterRevisionHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ChapterRevision) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range chapterRevisionBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ChapterRevision) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range chapterRevisionBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ChapterRevision) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range chapterRevisionBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ChapterRevision) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range chapterRevisionBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ChapterRevision) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range chapterRevisionAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ChapterRevision) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range chapterRevisionAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ChapterRevision) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range chapterRevisionAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ChapterRevision) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range chapterRevisionAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ChapterRevision) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range chapterRevisionAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddChapterRevisionHook registers your hook function for all future operations.
+func AddChapterRevisionHook(hookPoint boil.HookPoint, chapterRevisionHook ChapterRevisionHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		chapterRevisionBeforeInsertHooks = append(chapterRevisionBeforeInsertHooks, chapterRevisionHook)
+	case boil.BeforeUpdateHook:
+		chapterRevisionBeforeUpdateHooks = append(chapterRevisionBeforeUpdateHooks, chapterRevisionHook)
+	case boil.BeforeDeleteHook:
+		chapterRevisionBeforeDeleteHooks = append(chapterRevisionBeforeDeleteHooks, chapterRevisionHook)
+	case boil.BeforeUpsertHook:
+		chapterRevisionBeforeUpsertHooks = append(chapterRevisionBeforeUpsertHooks, chapterRevisionHook)
+	case boil.AfterInsertHook:
+		chapterRevisionAfterInsertHooks = append(chapterRevisionAfterInsertHooks, chapterRevisionHook)
+	case boil.AfterSelectHook:
+		chapterRevisionAfterSelectHooks = append(chapterRevisionAfterSelectHooks, chapterRevisionHook)
+	case boil.AfterUpdateHook:
+		chapterRevisionAfterUpdateHooks = append(chapterRevisionAfterUpdateHooks, chapterRevisionHook)
+	case boil.AfterDeleteHook:
+		chapterRevisionAfterDeleteHooks = append(chapterRevisionAfterDeleteHooks, chapterRevisionHook)
+	case boil.AfterUpsertHook:
+		chapterRevisionAfterUpsertHooks = append(chapterRevisionAfterUpsertHooks, chapterRevisionHook)
+	}
+}
+
+// One returns a single chapterRevision record from the query.
+func (q chapterRevisionQuery) One(exec boil.Executor) (*ChapterRevision, error) {
+	o := &ChapterRevision{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for chapter_revision")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ChapterRevision records from the query.
+func (q chapterRevisionQuery) All(exec boil.Executor) (ChapterRevisionSlice, error) {
+	var o []*ChapterRevision
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ChapterRevision slice")
+	}
+
+	if len(chapterRevisionAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ChapterRevision records in the query.
+func (q chapterRevisionQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count chapter_revision rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q chapterRevisionQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if chapter_revision exists")
+	}
+
+	return count > 0, nil
+}
+
+// Chapter pointed to by the foreign key.
+func (o *ChapterRevision) Chapter(mods ...qm.QueryMod) chapterQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ChapterID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Chapters(queryMods...)
+	queries.SetFrom(query.Query, "\"chapter\"")
+
+	return query
+}
+
+// User pointed to by the foreign key.
+func (o *ChapterRevision) User(mods ...qm.QueryMod) userAccountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	return query
+}
+
+// LoadChapter allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (chapterRevisionL) LoadChapter(e boil.Executor, singular bool, maybeChapterRevision interface{}, mods queries.Applicator) error {
+	var slice []*ChapterRevision
+	var object *ChapterRevision
+
+	if singular {
+		object = maybeChapterRevision.(*ChapterRevision)
+	} else {
+		slice = *maybeChapterRevision.(*[]*ChapterRevision)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &chapterRevisionR{}
+		}
+		args = append(args, object.ChapterID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &chapterRevisionR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ChapterID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ChapterID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`chapter`),
+		qm.WhereIn(`chapter.id in ?`, args...),
+		qmhelper.WhereIsNull(`chapter.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Chapter")
+	}
+
+	var resultSlice []*Chapter
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Chapter")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for chapter")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for chapter")
+	}
+
+	if len(chapterRevisionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Chapter = foreign
+		if foreign.R == nil {
+			foreign.R = &chapterR{}
+		}
+		foreign.R.ChapterRevisions = append(foreign.R.ChapterRevisions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ChapterID == foreign.ID {
+				local.R.Chapter = foreign
+				if foreign.R == nil {
+					foreign.R = &chapterR{}
+				}
+				foreign.R.ChapterRevisions = append(foreign.R.ChapterRevisions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (chapterRevisionL) LoadUser(e boil.Executor, singular bool, maybeChapterRevision interface{}, mods queries.Applicator) error {
+	var slice []*ChapterRevision
+	var object *ChapterRevision
+
+	if singular {
+		object = maybeChapterRevision.(*ChapterRevision)
+	} else {
+		slice = *maybeChapterRevision.(*[]*ChapterRevision)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &chapterRevisionR{}
+		}
+		if !queries.IsNil(object.UserID) {
+			args = append(args, object.UserID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &chapterRevisionR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.UserID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.UserID) {
+				args = append(args, obj.UserID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_account`),
+		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(chapterRevisionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userAccountR{}
+		}
+		foreign.R.UserChapterRevisions = append(foreign.R.UserChapterRevisions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.UserID, foreign.ID) {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.UserChapterRevisions = append(foreign.R.UserChapterRevisions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetChapter of the chapterRevision to the related item.
+// Sets o.R.Chapter to related.
+// Adds o to related.R.ChapterRevisions.
+func (o *ChapterRevision) SetChapter(exec boil.Executor, insert bool, related *Chapter) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"chapter_revision\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"chapter_id"}),
+		strmangle.WhereClause("\"", "\"", 2, chapterRevisionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ChapterID = related.ID
+	if o.R == nil {
+		o.R = &chapterRevisionR{
+			Chapter: related,
+		}
+	} else {
+		o.R.Chapter = related
+	}
+
+	if related.R == nil {
+		related.R = &chapterR{
+			ChapterRevisions: ChapterRevisionSlice{o},
+		}
+	} else {
+		related.R.ChapterRevisions = append(related.R.ChapterRevisions, o)
+	}
+
+	return nil
+}
+
+// SetUser of the chapterRevision to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.UserChapterRevisions.
+func (o *ChapterRevision) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"chapter_revision\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, chapterRevisionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.UserID, related.ID)
+	if o.R == nil {
+		o.R = &chapterRevisionR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userAccountR{
+			UserChapterRevisions: ChapterRevisionSlice{o},
+		}
+	} else {
+		related.R.UserChapterRevisions = append(related.R.UserChapterRevisions, o)
+	}
+
+	return nil
+}
+
+// RemoveUser relationship.
+// Sets o.R.User to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *ChapterRevision) RemoveUser(exec boil.Executor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.UserID, nil)
+	if err = o.Update(exec, boil.Whitelist("user_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.User = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.UserChapterRevisions {
+		if queries.Equal(o.UserID, ri.UserID) {
+			continue
+		}
+
+		ln := len(related.R.UserChapterRevisions)
+		if ln > 1 && i < ln-1 {
+			related.R.UserChapterRevisions[i] = related.R.UserChapterRevisions[ln-1]
+		}
+		related.R.UserChapterRevisions = related.R.UserChapterRevisions[:ln-1]
+		break
+	}
+	return nil
+}
+
+// ChapterRevisions retrieves all the records using an executor.
+func ChapterRevisions(mods ...qm.QueryMod) chapterRevisionQuery {
+	mods = append(mods, qm.From("\"chapter_revision\""))
+	return chapterRevisionQuery{NewQuery(mods...)}
+}
+
+// FindChapterRevision retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindChapterRevision(exec boil.Executor, iD int64, selectCols ...string) (*ChapterRevision, error) {
+	chapterRevisionObj := &ChapterRevision{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"chapter_revision\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, chapterRevisionObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from chapter_revision")
+	}
+
+	if err = chapterRevisionObj.doAfterSelectHooks(exec); err != nil {
+		return chapterRevisionObj, err
+	}
+
+	return chapterRevisionObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ChapterRevision) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no chapter_revision provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(chapterRevisionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	chapterRevisionInsertCacheMut.RLock()
+	cache, cached := chapterRevisionInsertCache[key]
+	chapterRevisionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			chapterRevisionAllColumns,
+			chapterRevisionColumnsWithDefault,
+			chapterRevisionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(chapterRevisionType, chapterRevisionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(chapterRevisionType, chapterRevisionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"chapter_revision\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"chapter_revision\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into chapter_revision")
+	}
+
+	if !cached {
+		chapterRevisionInsertCacheMut.Lock()
+		chapterRevisionInsertCache[key] = cache
+		chapterRevisionInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the ChapterRevision.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ChapterRevision) Update(exec boil.Executor, columns boil.Columns) error {
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	chapterRevisionUpdateCacheMut.RLock()
+	cache, cached := chapterRevisionUpdateCache[key]
+	chapterRevisionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			chapterRevisionAllColumns,
+			chapterRevisionPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update chapter_revision, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"chapter_revision\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, chapterRevisionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(chapterRevisionType, chapterRevisionMapping, append(wl, chapterRevisionPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update chapter_revision row")
+	}
+
+	if !cached {
+		chapterRevisionUpdateCacheMut.Lock()
+		chapterRevisionUpdateCache[key] = cache
+		chapterRevisionUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q chapterRevisionQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for chapter_revision")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ChapterRevisionSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), chapterRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"chapter_revision\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, chapterRevisionPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in chapterRevision slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ChapterRevision) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no chapter_revision provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(chapterRevisionColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	chapterRevisionUpsertCacheMut.RLock()
+	cache, cached := chapterRevisionUpsertCache[key]
+	chapterRevisionUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			chapterRevisionAllColumns,
+			chapterRevisionColumnsWithDefault,
+			chapterRevisionColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			chapterRevisionAllColumns,
+			chapterRevisionPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert chapter_revision, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(chapterRevisionPrimaryKeyColumns))
+			copy(conflict, chapterRevisionPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"chapter_revision\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(chapterRevisionType, chapterRevisionMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(chapterRevisionType, chapterRevisionMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert chapter_revision")
+	}
+
+	if !cached {
+		chapterRevisionUpsertCacheMut.Lock()
+		chapterRevisionUpsertCache[key] = cache
+		chapterRevisionUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single ChapterRevision record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ChapterRevision) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no ChapterRevision provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), chapterRevisionPrimaryKeyMapping)
+	sql := "DELETE FROM \"chapter_revision\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from chapter_revision")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q chapterRevisionQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no chapterRevisionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from chapter_revision")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ChapterRevisionSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(chapterRevisionBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), chapterRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"chapter_revision\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, chapterRevisionPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from chapterRevision slice")
+	}
+
+	if len(chapterRevisionAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ChapterRevision) Reload(exec boil.Executor) error {
+	ret, err := FindChapterRevision(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ChapterRevisionSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ChapterRevisionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), chapterRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"chapter_revision\".* FROM \"chapter_revision\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, chapterRevisionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ChapterRevisionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ChapterRevisionExists checks if the ChapterRevision row exists.
+func ChapterRevisionExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"chapter_revision\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if chapter_revision exists")
+	}
+
+	return exists, nil
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -27,6 +28,7 @@ type Cover struct {
 	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
 	ProjectID int64     `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
 	FileName  string    `boil:"file_name" json:"file_name" toml:"file_name" yaml:"file_name"`
+	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
 
 	R *coverR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L coverL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -38,12 +40,14 @@ var CoverColumns = struct {
 	UpdatedAt string
 	ProjectID string
 	FileName  string
+	DeletedAt string
 }{
 	ID:        "id",
 	CreatedAt: "created_at",
 	UpdatedAt: "updated_at",
 	ProjectID: "project_id",
 	FileName:  "file_name",
+	DeletedAt: "deleted_at",
 }
 
 var CoverTableColumns = struct {
@@ -52,12 +56,14 @@ var CoverTableColumns = struct {
 	UpdatedAt string
 	ProjectID string
 	FileName  string
+	DeletedAt string
 }{
 	ID:        "cover.id",
 	CreatedAt: "cover.created_at",
 	UpdatedAt: "cover.updated_at",
 	ProjectID: "cover.project_id",
 	FileName:  "cover.file_name",
+	DeletedAt: "cover.deleted_at",
 }
 
 // Generated where
@@ -68,12 +74,14 @@ var CoverWhere = struct {
 	UpdatedAt whereHelpertime_Time
 	ProjectID whereHelperint64
 	FileName  whereHelperstring
+	DeletedAt whereHelpernull_Time
 }{
 	ID:        whereHelperint64{field: "\"cover\".\"id\""},
 	CreatedAt: whereHelpertime_Time{field: "\"cover\".\"created_at\""},
 	UpdatedAt: whereHelpertime_Time{field: "\"cover\".\"updated_at\""},
 	ProjectID: whereHelperint64{field: "\"cover\".\"project_id\""},
 	FileName:  whereHelperstring{field: "\"cover\".\"file_name\""},
+	DeletedAt: whereHelpernull_Time{field: "\"cover\".\"deleted_at\""},
 }
 
 // CoverRels is where relationship names are stored.
@@ -100,8 +108,8 @@ func (*coverR) NewStruct() *coverR {
 type coverL struct{}
 
 var (
-	coverAllColumns            = []string{"id", "created_at", "updated_at", "project_id", "file_name"}
-	coverColumnsWithoutDefault = []string{"project_id"}
+	coverAllColumns            = []string{"id", "created_at", "updated_at", "project_id", "file_name", "deleted_at"}
+	coverColumnsWithoutDefault = []string{"project_id", "deleted_at"}
 	coverColumnsWithDefault    = []string{"id", "created_at", "updated_at", "file_name"}
 	coverPrimaryKeyColumns     = []string{"id"}
 )
@@ -349,6 +357,7 @@ func (q coverQuery) Exists(exec boil.Executor) (bool, error) {
 func (o *Cover) Project(mods ...qm.QueryMod) projectQuery {
 	queryMods := []qm.QueryMod{
 		qm.Where("\"id\" = ?", o.ProjectID),
+		qmhelper.WhereIsNull("deleted_at"),
 	}
 
 	queryMods = append(queryMods, mods...)
@@ -368,6 +377,7 @@ func (o *Cover) Projects(mods ...qm.QueryMod) projectQuery {
 
 	queryMods = append(queryMods,
 		qm.Where("\"project\".\"cover_id\"=?", o.ID),
+		qmhelper.WhereIsNull("\"project\".\"deleted_at\""),
 	)
 
 	query := Projects(queryMods...)
@@ -424,6 +434,7 @@ func (coverL) LoadProject(e boil.Executor, singular bool, maybeCover interface{}
 	query := NewQuery(
 		qm.From(`project`),
 		qm.WhereIn(`project.id in ?`, args...),
+		qmhelper.WhereIsNull(`project.deleted_at`),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -526,6 +537,7 @@ func (coverL) LoadProjects(e boil.Executor, singular bool, maybeCover interface{
 	query := NewQuery(
 		qm.From(`project`),
 		qm.WhereIn(`project.cover_id in ?`, args...),
+		qmhelper.WhereIsNull(`project.deleted_at`),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -755,7 +767,7 @@ func (o *Cover) RemoveProjects(exec boil.Executor, related ...*Project) error {
 
 // Covers retrieves all the records using an executor.
 func Covers(mods ...qm.QueryMod) coverQuery {
-	mods = append(mods, qm.From("\"cover\""))
+	mods = append(mods, qm.From("\"cover\""), qmhelper.WhereIsNull("\"cover\".\"deleted_at\""))
 	return coverQuery{NewQuery(mods...)}
 }
 
@@ -769,7 +781,7 @@ func FindCover(exec boil.Executor, iD int64, selectCols ...string) (*Cover, erro
 		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
 	}
 	query := fmt.Sprintf(
-		"select %s from \"cover\" where \"id\"=$1", sel,
+		"select %s from \"cover\" where \"id\"=$1 and \"deleted_at\" is null", sel,
 	)
 
 	q := queries.Raw(query, iD)
@@ -1112,7 +1124,7 @@ func (o *Cover) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumn
 
 // Delete deletes a single Cover record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *Cover) Delete(exec boil.Executor) error {
+func (o *Cover) Delete(exec boil.Executor, hardDelete bool) error {
 	if o == nil {
 		return errors.New("models: no Cover provided for delete")
 	}
@@ -1121,8 +1133,26 @@ func (o *Cover) Delete(exec boil.Executor) error {
 		return err
 	}
 
-	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), coverPrimaryKeyMapping)
-	sql := "DELETE FROM \"cover\" WHERE \"id\"=$1"
+	var (
+		sql  string
+		args []interface{}
+	)
+	if hardDelete {
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), coverPrimaryKeyMapping)
+		sql = "DELETE FROM \"cover\" WHERE \"id\"=$1"
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		o.DeletedAt = null.TimeFrom(currTime)
+		wl := []string{"deleted_at"}
+		sql = fmt.Sprintf("UPDATE \"cover\" SET %s WHERE \"id\"=$2",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+		)
+		valueMapping, err := queries.BindMapping(coverType, coverMapping, append(wl, coverPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), valueMapping)
+	}
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
@@ -1141,12 +1171,17 @@ func (o *Cover) Delete(exec boil.Executor) error {
 }
 
 // DeleteAll deletes all matching rows.
-func (q coverQuery) DeleteAll(exec boil.Executor) error {
+func (q coverQuery) DeleteAll(exec boil.Executor, hardDelete bool) error {
 	if q.Query == nil {
 		return errors.New("models: no coverQuery provided for delete all")
 	}
 
-	queries.SetDelete(q.Query)
+	if hardDelete {
+		queries.SetDelete(q.Query)
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		queries.SetUpdate(q.Query, M{"deleted_at": currTime})
+	}
 
 	_, err := q.Query.Exec(exec)
 	if err != nil {
@@ -1157,7 +1192,7 @@ func (q coverQuery) DeleteAll(exec boil.Executor) error {
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o CoverSlice) DeleteAll(exec boil.Executor) error {
+func (o CoverSlice) DeleteAll(exec boil.Executor, hardDelete bool) error {
 	if len(o) == 0 {
 		return nil
 	}
@@ -1170,15 +1205,32 @@ func (o CoverSlice) DeleteAll(exec boil.Executor) error {
 		}
 	}
 
-	var args []interface{}
-	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), coverPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
+	var (
+		sql  string
+		args []interface{}
+	)
+	if hardDelete {
+		for _, obj := range o {
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), coverPrimaryKeyMapping)
+			args = append(args, pkeyArgs...)
+		}
+		sql = "DELETE FROM \"cover\" WHERE " +
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, coverPrimaryKeyColumns, len(o))
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		for _, obj := range o {
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), coverPrimaryKeyMapping)
+			args = append(args, pkeyArgs...)
+			obj.DeletedAt = null.TimeFrom(currTime)
+		}
+		wl := []string{"deleted_at"}
+		sql = fmt.Sprintf("UPDATE \"cover\" SET %s WHERE "+
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 2, coverPrimaryKeyColumns, len(o)),
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+		)
+		args = append([]interface{}{currTime}, args...)
 	}
 
-	sql := "DELETE FROM \"cover\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, coverPrimaryKeyColumns, len(o))
-
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
 		fmt.Fprintln(boil.DebugWriter, args)
@@ -1226,7 +1278,8 @@ func (o *CoverSlice) ReloadAll(exec boil.Executor) error {
 	}
 
 	sql := "SELECT \"cover\".* FROM \"cover\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, coverPrimaryKeyColumns, len(*o))
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, coverPrimaryKeyColumns, len(*o)) +
+		"and \"deleted_at\" is null"
 
 	q := queries.Raw(sql, args...)
 
@@ -1243,7 +1296,7 @@ func (o *CoverSlice) ReloadAll(exec boil.Executor) error {
 // CoverExists checks if the Cover row exists.
 func CoverExists(exec boil.Executor, iD int64) (bool, error) {
 	var exists bool
-	sql := "select exists(select 1 from \"cover\" where \"id\"=$1 limit 1)"
+	sql := "select exists(select 1 from \"cover\" where \"id\"=$1 and \"deleted_at\" is null limit 1)"
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
@@ -0,0 +1,1308 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// Invite is an object representing the database table.
+type Invite struct {
+	ID          int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt   time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	CreatorID   null.Int64        `boil:"creator_id" json:"creator_id,omitempty" toml:"creator_id" yaml:"creator_id,omitempty"`
+	Hash        string            `boil:"hash" json:"hash" toml:"hash" yaml:"hash"`
+	Prefix      string            `boil:"prefix" json:"prefix" toml:"prefix" yaml:"prefix"`
+	MaxUses     int               `boil:"max_uses" json:"max_uses" toml:"max_uses" yaml:"max_uses"`
+	Uses        int               `boil:"uses" json:"uses" toml:"uses" yaml:"uses"`
+	RoleID      null.Int64        `boil:"role_id" json:"role_id,omitempty" toml:"role_id" yaml:"role_id,omitempty"`
+	Permissions types.StringArray `boil:"permissions" json:"permissions" toml:"permissions" yaml:"permissions"`
+	ExpiresAt   null.Time         `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
+
+	R *inviteR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L inviteL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InviteColumns = struct {
+	ID          string
+	CreatedAt   string
+	CreatorID   string
+	Hash        string
+	Prefix      string
+	MaxUses     string
+	Uses        string
+	RoleID      string
+	Permissions string
+	ExpiresAt   string
+}{
+	ID:          "id",
+	CreatedAt:   "created_at",
+	CreatorID:   "creator_id",
+	Hash:        "hash",
+	Prefix:      "prefix",
+	MaxUses:     "max_uses",
+	Uses:        "uses",
+	RoleID:      "role_id",
+	Permissions: "permissions",
+	ExpiresAt:   "expires_at",
+}
+
+var InviteTableColumns = struct {
+	ID          string
+	CreatedAt   string
+	CreatorID   string
+	Hash        string
+	Prefix      string
+	MaxUses     string
+	Uses        string
+	RoleID      string
+	Permissions string
+	ExpiresAt   string
+}{
+	ID:          "invite.id",
+	CreatedAt:   "invite.created_at",
+	CreatorID:   "invite.creator_id",
+	Hash:        "invite.hash",
+	Prefix:      "invite.prefix",
+	MaxUses:     "invite.max_uses",
+	Uses:        "invite.uses",
+	RoleID:      "invite.role_id",
+	Permissions: "invite.permissions",
+	ExpiresAt:   "invite.expires_at",
+}
+
+// Generated where
+
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+var InviteWhere = struct {
+	ID          whereHelperint64
+	CreatedAt   whereHelpertime_Time
+	CreatorID   whereHelpernull_Int64
+	Hash        whereHelperstring
+	Prefix      whereHelperstring
+	MaxUses     whereHelperint
+	Uses        whereHelperint
+	RoleID      whereHelpernull_Int64
+	Permissions whereHelpertypes_StringArray
+	ExpiresAt   whereHelpernull_Time
+}{
+	ID:          whereHelperint64{field: "\"invite\".\"id\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"invite\".\"created_at\""},
+	CreatorID:   whereHelpernull_Int64{field: "\"invite\".\"creator_id\""},
+	Hash:        whereHelperstring{field: "\"invite\".\"hash\""},
+	Prefix:      whereHelperstring{field: "\"invite\".\"prefix\""},
+	MaxUses:     whereHelperint{field: "\"invite\".\"max_uses\""},
+	Uses:        whereHelperint{field: "\"invite\".\"uses\""},
+	RoleID:      whereHelpernull_Int64{field: "\"invite\".\"role_id\""},
+	Permissions: whereHelpertypes_StringArray{field: "\"invite\".\"permissions\""},
+	ExpiresAt:   whereHelpernull_Time{field: "\"invite\".\"expires_at\""},
+}
+
+// InviteRels is where relationship names are stored.
+var InviteRels = struct {
+	Creator string
+	Role    string
+}{
+	Creator: "Creator",
+	Role:    "Role",
+}
+
+// inviteR is where relationships are stored.
+type inviteR struct {
+	Creator *User `boil:"Creator" json:"Creator" toml:"Creator" yaml:"Creator"`
+	Role    *Role `boil:"Role" json:"Role" toml:"Role" yaml:"Role"`
+}
+
+// NewStruct creates a new relationship struct
+func (*inviteR) NewStruct() *inviteR {
+	return &inviteR{}
+}
+
+// inviteL is where Load methods for each relationship are stored.
+type inviteL struct{}
+
+var (
+	inviteAllColumns            = []string{"id", "created_at", "creator_id", "hash", "prefix", "max_uses", "uses", "role_id", "permissions", "expires_at"}
+	inviteColumnsWithoutDefault = []string{"creator_id", "role_id", "expires_at"}
+	inviteColumnsWithDefault    = []string{"id", "created_at", "hash", "prefix", "max_uses", "uses", "permissions"}
+	invitePrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// InviteSlice is an alias for a slice of pointers to Invite.
+	// This should almost always be used instead of []Invite.
+	InviteSlice []*Invite
+	// InviteHook is the signature for custom Invite hook methods
+	InviteHook func(boil.Executor, *Invite) error
+
+	inviteQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	inviteType                 = reflect.TypeOf(&Invite{})
+	inviteMapping              = queries.MakeStructMapping(inviteType)
+	invitePrimaryKeyMapping, _ = queries.BindMapping(inviteType, inviteMapping, invitePrimaryKeyColumns)
+	inviteInsertCacheMut       sync.RWMutex
+	inviteInsertCache          = make(map[string]insertCache)
+	inviteUpdateCacheMut       sync.RWMutex
+	inviteUpdateCache          = make(map[string]updateCache)
+	inviteUpsertCacheMut       sync.RWMutex
+	inviteUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var inviteBeforeInsertHooks []InviteHook
+var inviteBeforeUpdateHooks []InviteHook
+var inviteBeforeDeleteHooks []InviteHook
+var inviteBeforeUpsertHooks []InviteHook
+
+var inviteAfterInsertHooks []InviteHook
+var inviteAfterSelectHooks []InviteHook
+var inviteAfterUpdateHooks []InviteHook
+var inviteAfterDeleteHooks []InviteHook
+var inviteAfterUpsertHooks []InviteHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Invite) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range inviteBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Invite) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range inviteBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Invite) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range inviteBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Invite) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range inviteBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Invite) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range inviteAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Invite) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range inviteAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Invite) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range inviteAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Invite) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range inviteAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Invite) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range inviteAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddInviteHook registers your hook function for all future operations.
+func AddInviteHook(hookPoint boil.HookPoint, inviteHook InviteHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		inviteBeforeInsertHooks = append(inviteBeforeInsertHooks, inviteHook)
+	case boil.BeforeUpdateHook:
+		inviteBeforeUpdateHooks = append(inviteBeforeUpdateHooks, inviteHook)
+	case boil.BeforeDeleteHook:
+		inviteBeforeDeleteHooks = append(inviteBeforeDeleteHooks, inviteHook)
+	case boil.BeforeUpsertHook:
+		inviteBeforeUpsertHooks = append(inviteBeforeUpsertHooks, inviteHook)
+	case boil.AfterInsertHook:
+		inviteAfterInsertHooks = append(inviteAfterInsertHooks, inviteHook)
+	case boil.AfterSelectHook:
+		inviteAfterSelectHooks = append(inviteAfterSelectHooks, inviteHook)
+	case boil.AfterUpdateHook:
+		inviteAfterUpdateHooks = append(inviteAfterUpdateHooks, inviteHook)
+	case boil.AfterDeleteHook:
+		inviteAfterDeleteHooks = append(inviteAfterDeleteHooks, inviteHook)
+	case boil.AfterUpsertHook:
+		inviteAfterUpsertHooks = append(inviteAfterUpsertHooks, inviteHook)
+	}
+}
+
+// One returns a single invite record from the query.
+func (q inviteQuery) One(exec boil.Executor) (*Invite, error) {
+	o := &Invite{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for invite")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Invite records from the query.
+func (q inviteQuery) All(exec boil.Executor) (InviteSlice, error) {
+	var o []*Invite
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Invite slice")
+	}
+
+	if len(inviteAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Invite records in the query.
+func (q inviteQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count invite rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q inviteQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if invite exists")
+	}
+
+	return count > 0, nil
+}
+
+// Creator pointed to by the foreign key.
+func (o *Invite) Creator(mods ...qm.QueryMod) userAccountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.CreatorID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	return query
+}
+
+// Role pointed to by the foreign key.
+func (o *Invite) Role(mods ...qm.QueryMod) roleQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.RoleID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Roles(queryMods...)
+	queries.SetFrom(query.Query, "\"role\"")
+
+	return query
+}
+
+// LoadCreator allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (inviteL) LoadCreator(e boil.Executor, singular bool, maybeInvite interface{}, mods queries.Applicator) error {
+	var slice []*Invite
+	var object *Invite
+
+	if singular {
+		object = maybeInvite.(*Invite)
+	} else {
+		slice = *maybeInvite.(*[]*Invite)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &inviteR{}
+		}
+		if !queries.IsNil(object.CreatorID) {
+			args = append(args, object.CreatorID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &inviteR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.CreatorID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.CreatorID) {
+				args = append(args, obj.CreatorID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_account`),
+		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(inviteAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Creator = foreign
+		if foreign.R == nil {
+			foreign.R = &userAccountR{}
+		}
+		foreign.R.Invites = append(foreign.R.Invites, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.CreatorID, foreign.ID) {
+				local.R.Creator = foreign
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.Invites = append(foreign.R.Invites, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRole allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (inviteL) LoadRole(e boil.Executor, singular bool, maybeInvite interface{}, mods queries.Applicator) error {
+	var slice []*Invite
+	var object *Invite
+
+	if singular {
+		object = maybeInvite.(*Invite)
+	} else {
+		slice = *maybeInvite.(*[]*Invite)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &inviteR{}
+		}
+		if !queries.IsNil(object.RoleID) {
+			args = append(args, object.RoleID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &inviteR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.RoleID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.RoleID) {
+				args = append(args, obj.RoleID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`role`),
+		qm.WhereIn(`role.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Role")
+	}
+
+	var resultSlice []*Role
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Role")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for role")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for role")
+	}
+
+	if len(inviteAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Role = foreign
+		if foreign.R == nil {
+			foreign.R = &roleR{}
+		}
+		foreign.R.Invites = append(foreign.R.Invites, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.RoleID, foreign.ID) {
+				local.R.Role = foreign
+				if foreign.R == nil {
+					foreign.R = &roleR{}
+				}
+				foreign.R.Invites = append(foreign.R.Invites, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetCreator of the invite to the related item.
+// Sets o.R.Creator to related.
+// Adds o to related.R.Invites.
+func (o *Invite) SetCreator(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"invite\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"creator_id"}),
+		strmangle.WhereClause("\"", "\"", 2, invitePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.CreatorID, related.ID)
+	if o.R == nil {
+		o.R = &inviteR{
+			Creator: related,
+		}
+	} else {
+		o.R.Creator = related
+	}
+
+	if related.R == nil {
+		related.R = &userAccountR{
+			Invites: InviteSlice{o},
+		}
+	} else {
+		related.R.Invites = append(related.R.Invites, o)
+	}
+
+	return nil
+}
+
+// RemoveCreator relationship.
+// Sets o.R.Creator to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *Invite) RemoveCreator(exec boil.Executor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.CreatorID, nil)
+	if err = o.Update(exec, boil.Whitelist("creator_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Creator = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.Invites {
+		if queries.Equal(o.CreatorID, ri.CreatorID) {
+			continue
+		}
+
+		ln := len(related.R.Invites)
+		if ln > 1 && i < ln-1 {
+			related.R.Invites[i] = related.R.Invites[ln-1]
+		}
+		related.R.Invites = related.R.Invites[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetRole of the invite to the related item.
+// Sets o.R.Role to related.
+// Adds o to related.R.Invites.
+func (o *Invite) SetRole(exec boil.Executor, insert bool, related *Role) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"invite\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"role_id"}),
+		strmangle.WhereClause("\"", "\"", 2, invitePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.RoleID, related.ID)
+	if o.R == nil {
+		o.R = &inviteR{
+			Role: related,
+		}
+	} else {
+		o.R.Role = related
+	}
+
+	if related.R == nil {
+		related.R = &roleR{
+			Invites: InviteSlice{o},
+		}
+	} else {
+		related.R.Invites = append(related.R.Invites, o)
+	}
+
+	return nil
+}
+
+// RemoveRole relationship.
+// Sets o.R.Role to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *Invite) RemoveRole(exec boil.Executor, related *Role) error {
+	var err error
+
+	queries.SetScanner(&o.RoleID, nil)
+	if err = o.Update(exec, boil.Whitelist("role_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Role = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.Invites {
+		if queries.Equal(o.RoleID, ri.RoleID) {
+			continue
+		}
+
+		ln := len(related.R.Invites)
+		if ln > 1 && i < ln-1 {
+			related.R.Invites[i] = related.R.Invites[ln-1]
+		}
+		related.R.Invites = related.R.Invites[:ln-1]
+		break
+	}
+	return nil
+}
+
+// Invites retrieves all the records using an executor.
+func Invites(mods ...qm.QueryMod) inviteQuery {
+	mods = append(mods, qm.From("\"invite\""))
+	return inviteQuery{NewQuery(mods...)}
+}
+
+// FindInvite retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInvite(exec boil.Executor, iD int64, selectCols ...string) (*Invite, error) {
+	inviteObj := &Invite{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"invite\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, inviteObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from invite")
+	}
+
+	if err = inviteObj.doAfterSelectHooks(exec); err != nil {
+		return inviteObj, err
+	}
+
+	return inviteObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Invite) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invite provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(inviteColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	inviteInsertCacheMut.RLock()
+	cache, cached := inviteInsertCache[key]
+	inviteInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			inviteAllColumns,
+			inviteColumnsWithDefault,
+			inviteColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(inviteType, inviteMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(inviteType, inviteMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"invite\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"invite\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into invite")
+	}
+
+	if !cached {
+		inviteInsertCacheMut.Lock()
+		inviteInsertCache[key] = cache
+		inviteInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the Invite.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Invite) Update(exec boil.Executor, columns boil.Columns) error {
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	inviteUpdateCacheMut.RLock()
+	cache, cached := inviteUpdateCache[key]
+	inviteUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			inviteAllColumns,
+			invitePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update invite, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"invite\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, invitePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(inviteType, inviteMapping, append(wl, invitePrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update invite row")
+	}
+
+	if !cached {
+		inviteUpdateCacheMut.Lock()
+		inviteUpdateCache[key] = cache
+		inviteUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q inviteQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for invite")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InviteSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invitePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"invite\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, invitePrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in invite slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Invite) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no invite provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(inviteColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	inviteUpsertCacheMut.RLock()
+	cache, cached := inviteUpsertCache[key]
+	inviteUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			inviteAllColumns,
+			inviteColumnsWithDefault,
+			inviteColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			inviteAllColumns,
+			invitePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert invite, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(invitePrimaryKeyColumns))
+			copy(conflict, invitePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"invite\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(inviteType, inviteMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(inviteType, inviteMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert invite")
+	}
+
+	if !cached {
+		inviteUpsertCacheMut.Lock()
+		inviteUpsertCache[key] = cache
+		inviteUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single Invite record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Invite) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no Invite provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), invitePrimaryKeyMapping)
+	sql := "DELETE FROM \"invite\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from invite")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q inviteQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no inviteQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from invite")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InviteSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(inviteBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invitePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"invite\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, invitePrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from invite slice")
+	}
+
+	if len(inviteAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Invite) Reload(exec boil.Executor) error {
+	ret, err := FindInvite(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InviteSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InviteSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invitePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"invite\".* FROM \"invite\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, invitePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in InviteSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InviteExists checks if the Invite row exists.
+func InviteExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"invite\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if invite exists")
+	}
+
+	return exists, nil
+}
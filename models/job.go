@@ -0,0 +1,1131 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// Job is an object representing the database table.
+type Job struct {
+	ID          int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt   time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt   time.Time         `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	UserID      null.Int64        `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	Kind        string            `boil:"kind" json:"kind" toml:"kind" yaml:"kind"`
+	Payload     types.JSON        `boil:"payload" json:"payload" toml:"payload" yaml:"payload"`
+	Status      string            `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Progress    int               `boil:"progress" json:"progress" toml:"progress" yaml:"progress"`
+	Total       int               `boil:"total" json:"total" toml:"total" yaml:"total"`
+	Attempts    int               `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
+	MaxAttempts int               `boil:"max_attempts" json:"max_attempts" toml:"max_attempts" yaml:"max_attempts"`
+	RunAt       time.Time         `boil:"run_at" json:"run_at" toml:"run_at" yaml:"run_at"`
+	StartedAt   null.Time         `boil:"started_at" json:"started_at,omitempty" toml:"started_at" yaml:"started_at,omitempty"`
+	FinishedAt  null.Time         `boil:"finished_at" json:"finished_at,omitempty" toml:"finished_at" yaml:"finished_at,omitempty"`
+	Error       null.String       `boil:"error" json:"error,omitempty" toml:"error" yaml:"error,omitempty"`
+	Scopes      types.StringArray `boil:"scopes" json:"scopes" toml:"scopes" yaml:"scopes"`
+
+	R *jobR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L jobL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var JobColumns = struct {
+	ID          string
+	CreatedAt   string
+	UpdatedAt   string
+	UserID      string
+	Kind        string
+	Payload     string
+	Status      string
+	Progress    string
+	Total       string
+	Attempts    string
+	MaxAttempts string
+	RunAt       string
+	StartedAt   string
+	FinishedAt  string
+	Error       string
+	Scopes      string
+}{
+	ID:          "id",
+	CreatedAt:   "created_at",
+	UpdatedAt:   "updated_at",
+	UserID:      "user_id",
+	Kind:        "kind",
+	Payload:     "payload",
+	Status:      "status",
+	Progress:    "progress",
+	Total:       "total",
+	Attempts:    "attempts",
+	MaxAttempts: "max_attempts",
+	RunAt:       "run_at",
+	StartedAt:   "started_at",
+	FinishedAt:  "finished_at",
+	Error:       "error",
+	Scopes:      "scopes",
+}
+
+var JobTableColumns = struct {
+	ID          string
+	CreatedAt   string
+	UpdatedAt   string
+	UserID      string
+	Kind        string
+	Payload     string
+	Status      string
+	Progress    string
+	Total       string
+	Attempts    string
+	MaxAttempts string
+	RunAt       string
+	StartedAt   string
+	FinishedAt  string
+	Error       string
+	Scopes      string
+}{
+	ID:          "job.id",
+	CreatedAt:   "job.created_at",
+	UpdatedAt:   "job.updated_at",
+	UserID:      "job.user_id",
+	Kind:        "job.kind",
+	Payload:     "job.payload",
+	Status:      "job.status",
+	Progress:    "job.progress",
+	Total:       "job.total",
+	Attempts:    "job.attempts",
+	MaxAttempts: "job.max_attempts",
+	RunAt:       "job.run_at",
+	StartedAt:   "job.started_at",
+	FinishedAt:  "job.finished_at",
+	Error:       "job.error",
+	Scopes:      "job.scopes",
+}
+
+// Generated where
+
+var JobWhere = struct {
+	ID          whereHelperint64
+	CreatedAt   whereHelpertime_Time
+	UpdatedAt   whereHelpertime_Time
+	UserID      whereHelpernull_Int64
+	Kind        whereHelperstring
+	Payload     whereHelpertypes_JSON
+	Status      whereHelperstring
+	Progress    whereHelperint
+	Total       whereHelperint
+	Attempts    whereHelperint
+	MaxAttempts whereHelperint
+	RunAt       whereHelpertime_Time
+	StartedAt   whereHelpernull_Time
+	FinishedAt  whereHelpernull_Time
+	Error       whereHelpernull_String
+	Scopes      whereHelpertypes_StringArray
+}{
+	ID:          whereHelperint64{field: "\"job\".\"id\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"job\".\"created_at\""},
+	UpdatedAt:   whereHelpertime_Time{field: "\"job\".\"updated_at\""},
+	UserID:      whereHelpernull_Int64{field: "\"job\".\"user_id\""},
+	Kind:        whereHelperstring{field: "\"job\".\"kind\""},
+	Payload:     whereHelpertypes_JSON{field: "\"job\".\"payload\""},
+	Status:      whereHelperstring{field: "\"job\".\"status\""},
+	Progress:    whereHelperint{field: "\"job\".\"progress\""},
+	Total:       whereHelperint{field: "\"job\".\"total\""},
+	Attempts:    whereHelperint{field: "\"job\".\"attempts\""},
+	MaxAttempts: whereHelperint{field: "\"job\".\"max_attempts\""},
+	RunAt:       whereHelpertime_Time{field: "\"job\".\"run_at\""},
+	StartedAt:   whereHelpernull_Time{field: "\"job\".\"started_at\""},
+	FinishedAt:  whereHelpernull_Time{field: "\"job\".\"finished_at\""},
+	Error:       whereHelpernull_String{field: "\"job\".\"error\""},
+	Scopes:      whereHelpertypes_StringArray{field: "\"job\".\"scopes\""},
+}
+
+// JobRels is where relationship names are stored.
+var JobRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// jobR is where relationships are stored.
+type jobR struct {
+	User *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*jobR) NewStruct() *jobR {
+	return &jobR{}
+}
+
+// jobL is where Load methods for each relationship are stored.
+type jobL struct{}
+
+var (
+	jobAllColumns            = []string{"id", "created_at", "updated_at", "user_id", "kind", "payload", "status", "progress", "total", "attempts", "max_attempts", "run_at", "started_at", "finished_at", "error", "scopes"}
+	jobColumnsWithoutDefault = []string{"user_id", "started_at", "finished_at", "error"}
+	jobColumnsWithDefault    = []string{"id", "created_at", "updated_at", "kind", "payload", "status", "progress", "total", "attempts", "max_attempts", "run_at", "scopes"}
+	jobPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// JobSlice is an alias for a slice of pointers to Job.
+	// This should almost always be used instead of []Job.
+	JobSlice []*Job
+	// JobHook is the signature for custom Job hook methods
+	JobHook func(boil.Executor, *Job) error
+
+	jobQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	jobType                 = reflect.TypeOf(&Job{})
+	jobMapping              = queries.MakeStructMapping(jobType)
+	jobPrimaryKeyMapping, _ = queries.BindMapping(jobType, jobMapping, jobPrimaryKeyColumns)
+	jobInsertCacheMut       sync.RWMutex
+	jobInsertCache          = make(map[string]insertCache)
+	jobUpdateCacheMut       sync.RWMutex
+	jobUpdateCache          = make(map[string]updateCache)
+	jobUpsertCacheMut       sync.RWMutex
+	jobUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var jobBeforeInsertHooks []JobHook
+var jobBeforeUpdateHooks []JobHook
+var jobBeforeDeleteHooks []JobHook
+var jobBeforeUpsertHooks []JobHook
+
+var jobAfterInsertHooks []JobHook
+var jobAfterSelectHooks []JobHook
+var jobAfterUpdateHooks []JobHook
+var jobAfterDeleteHooks []JobHook
+var jobAfterUpsertHooks []JobHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Job) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range jobBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Job) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range jobBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Job) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range jobBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Job) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range jobBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Job) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range jobAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Job) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range jobAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Job) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range jobAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Job) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range jobAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Job) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range jobAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddJobHook registers your hook function for all future operations.
+func AddJobHook(hookPoint boil.HookPoint, jobHook JobHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		jobBeforeInsertHooks = append(jobBeforeInsertHooks, jobHook)
+	case boil.BeforeUpdateHook:
+		jobBeforeUpdateHooks = append(jobBeforeUpdateHooks, jobHook)
+	case boil.BeforeDeleteHook:
+		jobBeforeDeleteHooks = append(jobBeforeDeleteHooks, jobHook)
+	case boil.BeforeUpsertHook:
+		jobBeforeUpsertHooks = append(jobBeforeUpsertHooks, jobHook)
+	case boil.AfterInsertHook:
+		jobAfterInsertHooks = append(jobAfterInsertHooks, jobHook)
+	case boil.AfterSelectHook:
+		jobAfterSelectHooks = append(jobAfterSelectHooks, jobHook)
+	case boil.AfterUpdateHook:
+		jobAfterUpdateHooks = append(jobAfterUpdateHooks, jobHook)
+	case boil.AfterDeleteHook:
+		jobAfterDeleteHooks = append(jobAfterDeleteHooks, jobHook)
+	case boil.AfterUpsertHook:
+		jobAfterUpsertHooks = append(jobAfterUpsertHooks, jobHook)
+	}
+}
+
+// One returns a single job record from the query.
+func (q jobQuery) One(exec boil.Executor) (*Job, error) {
+	o := &Job{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for job")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Job records from the query.
+func (q jobQuery) All(exec boil.Executor) (JobSlice, error) {
+	var o []*Job
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Job slice")
+	}
+
+	if len(jobAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Job records in the query.
+func (q jobQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count job rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q jobQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if job exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *Job) User(mods ...qm.QueryMod) userAccountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	return query
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (jobL) LoadUser(e boil.Executor, singular bool, maybeJob interface{}, mods queries.Applicator) error {
+	var slice []*Job
+	var object *Job
+
+	if singular {
+		object = maybeJob.(*Job)
+	} else {
+		slice = *maybeJob.(*[]*Job)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &jobR{}
+		}
+		if !queries.IsNil(object.UserID) {
+			args = append(args, object.UserID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &jobR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.UserID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.UserID) {
+				args = append(args, obj.UserID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_account`),
+		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(jobAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userAccountR{}
+		}
+		foreign.R.UserJobs = append(foreign.R.UserJobs, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.UserID, foreign.ID) {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.UserJobs = append(foreign.R.UserJobs, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the job to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.UserJobs.
+func (o *Job) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"job\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, jobPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.UserID, related.ID)
+	if o.R == nil {
+		o.R = &jobR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userAccountR{
+			UserJobs: JobSlice{o},
+		}
+	} else {
+		related.R.UserJobs = append(related.R.UserJobs, o)
+	}
+
+	return nil
+}
+
+// RemoveUser relationship.
+// Sets o.R.User to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *Job) RemoveUser(exec boil.Executor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.UserID, nil)
+	if err = o.Update(exec, boil.Whitelist("user_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.User = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.UserJobs {
+		if queries.Equal(o.UserID, ri.UserID) {
+			continue
+		}
+
+		ln := len(related.R.UserJobs)
+		if ln > 1 && i < ln-1 {
+			related.R.UserJobs[i] = related.R.UserJobs[ln-1]
+		}
+		related.R.UserJobs = related.R.UserJobs[:ln-1]
+		break
+	}
+	return nil
+}
+
+// Jobs retrieves all the records using an executor.
+func Jobs(mods ...qm.QueryMod) jobQuery {
+	mods = append(mods, qm.From("\"job\""))
+	return jobQuery{NewQuery(mods...)}
+}
+
+// FindJob retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindJob(exec boil.Executor, iD int64, selectCols ...string) (*Job, error) {
+	jobObj := &Job{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"job\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, jobObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from job")
+	}
+
+	if err = jobObj.doAfterSelectHooks(exec); err != nil {
+		return jobObj, err
+	}
+
+	return jobObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Job) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no job provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(jobColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	jobInsertCacheMut.RLock()
+	cache, cached := jobInsertCache[key]
+	jobInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			jobAllColumns,
+			jobColumnsWithDefault,
+			jobColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(jobType, jobMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(jobType, jobMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"job\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"job\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into job")
+	}
+
+	if !cached {
+		jobInsertCacheMut.Lock()
+		jobInsertCache[key] = cache
+		jobInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the Job.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Job) Update(exec boil.Executor, columns boil.Columns) error {
+	currTime := time.Now().In(boil.GetLocation())
+
+	o.UpdatedAt = currTime
+
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	jobUpdateCacheMut.RLock()
+	cache, cached := jobUpdateCache[key]
+	jobUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			jobAllColumns,
+			jobPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update job, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"job\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, jobPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(jobType, jobMapping, append(wl, jobPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update job row")
+	}
+
+	if !cached {
+		jobUpdateCacheMut.Lock()
+		jobUpdateCache[key] = cache
+		jobUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q jobQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for job")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o JobSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), jobPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"job\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, jobPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in job slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Job) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no job provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	o.UpdatedAt = currTime
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(jobColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	jobUpsertCacheMut.RLock()
+	cache, cached := jobUpsertCache[key]
+	jobUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			jobAllColumns,
+			jobColumnsWithDefault,
+			jobColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			jobAllColumns,
+			jobPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert job, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(jobPrimaryKeyColumns))
+			copy(conflict, jobPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"job\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(jobType, jobMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(jobType, jobMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert job")
+	}
+
+	if !cached {
+		jobUpsertCacheMut.Lock()
+		jobUpsertCache[key] = cache
+		jobUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single Job record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Job) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no Job provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), jobPrimaryKeyMapping)
+	sql := "DELETE FROM \"job\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from job")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q jobQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no jobQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from job")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o JobSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(jobBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), jobPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"job\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, jobPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from job slice")
+	}
+
+	if len(jobAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Job) Reload(exec boil.Executor) error {
+	ret, err := FindJob(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *JobSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := JobSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), jobPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"job\".* FROM \"job\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, jobPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in JobSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// JobExists checks if the Job row exists.
+func JobExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"job\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if job exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1059 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// LoginFailure is an object representing the database table.
+type LoginFailure struct {
+	ID        int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UserID    null.Int64  `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	Email     string      `boil:"email" json:"email" toml:"email" yaml:"email"`
+	IP        null.String `boil:"ip" json:"ip,omitempty" toml:"ip" yaml:"ip,omitempty"`
+	UserAgent null.String `boil:"user_agent" json:"user_agent,omitempty" toml:"user_agent" yaml:"user_agent,omitempty"`
+	Reason    string      `boil:"reason" json:"reason" toml:"reason" yaml:"reason"`
+
+	R *loginFailureR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L loginFailureL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var LoginFailureColumns = struct {
+	ID        string
+	CreatedAt string
+	UserID    string
+	Email     string
+	IP        string
+	UserAgent string
+	Reason    string
+}{
+	ID:        "id",
+	CreatedAt: "created_at",
+	UserID:    "user_id",
+	Email:     "email",
+	IP:        "ip",
+	UserAgent: "user_agent",
+	Reason:    "reason",
+}
+
+var LoginFailureTableColumns = struct {
+	ID        string
+	CreatedAt string
+	UserID    string
+	Email     string
+	IP        string
+	UserAgent string
+	Reason    string
+}{
+	ID:        "login_failure.id",
+	CreatedAt: "login_failure.created_at",
+	UserID:    "login_failure.user_id",
+	Email:     "login_failure.email",
+	IP:        "login_failure.ip",
+	UserAgent: "login_failure.user_agent",
+	Reason:    "login_failure.reason",
+}
+
+// Generated where
+
+var LoginFailureWhere = struct {
+	ID        whereHelperint64
+	CreatedAt whereHelpertime_Time
+	UserID    whereHelpernull_Int64
+	Email     whereHelperstring
+	IP        whereHelpernull_String
+	UserAgent whereHelpernull_String
+	Reason    whereHelperstring
+}{
+	ID:        whereHelperint64{field: "\"login_failure\".\"id\""},
+	CreatedAt: whereHelpertime_Time{field: "\"login_failure\".\"created_at\""},
+	UserID:    whereHelpernull_Int64{field: "\"login_failure\".\"user_id\""},
+	Email:     whereHelperstring{field: "\"login_failure\".\"email\""},
+	IP:        whereHelpernull_String{field: "\"login_failure\".\"ip\""},
+	UserAgent: whereHelpernull_String{field: "\"login_failure\".\"user_agent\""},
+	Reason:    whereHelperstring{field: "\"login_failure\".\"reason\""},
+}
+
+// LoginFailureRels is where relationship names are stored.
+var LoginFailureRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// loginFailureR is where relationships are stored.
+type loginFailureR struct {
+	User *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*loginFailureR) NewStruct() *loginFailureR {
+	return &loginFailureR{}
+}
+
+// loginFailureL is where Load methods for each relationship are stored.
+type loginFailureL struct{}
+
+var (
+	loginFailureAllColumns            = []string{"id", "created_at", "user_id", "email", "ip", "user_agent", "reason"}
+	loginFailureColumnsWithoutDefault = []string{"user_id"}
+	loginFailureColumnsWithDefault    = []string{"id", "created_at", "email", "ip", "user_agent", "reason"}
+	loginFailurePrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// LoginFailureSlice is an alias for a slice of pointers to LoginFailure.
+	// This should almost always be used instead of []LoginFailure.
+	LoginFailureSlice []*LoginFailure
+	// LoginFailureHook is the signature for custom LoginFailure hook methods
+	LoginFailureHook func(boil.Executor, *LoginFailure) error
+
+	loginFailureQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	loginFailureType                 = reflect.TypeOf(&LoginFailure{})
+	loginFailureMapping              = queries.MakeStructMapping(loginFailureType)
+	loginFailurePrimaryKeyMapping, _ = queries.BindMapping(loginFailureType, loginFailureMapping, loginFailurePrimaryKeyColumns)
+	loginFailureInsertCacheMut       sync.RWMutex
+	loginFailureInsertCache          = make(map[string]insertCache)
+	loginFailureUpdateCacheMut       sync.RWMutex
+	loginFailureUpdateCache          = make(map[string]updateCache)
+	loginFailureUpsertCacheMut       sync.RWMutex
+	loginFailureUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var loginFailureBeforeInsertHooks []LoginFailureHook
+var loginFailureBeforeUpdateHooks []LoginFailureHook
+var loginFailureBeforeDeleteHooks []LoginFailureHook
+var loginFailureBeforeUpsertHooks []LoginFailureHook
+
+var loginFailureAfterInsertHooks []LoginFailureHook
+var loginFailureAfterSelectHooks []LoginFailureHook
+var loginFailureAfterUpdateHooks []LoginFailureHook
+var loginFailureAfterDeleteHooks []LoginFailureHook
+var loginFailureAfterUpsertHooks []LoginFailureHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *LoginFailure) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range loginFailureBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *LoginFailure) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range loginFailureBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *LoginFailure) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range loginFailureBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *LoginFailure) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range loginFailureBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *LoginFailure) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range loginFailureAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *LoginFailure) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range loginFailureAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *LoginFailure) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range loginFailureAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *LoginFailure) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range loginFailureAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *LoginFailure) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range loginFailureAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddLoginFailureHook registers your hook function for all future operations.
+func AddLoginFailureHook(hookPoint boil.HookPoint, loginFailureHook LoginFailureHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		loginFailureBeforeInsertHooks = append(loginFailureBeforeInsertHooks, loginFailureHook)
+	case boil.BeforeUpdateHook:
+		loginFailureBeforeUpdateHooks = append(loginFailureBeforeUpdateHooks, loginFailureHook)
+	case boil.BeforeDeleteHook:
+		loginFailureBeforeDeleteHooks = append(loginFailureBeforeDeleteHooks, loginFailureHook)
+	case boil.BeforeUpsertHook:
+		loginFailureBeforeUpsertHooks = append(loginFailureBeforeUpsertHooks, loginFailureHook)
+	case boil.AfterInsertHook:
+		loginFailureAfterInsertHooks = append(loginFailureAfterInsertHooks, loginFailureHook)
+	case boil.AfterSelectHook:
+		loginFailureAfterSelectHooks = append(loginFailureAfterSelectHooks, loginFailureHook)
+	case boil.AfterUpdateHook:
+		loginFailureAfterUpdateHooks = append(loginFailureAfterUpdateHooks, loginFailureHook)
+	case boil.AfterDeleteHook:
+		loginFailureAfterDeleteHooks = append(loginFailureAfterDeleteHooks, loginFailureHook)
+	case boil.AfterUpsertHook:
+		loginFailureAfterUpsertHooks = append(loginFailureAfterUpsertHooks, loginFailureHook)
+	}
+}
+
+// One returns a single loginFailure record from the query.
+func (q loginFailureQuery) One(exec boil.Executor) (*LoginFailure, error) {
+	o := &LoginFailure{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for login_failure")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all LoginFailure records from the query.
+func (q loginFailureQuery) All(exec boil.Executor) (LoginFailureSlice, error) {
+	var o []*LoginFailure
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to LoginFailure slice")
+	}
+
+	if len(loginFailureAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all LoginFailure records in the query.
+func (q loginFailureQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count login_failure rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q loginFailureQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if login_failure exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *LoginFailure) User(mods ...qm.QueryMod) userAccountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	return query
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (loginFailureL) LoadUser(e boil.Executor, singular bool, maybeLoginFailure interface{}, mods queries.Applicator) error {
+	var slice []*LoginFailure
+	var object *LoginFailure
+
+	if singular {
+		object = maybeLoginFailure.(*LoginFailure)
+	} else {
+		slice = *maybeLoginFailure.(*[]*LoginFailure)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &loginFailureR{}
+		}
+		if !queries.IsNil(object.UserID) {
+			args = append(args, object.UserID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &loginFailureR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.UserID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.UserID) {
+				args = append(args, obj.UserID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_account`),
+		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(loginFailureAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userAccountR{}
+		}
+		foreign.R.LoginFailures = append(foreign.R.LoginFailures, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.UserID, foreign.ID) {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.LoginFailures = append(foreign.R.LoginFailures, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the loginFailure to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.LoginFailures.
+func (o *LoginFailure) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"login_failure\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, loginFailurePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.UserID, related.ID)
+	if o.R == nil {
+		o.R = &loginFailureR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userAccountR{
+			LoginFailures: LoginFailureSlice{o},
+		}
+	} else {
+		related.R.LoginFailures = append(related.R.LoginFailures, o)
+	}
+
+	return nil
+}
+
+// RemoveUser relationship.
+// Sets o.R.User to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *LoginFailure) RemoveUser(exec boil.Executor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.UserID, nil)
+	if err = o.Update(exec, boil.Whitelist("user_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.User = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.LoginFailures {
+		if queries.Equal(o.UserID, ri.UserID) {
+			continue
+		}
+
+		ln := len(related.R.LoginFailures)
+		if ln > 1 && i < ln-1 {
+			related.R.LoginFailures[i] = related.R.LoginFailures[ln-1]
+		}
+		related.R.LoginFailures = related.R.LoginFailures[:ln-1]
+		break
+	}
+	return nil
+}
+
+// LoginFailures retrieves all the records using an executor.
+func LoginFailures(mods ...qm.QueryMod) loginFailureQuery {
+	mods = append(mods, qm.From("\"login_failure\""))
+	return loginFailureQuery{NewQuery(mods...)}
+}
+
+// FindLoginFailure retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindLoginFailure(exec boil.Executor, iD int64, selectCols ...string) (*LoginFailure, error) {
+	loginFailureObj := &LoginFailure{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"login_failure\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, loginFailureObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from login_failure")
+	}
+
+	if err = loginFailureObj.doAfterSelectHooks(exec); err != nil {
+		return loginFailureObj, err
+	}
+
+	return loginFailureObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *LoginFailure) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no login_failure provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(loginFailureColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	loginFailureInsertCacheMut.RLock()
+	cache, cached := loginFailureInsertCache[key]
+	loginFailureInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			loginFailureAllColumns,
+			loginFailureColumnsWithDefault,
+			loginFailureColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(loginFailureType, loginFailureMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(loginFailureType, loginFailureMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"login_failure\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"login_failure\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into login_failure")
+	}
+
+	if !cached {
+		loginFailureInsertCacheMut.Lock()
+		loginFailureInsertCache[key] = cache
+		loginFailureInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the LoginFailure.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *LoginFailure) Update(exec boil.Executor, columns boil.Columns) error {
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	loginFailureUpdateCacheMut.RLock()
+	cache, cached := loginFailureUpdateCache[key]
+	loginFailureUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			loginFailureAllColumns,
+			loginFailurePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update login_failure, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"login_failure\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, loginFailurePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(loginFailureType, loginFailureMapping, append(wl, loginFailurePrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update login_failure row")
+	}
+
+	if !cached {
+		loginFailureUpdateCacheMut.Lock()
+		loginFailureUpdateCache[key] = cache
+		loginFailureUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q loginFailureQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for login_failure")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o LoginFailureSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), loginFailurePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"login_failure\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, loginFailurePrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in loginFailure slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *LoginFailure) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no login_failure provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(loginFailureColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	loginFailureUpsertCacheMut.RLock()
+	cache, cached := loginFailureUpsertCache[key]
+	loginFailureUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			loginFailureAllColumns,
+			loginFailureColumnsWithDefault,
+			loginFailureColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			loginFailureAllColumns,
+			loginFailurePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert login_failure, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(loginFailurePrimaryKeyColumns))
+			copy(conflict, loginFailurePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"login_failure\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(loginFailureType, loginFailureMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(loginFailureType, loginFailureMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert login_failure")
+	}
+
+	if !cached {
+		loginFailureUpsertCacheMut.Lock()
+		loginFailureUpsertCache[key] = cache
+		loginFailureUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single LoginFailure record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *LoginFailure) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no LoginFailure provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), loginFailurePrimaryKeyMapping)
+	sql := "DELETE FROM \"login_failure\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from login_failure")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q loginFailureQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no loginFailureQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from login_failure")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o LoginFailureSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(loginFailureBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), loginFailurePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"login_failure\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, loginFailurePrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from loginFailure slice")
+	}
+
+	if len(loginFailureAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *LoginFailure) Reload(exec boil.Executor) error {
+	ret, err := FindLoginFailure(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *LoginFailureSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := LoginFailureSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), loginFailurePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"login_failure\".* FROM \"login_failure\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, loginFailurePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in LoginFailureSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// LoginFailureExists checks if the LoginFailure row exists.
+func LoginFailureExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"login_failure\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if login_failure exists")
+	}
+
+	return exists, nil
+}
@@ -23,144 +23,168 @@ import (
 
 // Project is an object representing the database table.
 type Project struct {
-	ID            int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Slug          string      `boil:"slug" json:"slug" toml:"slug" yaml:"slug"`
-	Locked        null.Bool   `boil:"locked" json:"locked,omitempty" toml:"locked" yaml:"locked,omitempty"`
-	CreatedAt     time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt     time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	PublishedAt   null.Time   `boil:"published_at" json:"published_at,omitempty" toml:"published_at" yaml:"published_at,omitempty"`
-	Title         string      `boil:"title" json:"title" toml:"title" yaml:"title"`
-	Description   null.String `boil:"description" json:"description,omitempty" toml:"description" yaml:"description,omitempty"`
-	CoverID       null.Int64  `boil:"cover_id" json:"cover_id,omitempty" toml:"cover_id" yaml:"cover_id,omitempty"`
-	ProjectStatus string      `boil:"project_status" json:"project_status" toml:"project_status" yaml:"project_status"`
-	SeriesStatus  string      `boil:"series_status" json:"series_status" toml:"series_status" yaml:"series_status"`
-	Demographic   null.String `boil:"demographic" json:"demographic,omitempty" toml:"demographic" yaml:"demographic,omitempty"`
-	Rating        null.String `boil:"rating" json:"rating,omitempty" toml:"rating" yaml:"rating,omitempty"`
+	ID               int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Slug             string      `boil:"slug" json:"slug" toml:"slug" yaml:"slug"`
+	Locked           null.Bool   `boil:"locked" json:"locked,omitempty" toml:"locked" yaml:"locked,omitempty"`
+	CreatedAt        time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt        time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	PublishedAt      null.Time   `boil:"published_at" json:"published_at,omitempty" toml:"published_at" yaml:"published_at,omitempty"`
+	Title            string      `boil:"title" json:"title" toml:"title" yaml:"title"`
+	Description      null.String `boil:"description" json:"description,omitempty" toml:"description" yaml:"description,omitempty"`
+	CoverID          null.Int64  `boil:"cover_id" json:"cover_id,omitempty" toml:"cover_id" yaml:"cover_id,omitempty"`
+	ProjectStatus    string      `boil:"project_status" json:"project_status" toml:"project_status" yaml:"project_status"`
+	SeriesStatus     string      `boil:"series_status" json:"series_status" toml:"series_status" yaml:"series_status"`
+	Demographic      null.String `boil:"demographic" json:"demographic,omitempty" toml:"demographic" yaml:"demographic,omitempty"`
+	Rating           null.String `boil:"rating" json:"rating,omitempty" toml:"rating" yaml:"rating,omitempty"`
+	ReadingDirection null.String `boil:"reading_direction" json:"reading_direction,omitempty" toml:"reading_direction" yaml:"reading_direction,omitempty"`
+	ScheduledAt      null.Time   `boil:"scheduled_at" json:"scheduled_at,omitempty" toml:"scheduled_at" yaml:"scheduled_at,omitempty"`
+	DeletedAt        null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
 
 	R *projectR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L projectL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ProjectColumns = struct {
-	ID            string
-	Slug          string
-	Locked        string
-	CreatedAt     string
-	UpdatedAt     string
-	PublishedAt   string
-	Title         string
-	Description   string
-	CoverID       string
-	ProjectStatus string
-	SeriesStatus  string
-	Demographic   string
-	Rating        string
+	ID               string
+	Slug             string
+	Locked           string
+	CreatedAt        string
+	UpdatedAt        string
+	PublishedAt      string
+	Title            string
+	Description      string
+	CoverID          string
+	ProjectStatus    string
+	SeriesStatus     string
+	Demographic      string
+	Rating           string
+	ReadingDirection string
+	ScheduledAt      string
+	DeletedAt        string
 }{
-	ID:            "id",
-	Slug:          "slug",
-	Locked:        "locked",
-	CreatedAt:     "created_at",
-	UpdatedAt:     "updated_at",
-	PublishedAt:   "published_at",
-	Title:         "title",
-	Description:   "description",
-	CoverID:       "cover_id",
-	ProjectStatus: "project_status",
-	SeriesStatus:  "series_status",
-	Demographic:   "demographic",
-	Rating:        "rating",
+	ID:               "id",
+	Slug:             "slug",
+	Locked:           "locked",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	PublishedAt:      "published_at",
+	Title:            "title",
+	Description:      "description",
+	CoverID:          "cover_id",
+	ProjectStatus:    "project_status",
+	SeriesStatus:     "series_status",
+	Demographic:      "demographic",
+	Rating:           "rating",
+	ReadingDirection: "reading_direction",
+	ScheduledAt:      "scheduled_at",
+	DeletedAt:        "deleted_at",
 }
 
 var ProjectTableColumns = struct {
-	ID            string
-	Slug          string
-	Locked        string
-	CreatedAt     string
-	UpdatedAt     string
-	PublishedAt   string
-	Title         string
-	Description   string
-	CoverID       string
-	ProjectStatus string
-	SeriesStatus  string
-	Demographic   string
-	Rating        string
+	ID               string
+	Slug             string
+	Locked           string
+	CreatedAt        string
+	UpdatedAt        string
+	PublishedAt      string
+	Title            string
+	Description      string
+	CoverID          string
+	ProjectStatus    string
+	SeriesStatus     string
+	Demographic      string
+	Rating           string
+	ReadingDirection string
+	ScheduledAt      string
+	DeletedAt        string
 }{
-	ID:            "project.id",
-	Slug:          "project.slug",
-	Locked:        "project.locked",
-	CreatedAt:     "project.created_at",
-	UpdatedAt:     "project.updated_at",
-	PublishedAt:   "project.published_at",
-	Title:         "project.title",
-	Description:   "project.description",
-	CoverID:       "project.cover_id",
-	ProjectStatus: "project.project_status",
-	SeriesStatus:  "project.series_status",
-	Demographic:   "project.demographic",
-	Rating:        "project.rating",
+	ID:               "project.id",
+	Slug:             "project.slug",
+	Locked:           "project.locked",
+	CreatedAt:        "project.created_at",
+	UpdatedAt:        "project.updated_at",
+	PublishedAt:      "project.published_at",
+	Title:            "project.title",
+	Description:      "project.description",
+	CoverID:          "project.cover_id",
+	ProjectStatus:    "project.project_status",
+	SeriesStatus:     "project.series_status",
+	Demographic:      "project.demographic",
+	Rating:           "project.rating",
+	ReadingDirection: "project.reading_direction",
+	ScheduledAt:      "project.scheduled_at",
+	DeletedAt:        "project.deleted_at",
 }
 
 // Generated where
 
 var ProjectWhere = struct {
-	ID            whereHelperint64
-	Slug          whereHelperstring
-	Locked        whereHelpernull_Bool
-	CreatedAt     whereHelpertime_Time
-	UpdatedAt     whereHelpertime_Time
-	PublishedAt   whereHelpernull_Time
-	Title         whereHelperstring
-	Description   whereHelpernull_String
-	CoverID       whereHelpernull_Int64
-	ProjectStatus whereHelperstring
-	SeriesStatus  whereHelperstring
-	Demographic   whereHelpernull_String
-	Rating        whereHelpernull_String
+	ID               whereHelperint64
+	Slug             whereHelperstring
+	Locked           whereHelpernull_Bool
+	CreatedAt        whereHelpertime_Time
+	UpdatedAt        whereHelpertime_Time
+	PublishedAt      whereHelpernull_Time
+	Title            whereHelperstring
+	Description      whereHelpernull_String
+	CoverID          whereHelpernull_Int64
+	ProjectStatus    whereHelperstring
+	SeriesStatus     whereHelperstring
+	Demographic      whereHelpernull_String
+	Rating           whereHelpernull_String
+	ReadingDirection whereHelpernull_String
+	ScheduledAt      whereHelpernull_Time
+	DeletedAt        whereHelpernull_Time
 }{
-	ID:            whereHelperint64{field: "\"project\".\"id\""},
-	Slug:          whereHelperstring{field: "\"project\".\"slug\""},
-	Locked:        whereHelpernull_Bool{field: "\"project\".\"locked\""},
-	CreatedAt:     whereHelpertime_Time{field: "\"project\".\"created_at\""},
-	UpdatedAt:     whereHelpertime_Time{field: "\"project\".\"updated_at\""},
-	PublishedAt:   whereHelpernull_Time{field: "\"project\".\"published_at\""},
-	Title:         whereHelperstring{field: "\"project\".\"title\""},
-	Description:   whereHelpernull_String{field: "\"project\".\"description\""},
-	CoverID:       whereHelpernull_Int64{field: "\"project\".\"cover_id\""},
-	ProjectStatus: whereHelperstring{field: "\"project\".\"project_status\""},
-	SeriesStatus:  whereHelperstring{field: "\"project\".\"series_status\""},
-	Demographic:   whereHelpernull_String{field: "\"project\".\"demographic\""},
-	Rating:        whereHelpernull_String{field: "\"project\".\"rating\""},
+	ID:               whereHelperint64{field: "\"project\".\"id\""},
+	Slug:             whereHelperstring{field: "\"project\".\"slug\""},
+	Locked:           whereHelpernull_Bool{field: "\"project\".\"locked\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"project\".\"created_at\""},
+	UpdatedAt:        whereHelpertime_Time{field: "\"project\".\"updated_at\""},
+	PublishedAt:      whereHelpernull_Time{field: "\"project\".\"published_at\""},
+	Title:            whereHelperstring{field: "\"project\".\"title\""},
+	Description:      whereHelpernull_String{field: "\"project\".\"description\""},
+	CoverID:          whereHelpernull_Int64{field: "\"project\".\"cover_id\""},
+	ProjectStatus:    whereHelperstring{field: "\"project\".\"project_status\""},
+	SeriesStatus:     whereHelperstring{field: "\"project\".\"series_status\""},
+	Demographic:      whereHelpernull_String{field: "\"project\".\"demographic\""},
+	Rating:           whereHelpernull_String{field: "\"project\".\"rating\""},
+	ReadingDirection: whereHelpernull_String{field: "\"project\".\"reading_direction\""},
+	ScheduledAt:      whereHelpernull_Time{field: "\"project\".\"scheduled_at\""},
+	DeletedAt:        whereHelpernull_Time{field: "\"project\".\"deleted_at\""},
 }
 
 // ProjectRels is where relationship names are stored.
 var ProjectRels = struct {
-	Cover     string
-	Statistic string
-	Chapters  string
-	Covers    string
-	Artists   string
-	Authors   string
-	Tags      string
+	Cover          string
+	Statistic      string
+	Chapters       string
+	Covers         string
+	Artists        string
+	Authors        string
+	ProjectMembers string
+	Tags           string
 }{
-	Cover:     "Cover",
-	Statistic: "Statistic",
-	Chapters:  "Chapters",
-	Covers:    "Covers",
-	Artists:   "Artists",
-	Authors:   "Authors",
-	Tags:      "Tags",
+	Cover:          "Cover",
+	Statistic:      "Statistic",
+	Chapters:       "Chapters",
+	Covers:         "Covers",
+	Artists:        "Artists",
+	Authors:        "Authors",
+	ProjectMembers: "ProjectMembers",
+	Tags:           "Tags",
 }
 
 // projectR is where relationships are stored.
 type projectR struct {
-	Cover     *Cover       `boil:"Cover" json:"Cover" toml:"Cover" yaml:"Cover"`
-	Statistic *Statistic   `boil:"Statistic" json:"Statistic" toml:"Statistic" yaml:"Statistic"`
-	Chapters  ChapterSlice `boil:"Chapters" json:"Chapters" toml:"Chapters" yaml:"Chapters"`
-	Covers    CoverSlice   `boil:"Covers" json:"Covers" toml:"Covers" yaml:"Covers"`
-	Artists   AuthorSlice  `boil:"Artists" json:"Artists" toml:"Artists" yaml:"Artists"`
-	Authors   AuthorSlice  `boil:"Authors" json:"Authors" toml:"Authors" yaml:"Authors"`
-	Tags      TagSlice     `boil:"Tags" json:"Tags" toml:"Tags" yaml:"Tags"`
+	Cover          *Cover             `boil:"Cover" json:"Cover" toml:"Cover" yaml:"Cover"`
+	Statistic      *Statistic         `boil:"Statistic" json:"Statistic" toml:"Statistic" yaml:"Statistic"`
+	Chapters       ChapterSlice       `boil:"Chapters" json:"Chapters" toml:"Chapters" yaml:"Chapters"`
+	Covers         CoverSlice         `boil:"Covers" json:"Covers" toml:"Covers" yaml:"Covers"`
+	Artists        AuthorSlice        `boil:"Artists" json:"Artists" toml:"Artists" yaml:"Artists"`
+	Authors        AuthorSlice        `boil:"Authors" json:"Authors" toml:"Authors" yaml:"Authors"`
+	ProjectMembers ProjectMemberSlice `boil:"ProjectMembers" json:"ProjectMembers" toml:"ProjectMembers" yaml:"ProjectMembers"`
+	Tags           TagSlice           `boil:"Tags" json:"Tags" toml:"Tags" yaml:"Tags"`
 }
 
 // NewStruct creates a new relationship struct
@@ -172,9 +196,9 @@ func (*projectR) NewStruct() *projectR {
 type projectL struct{}
 
 var (
-	projectAllColumns            = []string{"id", "slug", "locked", "created_at", "updated_at", "published_at", "title", "description", "cover_id", "project_status", "series_status", "demographic", "rating"}
-	projectColumnsWithoutDefault = []string{"published_at", "cover_id"}
-	projectColumnsWithDefault    = []string{"id", "slug", "locked", "created_at", "updated_at", "title", "description", "project_status", "series_status", "demographic", "rating"}
+	projectAllColumns            = []string{"id", "slug", "locked", "created_at", "updated_at", "published_at", "title", "description", "cover_id", "project_status", "series_status", "demographic", "rating", "reading_direction", "scheduled_at", "deleted_at"}
+	projectColumnsWithoutDefault = []string{"published_at", "cover_id", "scheduled_at", "deleted_at"}
+	projectColumnsWithDefault    = []string{"id", "slug", "locked", "created_at", "updated_at", "title", "description", "project_status", "series_status", "demographic", "rating", "reading_direction"}
 	projectPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -421,6 +445,7 @@ func (q projectQuery) Exists(exec boil.Executor) (bool, error) {
 func (o *Project) Cover(mods ...qm.QueryMod) coverQuery {
 	queryMods := []qm.QueryMod{
 		qm.Where("\"id\" = ?", o.CoverID),
+		qmhelper.WhereIsNull("deleted_at"),
 	}
 
 	queryMods = append(queryMods, mods...)
@@ -454,6 +479,7 @@ func (o *Project) Chapters(mods ...qm.QueryMod) chapterQuery {
 
 	queryMods = append(queryMods,
 		qm.Where("\"chapter\".\"project_id\"=?", o.ID),
+		qmhelper.WhereIsNull("\"chapter\".\"deleted_at\""),
 	)
 
 	query := Chapters(queryMods...)
@@ -475,6 +501,7 @@ func (o *Project) Covers(mods ...qm.QueryMod) coverQuery {
 
 	queryMods = append(queryMods,
 		qm.Where("\"cover\".\"project_id\"=?", o.ID),
+		qmhelper.WhereIsNull("\"cover\".\"deleted_at\""),
 	)
 
 	query := Covers(queryMods...)
@@ -531,6 +558,27 @@ func (o *Project) Authors(mods ...qm.QueryMod) authorQuery {
 	return query
 }
 
+// ProjectMembers retrieves all the project_member's ProjectMembers with an executor.
+func (o *Project) ProjectMembers(mods ...qm.QueryMod) projectMemberQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"project_member\".\"project_id\"=?", o.ID),
+	)
+
+	query := ProjectMembers(queryMods...)
+	queries.SetFrom(query.Query, "\"project_member\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"project_member\".*"})
+	}
+
+	return query
+}
+
 // Tags retrieves all the tag's Tags with an executor.
 func (o *Project) Tags(mods ...qm.QueryMod) tagQuery {
 	var queryMods []qm.QueryMod
@@ -601,6 +649,7 @@ func (projectL) LoadCover(e boil.Executor, singular bool, maybeProject interface
 	query := NewQuery(
 		qm.From(`cover`),
 		qm.WhereIn(`cover.id in ?`, args...),
+		qmhelper.WhereIsNull(`cover.deleted_at`),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -804,6 +853,7 @@ func (projectL) LoadChapters(e boil.Executor, singular bool, maybeProject interf
 	query := NewQuery(
 		qm.From(`chapter`),
 		qm.WhereIn(`chapter.project_id in ?`, args...),
+		qmhelper.WhereIsNull(`chapter.deleted_at`),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -902,6 +952,7 @@ func (projectL) LoadCovers(e boil.Executor, singular bool, maybeProject interfac
 	query := NewQuery(
 		qm.From(`cover`),
 		qm.WhereIn(`cover.project_id in ?`, args...),
+		qmhelper.WhereIsNull(`cover.deleted_at`),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -1188,6 +1239,104 @@ func (projectL) LoadAuthors(e boil.Executor, singular bool, maybeProject interfa
 	return nil
 }
 
+// LoadProjectMembers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (projectL) LoadProjectMembers(e boil.Executor, singular bool, maybeProject interface{}, mods queries.Applicator) error {
+	var slice []*Project
+	var object *Project
+
+	if singular {
+		object = maybeProject.(*Project)
+	} else {
+		slice = *maybeProject.(*[]*Project)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &projectR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &projectR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`project_member`),
+		qm.WhereIn(`project_member.project_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load project_member")
+	}
+
+	var resultSlice []*ProjectMember
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice project_member")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on project_member")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for project_member")
+	}
+
+	if len(projectMemberAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ProjectMembers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &projectMemberR{}
+			}
+			foreign.R.Project = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ProjectID {
+				local.R.ProjectMembers = append(local.R.ProjectMembers, foreign)
+				if foreign.R == nil {
+					foreign.R = &projectMemberR{}
+				}
+				foreign.R.Project = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadTags allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (projectL) LoadTags(e boil.Executor, singular bool, maybeProject interface{}, mods queries.Applicator) error {
@@ -1839,6 +1988,58 @@ func removeAuthorsFromAuthorProjectsSlice(o *Project, related []*Author) {
 	}
 }
 
+// AddProjectMembers adds the given related objects to the existing relationships
+// of the project, optionally inserting them as new records.
+// Appends related to o.R.ProjectMembers.
+// Sets related.R.Project appropriately.
+func (o *Project) AddProjectMembers(exec boil.Executor, insert bool, related ...*ProjectMember) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ProjectID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"project_member\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"project_id"}),
+				strmangle.WhereClause("\"", "\"", 2, projectMemberPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ProjectID, rel.UserID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ProjectID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &projectR{
+			ProjectMembers: related,
+		}
+	} else {
+		o.R.ProjectMembers = append(o.R.ProjectMembers, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &projectMemberR{
+				Project: o,
+			}
+		} else {
+			rel.R.Project = o
+		}
+	}
+	return nil
+}
+
 // AddTags adds the given related objects to the existing relationships
 // of the project, optionally inserting them as new records.
 // Appends related to o.R.Tags.
@@ -1982,7 +2183,7 @@ func removeTagsFromProjectsSlice(o *Project, related []*Tag) {
 
 // Projects retrieves all the records using an executor.
 func Projects(mods ...qm.QueryMod) projectQuery {
-	mods = append(mods, qm.From("\"project\""))
+	mods = append(mods, qm.From("\"project\""), qmhelper.WhereIsNull("\"project\".\"deleted_at\""))
 	return projectQuery{NewQuery(mods...)}
 }
 
@@ -1996,7 +2197,7 @@ func FindProject(exec boil.Executor, iD int64, selectCols ...string) (*Project,
 		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
 	}
 	query := fmt.Sprintf(
-		"select %s from \"project\" where \"id\"=$1", sel,
+		"select %s from \"project\" where \"id\"=$1 and \"deleted_at\" is null", sel,
 	)
 
 	q := queries.Raw(query, iD)
@@ -2339,7 +2540,7 @@ func (o *Project) Upsert(exec boil.Executor, updateOnConflict bool, conflictColu
 
 // Delete deletes a single Project record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *Project) Delete(exec boil.Executor) error {
+func (o *Project) Delete(exec boil.Executor, hardDelete bool) error {
 	if o == nil {
 		return errors.New("models: no Project provided for delete")
 	}
@@ -2348,8 +2549,26 @@ func (o *Project) Delete(exec boil.Executor) error {
 		return err
 	}
 
-	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), projectPrimaryKeyMapping)
-	sql := "DELETE FROM \"project\" WHERE \"id\"=$1"
+	var (
+		sql  string
+		args []interface{}
+	)
+	if hardDelete {
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), projectPrimaryKeyMapping)
+		sql = "DELETE FROM \"project\" WHERE \"id\"=$1"
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		o.DeletedAt = null.TimeFrom(currTime)
+		wl := []string{"deleted_at"}
+		sql = fmt.Sprintf("UPDATE \"project\" SET %s WHERE \"id\"=$2",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+		)
+		valueMapping, err := queries.BindMapping(projectType, projectMapping, append(wl, projectPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), valueMapping)
+	}
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
@@ -2368,12 +2587,17 @@ func (o *Project) Delete(exec boil.Executor) error {
 }
 
 // DeleteAll deletes all matching rows.
-func (q projectQuery) DeleteAll(exec boil.Executor) error {
+func (q projectQuery) DeleteAll(exec boil.Executor, hardDelete bool) error {
 	if q.Query == nil {
 		return errors.New("models: no projectQuery provided for delete all")
 	}
 
-	queries.SetDelete(q.Query)
+	if hardDelete {
+		queries.SetDelete(q.Query)
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		queries.SetUpdate(q.Query, M{"deleted_at": currTime})
+	}
 
 	_, err := q.Query.Exec(exec)
 	if err != nil {
@@ -2384,7 +2608,7 @@ func (q projectQuery) DeleteAll(exec boil.Executor) error {
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o ProjectSlice) DeleteAll(exec boil.Executor) error {
+func (o ProjectSlice) DeleteAll(exec boil.Executor, hardDelete bool) error {
 	if len(o) == 0 {
 		return nil
 	}
@@ -2397,15 +2621,32 @@ func (o ProjectSlice) DeleteAll(exec boil.Executor) error {
 		}
 	}
 
-	var args []interface{}
-	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), projectPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
+	var (
+		sql  string
+		args []interface{}
+	)
+	if hardDelete {
+		for _, obj := range o {
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), projectPrimaryKeyMapping)
+			args = append(args, pkeyArgs...)
+		}
+		sql = "DELETE FROM \"project\" WHERE " +
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, projectPrimaryKeyColumns, len(o))
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		for _, obj := range o {
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), projectPrimaryKeyMapping)
+			args = append(args, pkeyArgs...)
+			obj.DeletedAt = null.TimeFrom(currTime)
+		}
+		wl := []string{"deleted_at"}
+		sql = fmt.Sprintf("UPDATE \"project\" SET %s WHERE "+
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 2, projectPrimaryKeyColumns, len(o)),
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+		)
+		args = append([]interface{}{currTime}, args...)
 	}
 
-	sql := "DELETE FROM \"project\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, projectPrimaryKeyColumns, len(o))
-
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
 		fmt.Fprintln(boil.DebugWriter, args)
@@ -2453,7 +2694,8 @@ func (o *ProjectSlice) ReloadAll(exec boil.Executor) error {
 	}
 
 	sql := "SELECT \"project\".* FROM \"project\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, projectPrimaryKeyColumns, len(*o))
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, projectPrimaryKeyColumns, len(*o)) +
+		"and \"deleted_at\" is null"
 
 	q := queries.Raw(sql, args...)
 
@@ -2470,7 +2712,7 @@ func (o *ProjectSlice) ReloadAll(exec boil.Executor) error {
 // ProjectExists checks if the Project row exists.
 func ProjectExists(exec boil.Executor, iD int64) (bool, error) {
 	var exists bool
-	sql := "select exists(select 1 from \"project\" where \"id\"=$1 limit 1)"
+	sql := "select exists(select 1 from \"project\" where \"id\"=$1 and \"deleted_at\" is null limit 1)"
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
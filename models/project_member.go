@@ -0,0 +1,1185 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// ProjectMember is an object representing the database table.
+type ProjectMember struct {
+	CreatedAt   time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt   time.Time         `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ProjectID   int64             `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
+	UserID      int64             `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Permissions types.StringArray `boil:"permissions" json:"permissions" toml:"permissions" yaml:"permissions"`
+
+	R *projectMemberR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L projectMemberL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ProjectMemberColumns = struct {
+	CreatedAt   string
+	UpdatedAt   string
+	ProjectID   string
+	UserID      string
+	Permissions string
+}{
+	CreatedAt:   "created_at",
+	UpdatedAt:   "updated_at",
+	ProjectID:   "project_id",
+	UserID:      "user_id",
+	Permissions: "permissions",
+}
+
+var ProjectMemberTableColumns = struct {
+	CreatedAt   string
+	UpdatedAt   string
+	ProjectID   string
+	UserID      string
+	Permissions string
+}{
+	CreatedAt:   "project_member.created_at",
+	UpdatedAt:   "project_member.updated_at",
+	ProjectID:   "project_member.project_id",
+	UserID:      "project_member.user_id",
+	Permissions: "project_member.permissions",
+}
+
+// Generated where
+
+var ProjectMemberWhere = struct {
+	CreatedAt   whereHelpertime_Time
+	UpdatedAt   whereHelpertime_Time
+	ProjectID   whereHelperint64
+	UserID      whereHelperint64
+	Permissions whereHelpertypes_StringArray
+}{
+	CreatedAt:   whereHelpertime_Time{field: "\"project_member\".\"created_at\""},
+	UpdatedAt:   whereHelpertime_Time{field: "\"project_member\".\"updated_at\""},
+	ProjectID:   whereHelperint64{field: "\"project_member\".\"project_id\""},
+	UserID:      whereHelperint64{field: "\"project_member\".\"user_id\""},
+	Permissions: whereHelpertypes_StringArray{field: "\"project_member\".\"permissions\""},
+}
+
+// ProjectMemberRels is where relationship names are stored.
+var ProjectMemberRels = struct {
+	Project string
+	User    string
+}{
+	Project: "Project",
+	User:    "User",
+}
+
+// projectMemberR is where relationships are stored.
+type projectMemberR struct {
+	Project *Project `boil:"Project" json:"Project" toml:"Project" yaml:"Project"`
+	User    *User    `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*projectMemberR) NewStruct() *projectMemberR {
+	return &projectMemberR{}
+}
+
+// projectMemberL is where Load methods for each relationship are stored.
+type projectMemberL struct{}
+
+var (
+	projectMemberAllColumns            = []string{"created_at", "updated_at", "project_id", "user_id", "permissions"}
+	projectMemberColumnsWithoutDefault = []string{"project_id", "user_id"}
+	projectMemberColumnsWithDefault    = []string{"created_at", "updated_at", "permissions"}
+	projectMemberPrimaryKeyColumns     = []string{"project_id", "user_id"}
+)
+
+type (
+	// ProjectMemberSlice is an alias for a slice of pointers to ProjectMember.
+	// This should almost always be used instead of []ProjectMember.
+	ProjectMemberSlice []*ProjectMember
+	// ProjectMemberHook is the signature for custom ProjectMember hook methods
+	ProjectMemberHook func(boil.Executor, *ProjectMember) error
+
+	projectMemberQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	projectMemberType                 = reflect.TypeOf(&ProjectMember{})
+	projectMemberMapping              = queries.MakeStructMapping(projectMemberType)
+	projectMemberPrimaryKeyMapping, _ = queries.BindMapping(projectMemberType, projectMemberMapping, projectMemberPrimaryKeyColumns)
+	projectMemberInsertCacheMut       sync.RWMutex
+	projectMemberInsertCache          = make(map[string]insertCache)
+	projectMemberUpdateCacheMut       sync.RWMutex
+	projectMemberUpdateCache          = make(map[string]updateCache)
+	projectMemberUpsertCacheMut       sync.RWMutex
+	projectMemberUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var projectMemberBeforeInsertHooks []ProjectMemberHook
+var projectMemberBeforeUpdateHooks []ProjectMemberHook
+var projectMemberBeforeDeleteHooks []ProjectMemberHook
+var projectMemberBeforeUpsertHooks []ProjectMemberHook
+
+var projectMemberAfterInsertHooks []ProjectMemberHook
+var projectMemberAfterSelectHooks []ProjectMemberHook
+var projectMemberAfterUpdateHooks []ProjectMemberHook
+var projectMemberAfterDeleteHooks []ProjectMemberHook
+var projectMemberAfterUpsertHooks []ProjectMemberHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ProjectMember) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range projectMemberBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ProjectMember) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range projectMemberBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ProjectMember) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range projectMemberBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ProjectMember) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range projectMemberBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ProjectMember) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range projectMemberAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ProjectMember) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range projectMemberAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ProjectMember) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range projectMemberAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ProjectMember) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range projectMemberAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ProjectMember) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range projectMemberAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddProjectMemberHook registers your hook function for all future operations.
+func AddProjectMemberHook(hookPoint boil.HookPoint, projectMemberHook ProjectMemberHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		projectMemberBeforeInsertHooks = append(projectMemberBeforeInsertHooks, projectMemberHook)
+	case boil.BeforeUpdateHook:
+		projectMemberBeforeUpdateHooks = append(projectMemberBeforeUpdateHooks, projectMemberHook)
+	case boil.BeforeDeleteHook:
+		projectMemberBeforeDeleteHooks = append(projectMemberBeforeDeleteHooks, projectMemberHook)
+	case boil.BeforeUpsertHook:
+		projectMemberBeforeUpsertHooks = append(projectMemberBeforeUpsertHooks, projectMemberHook)
+	case boil.AfterInsertHook:
+		projectMemberAfterInsertHooks = append(projectMemberAfterInsertHooks, projectMemberHook)
+	case boil.AfterSelectHook:
+		projectMemberAfterSelectHooks = append(projectMemberAfterSelectHooks, projectMemberHook)
+	case boil.AfterUpdateHook:
+		projectMemberAfterUpdateHooks = append(projectMemberAfterUpdateHooks, projectMemberHook)
+	case boil.AfterDeleteHook:
+		projectMemberAfterDeleteHooks = append(projectMemberAfterDeleteHooks, projectMemberHook)
+	case boil.AfterUpsertHook:
+		projectMemberAfterUpsertHooks = append(projectMemberAfterUpsertHooks, projectMemberHook)
+	}
+}
+
+// One returns a single projectMember record from the query.
+func (q projectMemberQuery) One(exec boil.Executor) (*ProjectMember, error) {
+	o := &ProjectMember{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for project_member")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ProjectMember records from the query.
+func (q projectMemberQuery) All(exec boil.Executor) (ProjectMemberSlice, error) {
+	var o []*ProjectMember
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ProjectMember slice")
+	}
+
+	if len(projectMemberAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ProjectMember records in the query.
+func (q projectMemberQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count project_member rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q projectMemberQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if project_member exists")
+	}
+
+	return count > 0, nil
+}
+
+// Project pointed to by the foreign key.
+func (o *ProjectMember) Project(mods ...qm.QueryMod) projectQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ProjectID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Projects(queryMods...)
+	queries.SetFrom(query.Query, "\"project\"")
+
+	return query
+}
+
+// User pointed to by the foreign key.
+func (o *ProjectMember) User(mods ...qm.QueryMod) userAccountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	return query
+}
+
+// LoadProject allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (projectMemberL) LoadProject(e boil.Executor, singular bool, maybeProjectMember interface{}, mods queries.Applicator) error {
+	var slice []*ProjectMember
+	var object *ProjectMember
+
+	if singular {
+		object = maybeProjectMember.(*ProjectMember)
+	} else {
+		slice = *maybeProjectMember.(*[]*ProjectMember)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &projectMemberR{}
+		}
+		args = append(args, object.ProjectID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &projectMemberR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ProjectID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ProjectID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`project`),
+		qm.WhereIn(`project.id in ?`, args...),
+		qmhelper.WhereIsNull(`project.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Project")
+	}
+
+	var resultSlice []*Project
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Project")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for project")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for project")
+	}
+
+	if len(projectMemberAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Project = foreign
+		if foreign.R == nil {
+			foreign.R = &projectR{}
+		}
+		foreign.R.ProjectMembers = append(foreign.R.ProjectMembers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ProjectID == foreign.ID {
+				local.R.Project = foreign
+				if foreign.R == nil {
+					foreign.R = &projectR{}
+				}
+				foreign.R.ProjectMembers = append(foreign.R.ProjectMembers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (projectMemberL) LoadUser(e boil.Executor, singular bool, maybeProjectMember interface{}, mods queries.Applicator) error {
+	var slice []*ProjectMember
+	var object *ProjectMember
+
+	if singular {
+		object = maybeProjectMember.(*ProjectMember)
+	} else {
+		slice = *maybeProjectMember.(*[]*ProjectMember)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &projectMemberR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &projectMemberR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_account`),
+		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(projectMemberAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userAccountR{}
+		}
+		foreign.R.ProjectMembers = append(foreign.R.ProjectMembers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.ProjectMembers = append(foreign.R.ProjectMembers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetProject of the projectMember to the related item.
+// Sets o.R.Project to related.
+// Adds o to related.R.ProjectMembers.
+func (o *ProjectMember) SetProject(exec boil.Executor, insert bool, related *Project) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"project_member\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"project_id"}),
+		strmangle.WhereClause("\"", "\"", 2, projectMemberPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ProjectID, o.UserID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ProjectID = related.ID
+	if o.R == nil {
+		o.R = &projectMemberR{
+			Project: related,
+		}
+	} else {
+		o.R.Project = related
+	}
+
+	if related.R == nil {
+		related.R = &projectR{
+			ProjectMembers: ProjectMemberSlice{o},
+		}
+	} else {
+		related.R.ProjectMembers = append(related.R.ProjectMembers, o)
+	}
+
+	return nil
+}
+
+// SetUser of the projectMember to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.ProjectMembers.
+func (o *ProjectMember) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"project_member\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, projectMemberPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ProjectID, o.UserID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &projectMemberR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userAccountR{
+			ProjectMembers: ProjectMemberSlice{o},
+		}
+	} else {
+		related.R.ProjectMembers = append(related.R.ProjectMembers, o)
+	}
+
+	return nil
+}
+
+// ProjectMembers retrieves all the records using an executor.
+func ProjectMembers(mods ...qm.QueryMod) projectMemberQuery {
+	mods = append(mods, qm.From("\"project_member\""))
+	return projectMemberQuery{NewQuery(mods...)}
+}
+
+// FindProjectMember retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindProjectMember(exec boil.Executor, projectID int64, userID int64, selectCols ...string) (*ProjectMember, error) {
+	projectMemberObj := &ProjectMember{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"project_member\" where \"project_id\"=$1 AND \"user_id\"=$2", sel,
+	)
+
+	q := queries.Raw(query, projectID, userID)
+
+	err := q.Bind(nil, exec, projectMemberObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from project_member")
+	}
+
+	if err = projectMemberObj.doAfterSelectHooks(exec); err != nil {
+		return projectMemberObj, err
+	}
+
+	return projectMemberObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ProjectMember) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no project_member provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(projectMemberColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	projectMemberInsertCacheMut.RLock()
+	cache, cached := projectMemberInsertCache[key]
+	projectMemberInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			projectMemberAllColumns,
+			projectMemberColumnsWithDefault,
+			projectMemberColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(projectMemberType, projectMemberMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(projectMemberType, projectMemberMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"project_member\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"project_member\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into project_member")
+	}
+
+	if !cached {
+		projectMemberInsertCacheMut.Lock()
+		projectMemberInsertCache[key] = cache
+		projectMemberInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the ProjectMember.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ProjectMember) Update(exec boil.Executor, columns boil.Columns) error {
+	currTime := time.Now().In(boil.GetLocation())
+
+	o.UpdatedAt = currTime
+
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	projectMemberUpdateCacheMut.RLock()
+	cache, cached := projectMemberUpdateCache[key]
+	projectMemberUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			projectMemberAllColumns,
+			projectMemberPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update project_member, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"project_member\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, projectMemberPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(projectMemberType, projectMemberMapping, append(wl, projectMemberPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update project_member row")
+	}
+
+	if !cached {
+		projectMemberUpdateCacheMut.Lock()
+		projectMemberUpdateCache[key] = cache
+		projectMemberUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q projectMemberQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for project_member")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ProjectMemberSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), projectMemberPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"project_member\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, projectMemberPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in projectMember slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ProjectMember) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no project_member provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	o.UpdatedAt = currTime
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(projectMemberColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	projectMemberUpsertCacheMut.RLock()
+	cache, cached := projectMemberUpsertCache[key]
+	projectMemberUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			projectMemberAllColumns,
+			projectMemberColumnsWithDefault,
+			projectMemberColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			projectMemberAllColumns,
+			projectMemberPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert project_member, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(projectMemberPrimaryKeyColumns))
+			copy(conflict, projectMemberPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"project_member\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(projectMemberType, projectMemberMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(projectMemberType, projectMemberMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert project_member")
+	}
+
+	if !cached {
+		projectMemberUpsertCacheMut.Lock()
+		projectMemberUpsertCache[key] = cache
+		projectMemberUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single ProjectMember record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ProjectMember) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no ProjectMember provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), projectMemberPrimaryKeyMapping)
+	sql := "DELETE FROM \"project_member\" WHERE \"project_id\"=$1 AND \"user_id\"=$2"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from project_member")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q projectMemberQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no projectMemberQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from project_member")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ProjectMemberSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(projectMemberBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), projectMemberPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"project_member\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, projectMemberPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from projectMember slice")
+	}
+
+	if len(projectMemberAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ProjectMember) Reload(exec boil.Executor) error {
+	ret, err := FindProjectMember(exec, o.ProjectID, o.UserID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ProjectMemberSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ProjectMemberSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), projectMemberPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"project_member\".* FROM \"project_member\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, projectMemberPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ProjectMemberSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ProjectMemberExists checks if the ProjectMember row exists.
+func ProjectMemberExists(exec boil.Executor, projectID int64, userID int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"project_member\" where \"project_id\"=$1 AND \"user_id\"=$2 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, projectID, userID)
+	}
+	row := exec.QueryRow(sql, projectID, userID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if project_member exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1008 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// RecoveryCode is an object representing the database table.
+type RecoveryCode struct {
+	ID        int64     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UserID    int64     `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Hash      string    `boil:"hash" json:"hash" toml:"hash" yaml:"hash"`
+	UsedAt    null.Time `boil:"used_at" json:"used_at,omitempty" toml:"used_at" yaml:"used_at,omitempty"`
+
+	R *recoveryCodeR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L recoveryCodeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var RecoveryCodeColumns = struct {
+	ID        string
+	CreatedAt string
+	UserID    string
+	Hash      string
+	UsedAt    string
+}{
+	ID:        "id",
+	CreatedAt: "created_at",
+	UserID:    "user_id",
+	Hash:      "hash",
+	UsedAt:    "used_at",
+}
+
+var RecoveryCodeTableColumns = struct {
+	ID        string
+	CreatedAt string
+	UserID    string
+	Hash      string
+	UsedAt    string
+}{
+	ID:        "recovery_code.id",
+	CreatedAt: "recovery_code.created_at",
+	UserID:    "recovery_code.user_id",
+	Hash:      "recovery_code.hash",
+	UsedAt:    "recovery_code.used_at",
+}
+
+// Generated where
+
+var RecoveryCodeWhere = struct {
+	ID        whereHelperint64
+	CreatedAt whereHelpertime_Time
+	UserID    whereHelperint64
+	Hash      whereHelperstring
+	UsedAt    whereHelpernull_Time
+}{
+	ID:        whereHelperint64{field: "\"recovery_code\".\"id\""},
+	CreatedAt: whereHelpertime_Time{field: "\"recovery_code\".\"created_at\""},
+	UserID:    whereHelperint64{field: "\"recovery_code\".\"user_id\""},
+	Hash:      whereHelperstring{field: "\"recovery_code\".\"hash\""},
+	UsedAt:    whereHelpernull_Time{field: "\"recovery_code\".\"used_at\""},
+}
+
+// RecoveryCodeRels is where relationship names are stored.
+var RecoveryCodeRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// recoveryCodeR is where relationships are stored.
+type recoveryCodeR struct {
+	User *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*recoveryCodeR) NewStruct() *recoveryCodeR {
+	return &recoveryCodeR{}
+}
+
+// recoveryCodeL is where Load methods for each relationship are stored.
+type recoveryCodeL struct{}
+
+var (
+	recoveryCodeAllColumns            = []string{"id", "created_at", "user_id", "hash", "used_at"}
+	recoveryCodeColumnsWithoutDefault = []string{"user_id", "used_at"}
+	recoveryCodeColumnsWithDefault    = []string{"id", "created_at", "hash"}
+	recoveryCodePrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// RecoveryCodeSlice is an alias for a slice of pointers to RecoveryCode.
+	// This should almost always be used instead of []RecoveryCode.
+	RecoveryCodeSlice []*RecoveryCode
+	// RecoveryCodeHook is the signature for custom RecoveryCode hook methods
+	RecoveryCodeHook func(boil.Executor, *RecoveryCode) error
+
+	recoveryCodeQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	recoveryCodeType                 = reflect.TypeOf(&RecoveryCode{})
+	recoveryCodeMapping              = queries.MakeStructMapping(recoveryCodeType)
+	recoveryCodePrimaryKeyMapping, _ = queries.BindMapping(recoveryCodeType, recoveryCodeMapping, recoveryCodePrimaryKeyColumns)
+	recoveryCodeInsertCacheMut       sync.RWMutex
+	recoveryCodeInsertCache          = make(map[string]insertCache)
+	recoveryCodeUpdateCacheMut       sync.RWMutex
+	recoveryCodeUpdateCache          = make(map[string]updateCache)
+	recoveryCodeUpsertCacheMut       sync.RWMutex
+	recoveryCodeUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var recoveryCodeBeforeInsertHooks []RecoveryCodeHook
+var recoveryCodeBeforeUpdateHooks []RecoveryCodeHook
+var recoveryCodeBeforeDeleteHooks []RecoveryCodeHook
+var recoveryCodeBeforeUpsertHooks []RecoveryCodeHook
+
+var recoveryCodeAfterInsertHooks []RecoveryCodeHook
+var recoveryCodeAfterSelectHooks []RecoveryCodeHook
+var recoveryCodeAfterUpdateHooks []RecoveryCodeHook
+var recoveryCodeAfterDeleteHooks []RecoveryCodeHook
+var recoveryCodeAfterUpsertHooks []RecoveryCodeHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *RecoveryCode) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range recoveryCodeBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *RecoveryCode) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range recoveryCodeBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *RecoveryCode) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range recoveryCodeBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *RecoveryCode) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range recoveryCodeBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *RecoveryCode) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range recoveryCodeAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *RecoveryCode) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range recoveryCodeAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *RecoveryCode) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range recoveryCodeAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *RecoveryCode) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range recoveryCodeAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *RecoveryCode) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range recoveryCodeAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddRecoveryCodeHook registers your hook function for all future operations.
+func AddRecoveryCodeHook(hookPoint boil.HookPoint, recoveryCodeHook RecoveryCodeHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		recoveryCodeBeforeInsertHooks = append(recoveryCodeBeforeInsertHooks, recoveryCodeHook)
+	case boil.BeforeUpdateHook:
+		recoveryCodeBeforeUpdateHooks = append(recoveryCodeBeforeUpdateHooks, recoveryCodeHook)
+	case boil.BeforeDeleteHook:
+		recoveryCodeBeforeDeleteHooks = append(recoveryCodeBeforeDeleteHooks, recoveryCodeHook)
+	case boil.BeforeUpsertHook:
+		recoveryCodeBeforeUpsertHooks = append(recoveryCodeBeforeUpsertHooks, recoveryCodeHook)
+	case boil.AfterInsertHook:
+		recoveryCodeAfterInsertHooks = append(recoveryCodeAfterInsertHooks, recoveryCodeHook)
+	case boil.AfterSelectHook:
+		recoveryCodeAfterSelectHooks = append(recoveryCodeAfterSelectHooks, recoveryCodeHook)
+	case boil.AfterUpdateHook:
+		recoveryCodeAfterUpdateHooks = append(recoveryCodeAfterUpdateHooks, recoveryCodeHook)
+	case boil.AfterDeleteHook:
+		recoveryCodeAfterDeleteHooks = append(recoveryCodeAfterDeleteHooks, recoveryCodeHook)
+	case boil.AfterUpsertHook:
+		recoveryCodeAfterUpsertHooks = append(recoveryCodeAfterUpsertHooks, recoveryCodeHook)
+	}
+}
+
+// One returns a single recoveryCode record from the query.
+func (q recoveryCodeQuery) One(exec boil.Executor) (*RecoveryCode, error) {
+	o := &RecoveryCode{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for recovery_code")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all RecoveryCode records from the query.
+func (q recoveryCodeQuery) All(exec boil.Executor) (RecoveryCodeSlice, error) {
+	var o []*RecoveryCode
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to RecoveryCode slice")
+	}
+
+	if len(recoveryCodeAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all RecoveryCode records in the query.
+func (q recoveryCodeQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count recovery_code rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q recoveryCodeQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if recovery_code exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *RecoveryCode) User(mods ...qm.QueryMod) userAccountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	return query
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (recoveryCodeL) LoadUser(e boil.Executor, singular bool, maybeRecoveryCode interface{}, mods queries.Applicator) error {
+	var slice []*RecoveryCode
+	var object *RecoveryCode
+
+	if singular {
+		object = maybeRecoveryCode.(*RecoveryCode)
+	} else {
+		slice = *maybeRecoveryCode.(*[]*RecoveryCode)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &recoveryCodeR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &recoveryCodeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_account`),
+		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(recoveryCodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userAccountR{}
+		}
+		foreign.R.RecoveryCodes = append(foreign.R.RecoveryCodes, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.RecoveryCodes = append(foreign.R.RecoveryCodes, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the recoveryCode to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.RecoveryCodes.
+func (o *RecoveryCode) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"recovery_code\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, recoveryCodePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &recoveryCodeR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userAccountR{
+			RecoveryCodes: RecoveryCodeSlice{o},
+		}
+	} else {
+		related.R.RecoveryCodes = append(related.R.RecoveryCodes, o)
+	}
+
+	return nil
+}
+
+// RecoveryCodes retrieves all the records using an executor.
+func RecoveryCodes(mods ...qm.QueryMod) recoveryCodeQuery {
+	mods = append(mods, qm.From("\"recovery_code\""))
+	return recoveryCodeQuery{NewQuery(mods...)}
+}
+
+// FindRecoveryCode retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindRecoveryCode(exec boil.Executor, iD int64, selectCols ...string) (*RecoveryCode, error) {
+	recoveryCodeObj := &RecoveryCode{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"recovery_code\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, recoveryCodeObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from recovery_code")
+	}
+
+	if err = recoveryCodeObj.doAfterSelectHooks(exec); err != nil {
+		return recoveryCodeObj, err
+	}
+
+	return recoveryCodeObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *RecoveryCode) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no recovery_code provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(recoveryCodeColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	recoveryCodeInsertCacheMut.RLock()
+	cache, cached := recoveryCodeInsertCache[key]
+	recoveryCodeInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			recoveryCodeAllColumns,
+			recoveryCodeColumnsWithDefault,
+			recoveryCodeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(recoveryCodeType, recoveryCodeMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(recoveryCodeType, recoveryCodeMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"recovery_code\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"recovery_code\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into recovery_code")
+	}
+
+	if !cached {
+		recoveryCodeInsertCacheMut.Lock()
+		recoveryCodeInsertCache[key] = cache
+		recoveryCodeInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the RecoveryCode.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *RecoveryCode) Update(exec boil.Executor, columns boil.Columns) error {
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	recoveryCodeUpdateCacheMut.RLock()
+	cache, cached := recoveryCodeUpdateCache[key]
+	recoveryCodeUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			recoveryCodeAllColumns,
+			recoveryCodePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update recovery_code, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"recovery_code\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, recoveryCodePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(recoveryCodeType, recoveryCodeMapping, append(wl, recoveryCodePrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update recovery_code row")
+	}
+
+	if !cached {
+		recoveryCodeUpdateCacheMut.Lock()
+		recoveryCodeUpdateCache[key] = cache
+		recoveryCodeUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q recoveryCodeQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for recovery_code")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o RecoveryCodeSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), recoveryCodePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"recovery_code\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, recoveryCodePrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in recoveryCode slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *RecoveryCode) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no recovery_code provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(recoveryCodeColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	recoveryCodeUpsertCacheMut.RLock()
+	cache, cached := recoveryCodeUpsertCache[key]
+	recoveryCodeUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			recoveryCodeAllColumns,
+			recoveryCodeColumnsWithDefault,
+			recoveryCodeColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			recoveryCodeAllColumns,
+			recoveryCodePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert recovery_code, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(recoveryCodePrimaryKeyColumns))
+			copy(conflict, recoveryCodePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"recovery_code\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(recoveryCodeType, recoveryCodeMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(recoveryCodeType, recoveryCodeMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert recovery_code")
+	}
+
+	if !cached {
+		recoveryCodeUpsertCacheMut.Lock()
+		recoveryCodeUpsertCache[key] = cache
+		recoveryCodeUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single RecoveryCode record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *RecoveryCode) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no RecoveryCode provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), recoveryCodePrimaryKeyMapping)
+	sql := "DELETE FROM \"recovery_code\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from recovery_code")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q recoveryCodeQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no recoveryCodeQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from recovery_code")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o RecoveryCodeSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(recoveryCodeBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), recoveryCodePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"recovery_code\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, recoveryCodePrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from recoveryCode slice")
+	}
+
+	if len(recoveryCodeAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *RecoveryCode) Reload(exec boil.Executor) error {
+	ret, err := FindRecoveryCode(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *RecoveryCodeSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := RecoveryCodeSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), recoveryCodePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"recovery_code\".* FROM \"recovery_code\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, recoveryCodePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in RecoveryCodeSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// RecoveryCodeExists checks if the RecoveryCode row exists.
+func RecoveryCodeExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"recovery_code\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if recovery_code exists")
+	}
+
+	return exists, nil
+}
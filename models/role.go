@@ -0,0 +1,1376 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// Role is an object representing the database table.
+type Role struct {
+	ID          int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt   time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt   time.Time         `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Name        string            `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Permissions types.StringArray `boil:"permissions" json:"permissions" toml:"permissions" yaml:"permissions"`
+
+	R *roleR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L roleL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var RoleColumns = struct {
+	ID          string
+	CreatedAt   string
+	UpdatedAt   string
+	Name        string
+	Permissions string
+}{
+	ID:          "id",
+	CreatedAt:   "created_at",
+	UpdatedAt:   "updated_at",
+	Name:        "name",
+	Permissions: "permissions",
+}
+
+var RoleTableColumns = struct {
+	ID          string
+	CreatedAt   string
+	UpdatedAt   string
+	Name        string
+	Permissions string
+}{
+	ID:          "role.id",
+	CreatedAt:   "role.created_at",
+	UpdatedAt:   "role.updated_at",
+	Name:        "role.name",
+	Permissions: "role.permissions",
+}
+
+// Generated where
+
+var RoleWhere = struct {
+	ID          whereHelperint64
+	CreatedAt   whereHelpertime_Time
+	UpdatedAt   whereHelpertime_Time
+	Name        whereHelperstring
+	Permissions whereHelpertypes_StringArray
+}{
+	ID:          whereHelperint64{field: "\"role\".\"id\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"role\".\"created_at\""},
+	UpdatedAt:   whereHelpertime_Time{field: "\"role\".\"updated_at\""},
+	Name:        whereHelperstring{field: "\"role\".\"name\""},
+	Permissions: whereHelpertypes_StringArray{field: "\"role\".\"permissions\""},
+}
+
+// RoleRels is where relationship names are stored.
+var RoleRels = struct {
+	Invites string
+	Users   string
+}{
+	Invites: "Invites",
+	Users:   "Users",
+}
+
+// roleR is where relationships are stored.
+type roleR struct {
+	Invites InviteSlice `boil:"Invites" json:"Invites" toml:"Invites" yaml:"Invites"`
+	Users   UserSlice   `boil:"Users" json:"Users" toml:"Users" yaml:"Users"`
+}
+
+// NewStruct creates a new relationship struct
+func (*roleR) NewStruct() *roleR {
+	return &roleR{}
+}
+
+// roleL is where Load methods for each relationship are stored.
+type roleL struct{}
+
+var (
+	roleAllColumns            = []string{"id", "created_at", "updated_at", "name", "permissions"}
+	roleColumnsWithoutDefault = []string{}
+	roleColumnsWithDefault    = []string{"id", "created_at", "updated_at", "name", "permissions"}
+	rolePrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// RoleSlice is an alias for a slice of pointers to Role.
+	// This should almost always be used instead of []Role.
+	RoleSlice []*Role
+	// RoleHook is the signature for custom Role hook methods
+	RoleHook func(boil.Executor, *Role) error
+
+	roleQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	roleType                 = reflect.TypeOf(&Role{})
+	roleMapping              = queries.MakeStructMapping(roleType)
+	rolePrimaryKeyMapping, _ = queries.BindMapping(roleType, roleMapping, rolePrimaryKeyColumns)
+	roleInsertCacheMut       sync.RWMutex
+	roleInsertCache          = make(map[string]insertCache)
+	roleUpdateCacheMut       sync.RWMutex
+	roleUpdateCache          = make(map[string]updateCache)
+	roleUpsertCacheMut       sync.RWMutex
+	roleUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var roleBeforeInsertHooks []RoleHook
+var roleBeforeUpdateHooks []RoleHook
+var roleBeforeDeleteHooks []RoleHook
+var roleBeforeUpsertHooks []RoleHook
+
+var roleAfterInsertHooks []RoleHook
+var roleAfterSelectHooks []RoleHook
+var roleAfterUpdateHooks []RoleHook
+var roleAfterDeleteHooks []RoleHook
+var roleAfterUpsertHooks []RoleHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Role) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range roleBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Role) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range roleBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Role) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range roleBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Role) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range roleBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Role) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range roleAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Role) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range roleAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Role) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range roleAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Role) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range roleAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Role) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range roleAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddRoleHook registers your hook function for all future operations.
+func AddRoleHook(hookPoint boil.HookPoint, roleHook RoleHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		roleBeforeInsertHooks = append(roleBeforeInsertHooks, roleHook)
+	case boil.BeforeUpdateHook:
+		roleBeforeUpdateHooks = append(roleBeforeUpdateHooks, roleHook)
+	case boil.BeforeDeleteHook:
+		roleBeforeDeleteHooks = append(roleBeforeDeleteHooks, roleHook)
+	case boil.BeforeUpsertHook:
+		roleBeforeUpsertHooks = append(roleBeforeUpsertHooks, roleHook)
+	case boil.AfterInsertHook:
+		roleAfterInsertHooks = append(roleAfterInsertHooks, roleHook)
+	case boil.AfterSelectHook:
+		roleAfterSelectHooks = append(roleAfterSelectHooks, roleHook)
+	case boil.AfterUpdateHook:
+		roleAfterUpdateHooks = append(roleAfterUpdateHooks, roleHook)
+	case boil.AfterDeleteHook:
+		roleAfterDeleteHooks = append(roleAfterDeleteHooks, roleHook)
+	case boil.AfterUpsertHook:
+		roleAfterUpsertHooks = append(roleAfterUpsertHooks, roleHook)
+	}
+}
+
+// One returns a single role record from the query.
+func (q roleQuery) One(exec boil.Executor) (*Role, error) {
+	o := &Role{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for role")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Role records from the query.
+func (q roleQuery) All(exec boil.Executor) (RoleSlice, error) {
+	var o []*Role
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Role slice")
+	}
+
+	if len(roleAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Role records in the query.
+func (q roleQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count role rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q roleQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if role exists")
+	}
+
+	return count > 0, nil
+}
+
+// Invites retrieves all the invite's Invites with an executor.
+func (o *Role) Invites(mods ...qm.QueryMod) inviteQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"invite\".\"role_id\"=?", o.ID),
+	)
+
+	query := Invites(queryMods...)
+	queries.SetFrom(query.Query, "\"invite\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"invite\".*"})
+	}
+
+	return query
+}
+
+// Users retrieves all the user_account's Users with an executor.
+func (o *Role) Users(mods ...qm.QueryMod) userAccountQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"user_roles\" on \"user_account\".\"id\" = \"user_roles\".\"user_id\""),
+		qm.Where("\"user_roles\".\"role_id\"=?", o.ID),
+	)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"user_account\".*"})
+	}
+
+	return query
+}
+
+// LoadInvites allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (roleL) LoadInvites(e boil.Executor, singular bool, maybeRole interface{}, mods queries.Applicator) error {
+	var slice []*Role
+	var object *Role
+
+	if singular {
+		object = maybeRole.(*Role)
+	} else {
+		slice = *maybeRole.(*[]*Role)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &roleR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &roleR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`invite`),
+		qm.WhereIn(`invite.role_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invite")
+	}
+
+	var resultSlice []*Invite
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invite")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invite")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invite")
+	}
+
+	if len(inviteAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Invites = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &inviteR{}
+			}
+			foreign.R.Role = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.RoleID) {
+				local.R.Invites = append(local.R.Invites, foreign)
+				if foreign.R == nil {
+					foreign.R = &inviteR{}
+				}
+				foreign.R.Role = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUsers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (roleL) LoadUsers(e boil.Executor, singular bool, maybeRole interface{}, mods queries.Applicator) error {
+	var slice []*Role
+	var object *Role
+
+	if singular {
+		object = maybeRole.(*Role)
+	} else {
+		slice = *maybeRole.(*[]*Role)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &roleR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &roleR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.Select("\"user_account\".id, \"user_account\".created_at, \"user_account\".updated_at, \"user_account\".deleted_at, \"user_account\".name, \"user_account\".email, \"user_account\".password, \"user_account\".permissions, \"user_account\".revoked_permissions, \"user_account\".totp_secret, \"user_account\".totp_enabled_at, \"user_account\".email_verified_at, \"a\".\"role_id\""),
+		qm.From("\"user_account\""),
+		qm.InnerJoin("\"user_roles\" as \"a\" on \"user_account\".\"id\" = \"a\".\"user_id\""),
+		qm.WhereIn("\"a\".\"role_id\" in ?", args...),
+		qmhelper.WhereIsNull("\"user_account\".\"deleted_at\""),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_account")
+	}
+
+	var resultSlice []*User
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(User)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Name, &one.Email, &one.Password, &one.Permissions, &one.RevokedPermissions, &one.TotpSecret, &one.TotpEnabledAt, &one.EmailVerifiedAt, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for user_account")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice user_account")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(userAccountAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Users = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userAccountR{}
+			}
+			foreign.R.Roles = append(foreign.R.Roles, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if local.ID == localJoinCol {
+				local.R.Users = append(local.R.Users, foreign)
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.Roles = append(foreign.R.Roles, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddInvites adds the given related objects to the existing relationships
+// of the role, optionally inserting them as new records.
+// Appends related to o.R.Invites.
+// Sets related.R.Role appropriately.
+func (o *Role) AddInvites(exec boil.Executor, insert bool, related ...*Invite) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.RoleID, o.ID)
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"invite\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"role_id"}),
+				strmangle.WhereClause("\"", "\"", 2, invitePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.RoleID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &roleR{
+			Invites: related,
+		}
+	} else {
+		o.R.Invites = append(o.R.Invites, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &inviteR{
+				Role: o,
+			}
+		} else {
+			rel.R.Role = o
+		}
+	}
+	return nil
+}
+
+// SetInvites removes all previously related items of the
+// role replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Role's Invites accordingly.
+// Replaces o.R.Invites with related.
+// Sets related.R.Role's Invites accordingly.
+func (o *Role) SetInvites(exec boil.Executor, insert bool, related ...*Invite) error {
+	query := "update \"invite\" set \"role_id\" = null where \"role_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Invites {
+			queries.SetScanner(&rel.RoleID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Role = nil
+		}
+
+		o.R.Invites = nil
+	}
+	return o.AddInvites(exec, insert, related...)
+}
+
+// RemoveInvites relationships from objects passed in.
+// Removes related items from R.Invites (uses pointer comparison, removal does not keep order)
+// Sets related.R.Role.
+func (o *Role) RemoveInvites(exec boil.Executor, related ...*Invite) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.RoleID, nil)
+		if rel.R != nil {
+			rel.R.Role = nil
+		}
+		if err = rel.Update(exec, boil.Whitelist("role_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Invites {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Invites)
+			if ln > 1 && i < ln-1 {
+				o.R.Invites[i] = o.R.Invites[ln-1]
+			}
+			o.R.Invites = o.R.Invites[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddUsers adds the given related objects to the existing relationships
+// of the role, optionally inserting them as new records.
+// Appends related to o.R.Users.
+// Sets related.R.Roles appropriately.
+func (o *Role) AddUsers(exec boil.Executor, insert bool, related ...*User) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"user_roles\" (\"role_id\", \"user_id\") values ($1, $2)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, query)
+			fmt.Fprintln(boil.DebugWriter, values)
+		}
+		_, err = exec.Exec(query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &roleR{
+			Users: related,
+		}
+	} else {
+		o.R.Users = append(o.R.Users, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userAccountR{
+				Roles: RoleSlice{o},
+			}
+		} else {
+			rel.R.Roles = append(rel.R.Roles, o)
+		}
+	}
+	return nil
+}
+
+// SetUsers removes all previously related items of the
+// role replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Roles's Users accordingly.
+// Replaces o.R.Users with related.
+// Sets related.R.Roles's Users accordingly.
+func (o *Role) SetUsers(exec boil.Executor, insert bool, related ...*User) error {
+	query := "delete from \"user_roles\" where \"role_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeUsersFromRolesSlice(o, related)
+	if o.R != nil {
+		o.R.Users = nil
+	}
+	return o.AddUsers(exec, insert, related...)
+}
+
+// RemoveUsers relationships from objects passed in.
+// Removes related items from R.Users (uses pointer comparison, removal does not keep order)
+// Sets related.R.Roles.
+func (o *Role) RemoveUsers(exec boil.Executor, related ...*User) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"user_roles\" where \"role_id\" = $1 and \"user_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeUsersFromRolesSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Users {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Users)
+			if ln > 1 && i < ln-1 {
+				o.R.Users[i] = o.R.Users[ln-1]
+			}
+			o.R.Users = o.R.Users[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeUsersFromRolesSlice(o *Role, related []*User) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Roles {
+			if o.ID != ri.ID {
+				continue
+			}
+
+			ln := len(rel.R.Roles)
+			if ln > 1 && i < ln-1 {
+				rel.R.Roles[i] = rel.R.Roles[ln-1]
+			}
+			rel.R.Roles = rel.R.Roles[:ln-1]
+			break
+		}
+	}
+}
+
+// Roles retrieves all the records using an executor.
+func Roles(mods ...qm.QueryMod) roleQuery {
+	mods = append(mods, qm.From("\"role\""))
+	return roleQuery{NewQuery(mods...)}
+}
+
+// FindRole retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindRole(exec boil.Executor, iD int64, selectCols ...string) (*Role, error) {
+	roleObj := &Role{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"role\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, roleObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from role")
+	}
+
+	if err = roleObj.doAfterSelectHooks(exec); err != nil {
+		return roleObj, err
+	}
+
+	return roleObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Role) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no role provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(roleColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	roleInsertCacheMut.RLock()
+	cache, cached := roleInsertCache[key]
+	roleInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			roleAllColumns,
+			roleColumnsWithDefault,
+			roleColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(roleType, roleMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(roleType, roleMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"role\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"role\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into role")
+	}
+
+	if !cached {
+		roleInsertCacheMut.Lock()
+		roleInsertCache[key] = cache
+		roleInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the Role.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Role) Update(exec boil.Executor, columns boil.Columns) error {
+	currTime := time.Now().In(boil.GetLocation())
+
+	o.UpdatedAt = currTime
+
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	roleUpdateCacheMut.RLock()
+	cache, cached := roleUpdateCache[key]
+	roleUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			roleAllColumns,
+			rolePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update role, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"role\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, rolePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(roleType, roleMapping, append(wl, rolePrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update role row")
+	}
+
+	if !cached {
+		roleUpdateCacheMut.Lock()
+		roleUpdateCache[key] = cache
+		roleUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q roleQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for role")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o RoleSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), rolePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"role\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, rolePrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in role slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Role) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no role provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	o.UpdatedAt = currTime
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(roleColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	roleUpsertCacheMut.RLock()
+	cache, cached := roleUpsertCache[key]
+	roleUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			roleAllColumns,
+			roleColumnsWithDefault,
+			roleColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			roleAllColumns,
+			rolePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert role, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(rolePrimaryKeyColumns))
+			copy(conflict, rolePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"role\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(roleType, roleMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(roleType, roleMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert role")
+	}
+
+	if !cached {
+		roleUpsertCacheMut.Lock()
+		roleUpsertCache[key] = cache
+		roleUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single Role record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Role) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no Role provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), rolePrimaryKeyMapping)
+	sql := "DELETE FROM \"role\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from role")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q roleQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no roleQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from role")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o RoleSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(roleBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), rolePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"role\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, rolePrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from role slice")
+	}
+
+	if len(roleAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Role) Reload(exec boil.Executor) error {
+	ret, err := FindRole(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *RoleSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := RoleSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), rolePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"role\".* FROM \"role\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, rolePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in RoleSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// RoleExists checks if the Role row exists.
+func RoleExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"role\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if role exists")
+	}
+
+	return exists, nil
+}
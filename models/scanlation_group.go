@@ -64,14 +64,17 @@ var ScanlationGroupWhere = struct {
 
 // ScanlationGroupRels is where relationship names are stored.
 var ScanlationGroupRels = struct {
-	Chapters string
+	Chapters               string
+	ScanlationGroupMembers string
 }{
-	Chapters: "Chapters",
+	Chapters:               "Chapters",
+	ScanlationGroupMembers: "ScanlationGroupMembers",
 }
 
 // scanlationGroupR is where relationships are stored.
 type scanlationGroupR struct {
-	Chapters ChapterSlice `boil:"Chapters" json:"Chapters" toml:"Chapters" yaml:"Chapters"`
+	Chapters               ChapterSlice               `boil:"Chapters" json:"Chapters" toml:"Chapters" yaml:"Chapters"`
+	ScanlationGroupMembers ScanlationGroupMemberSlice `boil:"ScanlationGroupMembers" json:"ScanlationGroupMembers" toml:"ScanlationGroupMembers" yaml:"ScanlationGroupMembers"`
 }
 
 // NewStruct creates a new relationship struct
@@ -350,6 +353,27 @@ func (o *ScanlationGroup) Chapters(mods ...qm.QueryMod) chapterQuery {
 	return query
 }
 
+// ScanlationGroupMembers retrieves all the scanlation_group_member's ScanlationGroupMembers with an executor.
+func (o *ScanlationGroup) ScanlationGroupMembers(mods ...qm.QueryMod) scanlationGroupMemberQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"scanlation_group_member\".\"scanlation_group_id\"=?", o.ID),
+	)
+
+	query := ScanlationGroupMembers(queryMods...)
+	queries.SetFrom(query.Query, "\"scanlation_group_member\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"scanlation_group_member\".*"})
+	}
+
+	return query
+}
+
 // LoadChapters allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (scanlationGroupL) LoadChapters(e boil.Executor, singular bool, maybeScanlationGroup interface{}, mods queries.Applicator) error {
@@ -390,10 +414,11 @@ func (scanlationGroupL) LoadChapters(e boil.Executor, singular bool, maybeScanla
 	}
 
 	query := NewQuery(
-		qm.Select("\"chapter\".id, \"chapter\".locked, \"chapter\".created_at, \"chapter\".updated_at, \"chapter\".published_at, \"chapter\".project_id, \"chapter\".uploader_id, \"chapter\".chapter, \"chapter\".volume, \"chapter\".title, \"chapter\".pages, \"a\".\"scanlation_group_id\""),
+		qm.Select("\"chapter\".id, \"chapter\".locked, \"chapter\".created_at, \"chapter\".updated_at, \"chapter\".published_at, \"chapter\".project_id, \"chapter\".uploader_id, \"chapter\".chapter, \"chapter\".volume, \"chapter\".title, \"chapter\".pages, \"chapter\".scheduled_at, \"chapter\".deleted_at, \"a\".\"scanlation_group_id\""),
 		qm.From("\"chapter\""),
 		qm.InnerJoin("\"chapter_scanlation_groups\" as \"a\" on \"chapter\".\"id\" = \"a\".\"chapter_id\""),
 		qm.WhereIn("\"a\".\"scanlation_group_id\" in ?", args...),
+		qmhelper.WhereIsNull("\"chapter\".\"deleted_at\""),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -411,7 +436,7 @@ func (scanlationGroupL) LoadChapters(e boil.Executor, singular bool, maybeScanla
 		one := new(Chapter)
 		var localJoinCol int64
 
-		err = results.Scan(&one.ID, &one.Locked, &one.CreatedAt, &one.UpdatedAt, &one.PublishedAt, &one.ProjectID, &one.UploaderID, &one.Chapter, &one.Volume, &one.Title, &one.Pages, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Locked, &one.CreatedAt, &one.UpdatedAt, &one.PublishedAt, &one.ProjectID, &one.UploaderID, &one.Chapter, &one.Volume, &one.Title, &one.Pages, &one.ScheduledAt, &one.DeletedAt, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for chapter")
 		}
@@ -465,6 +490,104 @@ func (scanlationGroupL) LoadChapters(e boil.Executor, singular bool, maybeScanla
 	return nil
 }
 
+// LoadScanlationGroupMembers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (scanlationGroupL) LoadScanlationGroupMembers(e boil.Executor, singular bool, maybeScanlationGroup interface{}, mods queries.Applicator) error {
+	var slice []*ScanlationGroup
+	var object *ScanlationGroup
+
+	if singular {
+		object = maybeScanlationGroup.(*ScanlationGroup)
+	} else {
+		slice = *maybeScanlationGroup.(*[]*ScanlationGroup)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &scanlationGroupR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &scanlationGroupR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`scanlation_group_member`),
+		qm.WhereIn(`scanlation_group_member.scanlation_group_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load scanlation_group_member")
+	}
+
+	var resultSlice []*ScanlationGroupMember
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice scanlation_group_member")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on scanlation_group_member")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for scanlation_group_member")
+	}
+
+	if len(scanlationGroupMemberAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ScanlationGroupMembers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &scanlationGroupMemberR{}
+			}
+			foreign.R.ScanlationGroup = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ScanlationGroupID {
+				local.R.ScanlationGroupMembers = append(local.R.ScanlationGroupMembers, foreign)
+				if foreign.R == nil {
+					foreign.R = &scanlationGroupMemberR{}
+				}
+				foreign.R.ScanlationGroup = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // AddChapters adds the given related objects to the existing relationships
 // of the scanlation_group, optionally inserting them as new records.
 // Appends related to o.R.Chapters.
@@ -606,6 +729,58 @@ func removeChaptersFromScanlationGroupsSlice(o *ScanlationGroup, related []*Chap
 	}
 }
 
+// AddScanlationGroupMembers adds the given related objects to the existing relationships
+// of the scanlation_group, optionally inserting them as new records.
+// Appends related to o.R.ScanlationGroupMembers.
+// Sets related.R.ScanlationGroup appropriately.
+func (o *ScanlationGroup) AddScanlationGroupMembers(exec boil.Executor, insert bool, related ...*ScanlationGroupMember) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ScanlationGroupID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"scanlation_group_member\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"scanlation_group_id"}),
+				strmangle.WhereClause("\"", "\"", 2, scanlationGroupMemberPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ScanlationGroupID, rel.UserID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ScanlationGroupID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &scanlationGroupR{
+			ScanlationGroupMembers: related,
+		}
+	} else {
+		o.R.ScanlationGroupMembers = append(o.R.ScanlationGroupMembers, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &scanlationGroupMemberR{
+				ScanlationGroup: o,
+			}
+		} else {
+			rel.R.ScanlationGroup = o
+		}
+	}
+	return nil
+}
+
 // ScanlationGroups retrieves all the records using an executor.
 func ScanlationGroups(mods ...qm.QueryMod) scanlationGroupQuery {
 	mods = append(mods, qm.From("\"scanlation_group\""))
@@ -0,0 +1,1182 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ScanlationGroupMember is an object representing the database table.
+type ScanlationGroupMember struct {
+	CreatedAt         time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt         time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ScanlationGroupID int64     `boil:"scanlation_group_id" json:"scanlation_group_id" toml:"scanlation_group_id" yaml:"scanlation_group_id"`
+	UserID            int64     `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Role              string    `boil:"role" json:"role" toml:"role" yaml:"role"`
+
+	R *scanlationGroupMemberR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L scanlationGroupMemberL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ScanlationGroupMemberColumns = struct {
+	CreatedAt         string
+	UpdatedAt         string
+	ScanlationGroupID string
+	UserID            string
+	Role              string
+}{
+	CreatedAt:         "created_at",
+	UpdatedAt:         "updated_at",
+	ScanlationGroupID: "scanlation_group_id",
+	UserID:            "user_id",
+	Role:              "role",
+}
+
+var ScanlationGroupMemberTableColumns = struct {
+	CreatedAt         string
+	UpdatedAt         string
+	ScanlationGroupID string
+	UserID            string
+	Role              string
+}{
+	CreatedAt:         "scanlation_group_member.created_at",
+	UpdatedAt:         "scanlation_group_member.updated_at",
+	ScanlationGroupID: "scanlation_group_member.scanlation_group_id",
+	UserID:            "scanlation_group_member.user_id",
+	Role:              "scanlation_group_member.role",
+}
+
+// Generated where
+
+var ScanlationGroupMemberWhere = struct {
+	CreatedAt         whereHelpertime_Time
+	UpdatedAt         whereHelpertime_Time
+	ScanlationGroupID whereHelperint64
+	UserID            whereHelperint64
+	Role              whereHelperstring
+}{
+	CreatedAt:         whereHelpertime_Time{field: "\"scanlation_group_member\".\"created_at\""},
+	UpdatedAt:         whereHelpertime_Time{field: "\"scanlation_group_member\".\"updated_at\""},
+	ScanlationGroupID: whereHelperint64{field: "\"scanlation_group_member\".\"scanlation_group_id\""},
+	UserID:            whereHelperint64{field: "\"scanlation_group_member\".\"user_id\""},
+	Role:              whereHelperstring{field: "\"scanlation_group_member\".\"role\""},
+}
+
+// ScanlationGroupMemberRels is where relationship names are stored.
+var ScanlationGroupMemberRels = struct {
+	ScanlationGroup string
+	User            string
+}{
+	ScanlationGroup: "ScanlationGroup",
+	User:            "User",
+}
+
+// scanlationGroupMemberR is where relationships are stored.
+type scanlationGroupMemberR struct {
+	ScanlationGroup *ScanlationGroup `boil:"ScanlationGroup" json:"ScanlationGroup" toml:"ScanlationGroup" yaml:"ScanlationGroup"`
+	User            *User            `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*scanlationGroupMemberR) NewStruct() *scanlationGroupMemberR {
+	return &scanlationGroupMemberR{}
+}
+
+// scanlationGroupMemberL is where Load methods for each relationship are stored.
+type scanlationGroupMemberL struct{}
+
+var (
+	scanlationGroupMemberAllColumns            = []string{"created_at", "updated_at", "scanlation_group_id", "user_id", "role"}
+	scanlationGroupMemberColumnsWithoutDefault = []string{"scanlation_group_id", "user_id"}
+	scanlationGroupMemberColumnsWithDefault    = []string{"created_at", "updated_at", "role"}
+	scanlationGroupMemberPrimaryKeyColumns     = []string{"scanlation_group_id", "user_id"}
+)
+
+type (
+	// ScanlationGroupMemberSlice is an alias for a slice of pointers to ScanlationGroupMember.
+	// This should almost always be used instead of []ScanlationGroupMember.
+	ScanlationGroupMemberSlice []*ScanlationGroupMember
+	// ScanlationGroupMemberHook is the signature for custom ScanlationGroupMember hook methods
+	ScanlationGroupMemberHook func(boil.Executor, *ScanlationGroupMember) error
+
+	scanlationGroupMemberQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	scanlationGroupMemberType                 = reflect.TypeOf(&ScanlationGroupMember{})
+	scanlationGroupMemberMapping              = queries.MakeStructMapping(scanlationGroupMemberType)
+	scanlationGroupMemberPrimaryKeyMapping, _ = queries.BindMapping(scanlationGroupMemberType, scanlationGroupMemberMapping, scanlationGroupMemberPrimaryKeyColumns)
+	scanlationGroupMemberInsertCacheMut       sync.RWMutex
+	scanlationGroupMemberInsertCache          = make(map[string]insertCache)
+	scanlationGroupMemberUpdateCacheMut       sync.RWMutex
+	scanlationGroupMemberUpdateCache          = make(map[string]updateCache)
+	scanlationGroupMemberUpsertCacheMut       sync.RWMutex
+	scanlationGroupMemberUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var scanlationGroupMemberBeforeInsertHooks []ScanlationGroupMemberHook
+var scanlationGroupMemberBeforeUpdateHooks []ScanlationGroupMemberHook
+var scanlationGroupMemberBeforeDeleteHooks []ScanlationGroupMemberHook
+var scanlationGroupMemberBeforeUpsertHooks []ScanlationGroupMemberHook
+
+var scanlationGroupMemberAfterInsertHooks []ScanlationGroupMemberHook
+var scanlationGroupMemberAfterSelectHooks []ScanlationGroupMemberHook
+var scanlationGroupMemberAfterUpdateHooks []ScanlationGroupMemberHook
+var scanlationGroupMemberAfterDeleteHooks []ScanlationGroupMemberHook
+var scanlationGroupMemberAfterUpsertHooks []ScanlationGroupMemberHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ScanlationGroupMember) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range scanlationGroupMemberBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ScanlationGroupMember) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range scanlationGroupMemberBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ScanlationGroupMember) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range scanlationGroupMemberBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ScanlationGroupMember) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range scanlationGroupMemberBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ScanlationGroupMember) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range scanlationGroupMemberAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ScanlationGroupMember) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range scanlationGroupMemberAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ScanlationGroupMember) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range scanlationGroupMemberAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ScanlationGroupMember) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range scanlationGroupMemberAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ScanlationGroupMember) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range scanlationGroupMemberAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddScanlationGroupMemberHook registers your hook function for all future operations.
+func AddScanlationGroupMemberHook(hookPoint boil.HookPoint, scanlationGroupMemberHook ScanlationGroupMemberHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		scanlationGroupMemberBeforeInsertHooks = append(scanlationGroupMemberBeforeInsertHooks, scanlationGroupMemberHook)
+	case boil.BeforeUpdateHook:
+		scanlationGroupMemberBeforeUpdateHooks = append(scanlationGroupMemberBeforeUpdateHooks, scanlationGroupMemberHook)
+	case boil.BeforeDeleteHook:
+		scanlationGroupMemberBeforeDeleteHooks = append(scanlationGroupMemberBeforeDeleteHooks, scanlationGroupMemberHook)
+	case boil.BeforeUpsertHook:
+		scanlationGroupMemberBeforeUpsertHooks = append(scanlationGroupMemberBeforeUpsertHooks, scanlationGroupMemberHook)
+	case boil.AfterInsertHook:
+		scanlationGroupMemberAfterInsertHooks = append(scanlationGroupMemberAfterInsertHooks, scanlationGroupMemberHook)
+	case boil.AfterSelectHook:
+		scanlationGroupMemberAfterSelectHooks = append(scanlationGroupMemberAfterSelectHooks, scanlationGroupMemberHook)
+	case boil.AfterUpdateHook:
+		scanlationGroupMemberAfterUpdateHooks = append(scanlationGroupMemberAfterUpdateHooks, scanlationGroupMemberHook)
+	case boil.AfterDeleteHook:
+		scanlationGroupMemberAfterDeleteHooks = append(scanlationGroupMemberAfterDeleteHooks, scanlationGroupMemberHook)
+	case boil.AfterUpsertHook:
+		scanlationGroupMemberAfterUpsertHooks = append(scanlationGroupMemberAfterUpsertHooks, scanlationGroupMemberHook)
+	}
+}
+
+// One returns a single scanlationGroupMember record from the query.
+func (q scanlationGroupMemberQuery) One(exec boil.Executor) (*ScanlationGroupMember, error) {
+	o := &ScanlationGroupMember{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for scanlation_group_member")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ScanlationGroupMember records from the query.
+func (q scanlationGroupMemberQuery) All(exec boil.Executor) (ScanlationGroupMemberSlice, error) {
+	var o []*ScanlationGroupMember
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ScanlationGroupMember slice")
+	}
+
+	if len(scanlationGroupMemberAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ScanlationGroupMember records in the query.
+func (q scanlationGroupMemberQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count scanlation_group_member rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q scanlationGroupMemberQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if scanlation_group_member exists")
+	}
+
+	return count > 0, nil
+}
+
+// ScanlationGroup pointed to by the foreign key.
+func (o *ScanlationGroupMember) ScanlationGroup(mods ...qm.QueryMod) scanlationGroupQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ScanlationGroupID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := ScanlationGroups(queryMods...)
+	queries.SetFrom(query.Query, "\"scanlation_group\"")
+
+	return query
+}
+
+// User pointed to by the foreign key.
+func (o *ScanlationGroupMember) User(mods ...qm.QueryMod) userAccountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	return query
+}
+
+// LoadScanlationGroup allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (scanlationGroupMemberL) LoadScanlationGroup(e boil.Executor, singular bool, maybeScanlationGroupMember interface{}, mods queries.Applicator) error {
+	var slice []*ScanlationGroupMember
+	var object *ScanlationGroupMember
+
+	if singular {
+		object = maybeScanlationGroupMember.(*ScanlationGroupMember)
+	} else {
+		slice = *maybeScanlationGroupMember.(*[]*ScanlationGroupMember)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &scanlationGroupMemberR{}
+		}
+		args = append(args, object.ScanlationGroupID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &scanlationGroupMemberR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ScanlationGroupID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ScanlationGroupID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`scanlation_group`),
+		qm.WhereIn(`scanlation_group.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load ScanlationGroup")
+	}
+
+	var resultSlice []*ScanlationGroup
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice ScanlationGroup")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for scanlation_group")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for scanlation_group")
+	}
+
+	if len(scanlationGroupMemberAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ScanlationGroup = foreign
+		if foreign.R == nil {
+			foreign.R = &scanlationGroupR{}
+		}
+		foreign.R.ScanlationGroupMembers = append(foreign.R.ScanlationGroupMembers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ScanlationGroupID == foreign.ID {
+				local.R.ScanlationGroup = foreign
+				if foreign.R == nil {
+					foreign.R = &scanlationGroupR{}
+				}
+				foreign.R.ScanlationGroupMembers = append(foreign.R.ScanlationGroupMembers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (scanlationGroupMemberL) LoadUser(e boil.Executor, singular bool, maybeScanlationGroupMember interface{}, mods queries.Applicator) error {
+	var slice []*ScanlationGroupMember
+	var object *ScanlationGroupMember
+
+	if singular {
+		object = maybeScanlationGroupMember.(*ScanlationGroupMember)
+	} else {
+		slice = *maybeScanlationGroupMember.(*[]*ScanlationGroupMember)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &scanlationGroupMemberR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &scanlationGroupMemberR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_account`),
+		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(scanlationGroupMemberAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userAccountR{}
+		}
+		foreign.R.ScanlationGroupMembers = append(foreign.R.ScanlationGroupMembers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.ScanlationGroupMembers = append(foreign.R.ScanlationGroupMembers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetScanlationGroup of the scanlationGroupMember to the related item.
+// Sets o.R.ScanlationGroup to related.
+// Adds o to related.R.ScanlationGroupMembers.
+func (o *ScanlationGroupMember) SetScanlationGroup(exec boil.Executor, insert bool, related *ScanlationGroup) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"scanlation_group_member\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"scanlation_group_id"}),
+		strmangle.WhereClause("\"", "\"", 2, scanlationGroupMemberPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ScanlationGroupID, o.UserID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ScanlationGroupID = related.ID
+	if o.R == nil {
+		o.R = &scanlationGroupMemberR{
+			ScanlationGroup: related,
+		}
+	} else {
+		o.R.ScanlationGroup = related
+	}
+
+	if related.R == nil {
+		related.R = &scanlationGroupR{
+			ScanlationGroupMembers: ScanlationGroupMemberSlice{o},
+		}
+	} else {
+		related.R.ScanlationGroupMembers = append(related.R.ScanlationGroupMembers, o)
+	}
+
+	return nil
+}
+
+// SetUser of the scanlationGroupMember to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.ScanlationGroupMembers.
+func (o *ScanlationGroupMember) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"scanlation_group_member\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, scanlationGroupMemberPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ScanlationGroupID, o.UserID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &scanlationGroupMemberR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userAccountR{
+			ScanlationGroupMembers: ScanlationGroupMemberSlice{o},
+		}
+	} else {
+		related.R.ScanlationGroupMembers = append(related.R.ScanlationGroupMembers, o)
+	}
+
+	return nil
+}
+
+// ScanlationGroupMembers retrieves all the records using an executor.
+func ScanlationGroupMembers(mods ...qm.QueryMod) scanlationGroupMemberQuery {
+	mods = append(mods, qm.From("\"scanlation_group_member\""))
+	return scanlationGroupMemberQuery{NewQuery(mods...)}
+}
+
+// FindScanlationGroupMember retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindScanlationGroupMember(exec boil.Executor, scanlationGroupID int64, userID int64, selectCols ...string) (*ScanlationGroupMember, error) {
+	scanlationGroupMemberObj := &ScanlationGroupMember{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"scanlation_group_member\" where \"scanlation_group_id\"=$1 AND \"user_id\"=$2", sel,
+	)
+
+	q := queries.Raw(query, scanlationGroupID, userID)
+
+	err := q.Bind(nil, exec, scanlationGroupMemberObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from scanlation_group_member")
+	}
+
+	if err = scanlationGroupMemberObj.doAfterSelectHooks(exec); err != nil {
+		return scanlationGroupMemberObj, err
+	}
+
+	return scanlationGroupMemberObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ScanlationGroupMember) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no scanlation_group_member provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(scanlationGroupMemberColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	scanlationGroupMemberInsertCacheMut.RLock()
+	cache, cached := scanlationGroupMemberInsertCache[key]
+	scanlationGroupMemberInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			scanlationGroupMemberAllColumns,
+			scanlationGroupMemberColumnsWithDefault,
+			scanlationGroupMemberColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(scanlationGroupMemberType, scanlationGroupMemberMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(scanlationGroupMemberType, scanlationGroupMemberMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"scanlation_group_member\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"scanlation_group_member\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into scanlation_group_member")
+	}
+
+	if !cached {
+		scanlationGroupMemberInsertCacheMut.Lock()
+		scanlationGroupMemberInsertCache[key] = cache
+		scanlationGroupMemberInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the ScanlationGroupMember.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ScanlationGroupMember) Update(exec boil.Executor, columns boil.Columns) error {
+	currTime := time.Now().In(boil.GetLocation())
+
+	o.UpdatedAt = currTime
+
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	scanlationGroupMemberUpdateCacheMut.RLock()
+	cache, cached := scanlationGroupMemberUpdateCache[key]
+	scanlationGroupMemberUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			scanlationGroupMemberAllColumns,
+			scanlationGroupMemberPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update scanlation_group_member, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"scanlation_group_member\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, scanlationGroupMemberPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(scanlationGroupMemberType, scanlationGroupMemberMapping, append(wl, scanlationGroupMemberPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update scanlation_group_member row")
+	}
+
+	if !cached {
+		scanlationGroupMemberUpdateCacheMut.Lock()
+		scanlationGroupMemberUpdateCache[key] = cache
+		scanlationGroupMemberUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q scanlationGroupMemberQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for scanlation_group_member")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ScanlationGroupMemberSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), scanlationGroupMemberPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"scanlation_group_member\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, scanlationGroupMemberPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in scanlationGroupMember slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ScanlationGroupMember) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no scanlation_group_member provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	o.UpdatedAt = currTime
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(scanlationGroupMemberColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	scanlationGroupMemberUpsertCacheMut.RLock()
+	cache, cached := scanlationGroupMemberUpsertCache[key]
+	scanlationGroupMemberUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			scanlationGroupMemberAllColumns,
+			scanlationGroupMemberColumnsWithDefault,
+			scanlationGroupMemberColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			scanlationGroupMemberAllColumns,
+			scanlationGroupMemberPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert scanlation_group_member, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(scanlationGroupMemberPrimaryKeyColumns))
+			copy(conflict, scanlationGroupMemberPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"scanlation_group_member\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(scanlationGroupMemberType, scanlationGroupMemberMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(scanlationGroupMemberType, scanlationGroupMemberMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert scanlation_group_member")
+	}
+
+	if !cached {
+		scanlationGroupMemberUpsertCacheMut.Lock()
+		scanlationGroupMemberUpsertCache[key] = cache
+		scanlationGroupMemberUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single ScanlationGroupMember record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ScanlationGroupMember) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no ScanlationGroupMember provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), scanlationGroupMemberPrimaryKeyMapping)
+	sql := "DELETE FROM \"scanlation_group_member\" WHERE \"scanlation_group_id\"=$1 AND \"user_id\"=$2"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from scanlation_group_member")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q scanlationGroupMemberQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no scanlationGroupMemberQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from scanlation_group_member")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ScanlationGroupMemberSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(scanlationGroupMemberBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), scanlationGroupMemberPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"scanlation_group_member\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, scanlationGroupMemberPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from scanlationGroupMember slice")
+	}
+
+	if len(scanlationGroupMemberAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ScanlationGroupMember) Reload(exec boil.Executor) error {
+	ret, err := FindScanlationGroupMember(exec, o.ScanlationGroupID, o.UserID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ScanlationGroupMemberSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ScanlationGroupMemberSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), scanlationGroupMemberPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"scanlation_group_member\".* FROM \"scanlation_group_member\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, scanlationGroupMemberPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ScanlationGroupMemberSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ScanlationGroupMemberExists checks if the ScanlationGroupMember row exists.
+func ScanlationGroupMemberExists(exec boil.Executor, scanlationGroupID int64, userID int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"scanlation_group_member\" where \"scanlation_group_id\"=$1 AND \"user_id\"=$2 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, scanlationGroupID, userID)
+	}
+	row := exec.QueryRow(sql, scanlationGroupID, userID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if scanlation_group_member exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,859 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// SigningKey is an object representing the database table.
+type SigningKey struct {
+	ID         int64     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	Kid        string    `boil:"kid" json:"kid" toml:"kid" yaml:"kid"`
+	Purpose    string    `boil:"purpose" json:"purpose" toml:"purpose" yaml:"purpose"`
+	Algorithm  string    `boil:"algorithm" json:"algorithm" toml:"algorithm" yaml:"algorithm"`
+	PrivateKey string    `boil:"private_key" json:"private_key" toml:"private_key" yaml:"private_key"`
+	RetiredAt  null.Time `boil:"retired_at" json:"retired_at,omitempty" toml:"retired_at" yaml:"retired_at,omitempty"`
+	ExpiresAt  null.Time `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
+
+	R *signingKeyR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L signingKeyL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var SigningKeyColumns = struct {
+	ID         string
+	CreatedAt  string
+	Kid        string
+	Purpose    string
+	Algorithm  string
+	PrivateKey string
+	RetiredAt  string
+	ExpiresAt  string
+}{
+	ID:         "id",
+	CreatedAt:  "created_at",
+	Kid:        "kid",
+	Purpose:    "purpose",
+	Algorithm:  "algorithm",
+	PrivateKey: "private_key",
+	RetiredAt:  "retired_at",
+	ExpiresAt:  "expires_at",
+}
+
+var SigningKeyTableColumns = struct {
+	ID         string
+	CreatedAt  string
+	Kid        string
+	Purpose    string
+	Algorithm  string
+	PrivateKey string
+	RetiredAt  string
+	ExpiresAt  string
+}{
+	ID:         "signing_key.id",
+	CreatedAt:  "signing_key.created_at",
+	Kid:        "signing_key.kid",
+	Purpose:    "signing_key.purpose",
+	Algorithm:  "signing_key.algorithm",
+	PrivateKey: "signing_key.private_key",
+	RetiredAt:  "signing_key.retired_at",
+	ExpiresAt:  "signing_key.expires_at",
+}
+
+// Generated where
+
+var SigningKeyWhere = struct {
+	ID         whereHelperint64
+	CreatedAt  whereHelpertime_Time
+	Kid        whereHelperstring
+	Purpose    whereHelperstring
+	Algorithm  whereHelperstring
+	PrivateKey whereHelperstring
+	RetiredAt  whereHelpernull_Time
+	ExpiresAt  whereHelpernull_Time
+}{
+	ID:         whereHelperint64{field: "\"signing_key\".\"id\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"signing_key\".\"created_at\""},
+	Kid:        whereHelperstring{field: "\"signing_key\".\"kid\""},
+	Purpose:    whereHelperstring{field: "\"signing_key\".\"purpose\""},
+	Algorithm:  whereHelperstring{field: "\"signing_key\".\"algorithm\""},
+	PrivateKey: whereHelperstring{field: "\"signing_key\".\"private_key\""},
+	RetiredAt:  whereHelpernull_Time{field: "\"signing_key\".\"retired_at\""},
+	ExpiresAt:  whereHelpernull_Time{field: "\"signing_key\".\"expires_at\""},
+}
+
+// SigningKeyRels is where relationship names are stored.
+var SigningKeyRels = struct {
+}{}
+
+// signingKeyR is where relationships are stored.
+type signingKeyR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*signingKeyR) NewStruct() *signingKeyR {
+	return &signingKeyR{}
+}
+
+// signingKeyL is where Load methods for each relationship are stored.
+type signingKeyL struct{}
+
+var (
+	signingKeyAllColumns            = []string{"id", "created_at", "kid", "purpose", "algorithm", "private_key", "retired_at", "expires_at"}
+	signingKeyColumnsWithoutDefault = []string{"private_key", "retired_at", "expires_at"}
+	signingKeyColumnsWithDefault    = []string{"id", "created_at", "kid", "purpose", "algorithm"}
+	signingKeyPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// SigningKeySlice is an alias for a slice of pointers to SigningKey.
+	// This should almost always be used instead of []SigningKey.
+	SigningKeySlice []*SigningKey
+	// SigningKeyHook is the signature for custom SigningKey hook methods
+	SigningKeyHook func(boil.Executor, *SigningKey) error
+
+	signingKeyQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	signingKeyType                 = reflect.TypeOf(&SigningKey{})
+	signingKeyMapping              = queries.MakeStructMapping(signingKeyType)
+	signingKeyPrimaryKeyMapping, _ = queries.BindMapping(signingKeyType, signingKeyMapping, signingKeyPrimaryKeyColumns)
+	signingKeyInsertCacheMut       sync.RWMutex
+	signingKeyInsertCache          = make(map[string]insertCache)
+	signingKeyUpdateCacheMut       sync.RWMutex
+	signingKeyUpdateCache          = make(map[string]updateCache)
+	signingKeyUpsertCacheMut       sync.RWMutex
+	signingKeyUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var signingKeyBeforeInsertHooks []SigningKeyHook
+var signingKeyBeforeUpdateHooks []SigningKeyHook
+var signingKeyBeforeDeleteHooks []SigningKeyHook
+var signingKeyBeforeUpsertHooks []SigningKeyHook
+
+var signingKeyAfterInsertHooks []SigningKeyHook
+var signingKeyAfterSelectHooks []SigningKeyHook
+var signingKeyAfterUpdateHooks []SigningKeyHook
+var signingKeyAfterDeleteHooks []SigningKeyHook
+var signingKeyAfterUpsertHooks []SigningKeyHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *SigningKey) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range signingKeyBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *SigningKey) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range signingKeyBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *SigningKey) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range signingKeyBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *SigningKey) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range signingKeyBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *SigningKey) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range signingKeyAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *SigningKey) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range signingKeyAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *SigningKey) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range signingKeyAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *SigningKey) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range signingKeyAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *SigningKey) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range signingKeyAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddSigningKeyHook registers your hook function for all future operations.
+func AddSigningKeyHook(hookPoint boil.HookPoint, signingKeyHook SigningKeyHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		signingKeyBeforeInsertHooks = append(signingKeyBeforeInsertHooks, signingKeyHook)
+	case boil.BeforeUpdateHook:
+		signingKeyBeforeUpdateHooks = append(signingKeyBeforeUpdateHooks, signingKeyHook)
+	case boil.BeforeDeleteHook:
+		signingKeyBeforeDeleteHooks = append(signingKeyBeforeDeleteHooks, signingKeyHook)
+	case boil.BeforeUpsertHook:
+		signingKeyBeforeUpsertHooks = append(signingKeyBeforeUpsertHooks, signingKeyHook)
+	case boil.AfterInsertHook:
+		signingKeyAfterInsertHooks = append(signingKeyAfterInsertHooks, signingKeyHook)
+	case boil.AfterSelectHook:
+		signingKeyAfterSelectHooks = append(signingKeyAfterSelectHooks, signingKeyHook)
+	case boil.AfterUpdateHook:
+		signingKeyAfterUpdateHooks = append(signingKeyAfterUpdateHooks, signingKeyHook)
+	case boil.AfterDeleteHook:
+		signingKeyAfterDeleteHooks = append(signingKeyAfterDeleteHooks, signingKeyHook)
+	case boil.AfterUpsertHook:
+		signingKeyAfterUpsertHooks = append(signingKeyAfterUpsertHooks, signingKeyHook)
+	}
+}
+
+// One returns a single signingKey record from the query.
+func (q signingKeyQuery) One(exec boil.Executor) (*SigningKey, error) {
+	o := &SigningKey{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for signing_key")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all SigningKey records from the query.
+func (q signingKeyQuery) All(exec boil.Executor) (SigningKeySlice, error) {
+	var o []*SigningKey
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to SigningKey slice")
+	}
+
+	if len(signingKeyAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all SigningKey records in the query.
+func (q signingKeyQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count signing_key rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q signingKeyQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if signing_key exists")
+	}
+
+	return count > 0, nil
+}
+
+// SigningKeys retrieves all the records using an executor.
+func SigningKeys(mods ...qm.QueryMod) signingKeyQuery {
+	mods = append(mods, qm.From("\"signing_key\""))
+	return signingKeyQuery{NewQuery(mods...)}
+}
+
+// FindSigningKey retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindSigningKey(exec boil.Executor, iD int64, selectCols ...string) (*SigningKey, error) {
+	signingKeyObj := &SigningKey{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"signing_key\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, signingKeyObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from signing_key")
+	}
+
+	if err = signingKeyObj.doAfterSelectHooks(exec); err != nil {
+		return signingKeyObj, err
+	}
+
+	return signingKeyObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *SigningKey) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no signing_key provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(signingKeyColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	signingKeyInsertCacheMut.RLock()
+	cache, cached := signingKeyInsertCache[key]
+	signingKeyInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			signingKeyAllColumns,
+			signingKeyColumnsWithDefault,
+			signingKeyColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(signingKeyType, signingKeyMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(signingKeyType, signingKeyMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"signing_key\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"signing_key\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into signing_key")
+	}
+
+	if !cached {
+		signingKeyInsertCacheMut.Lock()
+		signingKeyInsertCache[key] = cache
+		signingKeyInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the SigningKey.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *SigningKey) Update(exec boil.Executor, columns boil.Columns) error {
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	signingKeyUpdateCacheMut.RLock()
+	cache, cached := signingKeyUpdateCache[key]
+	signingKeyUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			signingKeyAllColumns,
+			signingKeyPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update signing_key, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"signing_key\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, signingKeyPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(signingKeyType, signingKeyMapping, append(wl, signingKeyPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update signing_key row")
+	}
+
+	if !cached {
+		signingKeyUpdateCacheMut.Lock()
+		signingKeyUpdateCache[key] = cache
+		signingKeyUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q signingKeyQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for signing_key")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o SigningKeySlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), signingKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"signing_key\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, signingKeyPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in signingKey slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *SigningKey) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no signing_key provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(signingKeyColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	signingKeyUpsertCacheMut.RLock()
+	cache, cached := signingKeyUpsertCache[key]
+	signingKeyUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			signingKeyAllColumns,
+			signingKeyColumnsWithDefault,
+			signingKeyColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			signingKeyAllColumns,
+			signingKeyPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert signing_key, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(signingKeyPrimaryKeyColumns))
+			copy(conflict, signingKeyPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"signing_key\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(signingKeyType, signingKeyMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(signingKeyType, signingKeyMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert signing_key")
+	}
+
+	if !cached {
+		signingKeyUpsertCacheMut.Lock()
+		signingKeyUpsertCache[key] = cache
+		signingKeyUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single SigningKey record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *SigningKey) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no SigningKey provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), signingKeyPrimaryKeyMapping)
+	sql := "DELETE FROM \"signing_key\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from signing_key")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q signingKeyQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no signingKeyQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from signing_key")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o SigningKeySlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(signingKeyBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), signingKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"signing_key\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, signingKeyPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from signingKey slice")
+	}
+
+	if len(signingKeyAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *SigningKey) Reload(exec boil.Executor) error {
+	ret, err := FindSigningKey(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *SigningKeySlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := SigningKeySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), signingKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"signing_key\".* FROM \"signing_key\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, signingKeyPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in SigningKeySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// SigningKeyExists checks if the SigningKey row exists.
+func SigningKeyExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"signing_key\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if signing_key exists")
+	}
+
+	return exists, nil
+}
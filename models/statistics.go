@@ -350,6 +350,7 @@ func (q statisticQuery) Exists(exec boil.Executor) (bool, error) {
 func (o *Statistic) Chapter(mods ...qm.QueryMod) chapterQuery {
 	queryMods := []qm.QueryMod{
 		qm.Where("\"id\" = ?", o.ChapterID),
+		qmhelper.WhereIsNull("deleted_at"),
 	}
 
 	queryMods = append(queryMods, mods...)
@@ -364,6 +365,7 @@ func (o *Statistic) Chapter(mods ...qm.QueryMod) chapterQuery {
 func (o *Statistic) Project(mods ...qm.QueryMod) projectQuery {
 	queryMods := []qm.QueryMod{
 		qm.Where("\"id\" = ?", o.ProjectID),
+		qmhelper.WhereIsNull("deleted_at"),
 	}
 
 	queryMods = append(queryMods, mods...)
@@ -422,6 +424,7 @@ func (statisticL) LoadChapter(e boil.Executor, singular bool, maybeStatistic int
 	query := NewQuery(
 		qm.From(`chapter`),
 		qm.WhereIn(`chapter.id in ?`, args...),
+		qmhelper.WhereIsNull(`chapter.deleted_at`),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -530,6 +533,7 @@ func (statisticL) LoadProject(e boil.Executor, singular bool, maybeStatistic int
 	query := NewQuery(
 		qm.From(`project`),
 		qm.WhereIn(`project.id in ?`, args...),
+		qmhelper.WhereIsNull(`project.deleted_at`),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -390,10 +390,11 @@ func (tagL) LoadProjects(e boil.Executor, singular bool, maybeTag interface{}, m
 	}
 
 	query := NewQuery(
-		qm.Select("\"project\".id, \"project\".slug, \"project\".locked, \"project\".created_at, \"project\".updated_at, \"project\".published_at, \"project\".title, \"project\".description, \"project\".cover_id, \"project\".project_status, \"project\".series_status, \"project\".demographic, \"project\".rating, \"a\".\"tag_id\""),
+		qm.Select("\"project\".id, \"project\".slug, \"project\".locked, \"project\".created_at, \"project\".updated_at, \"project\".published_at, \"project\".title, \"project\".description, \"project\".cover_id, \"project\".project_status, \"project\".series_status, \"project\".demographic, \"project\".rating, \"project\".reading_direction, \"project\".scheduled_at, \"project\".deleted_at, \"a\".\"tag_id\""),
 		qm.From("\"project\""),
 		qm.InnerJoin("\"project_tags\" as \"a\" on \"project\".\"id\" = \"a\".\"project_id\""),
 		qm.WhereIn("\"a\".\"tag_id\" in ?", args...),
+		qmhelper.WhereIsNull("\"project\".\"deleted_at\""),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -411,7 +412,7 @@ func (tagL) LoadProjects(e boil.Executor, singular bool, maybeTag interface{}, m
 		one := new(Project)
 		var localJoinCol int64
 
-		err = results.Scan(&one.ID, &one.Slug, &one.Locked, &one.CreatedAt, &one.UpdatedAt, &one.PublishedAt, &one.Title, &one.Description, &one.CoverID, &one.ProjectStatus, &one.SeriesStatus, &one.Demographic, &one.Rating, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Slug, &one.Locked, &one.CreatedAt, &one.UpdatedAt, &one.PublishedAt, &one.Title, &one.Description, &one.CoverID, &one.ProjectStatus, &one.SeriesStatus, &one.Demographic, &one.Rating, &one.ReadingDirection, &one.ScheduledAt, &one.DeletedAt, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for project")
 		}
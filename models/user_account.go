@@ -24,91 +24,152 @@ import (
 
 // User is an object representing the database table.
 type User struct {
-	ID          int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
-	CreatedAt   time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt   time.Time         `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	DeletedAt   null.Time         `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
-	Name        string            `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Email       string            `boil:"email" json:"email" toml:"email" yaml:"email"`
-	Password    string            `boil:"password" json:"password" toml:"password" yaml:"password"`
-	Permissions types.StringArray `boil:"permissions" json:"permissions" toml:"permissions" yaml:"permissions"`
+	ID                 int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt          time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt          time.Time         `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt          null.Time         `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Name               string            `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Email              string            `boil:"email" json:"email" toml:"email" yaml:"email"`
+	Password           string            `boil:"password" json:"password" toml:"password" yaml:"password"`
+	Permissions        types.StringArray `boil:"permissions" json:"permissions" toml:"permissions" yaml:"permissions"`
+	RevokedPermissions types.StringArray `boil:"revoked_permissions" json:"revoked_permissions" toml:"revoked_permissions" yaml:"revoked_permissions"`
+	TotpSecret         null.String       `boil:"totp_secret" json:"totp_secret,omitempty" toml:"totp_secret" yaml:"totp_secret,omitempty"`
+	TotpEnabledAt      null.Time         `boil:"totp_enabled_at" json:"totp_enabled_at,omitempty" toml:"totp_enabled_at" yaml:"totp_enabled_at,omitempty"`
+	EmailVerifiedAt    null.Time         `boil:"email_verified_at" json:"email_verified_at,omitempty" toml:"email_verified_at" yaml:"email_verified_at,omitempty"`
 
 	R *userAccountR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userAccountL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var UserColumns = struct {
-	ID          string
-	CreatedAt   string
-	UpdatedAt   string
-	DeletedAt   string
-	Name        string
-	Email       string
-	Password    string
-	Permissions string
+	ID                 string
+	CreatedAt          string
+	UpdatedAt          string
+	DeletedAt          string
+	Name               string
+	Email              string
+	Password           string
+	Permissions        string
+	RevokedPermissions string
+	TotpSecret         string
+	TotpEnabledAt      string
+	EmailVerifiedAt    string
 }{
-	ID:          "id",
-	CreatedAt:   "created_at",
-	UpdatedAt:   "updated_at",
-	DeletedAt:   "deleted_at",
-	Name:        "name",
-	Email:       "email",
-	Password:    "password",
-	Permissions: "permissions",
+	ID:                 "id",
+	CreatedAt:          "created_at",
+	UpdatedAt:          "updated_at",
+	DeletedAt:          "deleted_at",
+	Name:               "name",
+	Email:              "email",
+	Password:           "password",
+	Permissions:        "permissions",
+	RevokedPermissions: "revoked_permissions",
+	TotpSecret:         "totp_secret",
+	TotpEnabledAt:      "totp_enabled_at",
+	EmailVerifiedAt:    "email_verified_at",
 }
 
 var UserTableColumns = struct {
-	ID          string
-	CreatedAt   string
-	UpdatedAt   string
-	DeletedAt   string
-	Name        string
-	Email       string
-	Password    string
-	Permissions string
+	ID                 string
+	CreatedAt          string
+	UpdatedAt          string
+	DeletedAt          string
+	Name               string
+	Email              string
+	Password           string
+	Permissions        string
+	RevokedPermissions string
+	TotpSecret         string
+	TotpEnabledAt      string
+	EmailVerifiedAt    string
 }{
-	ID:          "user_account.id",
-	CreatedAt:   "user_account.created_at",
-	UpdatedAt:   "user_account.updated_at",
-	DeletedAt:   "user_account.deleted_at",
-	Name:        "user_account.name",
-	Email:       "user_account.email",
-	Password:    "user_account.password",
-	Permissions: "user_account.permissions",
+	ID:                 "user_account.id",
+	CreatedAt:          "user_account.created_at",
+	UpdatedAt:          "user_account.updated_at",
+	DeletedAt:          "user_account.deleted_at",
+	Name:               "user_account.name",
+	Email:              "user_account.email",
+	Password:           "user_account.password",
+	Permissions:        "user_account.permissions",
+	RevokedPermissions: "user_account.revoked_permissions",
+	TotpSecret:         "user_account.totp_secret",
+	TotpEnabledAt:      "user_account.totp_enabled_at",
+	EmailVerifiedAt:    "user_account.email_verified_at",
 }
 
 // Generated where
 
 var UserWhere = struct {
-	ID          whereHelperint64
-	CreatedAt   whereHelpertime_Time
-	UpdatedAt   whereHelpertime_Time
-	DeletedAt   whereHelpernull_Time
-	Name        whereHelperstring
-	Email       whereHelperstring
-	Password    whereHelperstring
-	Permissions whereHelpertypes_StringArray
+	ID                 whereHelperint64
+	CreatedAt          whereHelpertime_Time
+	UpdatedAt          whereHelpertime_Time
+	DeletedAt          whereHelpernull_Time
+	Name               whereHelperstring
+	Email              whereHelperstring
+	Password           whereHelperstring
+	Permissions        whereHelpertypes_StringArray
+	RevokedPermissions whereHelpertypes_StringArray
+	TotpSecret         whereHelpernull_String
+	TotpEnabledAt      whereHelpernull_Time
+	EmailVerifiedAt    whereHelpernull_Time
 }{
-	ID:          whereHelperint64{field: "\"user_account\".\"id\""},
-	CreatedAt:   whereHelpertime_Time{field: "\"user_account\".\"created_at\""},
-	UpdatedAt:   whereHelpertime_Time{field: "\"user_account\".\"updated_at\""},
-	DeletedAt:   whereHelpernull_Time{field: "\"user_account\".\"deleted_at\""},
-	Name:        whereHelperstring{field: "\"user_account\".\"name\""},
-	Email:       whereHelperstring{field: "\"user_account\".\"email\""},
-	Password:    whereHelperstring{field: "\"user_account\".\"password\""},
-	Permissions: whereHelpertypes_StringArray{field: "\"user_account\".\"permissions\""},
+	ID:                 whereHelperint64{field: "\"user_account\".\"id\""},
+	CreatedAt:          whereHelpertime_Time{field: "\"user_account\".\"created_at\""},
+	UpdatedAt:          whereHelpertime_Time{field: "\"user_account\".\"updated_at\""},
+	DeletedAt:          whereHelpernull_Time{field: "\"user_account\".\"deleted_at\""},
+	Name:               whereHelperstring{field: "\"user_account\".\"name\""},
+	Email:              whereHelperstring{field: "\"user_account\".\"email\""},
+	Password:           whereHelperstring{field: "\"user_account\".\"password\""},
+	Permissions:        whereHelpertypes_StringArray{field: "\"user_account\".\"permissions\""},
+	RevokedPermissions: whereHelpertypes_StringArray{field: "\"user_account\".\"revoked_permissions\""},
+	TotpSecret:         whereHelpernull_String{field: "\"user_account\".\"totp_secret\""},
+	TotpEnabledAt:      whereHelpernull_Time{field: "\"user_account\".\"totp_enabled_at\""},
+	EmailVerifiedAt:    whereHelpernull_Time{field: "\"user_account\".\"email_verified_at\""},
 }
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
-	Chapters string
+	AccessTokens           string
+	ActorAuditLogs         string
+	Chapters               string
+	UserChapterRevisions   string
+	Invites                string
+	UserJobs               string
+	LoginFailures          string
+	ProjectMembers         string
+	RecoveryCodes          string
+	ScanlationGroupMembers string
+	Identities             string
+	Roles                  string
 }{
-	Chapters: "Chapters",
+	AccessTokens:           "AccessTokens",
+	ActorAuditLogs:         "ActorAuditLogs",
+	Chapters:               "Chapters",
+	UserChapterRevisions:   "UserChapterRevisions",
+	Invites:                "Invites",
+	UserJobs:               "UserJobs",
+	LoginFailures:          "LoginFailures",
+	ProjectMembers:         "ProjectMembers",
+	RecoveryCodes:          "RecoveryCodes",
+	ScanlationGroupMembers: "ScanlationGroupMembers",
+	Identities:             "Identities",
+	Roles:                  "Roles",
 }
 
 // userAccountR is where relationships are stored.
 type userAccountR struct {
-	Chapters ChapterSlice `boil:"Chapters" json:"Chapters" toml:"Chapters" yaml:"Chapters"`
+	AccessTokens           AccessTokenSlice           `boil:"AccessTokens" json:"AccessTokens" toml:"AccessTokens" yaml:"AccessTokens"`
+	ActorAuditLogs         AuditLogSlice              `boil:"ActorAuditLogs" json:"ActorAuditLogs" toml:"ActorAuditLogs" yaml:"ActorAuditLogs"`
+	Chapters               ChapterSlice               `boil:"Chapters" json:"Chapters" toml:"Chapters" yaml:"Chapters"`
+	UserChapterRevisions   ChapterRevisionSlice       `boil:"UserChapterRevisions" json:"UserChapterRevisions" toml:"UserChapterRevisions" yaml:"UserChapterRevisions"`
+	Invites                InviteSlice                `boil:"Invites" json:"Invites" toml:"Invites" yaml:"Invites"`
+	UserJobs               JobSlice                   `boil:"UserJobs" json:"UserJobs" toml:"UserJobs" yaml:"UserJobs"`
+	LoginFailures          LoginFailureSlice          `boil:"LoginFailures" json:"LoginFailures" toml:"LoginFailures" yaml:"LoginFailures"`
+	ProjectMembers         ProjectMemberSlice         `boil:"ProjectMembers" json:"ProjectMembers" toml:"ProjectMembers" yaml:"ProjectMembers"`
+	RecoveryCodes          RecoveryCodeSlice          `boil:"RecoveryCodes" json:"RecoveryCodes" toml:"RecoveryCodes" yaml:"RecoveryCodes"`
+	ScanlationGroupMembers ScanlationGroupMemberSlice `boil:"ScanlationGroupMembers" json:"ScanlationGroupMembers" toml:"ScanlationGroupMembers" yaml:"ScanlationGroupMembers"`
+	Identities             UserIdentitySlice          `boil:"Identities" json:"Identities" toml:"Identities" yaml:"Identities"`
+	Roles                  RoleSlice                  `boil:"Roles" json:"Roles" toml:"Roles" yaml:"Roles"`
 }
 
 // NewStruct creates a new relationship struct
@@ -120,9 +181,9 @@ func (*userAccountR) NewStruct() *userAccountR {
 type userAccountL struct{}
 
 var (
-	userAccountAllColumns            = []string{"id", "created_at", "updated_at", "deleted_at", "name", "email", "password", "permissions"}
-	userAccountColumnsWithoutDefault = []string{"deleted_at", "password"}
-	userAccountColumnsWithDefault    = []string{"id", "created_at", "updated_at", "name", "email", "permissions"}
+	userAccountAllColumns            = []string{"id", "created_at", "updated_at", "deleted_at", "name", "email", "password", "permissions", "revoked_permissions", "totp_secret", "totp_enabled_at", "email_verified_at"}
+	userAccountColumnsWithoutDefault = []string{"deleted_at", "password", "totp_enabled_at"}
+	userAccountColumnsWithDefault    = []string{"id", "created_at", "updated_at", "name", "email", "permissions", "revoked_permissions", "totp_secret", "email_verified_at"}
 	userAccountPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -365,6 +426,48 @@ func (q userAccountQuery) Exists(exec boil.Executor) (bool, error) {
 	return count > 0, nil
 }
 
+// AccessTokens retrieves all the access_token's AccessTokens with an executor.
+func (o *User) AccessTokens(mods ...qm.QueryMod) accessTokenQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"access_token\".\"user_id\"=?", o.ID),
+	)
+
+	query := AccessTokens(queryMods...)
+	queries.SetFrom(query.Query, "\"access_token\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"access_token\".*"})
+	}
+
+	return query
+}
+
+// ActorAuditLogs retrieves all the audit_log's AuditLogs with an executor via actor_id column.
+func (o *User) ActorAuditLogs(mods ...qm.QueryMod) auditLogQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"audit_log\".\"actor_id\"=?", o.ID),
+	)
+
+	query := AuditLogs(queryMods...)
+	queries.SetFrom(query.Query, "\"audit_log\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"audit_log\".*"})
+	}
+
+	return query
+}
+
 // Chapters retrieves all the chapter's Chapters with an executor.
 func (o *User) Chapters(mods ...qm.QueryMod) chapterQuery {
 	var queryMods []qm.QueryMod
@@ -374,6 +477,7 @@ func (o *User) Chapters(mods ...qm.QueryMod) chapterQuery {
 
 	queryMods = append(queryMods,
 		qm.Where("\"chapter\".\"uploader_id\"=?", o.ID),
+		qmhelper.WhereIsNull("\"chapter\".\"deleted_at\""),
 	)
 
 	query := Chapters(queryMods...)
@@ -386,121 +490,2365 @@ func (o *User) Chapters(mods ...qm.QueryMod) chapterQuery {
 	return query
 }
 
-// LoadChapters allows an eager lookup of values, cached into the
+// UserChapterRevisions retrieves all the chapter_revision's ChapterRevisions with an executor via user_id column.
+func (o *User) UserChapterRevisions(mods ...qm.QueryMod) chapterRevisionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"chapter_revision\".\"user_id\"=?", o.ID),
+	)
+
+	query := ChapterRevisions(queryMods...)
+	queries.SetFrom(query.Query, "\"chapter_revision\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"chapter_revision\".*"})
+	}
+
+	return query
+}
+
+// Invites retrieves all the invite's Invites with an executor.
+func (o *User) Invites(mods ...qm.QueryMod) inviteQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"invite\".\"creator_id\"=?", o.ID),
+	)
+
+	query := Invites(queryMods...)
+	queries.SetFrom(query.Query, "\"invite\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"invite\".*"})
+	}
+
+	return query
+}
+
+// UserJobs retrieves all the job's Jobs with an executor via user_id column.
+func (o *User) UserJobs(mods ...qm.QueryMod) jobQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"job\".\"user_id\"=?", o.ID),
+	)
+
+	query := Jobs(queryMods...)
+	queries.SetFrom(query.Query, "\"job\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"job\".*"})
+	}
+
+	return query
+}
+
+// LoginFailures retrieves all the login_failure's LoginFailures with an executor.
+func (o *User) LoginFailures(mods ...qm.QueryMod) loginFailureQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"login_failure\".\"user_id\"=?", o.ID),
+	)
+
+	query := LoginFailures(queryMods...)
+	queries.SetFrom(query.Query, "\"login_failure\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"login_failure\".*"})
+	}
+
+	return query
+}
+
+// ProjectMembers retrieves all the project_member's ProjectMembers with an executor.
+func (o *User) ProjectMembers(mods ...qm.QueryMod) projectMemberQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"project_member\".\"user_id\"=?", o.ID),
+	)
+
+	query := ProjectMembers(queryMods...)
+	queries.SetFrom(query.Query, "\"project_member\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"project_member\".*"})
+	}
+
+	return query
+}
+
+// RecoveryCodes retrieves all the recovery_code's RecoveryCodes with an executor.
+func (o *User) RecoveryCodes(mods ...qm.QueryMod) recoveryCodeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"recovery_code\".\"user_id\"=?", o.ID),
+	)
+
+	query := RecoveryCodes(queryMods...)
+	queries.SetFrom(query.Query, "\"recovery_code\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"recovery_code\".*"})
+	}
+
+	return query
+}
+
+// ScanlationGroupMembers retrieves all the scanlation_group_member's ScanlationGroupMembers with an executor.
+func (o *User) ScanlationGroupMembers(mods ...qm.QueryMod) scanlationGroupMemberQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"scanlation_group_member\".\"user_id\"=?", o.ID),
+	)
+
+	query := ScanlationGroupMembers(queryMods...)
+	queries.SetFrom(query.Query, "\"scanlation_group_member\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"scanlation_group_member\".*"})
+	}
+
+	return query
+}
+
+// Identities retrieves all the user_identity's UserIdentities with an executor via user_id column.
+func (o *User) Identities(mods ...qm.QueryMod) userIdentityQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"user_identity\".\"user_id\"=?", o.ID),
+	)
+
+	query := UserIdentities(queryMods...)
+	queries.SetFrom(query.Query, "\"user_identity\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"user_identity\".*"})
+	}
+
+	return query
+}
+
+// Roles retrieves all the role's Roles with an executor.
+func (o *User) Roles(mods ...qm.QueryMod) roleQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"user_roles\" on \"role\".\"id\" = \"user_roles\".\"role_id\""),
+		qm.Where("\"user_roles\".\"user_id\"=?", o.ID),
+	)
+
+	query := Roles(queryMods...)
+	queries.SetFrom(query.Query, "\"role\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"role\".*"})
+	}
+
+	return query
+}
+
+// LoadAccessTokens allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userAccountL) LoadChapters(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userAccountL) LoadAccessTokens(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`access_token`),
+		qm.WhereIn(`access_token.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load access_token")
+	}
+
+	var resultSlice []*AccessToken
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice access_token")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on access_token")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for access_token")
+	}
+
+	if len(accessTokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.AccessTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &accessTokenR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.AccessTokens = append(local.R.AccessTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &accessTokenR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadActorAuditLogs allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadActorAuditLogs(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
-	if singular {
-		object = maybeUser.(*User)
-	} else {
-		slice = *maybeUser.(*[]*User)
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`audit_log`),
+		qm.WhereIn(`audit_log.actor_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load audit_log")
+	}
+
+	var resultSlice []*AuditLog
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice audit_log")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on audit_log")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for audit_log")
+	}
+
+	if len(auditLogAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ActorAuditLogs = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &auditLogR{}
+			}
+			foreign.R.Actor = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.ActorID) {
+				local.R.ActorAuditLogs = append(local.R.ActorAuditLogs, foreign)
+				if foreign.R == nil {
+					foreign.R = &auditLogR{}
+				}
+				foreign.R.Actor = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadChapters allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadChapters(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`chapter`),
+		qm.WhereIn(`chapter.uploader_id in ?`, args...),
+		qmhelper.WhereIsNull(`chapter.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load chapter")
+	}
+
+	var resultSlice []*Chapter
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice chapter")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on chapter")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for chapter")
+	}
+
+	if len(chapterAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Chapters = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &chapterR{}
+			}
+			foreign.R.Uploader = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.UploaderID) {
+				local.R.Chapters = append(local.R.Chapters, foreign)
+				if foreign.R == nil {
+					foreign.R = &chapterR{}
+				}
+				foreign.R.Uploader = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUserChapterRevisions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadUserChapterRevisions(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`chapter_revision`),
+		qm.WhereIn(`chapter_revision.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load chapter_revision")
+	}
+
+	var resultSlice []*ChapterRevision
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice chapter_revision")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on chapter_revision")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for chapter_revision")
+	}
+
+	if len(chapterRevisionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.UserChapterRevisions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &chapterRevisionR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.UserID) {
+				local.R.UserChapterRevisions = append(local.R.UserChapterRevisions, foreign)
+				if foreign.R == nil {
+					foreign.R = &chapterRevisionR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInvites allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadInvites(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`invite`),
+		qm.WhereIn(`invite.creator_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invite")
+	}
+
+	var resultSlice []*Invite
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invite")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invite")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invite")
+	}
+
+	if len(inviteAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Invites = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &inviteR{}
+			}
+			foreign.R.Creator = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.CreatorID) {
+				local.R.Invites = append(local.R.Invites, foreign)
+				if foreign.R == nil {
+					foreign.R = &inviteR{}
+				}
+				foreign.R.Creator = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUserJobs allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadUserJobs(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`job`),
+		qm.WhereIn(`job.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load job")
+	}
+
+	var resultSlice []*Job
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice job")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on job")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for job")
+	}
+
+	if len(jobAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.UserJobs = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &jobR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.UserID) {
+				local.R.UserJobs = append(local.R.UserJobs, foreign)
+				if foreign.R == nil {
+					foreign.R = &jobR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadLoginFailures allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadLoginFailures(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`login_failure`),
+		qm.WhereIn(`login_failure.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load login_failure")
+	}
+
+	var resultSlice []*LoginFailure
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice login_failure")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on login_failure")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for login_failure")
+	}
+
+	if len(loginFailureAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.LoginFailures = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &loginFailureR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.UserID) {
+				local.R.LoginFailures = append(local.R.LoginFailures, foreign)
+				if foreign.R == nil {
+					foreign.R = &loginFailureR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProjectMembers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadProjectMembers(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`project_member`),
+		qm.WhereIn(`project_member.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load project_member")
+	}
+
+	var resultSlice []*ProjectMember
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice project_member")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on project_member")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for project_member")
+	}
+
+	if len(projectMemberAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ProjectMembers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &projectMemberR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.ProjectMembers = append(local.R.ProjectMembers, foreign)
+				if foreign.R == nil {
+					foreign.R = &projectMemberR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRecoveryCodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadRecoveryCodes(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`recovery_code`),
+		qm.WhereIn(`recovery_code.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load recovery_code")
+	}
+
+	var resultSlice []*RecoveryCode
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice recovery_code")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on recovery_code")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for recovery_code")
+	}
+
+	if len(recoveryCodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.RecoveryCodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &recoveryCodeR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.RecoveryCodes = append(local.R.RecoveryCodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &recoveryCodeR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadScanlationGroupMembers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadScanlationGroupMembers(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`scanlation_group_member`),
+		qm.WhereIn(`scanlation_group_member.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load scanlation_group_member")
+	}
+
+	var resultSlice []*ScanlationGroupMember
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice scanlation_group_member")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on scanlation_group_member")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for scanlation_group_member")
+	}
+
+	if len(scanlationGroupMemberAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ScanlationGroupMembers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &scanlationGroupMemberR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.ScanlationGroupMembers = append(local.R.ScanlationGroupMembers, foreign)
+				if foreign.R == nil {
+					foreign.R = &scanlationGroupMemberR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadIdentities allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadIdentities(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_identity`),
+		qm.WhereIn(`user_identity.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_identity")
+	}
+
+	var resultSlice []*UserIdentity
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_identity")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_identity")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_identity")
+	}
+
+	if len(userIdentityAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Identities = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userIdentityR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Identities = append(local.R.Identities, foreign)
+				if foreign.R == nil {
+					foreign.R = &userIdentityR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRoles allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userAccountL) LoadRoles(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userAccountR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userAccountR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.Select("\"role\".id, \"role\".created_at, \"role\".updated_at, \"role\".name, \"role\".permissions, \"a\".\"user_id\""),
+		qm.From("\"role\""),
+		qm.InnerJoin("\"user_roles\" as \"a\" on \"role\".\"id\" = \"a\".\"role_id\""),
+		qm.WhereIn("\"a\".\"user_id\" in ?", args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load role")
+	}
+
+	var resultSlice []*Role
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(Role)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.CreatedAt, &one.UpdatedAt, &one.Name, &one.Permissions, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for role")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice role")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on role")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for role")
+	}
+
+	if len(roleAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Roles = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &roleR{}
+			}
+			foreign.R.Users = append(foreign.R.Users, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if local.ID == localJoinCol {
+				local.R.Roles = append(local.R.Roles, foreign)
+				if foreign.R == nil {
+					foreign.R = &roleR{}
+				}
+				foreign.R.Users = append(foreign.R.Users, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddAccessTokens adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.AccessTokens.
+// Sets related.R.User appropriately.
+func (o *User) AddAccessTokens(exec boil.Executor, insert bool, related ...*AccessToken) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"access_token\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, accessTokenPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userAccountR{
+			AccessTokens: related,
+		}
+	} else {
+		o.R.AccessTokens = append(o.R.AccessTokens, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &accessTokenR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddActorAuditLogs adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.ActorAuditLogs.
+// Sets related.R.Actor appropriately.
+func (o *User) AddActorAuditLogs(exec boil.Executor, insert bool, related ...*AuditLog) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.ActorID, o.ID)
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"audit_log\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"actor_id"}),
+				strmangle.WhereClause("\"", "\"", 2, auditLogPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.ActorID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userAccountR{
+			ActorAuditLogs: related,
+		}
+	} else {
+		o.R.ActorAuditLogs = append(o.R.ActorAuditLogs, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &auditLogR{
+				Actor: o,
+			}
+		} else {
+			rel.R.Actor = o
+		}
+	}
+	return nil
+}
+
+// SetActorAuditLogs removes all previously related items of the
+// user_account replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Actor's ActorAuditLogs accordingly.
+// Replaces o.R.ActorAuditLogs with related.
+// Sets related.R.Actor's ActorAuditLogs accordingly.
+func (o *User) SetActorAuditLogs(exec boil.Executor, insert bool, related ...*AuditLog) error {
+	query := "update \"audit_log\" set \"actor_id\" = null where \"actor_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.ActorAuditLogs {
+			queries.SetScanner(&rel.ActorID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Actor = nil
+		}
+
+		o.R.ActorAuditLogs = nil
+	}
+	return o.AddActorAuditLogs(exec, insert, related...)
+}
+
+// RemoveActorAuditLogs relationships from objects passed in.
+// Removes related items from R.ActorAuditLogs (uses pointer comparison, removal does not keep order)
+// Sets related.R.Actor.
+func (o *User) RemoveActorAuditLogs(exec boil.Executor, related ...*AuditLog) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.ActorID, nil)
+		if rel.R != nil {
+			rel.R.Actor = nil
+		}
+		if err = rel.Update(exec, boil.Whitelist("actor_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ActorAuditLogs {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.ActorAuditLogs)
+			if ln > 1 && i < ln-1 {
+				o.R.ActorAuditLogs[i] = o.R.ActorAuditLogs[ln-1]
+			}
+			o.R.ActorAuditLogs = o.R.ActorAuditLogs[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddChapters adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.Chapters.
+// Sets related.R.Uploader appropriately.
+func (o *User) AddChapters(exec boil.Executor, insert bool, related ...*Chapter) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UploaderID, o.ID)
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"chapter\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"uploader_id"}),
+				strmangle.WhereClause("\"", "\"", 2, chapterPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UploaderID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userAccountR{
+			Chapters: related,
+		}
+	} else {
+		o.R.Chapters = append(o.R.Chapters, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &chapterR{
+				Uploader: o,
+			}
+		} else {
+			rel.R.Uploader = o
+		}
+	}
+	return nil
+}
+
+// SetChapters removes all previously related items of the
+// user_account replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Uploader's Chapters accordingly.
+// Replaces o.R.Chapters with related.
+// Sets related.R.Uploader's Chapters accordingly.
+func (o *User) SetChapters(exec boil.Executor, insert bool, related ...*Chapter) error {
+	query := "update \"chapter\" set \"uploader_id\" = null where \"uploader_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Chapters {
+			queries.SetScanner(&rel.UploaderID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Uploader = nil
+		}
+
+		o.R.Chapters = nil
+	}
+	return o.AddChapters(exec, insert, related...)
+}
+
+// RemoveChapters relationships from objects passed in.
+// Removes related items from R.Chapters (uses pointer comparison, removal does not keep order)
+// Sets related.R.Uploader.
+func (o *User) RemoveChapters(exec boil.Executor, related ...*Chapter) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UploaderID, nil)
+		if rel.R != nil {
+			rel.R.Uploader = nil
+		}
+		if err = rel.Update(exec, boil.Whitelist("uploader_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Chapters {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Chapters)
+			if ln > 1 && i < ln-1 {
+				o.R.Chapters[i] = o.R.Chapters[ln-1]
+			}
+			o.R.Chapters = o.R.Chapters[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddUserChapterRevisions adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.UserChapterRevisions.
+// Sets related.R.User appropriately.
+func (o *User) AddUserChapterRevisions(exec boil.Executor, insert bool, related ...*ChapterRevision) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UserID, o.ID)
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"chapter_revision\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, chapterRevisionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UserID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userAccountR{
+			UserChapterRevisions: related,
+		}
+	} else {
+		o.R.UserChapterRevisions = append(o.R.UserChapterRevisions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &chapterRevisionR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// SetUserChapterRevisions removes all previously related items of the
+// user_account replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.User's UserChapterRevisions accordingly.
+// Replaces o.R.UserChapterRevisions with related.
+// Sets related.R.User's UserChapterRevisions accordingly.
+func (o *User) SetUserChapterRevisions(exec boil.Executor, insert bool, related ...*ChapterRevision) error {
+	query := "update \"chapter_revision\" set \"user_id\" = null where \"user_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.UserChapterRevisions {
+			queries.SetScanner(&rel.UserID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.User = nil
+		}
+
+		o.R.UserChapterRevisions = nil
+	}
+	return o.AddUserChapterRevisions(exec, insert, related...)
+}
+
+// RemoveUserChapterRevisions relationships from objects passed in.
+// Removes related items from R.UserChapterRevisions (uses pointer comparison, removal does not keep order)
+// Sets related.R.User.
+func (o *User) RemoveUserChapterRevisions(exec boil.Executor, related ...*ChapterRevision) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UserID, nil)
+		if rel.R != nil {
+			rel.R.User = nil
+		}
+		if err = rel.Update(exec, boil.Whitelist("user_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.UserChapterRevisions {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.UserChapterRevisions)
+			if ln > 1 && i < ln-1 {
+				o.R.UserChapterRevisions[i] = o.R.UserChapterRevisions[ln-1]
+			}
+			o.R.UserChapterRevisions = o.R.UserChapterRevisions[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddInvites adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.Invites.
+// Sets related.R.Creator appropriately.
+func (o *User) AddInvites(exec boil.Executor, insert bool, related ...*Invite) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.CreatorID, o.ID)
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"invite\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"creator_id"}),
+				strmangle.WhereClause("\"", "\"", 2, invitePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.CreatorID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userAccountR{
+			Invites: related,
+		}
+	} else {
+		o.R.Invites = append(o.R.Invites, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &inviteR{
+				Creator: o,
+			}
+		} else {
+			rel.R.Creator = o
+		}
+	}
+	return nil
+}
+
+// SetInvites removes all previously related items of the
+// user_account replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Creator's Invites accordingly.
+// Replaces o.R.Invites with related.
+// Sets related.R.Creator's Invites accordingly.
+func (o *User) SetInvites(exec boil.Executor, insert bool, related ...*Invite) error {
+	query := "update \"invite\" set \"creator_id\" = null where \"creator_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Invites {
+			queries.SetScanner(&rel.CreatorID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Creator = nil
+		}
+
+		o.R.Invites = nil
+	}
+	return o.AddInvites(exec, insert, related...)
+}
+
+// RemoveInvites relationships from objects passed in.
+// Removes related items from R.Invites (uses pointer comparison, removal does not keep order)
+// Sets related.R.Creator.
+func (o *User) RemoveInvites(exec boil.Executor, related ...*Invite) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.CreatorID, nil)
+		if rel.R != nil {
+			rel.R.Creator = nil
+		}
+		if err = rel.Update(exec, boil.Whitelist("creator_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Invites {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Invites)
+			if ln > 1 && i < ln-1 {
+				o.R.Invites[i] = o.R.Invites[ln-1]
+			}
+			o.R.Invites = o.R.Invites[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddUserJobs adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.UserJobs.
+// Sets related.R.User appropriately.
+func (o *User) AddUserJobs(exec boil.Executor, insert bool, related ...*Job) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UserID, o.ID)
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"job\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, jobPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UserID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userAccountR{
+			UserJobs: related,
+		}
+	} else {
+		o.R.UserJobs = append(o.R.UserJobs, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &jobR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// SetUserJobs removes all previously related items of the
+// user_account replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.User's UserJobs accordingly.
+// Replaces o.R.UserJobs with related.
+// Sets related.R.User's UserJobs accordingly.
+func (o *User) SetUserJobs(exec boil.Executor, insert bool, related ...*Job) error {
+	query := "update \"job\" set \"user_id\" = null where \"user_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.UserJobs {
+			queries.SetScanner(&rel.UserID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.User = nil
+		}
+
+		o.R.UserJobs = nil
+	}
+	return o.AddUserJobs(exec, insert, related...)
+}
+
+// RemoveUserJobs relationships from objects passed in.
+// Removes related items from R.UserJobs (uses pointer comparison, removal does not keep order)
+// Sets related.R.User.
+func (o *User) RemoveUserJobs(exec boil.Executor, related ...*Job) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UserID, nil)
+		if rel.R != nil {
+			rel.R.User = nil
+		}
+		if err = rel.Update(exec, boil.Whitelist("user_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.UserJobs {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.UserJobs)
+			if ln > 1 && i < ln-1 {
+				o.R.UserJobs[i] = o.R.UserJobs[ln-1]
+			}
+			o.R.UserJobs = o.R.UserJobs[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddLoginFailures adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.LoginFailures.
+// Sets related.R.User appropriately.
+func (o *User) AddLoginFailures(exec boil.Executor, insert bool, related ...*LoginFailure) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UserID, o.ID)
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"login_failure\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, loginFailurePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UserID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userAccountR{
+			LoginFailures: related,
+		}
+	} else {
+		o.R.LoginFailures = append(o.R.LoginFailures, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &loginFailureR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// SetLoginFailures removes all previously related items of the
+// user_account replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.User's LoginFailures accordingly.
+// Replaces o.R.LoginFailures with related.
+// Sets related.R.User's LoginFailures accordingly.
+func (o *User) SetLoginFailures(exec boil.Executor, insert bool, related ...*LoginFailure) error {
+	query := "update \"login_failure\" set \"user_id\" = null where \"user_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.LoginFailures {
+			queries.SetScanner(&rel.UserID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.User = nil
+		}
+
+		o.R.LoginFailures = nil
+	}
+	return o.AddLoginFailures(exec, insert, related...)
+}
+
+// RemoveLoginFailures relationships from objects passed in.
+// Removes related items from R.LoginFailures (uses pointer comparison, removal does not keep order)
+// Sets related.R.User.
+func (o *User) RemoveLoginFailures(exec boil.Executor, related ...*LoginFailure) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UserID, nil)
+		if rel.R != nil {
+			rel.R.User = nil
+		}
+		if err = rel.Update(exec, boil.Whitelist("user_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.LoginFailures {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.LoginFailures)
+			if ln > 1 && i < ln-1 {
+				o.R.LoginFailures[i] = o.R.LoginFailures[ln-1]
+			}
+			o.R.LoginFailures = o.R.LoginFailures[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddProjectMembers adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.ProjectMembers.
+// Sets related.R.User appropriately.
+func (o *User) AddProjectMembers(exec boil.Executor, insert bool, related ...*ProjectMember) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"project_member\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, projectMemberPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ProjectID, rel.UserID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
 	}
 
-	args := make([]interface{}, 0, 1)
-	if singular {
-		if object.R == nil {
-			object.R = &userAccountR{}
+	if o.R == nil {
+		o.R = &userAccountR{
+			ProjectMembers: related,
 		}
-		args = append(args, object.ID)
 	} else {
-	Outer:
-		for _, obj := range slice {
-			if obj.R == nil {
-				obj.R = &userAccountR{}
-			}
+		o.R.ProjectMembers = append(o.R.ProjectMembers, related...)
+	}
 
-			for _, a := range args {
-				if queries.Equal(a, obj.ID) {
-					continue Outer
-				}
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &projectMemberR{
+				User: o,
 			}
-
-			args = append(args, obj.ID)
+		} else {
+			rel.R.User = o
 		}
 	}
+	return nil
+}
 
-	if len(args) == 0 {
-		return nil
-	}
+// AddRecoveryCodes adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.RecoveryCodes.
+// Sets related.R.User appropriately.
+func (o *User) AddRecoveryCodes(exec boil.Executor, insert bool, related ...*RecoveryCode) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"recovery_code\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, recoveryCodePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
 
-	query := NewQuery(
-		qm.From(`chapter`),
-		qm.WhereIn(`chapter.uploader_id in ?`, args...),
-	)
-	if mods != nil {
-		mods.Apply(query)
-	}
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
 
-	results, err := query.Query(e)
-	if err != nil {
-		return errors.Wrap(err, "failed to eager load chapter")
+			rel.UserID = o.ID
+		}
 	}
 
-	var resultSlice []*Chapter
-	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice chapter")
+	if o.R == nil {
+		o.R = &userAccountR{
+			RecoveryCodes: related,
+		}
+	} else {
+		o.R.RecoveryCodes = append(o.R.RecoveryCodes, related...)
 	}
 
-	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on chapter")
-	}
-	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for chapter")
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &recoveryCodeR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
 	}
+	return nil
+}
 
-	if len(chapterAfterSelectHooks) != 0 {
-		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
-				return err
+// AddScanlationGroupMembers adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.ScanlationGroupMembers.
+// Sets related.R.User appropriately.
+func (o *User) AddScanlationGroupMembers(exec boil.Executor, insert bool, related ...*ScanlationGroupMember) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"scanlation_group_member\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, scanlationGroupMemberPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ScanlationGroupID, rel.UserID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
 			}
+
+			rel.UserID = o.ID
 		}
 	}
-	if singular {
-		object.R.Chapters = resultSlice
-		for _, foreign := range resultSlice {
-			if foreign.R == nil {
-				foreign.R = &chapterR{}
-			}
-			foreign.R.Uploader = object
+
+	if o.R == nil {
+		o.R = &userAccountR{
+			ScanlationGroupMembers: related,
 		}
-		return nil
+	} else {
+		o.R.ScanlationGroupMembers = append(o.R.ScanlationGroupMembers, related...)
 	}
 
-	for _, foreign := range resultSlice {
-		for _, local := range slice {
-			if queries.Equal(local.ID, foreign.UploaderID) {
-				local.R.Chapters = append(local.R.Chapters, foreign)
-				if foreign.R == nil {
-					foreign.R = &chapterR{}
-				}
-				foreign.R.Uploader = local
-				break
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &scanlationGroupMemberR{
+				User: o,
 			}
+		} else {
+			rel.R.User = o
 		}
 	}
-
 	return nil
 }
 
-// AddChapters adds the given related objects to the existing relationships
+// AddIdentities adds the given related objects to the existing relationships
 // of the user_account, optionally inserting them as new records.
-// Appends related to o.R.Chapters.
-// Sets related.R.Uploader appropriately.
-func (o *User) AddChapters(exec boil.Executor, insert bool, related ...*Chapter) error {
+// Appends related to o.R.Identities.
+// Sets related.R.User appropriately.
+func (o *User) AddIdentities(exec boil.Executor, insert bool, related ...*UserIdentity) error {
 	var err error
 	for _, rel := range related {
 		if insert {
-			queries.Assign(&rel.UploaderID, o.ID)
+			rel.UserID = o.ID
 			if err = rel.Insert(exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
 		} else {
 			updateQuery := fmt.Sprintf(
-				"UPDATE \"chapter\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"uploader_id"}),
-				strmangle.WhereClause("\"", "\"", 2, chapterPrimaryKeyColumns),
+				"UPDATE \"user_identity\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userIdentityPrimaryKeyColumns),
 			)
 			values := []interface{}{o.ID, rel.ID}
 
@@ -512,38 +2860,85 @@ func (o *User) AddChapters(exec boil.Executor, insert bool, related ...*Chapter)
 				return errors.Wrap(err, "failed to update foreign table")
 			}
 
-			queries.Assign(&rel.UploaderID, o.ID)
+			rel.UserID = o.ID
 		}
 	}
 
 	if o.R == nil {
 		o.R = &userAccountR{
-			Chapters: related,
+			Identities: related,
 		}
 	} else {
-		o.R.Chapters = append(o.R.Chapters, related...)
+		o.R.Identities = append(o.R.Identities, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
-			rel.R = &chapterR{
-				Uploader: o,
+			rel.R = &userIdentityR{
+				User: o,
 			}
 		} else {
-			rel.R.Uploader = o
+			rel.R.User = o
 		}
 	}
 	return nil
 }
 
-// SetChapters removes all previously related items of the
+// AddRoles adds the given related objects to the existing relationships
+// of the user_account, optionally inserting them as new records.
+// Appends related to o.R.Roles.
+// Sets related.R.Users appropriately.
+func (o *User) AddRoles(exec boil.Executor, insert bool, related ...*Role) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"user_roles\" (\"user_id\", \"role_id\") values ($1, $2)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, query)
+			fmt.Fprintln(boil.DebugWriter, values)
+		}
+		_, err = exec.Exec(query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &userAccountR{
+			Roles: related,
+		}
+	} else {
+		o.R.Roles = append(o.R.Roles, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &roleR{
+				Users: UserSlice{o},
+			}
+		} else {
+			rel.R.Users = append(rel.R.Users, o)
+		}
+	}
+	return nil
+}
+
+// SetRoles removes all previously related items of the
 // user_account replacing them completely with the passed
 // in related items, optionally inserting them as new records.
-// Sets o.R.Uploader's Chapters accordingly.
-// Replaces o.R.Chapters with related.
-// Sets related.R.Uploader's Chapters accordingly.
-func (o *User) SetChapters(exec boil.Executor, insert bool, related ...*Chapter) error {
-	query := "update \"chapter\" set \"uploader_id\" = null where \"uploader_id\" = $1"
+// Sets o.R.Users's Roles accordingly.
+// Replaces o.R.Roles with related.
+// Sets related.R.Users's Roles accordingly.
+func (o *User) SetRoles(exec boil.Executor, insert bool, related ...*Role) error {
+	query := "delete from \"user_roles\" where \"user_id\" = $1"
 	values := []interface{}{o.ID}
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, query)
@@ -554,54 +2949,55 @@ func (o *User) SetChapters(exec boil.Executor, insert bool, related ...*Chapter)
 		return errors.Wrap(err, "failed to remove relationships before set")
 	}
 
+	removeRolesFromUsersSlice(o, related)
 	if o.R != nil {
-		for _, rel := range o.R.Chapters {
-			queries.SetScanner(&rel.UploaderID, nil)
-			if rel.R == nil {
-				continue
-			}
-
-			rel.R.Uploader = nil
-		}
-
-		o.R.Chapters = nil
+		o.R.Roles = nil
 	}
-	return o.AddChapters(exec, insert, related...)
+	return o.AddRoles(exec, insert, related...)
 }
 
-// RemoveChapters relationships from objects passed in.
-// Removes related items from R.Chapters (uses pointer comparison, removal does not keep order)
-// Sets related.R.Uploader.
-func (o *User) RemoveChapters(exec boil.Executor, related ...*Chapter) error {
+// RemoveRoles relationships from objects passed in.
+// Removes related items from R.Roles (uses pointer comparison, removal does not keep order)
+// Sets related.R.Users.
+func (o *User) RemoveRoles(exec boil.Executor, related ...*Role) error {
 	if len(related) == 0 {
 		return nil
 	}
 
 	var err error
+	query := fmt.Sprintf(
+		"delete from \"user_roles\" where \"user_id\" = $1 and \"role_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
 	for _, rel := range related {
-		queries.SetScanner(&rel.UploaderID, nil)
-		if rel.R != nil {
-			rel.R.Uploader = nil
-		}
-		if err = rel.Update(exec, boil.Whitelist("uploader_id")); err != nil {
-			return err
-		}
+		values = append(values, rel.ID)
 	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeRolesFromUsersSlice(o, related)
 	if o.R == nil {
 		return nil
 	}
 
 	for _, rel := range related {
-		for i, ri := range o.R.Chapters {
+		for i, ri := range o.R.Roles {
 			if rel != ri {
 				continue
 			}
 
-			ln := len(o.R.Chapters)
+			ln := len(o.R.Roles)
 			if ln > 1 && i < ln-1 {
-				o.R.Chapters[i] = o.R.Chapters[ln-1]
+				o.R.Roles[i] = o.R.Roles[ln-1]
 			}
-			o.R.Chapters = o.R.Chapters[:ln-1]
+			o.R.Roles = o.R.Roles[:ln-1]
 			break
 		}
 	}
@@ -609,9 +3005,29 @@ func (o *User) RemoveChapters(exec boil.Executor, related ...*Chapter) error {
 	return nil
 }
 
+func removeRolesFromUsersSlice(o *User, related []*Role) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Users {
+			if o.ID != ri.ID {
+				continue
+			}
+
+			ln := len(rel.R.Users)
+			if ln > 1 && i < ln-1 {
+				rel.R.Users[i] = rel.R.Users[ln-1]
+			}
+			rel.R.Users = rel.R.Users[:ln-1]
+			break
+		}
+	}
+}
+
 // Users retrieves all the records using an executor.
 func Users(mods ...qm.QueryMod) userAccountQuery {
-	mods = append(mods, qm.From("\"user_account\""))
+	mods = append(mods, qm.From("\"user_account\""), qmhelper.WhereIsNull("\"user_account\".\"deleted_at\""))
 	return userAccountQuery{NewQuery(mods...)}
 }
 
@@ -625,7 +3041,7 @@ func FindUser(exec boil.Executor, iD int64, selectCols ...string) (*User, error)
 		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
 	}
 	query := fmt.Sprintf(
-		"select %s from \"user_account\" where \"id\"=$1", sel,
+		"select %s from \"user_account\" where \"id\"=$1 and \"deleted_at\" is null", sel,
 	)
 
 	q := queries.Raw(query, iD)
@@ -968,7 +3384,7 @@ func (o *User) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns
 
 // Delete deletes a single User record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *User) Delete(exec boil.Executor) error {
+func (o *User) Delete(exec boil.Executor, hardDelete bool) error {
 	if o == nil {
 		return errors.New("models: no User provided for delete")
 	}
@@ -977,8 +3393,26 @@ func (o *User) Delete(exec boil.Executor) error {
 		return err
 	}
 
-	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), userAccountPrimaryKeyMapping)
-	sql := "DELETE FROM \"user_account\" WHERE \"id\"=$1"
+	var (
+		sql  string
+		args []interface{}
+	)
+	if hardDelete {
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), userAccountPrimaryKeyMapping)
+		sql = "DELETE FROM \"user_account\" WHERE \"id\"=$1"
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		o.DeletedAt = null.TimeFrom(currTime)
+		wl := []string{"deleted_at"}
+		sql = fmt.Sprintf("UPDATE \"user_account\" SET %s WHERE \"id\"=$2",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+		)
+		valueMapping, err := queries.BindMapping(userAccountType, userAccountMapping, append(wl, userAccountPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), valueMapping)
+	}
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
@@ -997,12 +3431,17 @@ func (o *User) Delete(exec boil.Executor) error {
 }
 
 // DeleteAll deletes all matching rows.
-func (q userAccountQuery) DeleteAll(exec boil.Executor) error {
+func (q userAccountQuery) DeleteAll(exec boil.Executor, hardDelete bool) error {
 	if q.Query == nil {
 		return errors.New("models: no userAccountQuery provided for delete all")
 	}
 
-	queries.SetDelete(q.Query)
+	if hardDelete {
+		queries.SetDelete(q.Query)
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		queries.SetUpdate(q.Query, M{"deleted_at": currTime})
+	}
 
 	_, err := q.Query.Exec(exec)
 	if err != nil {
@@ -1013,7 +3452,7 @@ func (q userAccountQuery) DeleteAll(exec boil.Executor) error {
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o UserSlice) DeleteAll(exec boil.Executor) error {
+func (o UserSlice) DeleteAll(exec boil.Executor, hardDelete bool) error {
 	if len(o) == 0 {
 		return nil
 	}
@@ -1026,15 +3465,32 @@ func (o UserSlice) DeleteAll(exec boil.Executor) error {
 		}
 	}
 
-	var args []interface{}
-	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userAccountPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
+	var (
+		sql  string
+		args []interface{}
+	)
+	if hardDelete {
+		for _, obj := range o {
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userAccountPrimaryKeyMapping)
+			args = append(args, pkeyArgs...)
+		}
+		sql = "DELETE FROM \"user_account\" WHERE " +
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userAccountPrimaryKeyColumns, len(o))
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		for _, obj := range o {
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userAccountPrimaryKeyMapping)
+			args = append(args, pkeyArgs...)
+			obj.DeletedAt = null.TimeFrom(currTime)
+		}
+		wl := []string{"deleted_at"}
+		sql = fmt.Sprintf("UPDATE \"user_account\" SET %s WHERE "+
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 2, userAccountPrimaryKeyColumns, len(o)),
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+		)
+		args = append([]interface{}{currTime}, args...)
 	}
 
-	sql := "DELETE FROM \"user_account\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userAccountPrimaryKeyColumns, len(o))
-
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
 		fmt.Fprintln(boil.DebugWriter, args)
@@ -1082,7 +3538,8 @@ func (o *UserSlice) ReloadAll(exec boil.Executor) error {
 	}
 
 	sql := "SELECT \"user_account\".* FROM \"user_account\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userAccountPrimaryKeyColumns, len(*o))
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userAccountPrimaryKeyColumns, len(*o)) +
+		"and \"deleted_at\" is null"
 
 	q := queries.Raw(sql, args...)
 
@@ -1099,7 +3556,7 @@ func (o *UserSlice) ReloadAll(exec boil.Executor) error {
 // UserExists checks if the User row exists.
 func UserExists(exec boil.Executor, iD int64) (bool, error) {
 	var exists bool
-	sql := "select exists(select 1 from \"user_account\" where \"id\"=$1 limit 1)"
+	sql := "select exists(select 1 from \"user_account\" where \"id\"=$1 and \"deleted_at\" is null limit 1)"
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
@@ -0,0 +1,1022 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// UserIdentity is an object representing the database table.
+type UserIdentity struct {
+	ID          int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt   time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UserID      int64       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Issuer      string      `boil:"issuer" json:"issuer" toml:"issuer" yaml:"issuer"`
+	Subject     string      `boil:"subject" json:"subject" toml:"subject" yaml:"subject"`
+	Email       null.String `boil:"email" json:"email,omitempty" toml:"email" yaml:"email,omitempty"`
+	LastLoginAt null.Time   `boil:"last_login_at" json:"last_login_at,omitempty" toml:"last_login_at" yaml:"last_login_at,omitempty"`
+
+	R *userIdentityR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L userIdentityL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var UserIdentityColumns = struct {
+	ID          string
+	CreatedAt   string
+	UserID      string
+	Issuer      string
+	Subject     string
+	Email       string
+	LastLoginAt string
+}{
+	ID:          "id",
+	CreatedAt:   "created_at",
+	UserID:      "user_id",
+	Issuer:      "issuer",
+	Subject:     "subject",
+	Email:       "email",
+	LastLoginAt: "last_login_at",
+}
+
+var UserIdentityTableColumns = struct {
+	ID          string
+	CreatedAt   string
+	UserID      string
+	Issuer      string
+	Subject     string
+	Email       string
+	LastLoginAt string
+}{
+	ID:          "user_identity.id",
+	CreatedAt:   "user_identity.created_at",
+	UserID:      "user_identity.user_id",
+	Issuer:      "user_identity.issuer",
+	Subject:     "user_identity.subject",
+	Email:       "user_identity.email",
+	LastLoginAt: "user_identity.last_login_at",
+}
+
+// Generated where
+
+var UserIdentityWhere = struct {
+	ID          whereHelperint64
+	CreatedAt   whereHelpertime_Time
+	UserID      whereHelperint64
+	Issuer      whereHelperstring
+	Subject     whereHelperstring
+	Email       whereHelpernull_String
+	LastLoginAt whereHelpernull_Time
+}{
+	ID:          whereHelperint64{field: "\"user_identity\".\"id\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"user_identity\".\"created_at\""},
+	UserID:      whereHelperint64{field: "\"user_identity\".\"user_id\""},
+	Issuer:      whereHelperstring{field: "\"user_identity\".\"issuer\""},
+	Subject:     whereHelperstring{field: "\"user_identity\".\"subject\""},
+	Email:       whereHelpernull_String{field: "\"user_identity\".\"email\""},
+	LastLoginAt: whereHelpernull_Time{field: "\"user_identity\".\"last_login_at\""},
+}
+
+// UserIdentityRels is where relationship names are stored.
+var UserIdentityRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// userIdentityR is where relationships are stored.
+type userIdentityR struct {
+	User *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*userIdentityR) NewStruct() *userIdentityR {
+	return &userIdentityR{}
+}
+
+// userIdentityL is where Load methods for each relationship are stored.
+type userIdentityL struct{}
+
+var (
+	userIdentityAllColumns            = []string{"id", "created_at", "user_id", "issuer", "subject", "email", "last_login_at"}
+	userIdentityColumnsWithoutDefault = []string{"user_id", "last_login_at"}
+	userIdentityColumnsWithDefault    = []string{"id", "created_at", "issuer", "subject", "email"}
+	userIdentityPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// UserIdentitySlice is an alias for a slice of pointers to UserIdentity.
+	// This should almost always be used instead of []UserIdentity.
+	UserIdentitySlice []*UserIdentity
+	// UserIdentityHook is the signature for custom UserIdentity hook methods
+	UserIdentityHook func(boil.Executor, *UserIdentity) error
+
+	userIdentityQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	userIdentityType                 = reflect.TypeOf(&UserIdentity{})
+	userIdentityMapping              = queries.MakeStructMapping(userIdentityType)
+	userIdentityPrimaryKeyMapping, _ = queries.BindMapping(userIdentityType, userIdentityMapping, userIdentityPrimaryKeyColumns)
+	userIdentityInsertCacheMut       sync.RWMutex
+	userIdentityInsertCache          = make(map[string]insertCache)
+	userIdentityUpdateCacheMut       sync.RWMutex
+	userIdentityUpdateCache          = make(map[string]updateCache)
+	userIdentityUpsertCacheMut       sync.RWMutex
+	userIdentityUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var userIdentityBeforeInsertHooks []UserIdentityHook
+var userIdentityBeforeUpdateHooks []UserIdentityHook
+var userIdentityBeforeDeleteHooks []UserIdentityHook
+var userIdentityBeforeUpsertHooks []UserIdentityHook
+
+var userIdentityAfterInsertHooks []UserIdentityHook
+var userIdentityAfterSelectHooks []UserIdentityHook
+var userIdentityAfterUpdateHooks []UserIdentityHook
+var userIdentityAfterDeleteHooks []UserIdentityHook
+var userIdentityAfterUpsertHooks []UserIdentityHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *UserIdentity) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range userIdentityBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *UserIdentity) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range userIdentityBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *UserIdentity) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range userIdentityBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *UserIdentity) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range userIdentityBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *UserIdentity) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range userIdentityAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *UserIdentity) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range userIdentityAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *UserIdentity) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range userIdentityAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *UserIdentity) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range userIdentityAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *UserIdentity) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range userIdentityAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddUserIdentityHook registers your hook function for all future operations.
+func AddUserIdentityHook(hookPoint boil.HookPoint, userIdentityHook UserIdentityHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		userIdentityBeforeInsertHooks = append(userIdentityBeforeInsertHooks, userIdentityHook)
+	case boil.BeforeUpdateHook:
+		userIdentityBeforeUpdateHooks = append(userIdentityBeforeUpdateHooks, userIdentityHook)
+	case boil.BeforeDeleteHook:
+		userIdentityBeforeDeleteHooks = append(userIdentityBeforeDeleteHooks, userIdentityHook)
+	case boil.BeforeUpsertHook:
+		userIdentityBeforeUpsertHooks = append(userIdentityBeforeUpsertHooks, userIdentityHook)
+	case boil.AfterInsertHook:
+		userIdentityAfterInsertHooks = append(userIdentityAfterInsertHooks, userIdentityHook)
+	case boil.AfterSelectHook:
+		userIdentityAfterSelectHooks = append(userIdentityAfterSelectHooks, userIdentityHook)
+	case boil.AfterUpdateHook:
+		userIdentityAfterUpdateHooks = append(userIdentityAfterUpdateHooks, userIdentityHook)
+	case boil.AfterDeleteHook:
+		userIdentityAfterDeleteHooks = append(userIdentityAfterDeleteHooks, userIdentityHook)
+	case boil.AfterUpsertHook:
+		userIdentityAfterUpsertHooks = append(userIdentityAfterUpsertHooks, userIdentityHook)
+	}
+}
+
+// One returns a single userIdentity record from the query.
+func (q userIdentityQuery) One(exec boil.Executor) (*UserIdentity, error) {
+	o := &UserIdentity{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for user_identity")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all UserIdentity records from the query.
+func (q userIdentityQuery) All(exec boil.Executor) (UserIdentitySlice, error) {
+	var o []*UserIdentity
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to UserIdentity slice")
+	}
+
+	if len(userIdentityAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all UserIdentity records in the query.
+func (q userIdentityQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count user_identity rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q userIdentityQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if user_identity exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *UserIdentity) User(mods ...qm.QueryMod) userAccountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"user_account\"")
+
+	return query
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (userIdentityL) LoadUser(e boil.Executor, singular bool, maybeUserIdentity interface{}, mods queries.Applicator) error {
+	var slice []*UserIdentity
+	var object *UserIdentity
+
+	if singular {
+		object = maybeUserIdentity.(*UserIdentity)
+	} else {
+		slice = *maybeUserIdentity.(*[]*UserIdentity)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userIdentityR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userIdentityR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_account`),
+		qm.WhereIn(`user_account.id in ?`, args...),
+		qmhelper.WhereIsNull(`user_account.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_account")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_account")
+	}
+
+	if len(userIdentityAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userAccountR{}
+		}
+		foreign.R.Identities = append(foreign.R.Identities, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userAccountR{}
+				}
+				foreign.R.Identities = append(foreign.R.Identities, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the userIdentity to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.Identities.
+func (o *UserIdentity) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"user_identity\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, userIdentityPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &userIdentityR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userAccountR{
+			Identities: UserIdentitySlice{o},
+		}
+	} else {
+		related.R.Identities = append(related.R.Identities, o)
+	}
+
+	return nil
+}
+
+// UserIdentities retrieves all the records using an executor.
+func UserIdentities(mods ...qm.QueryMod) userIdentityQuery {
+	mods = append(mods, qm.From("\"user_identity\""))
+	return userIdentityQuery{NewQuery(mods...)}
+}
+
+// FindUserIdentity retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindUserIdentity(exec boil.Executor, iD int64, selectCols ...string) (*UserIdentity, error) {
+	userIdentityObj := &UserIdentity{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"user_identity\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, userIdentityObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from user_identity")
+	}
+
+	if err = userIdentityObj.doAfterSelectHooks(exec); err != nil {
+		return userIdentityObj, err
+	}
+
+	return userIdentityObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *UserIdentity) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no user_identity provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(userIdentityColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	userIdentityInsertCacheMut.RLock()
+	cache, cached := userIdentityInsertCache[key]
+	userIdentityInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			userIdentityAllColumns,
+			userIdentityColumnsWithDefault,
+			userIdentityColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(userIdentityType, userIdentityMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(userIdentityType, userIdentityMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"user_identity\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"user_identity\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into user_identity")
+	}
+
+	if !cached {
+		userIdentityInsertCacheMut.Lock()
+		userIdentityInsertCache[key] = cache
+		userIdentityInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the UserIdentity.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *UserIdentity) Update(exec boil.Executor, columns boil.Columns) error {
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	userIdentityUpdateCacheMut.RLock()
+	cache, cached := userIdentityUpdateCache[key]
+	userIdentityUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			userIdentityAllColumns,
+			userIdentityPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update user_identity, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"user_identity\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, userIdentityPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(userIdentityType, userIdentityMapping, append(wl, userIdentityPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update user_identity row")
+	}
+
+	if !cached {
+		userIdentityUpdateCacheMut.Lock()
+		userIdentityUpdateCache[key] = cache
+		userIdentityUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q userIdentityQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for user_identity")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o UserIdentitySlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userIdentityPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"user_identity\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, userIdentityPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in userIdentity slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *UserIdentity) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no user_identity provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(userIdentityColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	userIdentityUpsertCacheMut.RLock()
+	cache, cached := userIdentityUpsertCache[key]
+	userIdentityUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			userIdentityAllColumns,
+			userIdentityColumnsWithDefault,
+			userIdentityColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			userIdentityAllColumns,
+			userIdentityPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert user_identity, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(userIdentityPrimaryKeyColumns))
+			copy(conflict, userIdentityPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"user_identity\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(userIdentityType, userIdentityMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(userIdentityType, userIdentityMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert user_identity")
+	}
+
+	if !cached {
+		userIdentityUpsertCacheMut.Lock()
+		userIdentityUpsertCache[key] = cache
+		userIdentityUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single UserIdentity record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *UserIdentity) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no UserIdentity provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), userIdentityPrimaryKeyMapping)
+	sql := "DELETE FROM \"user_identity\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from user_identity")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q userIdentityQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no userIdentityQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from user_identity")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o UserIdentitySlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(userIdentityBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userIdentityPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"user_identity\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userIdentityPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from userIdentity slice")
+	}
+
+	if len(userIdentityAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *UserIdentity) Reload(exec boil.Executor) error {
+	ret, err := FindUserIdentity(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *UserIdentitySlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := UserIdentitySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userIdentityPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"user_identity\".* FROM \"user_identity\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userIdentityPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in UserIdentitySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// UserIdentityExists checks if the UserIdentity row exists.
+func UserIdentityExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"user_identity\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if user_identity exists")
+	}
+
+	return exists, nil
+}
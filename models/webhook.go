@@ -0,0 +1,1052 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// Webhook is an object representing the database table.
+type Webhook struct {
+	ID          int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt   time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt   time.Time         `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	URL         string            `boil:"url" json:"url" toml:"url" yaml:"url"`
+	Secret      string            `boil:"secret" json:"secret" toml:"secret" yaml:"secret"`
+	Events      types.StringArray `boil:"events" json:"events" toml:"events" yaml:"events"`
+	Active      bool              `boil:"active" json:"active" toml:"active" yaml:"active"`
+	Description null.String       `boil:"description" json:"description,omitempty" toml:"description" yaml:"description,omitempty"`
+
+	R *webhookR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L webhookL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var WebhookColumns = struct {
+	ID          string
+	CreatedAt   string
+	UpdatedAt   string
+	URL         string
+	Secret      string
+	Events      string
+	Active      string
+	Description string
+}{
+	ID:          "id",
+	CreatedAt:   "created_at",
+	UpdatedAt:   "updated_at",
+	URL:         "url",
+	Secret:      "secret",
+	Events:      "events",
+	Active:      "active",
+	Description: "description",
+}
+
+var WebhookTableColumns = struct {
+	ID          string
+	CreatedAt   string
+	UpdatedAt   string
+	URL         string
+	Secret      string
+	Events      string
+	Active      string
+	Description string
+}{
+	ID:          "webhook.id",
+	CreatedAt:   "webhook.created_at",
+	UpdatedAt:   "webhook.updated_at",
+	URL:         "webhook.url",
+	Secret:      "webhook.secret",
+	Events:      "webhook.events",
+	Active:      "webhook.active",
+	Description: "webhook.description",
+}
+
+// Generated where
+
+type whereHelperbool struct{ field string }
+
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+var WebhookWhere = struct {
+	ID          whereHelperint64
+	CreatedAt   whereHelpertime_Time
+	UpdatedAt   whereHelpertime_Time
+	URL         whereHelperstring
+	Secret      whereHelperstring
+	Events      whereHelpertypes_StringArray
+	Active      whereHelperbool
+	Description whereHelpernull_String
+}{
+	ID:          whereHelperint64{field: "\"webhook\".\"id\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"webhook\".\"created_at\""},
+	UpdatedAt:   whereHelpertime_Time{field: "\"webhook\".\"updated_at\""},
+	URL:         whereHelperstring{field: "\"webhook\".\"url\""},
+	Secret:      whereHelperstring{field: "\"webhook\".\"secret\""},
+	Events:      whereHelpertypes_StringArray{field: "\"webhook\".\"events\""},
+	Active:      whereHelperbool{field: "\"webhook\".\"active\""},
+	Description: whereHelpernull_String{field: "\"webhook\".\"description\""},
+}
+
+// WebhookRels is where relationship names are stored.
+var WebhookRels = struct {
+	WebhookDeliveries string
+}{
+	WebhookDeliveries: "WebhookDeliveries",
+}
+
+// webhookR is where relationships are stored.
+type webhookR struct {
+	WebhookDeliveries WebhookDeliverySlice `boil:"WebhookDeliveries" json:"WebhookDeliveries" toml:"WebhookDeliveries" yaml:"WebhookDeliveries"`
+}
+
+// NewStruct creates a new relationship struct
+func (*webhookR) NewStruct() *webhookR {
+	return &webhookR{}
+}
+
+// webhookL is where Load methods for each relationship are stored.
+type webhookL struct{}
+
+var (
+	webhookAllColumns            = []string{"id", "created_at", "updated_at", "url", "secret", "events", "active", "description"}
+	webhookColumnsWithoutDefault = []string{}
+	webhookColumnsWithDefault    = []string{"id", "created_at", "updated_at", "url", "secret", "events", "active", "description"}
+	webhookPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// WebhookSlice is an alias for a slice of pointers to Webhook.
+	// This should almost always be used instead of []Webhook.
+	WebhookSlice []*Webhook
+	// WebhookHook is the signature for custom Webhook hook methods
+	WebhookHook func(boil.Executor, *Webhook) error
+
+	webhookQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	webhookType                 = reflect.TypeOf(&Webhook{})
+	webhookMapping              = queries.MakeStructMapping(webhookType)
+	webhookPrimaryKeyMapping, _ = queries.BindMapping(webhookType, webhookMapping, webhookPrimaryKeyColumns)
+	webhookInsertCacheMut       sync.RWMutex
+	webhookInsertCache          = make(map[string]insertCache)
+	webhookUpdateCacheMut       sync.RWMutex
+	webhookUpdateCache          = make(map[string]updateCache)
+	webhookUpsertCacheMut       sync.RWMutex
+	webhookUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var webhookBeforeInsertHooks []WebhookHook
+var webhookBeforeUpdateHooks []WebhookHook
+var webhookBeforeDeleteHooks []WebhookHook
+var webhookBeforeUpsertHooks []WebhookHook
+
+var webhookAfterInsertHooks []WebhookHook
+var webhookAfterSelectHooks []WebhookHook
+var webhookAfterUpdateHooks []WebhookHook
+var webhookAfterDeleteHooks []WebhookHook
+var webhookAfterUpsertHooks []WebhookHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Webhook) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Webhook) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Webhook) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Webhook) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Webhook) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Webhook) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Webhook) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Webhook) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Webhook) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddWebhookHook registers your hook function for all future operations.
+func AddWebhookHook(hookPoint boil.HookPoint, webhookHook WebhookHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		webhookBeforeInsertHooks = append(webhookBeforeInsertHooks, webhookHook)
+	case boil.BeforeUpdateHook:
+		webhookBeforeUpdateHooks = append(webhookBeforeUpdateHooks, webhookHook)
+	case boil.BeforeDeleteHook:
+		webhookBeforeDeleteHooks = append(webhookBeforeDeleteHooks, webhookHook)
+	case boil.BeforeUpsertHook:
+		webhookBeforeUpsertHooks = append(webhookBeforeUpsertHooks, webhookHook)
+	case boil.AfterInsertHook:
+		webhookAfterInsertHooks = append(webhookAfterInsertHooks, webhookHook)
+	case boil.AfterSelectHook:
+		webhookAfterSelectHooks = append(webhookAfterSelectHooks, webhookHook)
+	case boil.AfterUpdateHook:
+		webhookAfterUpdateHooks = append(webhookAfterUpdateHooks, webhookHook)
+	case boil.AfterDeleteHook:
+		webhookAfterDeleteHooks = append(webhookAfterDeleteHooks, webhookHook)
+	case boil.AfterUpsertHook:
+		webhookAfterUpsertHooks = append(webhookAfterUpsertHooks, webhookHook)
+	}
+}
+
+// One returns a single webhook record from the query.
+func (q webhookQuery) One(exec boil.Executor) (*Webhook, error) {
+	o := &Webhook{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for webhook")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Webhook records from the query.
+func (q webhookQuery) All(exec boil.Executor) (WebhookSlice, error) {
+	var o []*Webhook
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Webhook slice")
+	}
+
+	if len(webhookAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Webhook records in the query.
+func (q webhookQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count webhook rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q webhookQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if webhook exists")
+	}
+
+	return count > 0, nil
+}
+
+// WebhookDeliveries retrieves all the webhook_delivery's WebhookDeliveries with an executor.
+func (o *Webhook) WebhookDeliveries(mods ...qm.QueryMod) webhookDeliveryQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"webhook_delivery\".\"webhook_id\"=?", o.ID),
+	)
+
+	query := WebhookDeliveries(queryMods...)
+	queries.SetFrom(query.Query, "\"webhook_delivery\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"webhook_delivery\".*"})
+	}
+
+	return query
+}
+
+// LoadWebhookDeliveries allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (webhookL) LoadWebhookDeliveries(e boil.Executor, singular bool, maybeWebhook interface{}, mods queries.Applicator) error {
+	var slice []*Webhook
+	var object *Webhook
+
+	if singular {
+		object = maybeWebhook.(*Webhook)
+	} else {
+		slice = *maybeWebhook.(*[]*Webhook)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &webhookR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &webhookR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`webhook_delivery`),
+		qm.WhereIn(`webhook_delivery.webhook_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load webhook_delivery")
+	}
+
+	var resultSlice []*WebhookDelivery
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice webhook_delivery")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on webhook_delivery")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for webhook_delivery")
+	}
+
+	if len(webhookDeliveryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.WebhookDeliveries = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &webhookDeliveryR{}
+			}
+			foreign.R.Webhook = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.WebhookID {
+				local.R.WebhookDeliveries = append(local.R.WebhookDeliveries, foreign)
+				if foreign.R == nil {
+					foreign.R = &webhookDeliveryR{}
+				}
+				foreign.R.Webhook = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddWebhookDeliveries adds the given related objects to the existing relationships
+// of the webhook, optionally inserting them as new records.
+// Appends related to o.R.WebhookDeliveries.
+// Sets related.R.Webhook appropriately.
+func (o *Webhook) AddWebhookDeliveries(exec boil.Executor, insert bool, related ...*WebhookDelivery) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.WebhookID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"webhook_delivery\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"webhook_id"}),
+				strmangle.WhereClause("\"", "\"", 2, webhookDeliveryPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.WebhookID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &webhookR{
+			WebhookDeliveries: related,
+		}
+	} else {
+		o.R.WebhookDeliveries = append(o.R.WebhookDeliveries, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &webhookDeliveryR{
+				Webhook: o,
+			}
+		} else {
+			rel.R.Webhook = o
+		}
+	}
+	return nil
+}
+
+// Webhooks retrieves all the records using an executor.
+func Webhooks(mods ...qm.QueryMod) webhookQuery {
+	mods = append(mods, qm.From("\"webhook\""))
+	return webhookQuery{NewQuery(mods...)}
+}
+
+// FindWebhook retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindWebhook(exec boil.Executor, iD int64, selectCols ...string) (*Webhook, error) {
+	webhookObj := &Webhook{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"webhook\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, webhookObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from webhook")
+	}
+
+	if err = webhookObj.doAfterSelectHooks(exec); err != nil {
+		return webhookObj, err
+	}
+
+	return webhookObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Webhook) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no webhook provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(webhookColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	webhookInsertCacheMut.RLock()
+	cache, cached := webhookInsertCache[key]
+	webhookInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			webhookAllColumns,
+			webhookColumnsWithDefault,
+			webhookColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(webhookType, webhookMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(webhookType, webhookMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"webhook\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"webhook\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into webhook")
+	}
+
+	if !cached {
+		webhookInsertCacheMut.Lock()
+		webhookInsertCache[key] = cache
+		webhookInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the Webhook.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Webhook) Update(exec boil.Executor, columns boil.Columns) error {
+	currTime := time.Now().In(boil.GetLocation())
+
+	o.UpdatedAt = currTime
+
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	webhookUpdateCacheMut.RLock()
+	cache, cached := webhookUpdateCache[key]
+	webhookUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			webhookAllColumns,
+			webhookPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update webhook, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"webhook\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, webhookPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(webhookType, webhookMapping, append(wl, webhookPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update webhook row")
+	}
+
+	if !cached {
+		webhookUpdateCacheMut.Lock()
+		webhookUpdateCache[key] = cache
+		webhookUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q webhookQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for webhook")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o WebhookSlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"webhook\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, webhookPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in webhook slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Webhook) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no webhook provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	o.UpdatedAt = currTime
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(webhookColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	webhookUpsertCacheMut.RLock()
+	cache, cached := webhookUpsertCache[key]
+	webhookUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			webhookAllColumns,
+			webhookColumnsWithDefault,
+			webhookColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			webhookAllColumns,
+			webhookPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert webhook, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(webhookPrimaryKeyColumns))
+			copy(conflict, webhookPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"webhook\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(webhookType, webhookMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(webhookType, webhookMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert webhook")
+	}
+
+	if !cached {
+		webhookUpsertCacheMut.Lock()
+		webhookUpsertCache[key] = cache
+		webhookUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single Webhook record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Webhook) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no Webhook provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), webhookPrimaryKeyMapping)
+	sql := "DELETE FROM \"webhook\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from webhook")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q webhookQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no webhookQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from webhook")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o WebhookSlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(webhookBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"webhook\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, webhookPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from webhook slice")
+	}
+
+	if len(webhookAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Webhook) Reload(exec boil.Executor) error {
+	ret, err := FindWebhook(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *WebhookSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := WebhookSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"webhook\".* FROM \"webhook\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, webhookPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in WebhookSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// WebhookExists checks if the Webhook row exists.
+func WebhookExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"webhook\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if webhook exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1088 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// WebhookDelivery is an object representing the database table.
+type WebhookDelivery struct {
+	ID             int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt      time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	WebhookID      int64       `boil:"webhook_id" json:"webhook_id" toml:"webhook_id" yaml:"webhook_id"`
+	Event          string      `boil:"event" json:"event" toml:"event" yaml:"event"`
+	Payload        types.JSON  `boil:"payload" json:"payload" toml:"payload" yaml:"payload"`
+	Status         string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Attempts       int         `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
+	ResponseStatus null.Int    `boil:"response_status" json:"response_status,omitempty" toml:"response_status" yaml:"response_status,omitempty"`
+	ResponseBody   null.String `boil:"response_body" json:"response_body,omitempty" toml:"response_body" yaml:"response_body,omitempty"`
+	Error          null.String `boil:"error" json:"error,omitempty" toml:"error" yaml:"error,omitempty"`
+	DeliveredAt    null.Time   `boil:"delivered_at" json:"delivered_at,omitempty" toml:"delivered_at" yaml:"delivered_at,omitempty"`
+
+	R *webhookDeliveryR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L webhookDeliveryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var WebhookDeliveryColumns = struct {
+	ID             string
+	CreatedAt      string
+	UpdatedAt      string
+	WebhookID      string
+	Event          string
+	Payload        string
+	Status         string
+	Attempts       string
+	ResponseStatus string
+	ResponseBody   string
+	Error          string
+	DeliveredAt    string
+}{
+	ID:             "id",
+	CreatedAt:      "created_at",
+	UpdatedAt:      "updated_at",
+	WebhookID:      "webhook_id",
+	Event:          "event",
+	Payload:        "payload",
+	Status:         "status",
+	Attempts:       "attempts",
+	ResponseStatus: "response_status",
+	ResponseBody:   "response_body",
+	Error:          "error",
+	DeliveredAt:    "delivered_at",
+}
+
+var WebhookDeliveryTableColumns = struct {
+	ID             string
+	CreatedAt      string
+	UpdatedAt      string
+	WebhookID      string
+	Event          string
+	Payload        string
+	Status         string
+	Attempts       string
+	ResponseStatus string
+	ResponseBody   string
+	Error          string
+	DeliveredAt    string
+}{
+	ID:             "webhook_delivery.id",
+	CreatedAt:      "webhook_delivery.created_at",
+	UpdatedAt:      "webhook_delivery.updated_at",
+	WebhookID:      "webhook_delivery.webhook_id",
+	Event:          "webhook_delivery.event",
+	Payload:        "webhook_delivery.payload",
+	Status:         "webhook_delivery.status",
+	Attempts:       "webhook_delivery.attempts",
+	ResponseStatus: "webhook_delivery.response_status",
+	ResponseBody:   "webhook_delivery.response_body",
+	Error:          "webhook_delivery.error",
+	DeliveredAt:    "webhook_delivery.delivered_at",
+}
+
+// Generated where
+
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var WebhookDeliveryWhere = struct {
+	ID             whereHelperint64
+	CreatedAt      whereHelpertime_Time
+	UpdatedAt      whereHelpertime_Time
+	WebhookID      whereHelperint64
+	Event          whereHelperstring
+	Payload        whereHelpertypes_JSON
+	Status         whereHelperstring
+	Attempts       whereHelperint
+	ResponseStatus whereHelpernull_Int
+	ResponseBody   whereHelpernull_String
+	Error          whereHelpernull_String
+	DeliveredAt    whereHelpernull_Time
+}{
+	ID:             whereHelperint64{field: "\"webhook_delivery\".\"id\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"webhook_delivery\".\"created_at\""},
+	UpdatedAt:      whereHelpertime_Time{field: "\"webhook_delivery\".\"updated_at\""},
+	WebhookID:      whereHelperint64{field: "\"webhook_delivery\".\"webhook_id\""},
+	Event:          whereHelperstring{field: "\"webhook_delivery\".\"event\""},
+	Payload:        whereHelpertypes_JSON{field: "\"webhook_delivery\".\"payload\""},
+	Status:         whereHelperstring{field: "\"webhook_delivery\".\"status\""},
+	Attempts:       whereHelperint{field: "\"webhook_delivery\".\"attempts\""},
+	ResponseStatus: whereHelpernull_Int{field: "\"webhook_delivery\".\"response_status\""},
+	ResponseBody:   whereHelpernull_String{field: "\"webhook_delivery\".\"response_body\""},
+	Error:          whereHelpernull_String{field: "\"webhook_delivery\".\"error\""},
+	DeliveredAt:    whereHelpernull_Time{field: "\"webhook_delivery\".\"delivered_at\""},
+}
+
+// WebhookDeliveryRels is where relationship names are stored.
+var WebhookDeliveryRels = struct {
+	Webhook string
+}{
+	Webhook: "Webhook",
+}
+
+// webhookDeliveryR is where relationships are stored.
+type webhookDeliveryR struct {
+	Webhook *Webhook `boil:"Webhook" json:"Webhook" toml:"Webhook" yaml:"Webhook"`
+}
+
+// NewStruct creates a new relationship struct
+func (*webhookDeliveryR) NewStruct() *webhookDeliveryR {
+	return &webhookDeliveryR{}
+}
+
+// webhookDeliveryL is where Load methods for each relationship are stored.
+type webhookDeliveryL struct{}
+
+var (
+	webhookDeliveryAllColumns            = []string{"id", "created_at", "updated_at", "webhook_id", "event", "payload", "status", "attempts", "response_status", "response_body", "error", "delivered_at"}
+	webhookDeliveryColumnsWithoutDefault = []string{"webhook_id", "response_status", "response_body", "error", "delivered_at"}
+	webhookDeliveryColumnsWithDefault    = []string{"id", "created_at", "updated_at", "event", "payload", "status", "attempts"}
+	webhookDeliveryPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// WebhookDeliverySlice is an alias for a slice of pointers to WebhookDelivery.
+	// This should almost always be used instead of []WebhookDelivery.
+	WebhookDeliverySlice []*WebhookDelivery
+	// WebhookDeliveryHook is the signature for custom WebhookDelivery hook methods
+	WebhookDeliveryHook func(boil.Executor, *WebhookDelivery) error
+
+	webhookDeliveryQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	webhookDeliveryType                 = reflect.TypeOf(&WebhookDelivery{})
+	webhookDeliveryMapping              = queries.MakeStructMapping(webhookDeliveryType)
+	webhookDeliveryPrimaryKeyMapping, _ = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, webhookDeliveryPrimaryKeyColumns)
+	webhookDeliveryInsertCacheMut       sync.RWMutex
+	webhookDeliveryInsertCache          = make(map[string]insertCache)
+	webhookDeliveryUpdateCacheMut       sync.RWMutex
+	webhookDeliveryUpdateCache          = make(map[string]updateCache)
+	webhookDeliveryUpsertCacheMut       sync.RWMutex
+	webhookDeliveryUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var webhookDeliveryBeforeInsertHooks []WebhookDeliveryHook
+var webhookDeliveryBeforeUpdateHooks []WebhookDeliveryHook
+var webhookDeliveryBeforeDeleteHooks []WebhookDeliveryHook
+var webhookDeliveryBeforeUpsertHooks []WebhookDeliveryHook
+
+var webhookDeliveryAfterInsertHooks []WebhookDeliveryHook
+var webhookDeliveryAfterSelectHooks []WebhookDeliveryHook
+var webhookDeliveryAfterUpdateHooks []WebhookDeliveryHook
+var webhookDeliveryAfterDeleteHooks []WebhookDeliveryHook
+var webhookDeliveryAfterUpsertHooks []WebhookDeliveryHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *WebhookDelivery) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookDeliveryBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *WebhookDelivery) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookDeliveryBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *WebhookDelivery) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookDeliveryBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *WebhookDelivery) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookDeliveryBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *WebhookDelivery) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookDeliveryAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *WebhookDelivery) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookDeliveryAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *WebhookDelivery) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookDeliveryAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *WebhookDelivery) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookDeliveryAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *WebhookDelivery) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range webhookDeliveryAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddWebhookDeliveryHook registers your hook function for all future operations.
+func AddWebhookDeliveryHook(hookPoint boil.HookPoint, webhookDeliveryHook WebhookDeliveryHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		webhookDeliveryBeforeInsertHooks = append(webhookDeliveryBeforeInsertHooks, webhookDeliveryHook)
+	case boil.BeforeUpdateHook:
+		webhookDeliveryBeforeUpdateHooks = append(webhookDeliveryBeforeUpdateHooks, webhookDeliveryHook)
+	case boil.BeforeDeleteHook:
+		webhookDeliveryBeforeDeleteHooks = append(webhookDeliveryBeforeDeleteHooks, webhookDeliveryHook)
+	case boil.BeforeUpsertHook:
+		webhookDeliveryBeforeUpsertHooks = append(webhookDeliveryBeforeUpsertHooks, webhookDeliveryHook)
+	case boil.AfterInsertHook:
+		webhookDeliveryAfterInsertHooks = append(webhookDeliveryAfterInsertHooks, webhookDeliveryHook)
+	case boil.AfterSelectHook:
+		webhookDeliveryAfterSelectHooks = append(webhookDeliveryAfterSelectHooks, webhookDeliveryHook)
+	case boil.AfterUpdateHook:
+		webhookDeliveryAfterUpdateHooks = append(webhookDeliveryAfterUpdateHooks, webhookDeliveryHook)
+	case boil.AfterDeleteHook:
+		webhookDeliveryAfterDeleteHooks = append(webhookDeliveryAfterDeleteHooks, webhookDeliveryHook)
+	case boil.AfterUpsertHook:
+		webhookDeliveryAfterUpsertHooks = append(webhookDeliveryAfterUpsertHooks, webhookDeliveryHook)
+	}
+}
+
+// One returns a single webhookDelivery record from the query.
+func (q webhookDeliveryQuery) One(exec boil.Executor) (*WebhookDelivery, error) {
+	o := &WebhookDelivery{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for webhook_delivery")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all WebhookDelivery records from the query.
+func (q webhookDeliveryQuery) All(exec boil.Executor) (WebhookDeliverySlice, error) {
+	var o []*WebhookDelivery
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to WebhookDelivery slice")
+	}
+
+	if len(webhookDeliveryAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all WebhookDelivery records in the query.
+func (q webhookDeliveryQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count webhook_delivery rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q webhookDeliveryQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if webhook_delivery exists")
+	}
+
+	return count > 0, nil
+}
+
+// Webhook pointed to by the foreign key.
+func (o *WebhookDelivery) Webhook(mods ...qm.QueryMod) webhookQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.WebhookID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Webhooks(queryMods...)
+	queries.SetFrom(query.Query, "\"webhook\"")
+
+	return query
+}
+
+// LoadWebhook allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (webhookDeliveryL) LoadWebhook(e boil.Executor, singular bool, maybeWebhookDelivery interface{}, mods queries.Applicator) error {
+	var slice []*WebhookDelivery
+	var object *WebhookDelivery
+
+	if singular {
+		object = maybeWebhookDelivery.(*WebhookDelivery)
+	} else {
+		slice = *maybeWebhookDelivery.(*[]*WebhookDelivery)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &webhookDeliveryR{}
+		}
+		args = append(args, object.WebhookID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &webhookDeliveryR{}
+			}
+
+			for _, a := range args {
+				if a == obj.WebhookID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.WebhookID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`webhook`),
+		qm.WhereIn(`webhook.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Webhook")
+	}
+
+	var resultSlice []*Webhook
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Webhook")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for webhook")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for webhook")
+	}
+
+	if len(webhookDeliveryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Webhook = foreign
+		if foreign.R == nil {
+			foreign.R = &webhookR{}
+		}
+		foreign.R.WebhookDeliveries = append(foreign.R.WebhookDeliveries, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.WebhookID == foreign.ID {
+				local.R.Webhook = foreign
+				if foreign.R == nil {
+					foreign.R = &webhookR{}
+				}
+				foreign.R.WebhookDeliveries = append(foreign.R.WebhookDeliveries, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetWebhook of the webhookDelivery to the related item.
+// Sets o.R.Webhook to related.
+// Adds o to related.R.WebhookDeliveries.
+func (o *WebhookDelivery) SetWebhook(exec boil.Executor, insert bool, related *Webhook) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"webhook_delivery\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"webhook_id"}),
+		strmangle.WhereClause("\"", "\"", 2, webhookDeliveryPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.WebhookID = related.ID
+	if o.R == nil {
+		o.R = &webhookDeliveryR{
+			Webhook: related,
+		}
+	} else {
+		o.R.Webhook = related
+	}
+
+	if related.R == nil {
+		related.R = &webhookR{
+			WebhookDeliveries: WebhookDeliverySlice{o},
+		}
+	} else {
+		related.R.WebhookDeliveries = append(related.R.WebhookDeliveries, o)
+	}
+
+	return nil
+}
+
+// WebhookDeliveries retrieves all the records using an executor.
+func WebhookDeliveries(mods ...qm.QueryMod) webhookDeliveryQuery {
+	mods = append(mods, qm.From("\"webhook_delivery\""))
+	return webhookDeliveryQuery{NewQuery(mods...)}
+}
+
+// FindWebhookDelivery retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindWebhookDelivery(exec boil.Executor, iD int64, selectCols ...string) (*WebhookDelivery, error) {
+	webhookDeliveryObj := &WebhookDelivery{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"webhook_delivery\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, webhookDeliveryObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from webhook_delivery")
+	}
+
+	if err = webhookDeliveryObj.doAfterSelectHooks(exec); err != nil {
+		return webhookDeliveryObj, err
+	}
+
+	return webhookDeliveryObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *WebhookDelivery) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no webhook_delivery provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(webhookDeliveryColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	webhookDeliveryInsertCacheMut.RLock()
+	cache, cached := webhookDeliveryInsertCache[key]
+	webhookDeliveryInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			webhookDeliveryAllColumns,
+			webhookDeliveryColumnsWithDefault,
+			webhookDeliveryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"webhook_delivery\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"webhook_delivery\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into webhook_delivery")
+	}
+
+	if !cached {
+		webhookDeliveryInsertCacheMut.Lock()
+		webhookDeliveryInsertCache[key] = cache
+		webhookDeliveryInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// Update uses an executor to update the WebhookDelivery.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *WebhookDelivery) Update(exec boil.Executor, columns boil.Columns) error {
+	currTime := time.Now().In(boil.GetLocation())
+
+	o.UpdatedAt = currTime
+
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+	key := makeCacheKey(columns, nil)
+	webhookDeliveryUpdateCacheMut.RLock()
+	cache, cached := webhookDeliveryUpdateCache[key]
+	webhookDeliveryUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			webhookDeliveryAllColumns,
+			webhookDeliveryPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return errors.New("models: unable to update webhook_delivery, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"webhook_delivery\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, webhookDeliveryPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, append(wl, webhookDeliveryPrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update webhook_delivery row")
+	}
+
+	if !cached {
+		webhookDeliveryUpdateCacheMut.Lock()
+		webhookDeliveryUpdateCache[key] = cache
+		webhookDeliveryUpdateCacheMut.Unlock()
+	}
+
+	return o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q webhookDeliveryQuery) UpdateAll(exec boil.Executor, cols M) error {
+	queries.SetUpdate(q.Query, cols)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all for webhook_delivery")
+	}
+
+	return nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o WebhookDeliverySlice) UpdateAll(exec boil.Executor, cols M) error {
+	ln := int64(len(o))
+	if ln == 0 {
+		return nil
+	}
+
+	if len(cols) == 0 {
+		return errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookDeliveryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"webhook_delivery\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, webhookDeliveryPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to update all in webhookDelivery slice")
+	}
+
+	return nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *WebhookDelivery) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no webhook_delivery provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	o.UpdatedAt = currTime
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(webhookDeliveryColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	webhookDeliveryUpsertCacheMut.RLock()
+	cache, cached := webhookDeliveryUpsertCache[key]
+	webhookDeliveryUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			webhookDeliveryAllColumns,
+			webhookDeliveryColumnsWithDefault,
+			webhookDeliveryColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			webhookDeliveryAllColumns,
+			webhookDeliveryPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert webhook_delivery, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(webhookDeliveryPrimaryKeyColumns))
+			copy(conflict, webhookDeliveryPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"webhook_delivery\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(webhookDeliveryType, webhookDeliveryMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert webhook_delivery")
+	}
+
+	if !cached {
+		webhookDeliveryUpsertCacheMut.Lock()
+		webhookDeliveryUpsertCache[key] = cache
+		webhookDeliveryUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// Delete deletes a single WebhookDelivery record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *WebhookDelivery) Delete(exec boil.Executor) error {
+	if o == nil {
+		return errors.New("models: no WebhookDelivery provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), webhookDeliveryPrimaryKeyMapping)
+	sql := "DELETE FROM \"webhook_delivery\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete from webhook_delivery")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q webhookDeliveryQuery) DeleteAll(exec boil.Executor) error {
+	if q.Query == nil {
+		return errors.New("models: no webhookDeliveryQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	_, err := q.Query.Exec(exec)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from webhook_delivery")
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o WebhookDeliverySlice) DeleteAll(exec boil.Executor) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	if len(webhookDeliveryBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookDeliveryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"webhook_delivery\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, webhookDeliveryPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	_, err := exec.Exec(sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to delete all from webhookDelivery slice")
+	}
+
+	if len(webhookDeliveryAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *WebhookDelivery) Reload(exec boil.Executor) error {
+	ret, err := FindWebhookDelivery(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *WebhookDeliverySlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := WebhookDeliverySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookDeliveryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"webhook_delivery\".* FROM \"webhook_delivery\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, webhookDeliveryPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in WebhookDeliverySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// WebhookDeliveryExists checks if the WebhookDelivery row exists.
+func WebhookDeliveryExists(exec boil.Executor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"webhook_delivery\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if webhook_delivery exists")
+	}
+
+	return exists, nil
+}
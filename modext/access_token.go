@@ -0,0 +1,42 @@
+package modext
+
+import "kasen/models"
+
+type AccessToken struct {
+	ID         int64    `json:"id"`
+	CreatedAt  int64    `json:"createdAt"`
+	ExpiresAt  int64    `json:"expiresAt,omitempty"`
+	LastUsedAt int64    `json:"lastUsedAt,omitempty"`
+	LastUsedIP string   `json:"lastUsedIp,omitempty"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+
+	// Token is only returned once, after the token has been created.
+	Token string `json:"token,omitempty"`
+}
+
+func NewAccessToken(token *models.AccessToken) *AccessToken {
+	if token == nil {
+		return nil
+	}
+
+	t := &AccessToken{
+		ID:         token.ID,
+		CreatedAt:  token.CreatedAt.Unix(),
+		LastUsedIP: token.LastUsedIP.String,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scopes:     token.Scopes,
+	}
+
+	if token.ExpiresAt.Valid {
+		t.ExpiresAt = token.ExpiresAt.Time.Unix()
+	}
+
+	if token.LastUsedAt.Valid {
+		t.LastUsedAt = token.LastUsedAt.Time.Unix()
+	}
+
+	return t
+}
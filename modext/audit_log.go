@@ -0,0 +1,37 @@
+package modext
+
+import (
+	"encoding/json"
+
+	"kasen/models"
+)
+
+type AuditLog struct {
+	ID         int64           `json:"id"`
+	CreatedAt  int64           `json:"createdAt"`
+	ActorID    int64           `json:"actorId,omitempty"`
+	ActorName  string          `json:"actorName,omitempty"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   int64           `json:"targetId,omitempty"`
+	Diff       json.RawMessage `json:"diff,omitempty"`
+	IP         string          `json:"ip,omitempty"`
+}
+
+func NewAuditLog(log *models.AuditLog) *AuditLog {
+	if log == nil {
+		return nil
+	}
+
+	return &AuditLog{
+		ID:         log.ID,
+		CreatedAt:  log.CreatedAt.Unix(),
+		ActorID:    log.ActorID.Int64,
+		ActorName:  log.ActorName.String,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID.Int64,
+		Diff:       json.RawMessage(log.Diff),
+		IP:         log.IP.String,
+	}
+}
@@ -14,6 +14,8 @@ type Chapter struct {
 	CreatedAt   int64    `json:"createdAt"`
 	UpdatedAt   int64    `json:"updatedAt"`
 	PublishedAt int64    `json:"publishedAt,omitempty"`
+	ScheduledAt int64    `json:"scheduledAt,omitempty"`
+	DeletedAt   int64    `json:"deletedAt,omitempty"`
 	Chapter     string   `json:"chapter"`
 	Volume      string   `json:"volume,omitempty"`
 	Title       string   `json:"title,omitempty"`
@@ -46,6 +48,14 @@ func NewChapter(chapter *models.Chapter) *Chapter {
 		c.PublishedAt = chapter.PublishedAt.Time.Unix()
 	}
 
+	if chapter.ScheduledAt.Valid {
+		c.ScheduledAt = chapter.ScheduledAt.Time.Unix()
+	}
+
+	if chapter.DeletedAt.Valid {
+		c.DeletedAt = chapter.DeletedAt.Time.Unix()
+	}
+
 	return c
 }
 
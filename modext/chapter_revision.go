@@ -0,0 +1,33 @@
+package modext
+
+import "kasen/models"
+
+type ChapterRevision struct {
+	ID        int64    `json:"id"`
+	CreatedAt int64    `json:"createdAt"`
+	Action    string   `json:"action"`
+	Pages     []string `json:"pages"`
+
+	User *User `json:"user,omitempty"`
+}
+
+func NewChapterRevision(revision *models.ChapterRevision) *ChapterRevision {
+	if revision == nil {
+		return nil
+	}
+
+	return &ChapterRevision{
+		ID:        revision.ID,
+		CreatedAt: revision.CreatedAt.Unix(),
+		Action:    revision.Action,
+		Pages:     revision.Pages,
+	}
+}
+
+func (r *ChapterRevision) LoadUser(revision *models.ChapterRevision) *ChapterRevision {
+	if revision == nil || revision.R == nil || revision.R.User == nil {
+		return r
+	}
+	r.User = NewUser(revision.R.User)
+	return r
+}
@@ -10,19 +10,36 @@ type Cover struct {
 	ID        int64  `json:"id"`
 	CreatedAt int64  `json:"createdAt"`
 	UpdatedAt int64  `json:"updatedAt"`
+	DeletedAt int64  `json:"deletedAt,omitempty"`
 	FileName  string `json:"fileName"`
+
+	Project *Project `json:"project,omitempty"`
 }
 
 func NewCover(cover *models.Cover) *Cover {
 	if cover == nil {
 		return nil
 	}
-	return &Cover{
+	c := &Cover{
 		ID:        cover.ID,
 		CreatedAt: cover.CreatedAt.Unix(),
 		UpdatedAt: cover.UpdatedAt.Unix(),
 		FileName:  cover.FileName,
 	}
+
+	if cover.DeletedAt.Valid {
+		c.DeletedAt = cover.DeletedAt.Time.Unix()
+	}
+
+	return c
+}
+
+func (c *Cover) LoadProject(cover *models.Cover) *Cover {
+	if cover == nil || cover.R == nil || cover.R.Project == nil {
+		return c
+	}
+	c.Project = NewProject(cover.R.Project)
+	return c
 }
 
 func (c *Cover) Path(p *Project) string {
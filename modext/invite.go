@@ -0,0 +1,59 @@
+package modext
+
+import "kasen/models"
+
+type Invite struct {
+	ID          int64    `json:"id"`
+	CreatedAt   int64    `json:"createdAt"`
+	ExpiresAt   int64    `json:"expiresAt,omitempty"`
+	CreatorID   int64    `json:"creatorId,omitempty"`
+	Prefix      string   `json:"prefix"`
+	MaxUses     int      `json:"maxUses"`
+	Uses        int      `json:"uses"`
+	Permissions []string `json:"permissions"`
+
+	Role    *Role `json:"role,omitempty"`
+	Creator *User `json:"creator,omitempty"`
+
+	// Code and URL are only returned once, after the invite has been created.
+	Code string `json:"code,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+func NewInvite(invite *models.Invite) *Invite {
+	if invite == nil {
+		return nil
+	}
+
+	i := &Invite{
+		ID:          invite.ID,
+		CreatedAt:   invite.CreatedAt.Unix(),
+		CreatorID:   invite.CreatorID.Int64,
+		Prefix:      invite.Prefix,
+		MaxUses:     invite.MaxUses,
+		Uses:        invite.Uses,
+		Permissions: invite.Permissions,
+	}
+
+	if invite.ExpiresAt.Valid {
+		i.ExpiresAt = invite.ExpiresAt.Time.Unix()
+	}
+
+	return i
+}
+
+func (i *Invite) LoadRole(invite *models.Invite) *Invite {
+	if invite == nil || invite.R == nil || invite.R.Role == nil {
+		return i
+	}
+	i.Role = NewRole(invite.R.Role)
+	return i
+}
+
+func (i *Invite) LoadCreator(invite *models.Invite) *Invite {
+	if invite == nil || invite.R == nil || invite.R.Creator == nil {
+		return i
+	}
+	i.Creator = NewUser(invite.R.Creator)
+	return i
+}
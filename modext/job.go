@@ -0,0 +1,57 @@
+package modext
+
+import (
+	"encoding/json"
+
+	"kasen/models"
+)
+
+type Job struct {
+	ID          int64           `json:"id"`
+	CreatedAt   int64           `json:"createdAt"`
+	UpdatedAt   int64           `json:"updatedAt"`
+	UserID      int64           `json:"userId,omitempty"`
+	Kind        string          `json:"kind"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	Status      string          `json:"status"`
+	Progress    int             `json:"progress"`
+	Total       int             `json:"total"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"maxAttempts"`
+	RunAt       int64           `json:"runAt"`
+	StartedAt   int64           `json:"startedAt,omitempty"`
+	FinishedAt  int64           `json:"finishedAt,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+func NewJob(job *models.Job) *Job {
+	if job == nil {
+		return nil
+	}
+
+	j := &Job{
+		ID:          job.ID,
+		CreatedAt:   job.CreatedAt.Unix(),
+		UpdatedAt:   job.UpdatedAt.Unix(),
+		UserID:      job.UserID.Int64,
+		Kind:        job.Kind,
+		Payload:     json.RawMessage(job.Payload),
+		Status:      job.Status,
+		Progress:    job.Progress,
+		Total:       job.Total,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt.Unix(),
+		Error:       job.Error.String,
+	}
+
+	if job.StartedAt.Valid {
+		j.StartedAt = job.StartedAt.Time.Unix()
+	}
+
+	if job.FinishedAt.Valid {
+		j.FinishedAt = job.FinishedAt.Time.Unix()
+	}
+
+	return j
+}
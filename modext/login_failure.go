@@ -0,0 +1,29 @@
+package modext
+
+import "kasen/models"
+
+type LoginFailure struct {
+	ID        int64  `json:"id"`
+	CreatedAt int64  `json:"createdAt"`
+	UserID    int64  `json:"userId,omitempty"`
+	Email     string `json:"email"`
+	IP        string `json:"ip,omitempty"`
+	UserAgent string `json:"userAgent,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+func NewLoginFailure(failure *models.LoginFailure) *LoginFailure {
+	if failure == nil {
+		return nil
+	}
+
+	return &LoginFailure{
+		ID:        failure.ID,
+		CreatedAt: failure.CreatedAt.Unix(),
+		UserID:    failure.UserID.Int64,
+		Email:     failure.Email,
+		IP:        failure.IP.String,
+		UserAgent: failure.UserAgent.String,
+		Reason:    failure.Reason,
+	}
+}
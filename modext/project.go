@@ -3,18 +3,21 @@ package modext
 import "kasen/models"
 
 type Project struct {
-	ID            int64  `json:"id"`
-	Slug          string `json:"slug"`
-	Locked        bool   `json:"locked,omitempty"`
-	CreatedAt     int64  `json:"createdAt"`
-	UpdatedAt     int64  `json:"updatedAt"`
-	PublishedAt   int64  `json:"publishedAt,omitempty"`
-	Title         string `json:"title"`
-	Description   string `json:"description,omitempty"`
-	ProjectStatus string `json:"projectStatus"`
-	SeriesStatus  string `json:"seriesStatus"`
-	Demographic   string `json:"demographic,omitempty"`
-	Rating        string `json:"rating,omitempty"`
+	ID               int64  `json:"id"`
+	Slug             string `json:"slug"`
+	Locked           bool   `json:"locked,omitempty"`
+	CreatedAt        int64  `json:"createdAt"`
+	UpdatedAt        int64  `json:"updatedAt"`
+	PublishedAt      int64  `json:"publishedAt,omitempty"`
+	ScheduledAt      int64  `json:"scheduledAt,omitempty"`
+	DeletedAt        int64  `json:"deletedAt,omitempty"`
+	Title            string `json:"title"`
+	Description      string `json:"description,omitempty"`
+	ProjectStatus    string `json:"projectStatus"`
+	SeriesStatus     string `json:"seriesStatus"`
+	Demographic      string `json:"demographic,omitempty"`
+	Rating           string `json:"rating,omitempty"`
+	ReadingDirection string `json:"readingDirection,omitempty"`
 
 	Artists  []*Author     `json:"artists,omitempty"`
 	Authors  []*Author     `json:"authors,omitempty"`
@@ -31,23 +34,32 @@ func NewProject(project *models.Project) *Project {
 	}
 
 	p := &Project{
-		ID:            project.ID,
-		Slug:          project.Slug,
-		Locked:        project.Locked.Bool,
-		CreatedAt:     project.CreatedAt.Unix(),
-		UpdatedAt:     project.UpdatedAt.Unix(),
-		Title:         project.Title,
-		Description:   project.Description.String,
-		ProjectStatus: project.ProjectStatus,
-		SeriesStatus:  project.SeriesStatus,
-		Demographic:   project.Demographic.String,
-		Rating:        project.Rating.String,
+		ID:               project.ID,
+		Slug:             project.Slug,
+		Locked:           project.Locked.Bool,
+		CreatedAt:        project.CreatedAt.Unix(),
+		UpdatedAt:        project.UpdatedAt.Unix(),
+		Title:            project.Title,
+		Description:      project.Description.String,
+		ProjectStatus:    project.ProjectStatus,
+		SeriesStatus:     project.SeriesStatus,
+		Demographic:      project.Demographic.String,
+		Rating:           project.Rating.String,
+		ReadingDirection: project.ReadingDirection.String,
 	}
 
 	if project.PublishedAt.Valid {
 		p.PublishedAt = project.PublishedAt.Time.Unix()
 	}
 
+	if project.ScheduledAt.Valid {
+		p.ScheduledAt = project.ScheduledAt.Time.Unix()
+	}
+
+	if project.DeletedAt.Valid {
+		p.DeletedAt = project.DeletedAt.Time.Unix()
+	}
+
 	return p
 }
 
@@ -0,0 +1,45 @@
+package modext
+
+import "kasen/models"
+
+type ProjectMember struct {
+	CreatedAt   int64    `json:"createdAt"`
+	UpdatedAt   int64    `json:"updatedAt"`
+	ProjectID   int64    `json:"projectId"`
+	UserID      int64    `json:"userId"`
+	Permissions []string `json:"permissions"`
+
+	User *User `json:"user,omitempty"`
+}
+
+func NewProjectMember(member *models.ProjectMember) *ProjectMember {
+	if member == nil {
+		return nil
+	}
+	return &ProjectMember{
+		CreatedAt:   member.CreatedAt.Unix(),
+		UpdatedAt:   member.UpdatedAt.Unix(),
+		ProjectID:   member.ProjectID,
+		UserID:      member.UserID,
+		Permissions: member.Permissions,
+	}
+}
+
+func (m *ProjectMember) LoadUser(member *models.ProjectMember) *ProjectMember {
+	if member == nil || member.R == nil || member.R.User == nil {
+		return m
+	}
+	m.User = NewUser(member.R.User)
+	return m
+}
+
+func (m *ProjectMember) hasPermissions(perms ...string) bool {
+	for _, perm := range perms {
+		for _, memberPerm := range m.Permissions {
+			if memberPerm == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
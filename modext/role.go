@@ -0,0 +1,24 @@
+package modext
+
+import "kasen/models"
+
+type Role struct {
+	ID          int64    `json:"id"`
+	CreatedAt   int64    `json:"createdAt"`
+	UpdatedAt   int64    `json:"updatedAt"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+func NewRole(role *models.Role) *Role {
+	if role == nil {
+		return nil
+	}
+	return &Role{
+		ID:          role.ID,
+		CreatedAt:   role.CreatedAt.Unix(),
+		UpdatedAt:   role.UpdatedAt.Unix(),
+		Name:        role.Name,
+		Permissions: role.Permissions,
+	}
+}
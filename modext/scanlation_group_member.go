@@ -0,0 +1,34 @@
+package modext
+
+import "kasen/models"
+
+type ScanlationGroupMember struct {
+	CreatedAt         int64  `json:"createdAt"`
+	UpdatedAt         int64  `json:"updatedAt"`
+	ScanlationGroupID int64  `json:"scanlationGroupId"`
+	UserID            int64  `json:"userId"`
+	Role              string `json:"role"`
+
+	User *User `json:"user,omitempty"`
+}
+
+func NewScanlationGroupMember(member *models.ScanlationGroupMember) *ScanlationGroupMember {
+	if member == nil {
+		return nil
+	}
+	return &ScanlationGroupMember{
+		CreatedAt:         member.CreatedAt.Unix(),
+		UpdatedAt:         member.UpdatedAt.Unix(),
+		ScanlationGroupID: member.ScanlationGroupID,
+		UserID:            member.UserID,
+		Role:              member.Role,
+	}
+}
+
+func (m *ScanlationGroupMember) LoadUser(member *models.ScanlationGroupMember) *ScanlationGroupMember {
+	if member == nil || member.R == nil || member.R.User == nil {
+		return m
+	}
+	m.User = NewUser(member.R.User)
+	return m
+}
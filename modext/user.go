@@ -1,6 +1,7 @@
 package modext
 
 import (
+	"kasen/constants"
 	"kasen/models"
 
 	"golang.org/x/crypto/bcrypt"
@@ -12,6 +13,30 @@ type User struct {
 	Password    string   `json:"-"`
 	Email       string   `json:"-"`
 	Permissions []string `json:"permissions,omitempty"`
+	Roles       []*Role  `json:"roles,omitempty"`
+
+	EmailVerified bool `json:"emailVerified"`
+
+	// GrantedPermissions and RevokedPermissions override the permissions
+	// of the roles of the user, Permissions holds the resolved result.
+	GrantedPermissions []string `json:"grantedPermissions,omitempty"`
+	RevokedPermissions []string `json:"revokedPermissions,omitempty"`
+
+	// Memberships are the projects whose chapters the user
+	// can manage regardless of its permissions.
+	Memberships []*ProjectMember `json:"memberships,omitempty"`
+
+	// ScanlationGroups are the scanlation groups the user is a member of.
+	ScanlationGroups []*ScanlationGroupMember `json:"scanlationGroups,omitempty"`
+
+	// TwoFactorRequired is true if some permissions of the user
+	// are withheld until it enables two-factor authentication.
+	TwoFactorEnabled  bool `json:"twoFactorEnabled"`
+	TwoFactorRequired bool `json:"twoFactorRequired,omitempty"`
+
+	// IP is the client IP of the request made by the user,
+	// it's recorded in the audit log.
+	IP string `json:"-"`
 
 	Chapters []*Chapter `json:"-"`
 }
@@ -20,13 +45,89 @@ func NewUser(user *models.User) *User {
 	if user == nil {
 		return nil
 	}
-	return &User{
-		ID:          user.ID,
-		Name:        user.Name,
-		Password:    user.Password,
-		Email:       user.Email,
-		Permissions: user.Permissions,
+	u := &User{
+		ID:                 user.ID,
+		Name:               user.Name,
+		Password:           user.Password,
+		Email:              user.Email,
+		GrantedPermissions: user.Permissions,
+		RevokedPermissions: user.RevokedPermissions,
+		EmailVerified:      user.EmailVerifiedAt.Valid,
+		TwoFactorEnabled:   user.TotpEnabledAt.Valid,
+	}
+	u.ResolvePermissions()
+	return u
+}
+
+func (u *User) LoadRoles(user *models.User) *User {
+	if user == nil || user.R == nil || len(user.R.Roles) == 0 {
+		return u
+	}
+
+	u.Roles = make([]*Role, len(user.R.Roles))
+	for i, role := range user.R.Roles {
+		u.Roles[i] = NewRole(role)
+	}
+
+	u.ResolvePermissions()
+	return u
+}
+
+func (u *User) LoadMemberships(user *models.User) *User {
+	if user == nil || user.R == nil || len(user.R.ProjectMembers) == 0 {
+		return u
+	}
+
+	u.Memberships = make([]*ProjectMember, len(user.R.ProjectMembers))
+	for i, member := range user.R.ProjectMembers {
+		u.Memberships[i] = NewProjectMember(member)
+	}
+
+	return u
+}
+
+func (u *User) LoadScanlationGroups(user *models.User) *User {
+	if user == nil || user.R == nil || len(user.R.ScanlationGroupMembers) == 0 {
+		return u
+	}
+
+	u.ScanlationGroups = make([]*ScanlationGroupMember, len(user.R.ScanlationGroupMembers))
+	for i, member := range user.R.ScanlationGroupMembers {
+		u.ScanlationGroups[i] = NewScanlationGroupMember(member)
+	}
+
+	return u
+}
+
+// ResolvePermissions resolves the effective permissions of the user
+// from the permissions of its roles and its own overrides.
+func (u *User) ResolvePermissions() {
+	var perms []string
+	add := func(perm string) {
+		for _, p := range u.RevokedPermissions {
+			if p == perm {
+				return
+			}
+		}
+		for _, p := range perms {
+			if p == perm {
+				return
+			}
+		}
+		perms = append(perms, perm)
+	}
+
+	for _, role := range u.Roles {
+		for _, perm := range role.Permissions {
+			add(perm)
+		}
+	}
+
+	for _, perm := range u.GrantedPermissions {
+		add(perm)
 	}
+
+	u.Permissions = perms
 }
 
 func (u *User) LoadChapters(user *models.User) *User {
@@ -44,11 +145,12 @@ func (u *User) LoadChapters(user *models.User) *User {
 
 func (u *User) ToModel() *models.User {
 	return &models.User{
-		ID:          u.ID,
-		Name:        u.Name,
-		Password:    u.Password,
-		Email:       u.Email,
-		Permissions: u.Permissions,
+		ID:                 u.ID,
+		Name:               u.Name,
+		Password:           u.Password,
+		Email:              u.Email,
+		Permissions:        u.GrantedPermissions,
+		RevokedPermissions: u.RevokedPermissions,
 	}
 }
 
@@ -71,3 +173,121 @@ func (u *User) HasPermissions(perms ...string) bool {
 	}
 	return false
 }
+
+// HasProjectPermissions checks if the user has one of the given permissions,
+// either globally or as a member of the given project.
+func (u *User) HasProjectPermissions(projectID int64, perms ...string) bool {
+	if u.HasPermissions(perms...) {
+		return true
+	}
+	for _, member := range u.Memberships {
+		if member.ProjectID == projectID && member.hasPermissions(perms...) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyProjectPermissions checks if the user has one of the given permissions,
+// either globally or as a member of any project.
+func (u *User) HasAnyProjectPermissions(perms ...string) bool {
+	if u.HasPermissions(perms...) {
+		return true
+	}
+	for _, member := range u.Memberships {
+		if member.hasPermissions(perms...) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsScanlationGroupMember checks if the user is a member of the given
+// scanlation group, and its leader if leader is true.
+func (u *User) IsScanlationGroupMember(groupID int64, leader bool) bool {
+	for _, member := range u.ScanlationGroups {
+		if member.ScanlationGroupID == groupID {
+			return !leader || member.Role == constants.ScanlationGroupLeader
+		}
+	}
+	return false
+}
+
+// RestrictPermissions restricts the permissions of the user, including
+// the permissions given by its memberships, to the given scopes.
+func (u *User) RestrictPermissions(scopes []string) {
+	restrict := func(perms []string) []string {
+		var restricted []string
+		for _, perm := range perms {
+			for _, scope := range scopes {
+				if perm == scope {
+					restricted = append(restricted, perm)
+					break
+				}
+			}
+		}
+		return restricted
+	}
+
+	u.Permissions = restrict(u.Permissions)
+	for _, member := range u.Memberships {
+		member.Permissions = restrict(member.Permissions)
+	}
+}
+
+// Scopes returns the permissions of the user, including the permissions
+// given by its memberships, so that they can be given to RestrictPermissions.
+func (u *User) Scopes() []string {
+	var scopes []string
+	add := func(perms []string) {
+		for _, perm := range perms {
+			found := false
+			for _, scope := range scopes {
+				if perm == scope {
+					found = true
+					break
+				}
+			}
+			if !found {
+				scopes = append(scopes, perm)
+			}
+		}
+	}
+
+	add(u.Permissions)
+	for _, member := range u.Memberships {
+		add(member.Permissions)
+	}
+	return scopes
+}
+
+// WithholdPermissions removes the given permissions from the user,
+// including the permissions given by its memberships.
+// Returns true if the user had any of them.
+func (u *User) WithholdPermissions(perms []string) bool {
+	withheld := false
+	withhold := func(userPerms []string) []string {
+		var kept []string
+		for _, perm := range userPerms {
+			keep := true
+			for _, p := range perms {
+				if perm == p {
+					keep = false
+					break
+				}
+			}
+			if keep {
+				kept = append(kept, perm)
+			} else {
+				withheld = true
+			}
+		}
+		return kept
+	}
+
+	u.Permissions = withhold(u.Permissions)
+	for _, member := range u.Memberships {
+		member.Permissions = withhold(member.Permissions)
+	}
+	return withheld
+}
@@ -0,0 +1,32 @@
+package modext
+
+import "kasen/models"
+
+type UserIdentity struct {
+	ID          int64  `json:"id"`
+	CreatedAt   int64  `json:"createdAt"`
+	LastLoginAt int64  `json:"lastLoginAt,omitempty"`
+	Issuer      string `json:"issuer"`
+	Subject     string `json:"subject"`
+	Email       string `json:"email,omitempty"`
+}
+
+func NewUserIdentity(identity *models.UserIdentity) *UserIdentity {
+	if identity == nil {
+		return nil
+	}
+
+	i := &UserIdentity{
+		ID:        identity.ID,
+		CreatedAt: identity.CreatedAt.Unix(),
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
+		Email:     identity.Email.String,
+	}
+
+	if identity.LastLoginAt.Valid {
+		i.LastLoginAt = identity.LastLoginAt.Time.Unix()
+	}
+
+	return i
+}
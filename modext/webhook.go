@@ -0,0 +1,85 @@
+package modext
+
+import (
+	"encoding/json"
+
+	"kasen/models"
+)
+
+type Webhook struct {
+	ID          int64    `json:"id"`
+	CreatedAt   int64    `json:"createdAt"`
+	UpdatedAt   int64    `json:"updatedAt"`
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret,omitempty"`
+	Events      []string `json:"events"`
+	Active      bool     `json:"active"`
+	Description string   `json:"description,omitempty"`
+}
+
+func NewWebhook(webhook *models.Webhook) *Webhook {
+	if webhook == nil {
+		return nil
+	}
+
+	return &Webhook{
+		ID:          webhook.ID,
+		CreatedAt:   webhook.CreatedAt.Unix(),
+		UpdatedAt:   webhook.UpdatedAt.Unix(),
+		URL:         webhook.URL,
+		Events:      webhook.Events,
+		Active:      webhook.Active,
+		Description: webhook.Description.String,
+	}
+}
+
+// LoadSecret loads the secret of the webhook,
+// which is only returned after it has been set.
+func (w *Webhook) LoadSecret(webhook *models.Webhook) *Webhook {
+	if webhook == nil {
+		return w
+	}
+	w.Secret = webhook.Secret
+	return w
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	CreatedAt      int64           `json:"createdAt"`
+	UpdatedAt      int64           `json:"updatedAt"`
+	WebhookID      int64           `json:"webhookId"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload,omitempty"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"responseStatus,omitempty"`
+	ResponseBody   string          `json:"responseBody,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	DeliveredAt    int64           `json:"deliveredAt,omitempty"`
+}
+
+func NewWebhookDelivery(delivery *models.WebhookDelivery) *WebhookDelivery {
+	if delivery == nil {
+		return nil
+	}
+
+	d := &WebhookDelivery{
+		ID:             delivery.ID,
+		CreatedAt:      delivery.CreatedAt.Unix(),
+		UpdatedAt:      delivery.UpdatedAt.Unix(),
+		WebhookID:      delivery.WebhookID,
+		Event:          delivery.Event,
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus.Int,
+		ResponseBody:   delivery.ResponseBody.String,
+		Error:          delivery.Error.String,
+	}
+
+	if delivery.DeliveredAt.Valid {
+		d.DeliveredAt = delivery.DeliveredAt.Time.Unix()
+	}
+
+	return d
+}
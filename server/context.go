@@ -113,6 +113,15 @@ func (c *Context) GetUser() *modext.User {
 	if err != nil {
 		return nil
 	}
+	u.IP = c.ClientIP()
+
+	if scopes, ok := c.Get("scopes"); ok {
+		u.RestrictPermissions(scopes.([]string))
+	}
+
+	if !u.TwoFactorEnabled {
+		u.TwoFactorRequired = u.WithholdPermissions(config.GetService().TwoFactorPermissions)
+	}
 
 	c.Set("user", u)
 	c.SetData("user", u)
@@ -188,20 +197,22 @@ func (c *Context) VerifySessionCookie() (uid int64, ok bool) {
 		return uid, uid > 0
 	}
 
+	var sid string
 	var err error
 	c.Set("VerifySessionCookie", 1)
 
 	sessionToken, _ := c.Cookie("session")
 	if len(sessionToken) > 0 {
-		uid, err = services.VerifySessionToken(sessionToken)
+		uid, sid, err = services.VerifySessionToken(sessionToken)
 	}
 
 	refreshToken, _ := c.Cookie("refresh")
 	if len(refreshToken) > 0 && (len(sessionToken) == 0 || err != nil) {
-		var t *services.Token
-		uid, t, err = services.RefreshToken(refreshToken)
-		if err == nil {
-			c.SetCookie("session", t.String, t.ExprDate)
+		var rt, st *services.Token
+		uid, sid, rt, st, err = services.RefreshToken(refreshToken, c.ClientIP())
+		// The tokens are nil if they were just issued to a concurrent request.
+		if err == nil && rt != nil {
+			c.SetTokens(st, rt)
 		}
 	}
 
@@ -211,6 +222,7 @@ func (c *Context) VerifySessionCookie() (uid int64, ok bool) {
 	}
 
 	c.Set("uid", uid)
+	c.Set("sid", sid)
 	return uid, true
 }
 
@@ -232,15 +244,45 @@ func (c *Context) VerifySessionHeader() (uid int64, ok bool) {
 		return
 	}
 
-	uid, err := services.VerifySessionToken(token)
+	if strings.HasPrefix(token, services.AccessTokenPrefix) {
+		var scopes []string
+		var err error
+		if uid, scopes, err = services.VerifyAccessToken(token, c.ClientIP()); err != nil {
+			return 0, false
+		}
+
+		c.Set("uid", uid)
+		c.Set("scopes", scopes)
+		return uid, true
+	}
+
+	uid, sid, err := services.VerifySessionToken(token)
 	if err != nil {
 		return
 	}
 
 	c.Set("uid", uid)
+	c.Set("sid", sid)
 	return uid, true
 }
 
+// SessionID gets the id of the session of the request,
+// which is empty if the request is not authorized with a session.
+func (c *Context) SessionID() string {
+	return c.GetString("sid")
+}
+
+// SessionClient gets the client of the request, which starts a session on login.
+func (c *Context) SessionClient() services.SessionClient {
+	return services.SessionClient{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+}
+
+// UsesAccessToken checks if the request is authorized with a personal access token.
+func (c *Context) UsesAccessToken() bool {
+	_, ok := c.Get("scopes")
+	return ok
+}
+
 func (c *Context) ParamInt(name string) (int, error) {
 	return strconv.Atoi(c.Param(name))
 }
@@ -66,6 +66,20 @@ func WithPermissions(permissions ...string) Handler {
 	}
 }
 
+// WithProjectPermissions is like WithPermissions, but lets the members
+// of any project through as well. The services check the permissions
+// against the project of the request.
+func WithProjectPermissions(permissions ...string) Handler {
+	return func(c *Context) {
+		user := c.GetUser()
+		if user == nil || !user.HasAnyProjectPermissions(permissions...) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
 var limiters = make(map[string]Handler)
 
 func WithRateLimit(prefix, formatted string) Handler {
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	. "kasen/database"
+
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var AccessTokenCols = models.AccessTokenColumns
+
+// AccessTokenPrefix is the prefix of every personal access token,
+// which tells them apart from session tokens.
+const AccessTokenPrefix = "kasen_pat_"
+
+// accessTokenUsageInterval is the interval at which the last usage
+// of an access token is recorded.
+const accessTokenUsageInterval = time.Minute
+
+// hashAccessToken hashes the given access token, only the hashes are stored.
+func hashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newAccessToken generates a random access token.
+func newAccessToken() (string, error) {
+	buf, err := randomBytes(32)
+	if err != nil {
+		return "", err
+	}
+	return AccessTokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// AccessTokenDraft represents the draft of a personal access token.
+type AccessTokenDraft struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt int64    `json:"expiresAt"`
+}
+
+func (draft *AccessTokenDraft) validate(user *modext.User) error {
+	draft.Name = strings.TrimSpace(draft.Name)
+
+	if len(draft.Name) == 0 {
+		return errs.ErrAccessTokenNameRequired
+	} else if utf8.RuneCountInString(draft.Name) > 64 {
+		return errs.ErrAccessTokenNameTooLong
+	}
+
+	if draft.ExpiresAt > 0 && !time.Unix(draft.ExpiresAt, 0).After(time.Now()) {
+		return errs.ErrAccessTokenExpirationInvalid
+	}
+
+	scopes, err := validatePermissions(draft.Scopes)
+	if err != nil {
+		return err
+	}
+
+	for _, scope := range scopes {
+		if !user.HasAnyProjectPermissions(scope) {
+			return errs.ErrAccessTokenScopeInvalid
+		}
+	}
+	draft.Scopes = scopes
+
+	return nil
+}
+
+// This function simply calls CreateAccessTokenEx with the global Write connection.
+func CreateAccessToken(user *modext.User, draft AccessTokenDraft) (*modext.AccessToken, error) {
+	return CreateAccessTokenEx(WriteDB, user, draft)
+}
+
+// CreateAccessTokenEx creates a personal access token for the given user,
+// with scopes limited to the permissions of the user. The token is only
+// returned here, as only its hash is stored.
+func CreateAccessTokenEx(e boil.Executor, user *modext.User, draft AccessTokenDraft) (*modext.AccessToken, error) {
+	if err := draft.validate(user); err != nil {
+		return nil, err
+	}
+
+	token, err := newAccessToken()
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	t := &models.AccessToken{
+		UserID: user.ID,
+		Name:   draft.Name,
+		Hash:   hashAccessToken(token),
+		Prefix: token[:len(AccessTokenPrefix)+4],
+		Scopes: draft.Scopes,
+	}
+
+	if draft.ExpiresAt > 0 {
+		t.ExpiresAt = null.TimeFrom(time.Unix(draft.ExpiresAt, 0).UTC())
+	}
+
+	if err := t.Insert(e, boil.Infer()); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	result := modext.NewAccessToken(t)
+	result.Token = token
+	return result, nil
+}
+
+// This function simply calls GetAccessTokensEx with the global Read connection.
+func GetAccessTokens(user *modext.User) ([]*modext.AccessToken, error) {
+	return GetAccessTokensEx(ReadDB, user)
+}
+
+// GetAccessTokensEx gets the personal access tokens of the given user,
+// results are sorted from the most recent.
+func GetAccessTokensEx(e boil.Executor, user *modext.User) ([]*modext.AccessToken, error) {
+	tokens, err := models.AccessTokens(
+		Where("user_id = ?", user.ID),
+		OrderBy("id DESC"),
+	).All(e)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	result := make([]*modext.AccessToken, len(tokens))
+	for i, t := range tokens {
+		result[i] = modext.NewAccessToken(t)
+	}
+	return result, nil
+}
+
+// This function simply calls DeleteAccessTokenEx with the global Write connection.
+func DeleteAccessToken(user *modext.User, id int64) error {
+	return DeleteAccessTokenEx(WriteDB, user, id)
+}
+
+// DeleteAccessTokenEx revokes a personal access token of the given user.
+func DeleteAccessTokenEx(e boil.Executor, user *modext.User, id int64) error {
+	t, err := models.AccessTokens(Where("id = ? AND user_id = ?", id, user.ID)).One(e)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errs.ErrAccessTokenNotFound
+		}
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	if err := t.Delete(e); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+	return nil
+}
+
+// VerifyAccessToken verifies the given personal access token, and returns
+// the uid of its user and its scopes if the token is valid.
+// The last usage of the token is recorded along with the given ip.
+func VerifyAccessToken(token, ip string) (uid int64, scopes []string, err error) {
+	if !strings.HasPrefix(token, AccessTokenPrefix) {
+		return 0, nil, errs.ErrInvalidToken
+	}
+
+	t, err := models.AccessTokens(Where("hash = ?", hashAccessToken(token))).One(ReadDB)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil, errs.ErrInvalidToken
+		}
+		log.Println(err)
+		return 0, nil, errs.ErrUnknown
+	}
+
+	now := time.Now().UTC()
+	if t.ExpiresAt.Valid && !t.ExpiresAt.Time.After(now) {
+		return 0, nil, errs.ErrInvalidToken
+	}
+
+	if !t.LastUsedAt.Valid || now.Sub(t.LastUsedAt.Time) >= accessTokenUsageInterval {
+		go func() {
+			t.LastUsedAt = null.TimeFrom(now)
+			t.LastUsedIP = null.NewString(ip, len(ip) > 0)
+			if err := t.Update(WriteDB, boil.Whitelist(AccessTokenCols.LastUsedAt, AccessTokenCols.LastUsedIP)); err != nil {
+				log.Println(err)
+			}
+		}()
+	}
+
+	return t.UserID, t.Scopes, nil
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+
+	. "kasen/cache"
+	. "kasen/database"
+
+	"kasen/errs"
+	"kasen/models"
+	"kasen/storage"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gosimple/slug"
+	"github.com/pkg/errors"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// archivesBuilding keeps track of the archives being generated, only one
+// request generates a given archive at a time, concurrent requests stream
+// it without caching.
+var archivesBuilding = struct {
+	sync.Mutex
+	keys map[string]bool
+}{keys: make(map[string]bool)}
+
+// archivesGenerationKey gets the Redis key of the archives generation of
+// the given project, it's increased every time the archives are invalidated.
+func archivesGenerationKey(pid int64) string {
+	return fmt.Sprintf("archives:generation:%d", pid)
+}
+
+// getArchivesGeneration gets the archives generation of the given project.
+func getArchivesGeneration(pid int64) (int64, error) {
+	generation, err := Redis.Get(context.Background(), archivesGenerationKey(pid)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return generation, err
+}
+
+// archivesKey gets the storage key of the given cached archive,
+// or the prefix of the cached archives of the project if the
+// name is empty.
+func archivesKey(pid int64, name string) string {
+	return fmt.Sprintf("archives/%d/%s", pid, name)
+}
+
+// invalidateArchives increases the archives generation of the given project,
+// so that its cached archives are no longer served, and removes them.
+func invalidateArchives(pid int64) {
+	if err := Redis.Incr(context.Background(), archivesGenerationKey(pid)).Err(); err != nil {
+		log.Println(err)
+	}
+
+	go func() {
+		if err := removeObjects(archivesKey(pid, "")); err != nil {
+			log.Println(err)
+		}
+	}()
+}
+
+// DownloadProjectOptions represents the options for downloading a project.
+type DownloadProjectOptions struct {
+	// Volume limits the archive to the chapters of the given volume,
+	// all chapters are included if it's empty.
+	Volume string
+
+	// Format is either DownloadFormatZip, which puts the pages of
+	// every chapter into its own directory, DownloadFormatCBZ,
+	// which puts every chapter into its own CBZ, or DownloadFormatEPUB,
+	// which puts every page into a single EPUB.
+	Format string
+}
+
+// DownloadProject downloads all published chapters of the given project,
+// or of one of its volumes, as a zip or as an EPUB.
+//
+// Archives are streamed while being generated, and cached in the storage
+// until the chapters or the project are updated.
+func DownloadProject(pid int64, opts DownloadProjectOptions, w http.ResponseWriter, r *http.Request) error {
+	switch opts.Format {
+	case DownloadFormatCBZ, DownloadFormatEPUB:
+	default:
+		opts.Format = DownloadFormatZip
+	}
+
+	p, err := models.Projects(
+		Where("id = ?", pid),
+		Where("published_at IS NOT NULL"),
+		Load(ProjectRels.Artists),
+		Load(ProjectRels.Authors),
+		Load(ProjectRels.Tags),
+		Load(ProjectRels.Cover),
+	).One(ReadDB)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errs.ErrProjectNotFound
+		}
+		return errors.Wrap(err, "Failed to get project")
+	}
+
+	name, title := p.Slug, p.Title
+	cacheName := "all"
+	if len(opts.Volume) > 0 {
+		name += "_vol-" + slug.Make(opts.Volume)
+		title += " - Vol. " + opts.Volume
+		cacheName = fmt.Sprintf("volume-%x", opts.Volume)
+	}
+
+	ext := "zip"
+	if opts.Format == DownloadFormatEPUB {
+		ext = "epub"
+	}
+	cacheName += "." + opts.Format + "." + ext
+
+	disposition := fmt.Sprintf("attachment; filename=%s.%s", name, ext)
+	if opts.Format == DownloadFormatEPUB {
+		w.Header().Set("Content-Type", "application/epub+zip")
+	}
+
+	generation, err := getArchivesGeneration(pid)
+	if err != nil {
+		log.Println(err)
+		generation = -1
+	}
+
+	key := archivesKey(pid, fmt.Sprintf("%d/%s", generation, cacheName))
+	if generation >= 0 {
+		if info, err := store.Stat(key); err == nil {
+			w.Header().Set("Content-Disposition", disposition)
+			proxyObject(key, info, w, r)
+			return nil
+		} else if !errors.Is(err, storage.ErrNotExist) {
+			log.Println(err)
+		}
+	}
+
+	selectQueries := []QueryMod{
+		Where("project_id = ?", pid),
+		Where("published_at IS NOT NULL"),
+		OrderBy(`
+		CAST(NULLIF(regexp_replace(chapter.volume, '\D', '', 'g'), '') AS double precision) ASC,
+		COALESCE(chapter.chapter, '')::bytea ASC`),
+	}
+	if len(opts.Volume) > 0 {
+		selectQueries = append(selectQueries, Where("volume = ?", opts.Volume))
+	}
+	if opts.Format != DownloadFormatZip {
+		selectQueries = append(selectQueries, Load(ChapterRels.ScanlationGroups))
+	}
+
+	chapters, err := models.Chapters(selectQueries...).All(ReadDB)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get chapters")
+	} else if len(chapters) == 0 {
+		return errs.ErrChapterNotFound
+	}
+
+	for _, c := range chapters {
+		if c.R == nil {
+			c.R = c.R.NewStruct()
+		}
+		c.R.Project = p
+	}
+
+	w.Header().Set("Content-Disposition", disposition)
+
+	// Archives are not cached if the generation is unknown.
+	if generation < 0 {
+		return writeProjectArchive(w, p, title, chapters, opts.Format)
+	}
+
+	archivesBuilding.Lock()
+	building := archivesBuilding.keys[key]
+	if !building {
+		archivesBuilding.keys[key] = true
+	}
+	archivesBuilding.Unlock()
+
+	if building {
+		return writeProjectArchive(w, p, title, chapters, opts.Format)
+	}
+
+	defer func() {
+		archivesBuilding.Lock()
+		delete(archivesBuilding.keys, key)
+		archivesBuilding.Unlock()
+	}()
+
+	tmp, err := os.CreateTemp(GetTempDir(), "tmp-")
+	if err != nil {
+		log.Println(err)
+		return writeProjectArchive(w, p, title, chapters, opts.Format)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := writeProjectArchive(io.MultiWriter(w, tmp), p, title, chapters, opts.Format); err != nil {
+		return err
+	}
+
+	if err := putObject(key, tmp); err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	// The chapters have been updated while the archive was generated,
+	// and the outdated archive may have been stored after the cached
+	// archives were removed.
+	if current, err := getArchivesGeneration(pid); err != nil || current != generation {
+		if err := store.Delete(key); err != nil {
+			log.Println(err)
+		}
+	}
+	return nil
+}
+
+// writeProjectArchive writes the given chapters as a zip, or as an EPUB.
+func writeProjectArchive(w io.Writer, p *models.Project, title string, chapters []*models.Chapter, format string) error {
+	if format == DownloadFormatEPUB {
+		return writeEPUB(w, newEPUBBook(p, title, chapters))
+	}
+
+	zw := zip.NewWriter(w)
+	for _, c := range chapters {
+		name := slug.Make(formatChapterModel(c))
+
+		if format == DownloadFormatZip {
+			if err := writeChapterPages(zw, c, name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Pages are already compressed.
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".cbz", Method: zip.Store})
+		if err != nil {
+			return errors.Wrap(err, "Failed to create file in zip")
+		}
+
+		cbz := zip.NewWriter(f)
+		if err := writeChapterCBZ(cbz, c); err != nil {
+			return err
+		}
+
+		if err := cbz.Close(); err != nil {
+			return errors.Wrap(err, "Failed to write CBZ")
+		}
+	}
+	return zw.Close()
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	. "kasen/database"
+
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var AuditLogCols = models.AuditLogColumns
+
+// Audit actions.
+const (
+	AuditCreate            = "create"
+	AuditUpdate            = "update"
+	AuditDelete            = "delete"
+	AuditRestore           = "restore"
+	AuditPurge             = "purge"
+	AuditPublish           = "publish"
+	AuditUnpublish         = "unpublish"
+	AuditLock              = "lock"
+	AuditUnlock            = "unlock"
+	AuditSchedule          = "schedule"
+	AuditUnschedule        = "unschedule"
+	AuditUploadPages       = "upload_pages"
+	AuditDeletePage        = "delete_page"
+	AuditRollback          = "rollback"
+	AuditSetCover          = "set_cover"
+	AuditUpdatePassword    = "update_password"
+	AuditUpdatePermissions = "update_permissions"
+	AuditUpdateRoles       = "update_roles"
+	AuditUpdateMember      = "update_member"
+	AuditRemoveMember      = "remove_member"
+	AuditLinkIdentity      = "link_identity"
+	AuditUnlinkIdentity    = "unlink_identity"
+	AuditEnableTwoFactor   = "enable_two_factor"
+	AuditDisableTwoFactor  = "disable_two_factor"
+	AuditRecoveryCodes     = "recovery_codes"
+	AuditRevokeSessions    = "revoke_sessions"
+	AuditReuseRefreshToken = "reuse_refresh_token"
+)
+
+// Audit target types.
+const (
+	AuditTargetProject         = "project"
+	AuditTargetChapter         = "chapter"
+	AuditTargetCover           = "cover"
+	AuditTargetUser            = "user"
+	AuditTargetAuthor          = "author"
+	AuditTargetTag             = "tag"
+	AuditTargetScanlationGroup = "scanlation_group"
+	AuditTargetWebhook         = "webhook"
+	AuditTargetConfig          = "config"
+	AuditTargetRole            = "role"
+	AuditTargetInvite          = "invite"
+)
+
+// auditChange represents the change of a field.
+type auditChange struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// auditFields gets the JSON fields of the given value.
+func auditFields(v interface{}) (map[string]json.RawMessage, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// auditDiff gets the fields which differ between the given values,
+// either of which can be nil if the target was created or deleted.
+func auditDiff(before, after interface{}) ([]byte, error) {
+	b, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]*auditChange)
+	for k, v := range b {
+		if av, ok := a[k]; !ok || !bytes.Equal(v, av) {
+			diff[k] = &auditChange{Before: v, After: a[k]}
+		}
+	}
+
+	for k, v := range a {
+		if _, ok := b[k]; !ok {
+			diff[k] = &auditChange{After: v}
+		}
+	}
+	return json.Marshal(diff)
+}
+
+// recordAudit records a mutation made by the given actor, the actor is
+// nil if it was made by Kasen itself. Failures are only logged, as the
+// mutation has already been made.
+func recordAudit(actor *modext.User, action, targetType string, targetID int64, before, after interface{}) {
+	diff, err := auditDiff(before, after)
+	if err != nil {
+		log.Println(err)
+		diff = []byte("{}")
+	}
+
+	l := &models.AuditLog{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   null.NewInt64(targetID, targetID > 0),
+		Diff:       diff,
+	}
+
+	if actor != nil {
+		l.ActorID = null.NewInt64(actor.ID, actor.ID > 0)
+		l.ActorName = null.StringFrom(actor.Name)
+		l.IP = null.NewString(actor.IP, len(actor.IP) > 0)
+	}
+
+	if err := l.Insert(WriteDB, boil.Infer()); err != nil {
+		log.Println(err)
+	}
+}
+
+// GetAuditLogsOptions represents the options for getting the audit log.
+type GetAuditLogsOptions struct {
+	ActorID    int64  `form:"actor"`
+	Action     string `form:"action"`
+	TargetType string `form:"targetType"`
+	TargetID   int64  `form:"targetId"`
+	Since      int64  `form:"since"`
+	Until      int64  `form:"until"`
+	Limit      int    `form:"limit"`
+	Offset     int    `form:"offset"`
+}
+
+func (opts *GetAuditLogsOptions) validate() {
+	opts.Action = strings.ToLower(opts.Action)
+	opts.TargetType = strings.ToLower(opts.TargetType)
+
+	if opts.Limit <= 0 || opts.Limit > 100 {
+		opts.Limit = 100
+	}
+
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+}
+
+// GetAuditLogsResult represents the result of GetAuditLogs.
+type GetAuditLogsResult struct {
+	Logs  []*modext.AuditLog `json:"data"`
+	Total int64              `json:"total"`
+	Err   error              `json:"error,omitempty"`
+}
+
+// This function simply calls GetAuditLogsEx with the global Read connection.
+func GetAuditLogs(opts GetAuditLogsOptions) *GetAuditLogsResult {
+	return GetAuditLogsEx(ReadDB, opts)
+}
+
+// GetAuditLogsEx gets the audit log with the given options,
+// ordered from the most recent.
+func GetAuditLogsEx(e boil.Executor, opts GetAuditLogsOptions) *GetAuditLogsResult {
+	opts.validate()
+
+	var queries []QueryMod
+	if opts.ActorID > 0 {
+		queries = append(queries, Where("actor_id = ?", opts.ActorID))
+	}
+
+	if len(opts.Action) > 0 {
+		queries = append(queries, Where("action = ?", opts.Action))
+	}
+
+	if len(opts.TargetType) > 0 {
+		queries = append(queries, Where("target_type = ?", opts.TargetType))
+	}
+
+	if opts.TargetID > 0 {
+		queries = append(queries, Where("target_id = ?", opts.TargetID))
+	}
+
+	if opts.Since > 0 {
+		queries = append(queries, Where("created_at >= ?", time.Unix(opts.Since, 0).UTC()))
+	}
+
+	if opts.Until > 0 {
+		queries = append(queries, Where("created_at < ?", time.Unix(opts.Until, 0).UTC()))
+	}
+
+	result := &GetAuditLogsResult{}
+
+	total, err := models.AuditLogs(queries...).Count(e)
+	if err != nil {
+		log.Println(err)
+		result.Err = errs.ErrUnknown
+		return result
+	}
+
+	queries = append(queries,
+		OrderBy(fmt.Sprintf("%s DESC", AuditLogCols.ID)),
+		Limit(opts.Limit),
+		Offset(opts.Offset))
+
+	logs, err := models.AuditLogs(queries...).All(e)
+	if err != nil {
+		log.Println(err)
+		result.Err = errs.ErrUnknown
+		return result
+	}
+
+	result.Total = total
+	result.Logs = make([]*modext.AuditLog, len(logs))
+	for i, l := range logs {
+		result.Logs[i] = modext.NewAuditLog(l)
+	}
+	return result
+}
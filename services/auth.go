@@ -2,9 +2,11 @@ package services
 
 import (
 	"log"
+	"strings"
 
 	"kasen/config"
 	"kasen/errs"
+	"kasen/modext"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -13,84 +15,157 @@ import (
 type LoginOptions struct {
 	Email       string
 	RawPassword string
+	Client      SessionClient
 }
 
 // Login logs in a user with the given options
 // and returns a new refresh and session token if successful,
 // or an error if user does not exist or the password is incorrect.
-func Login(opts LoginOptions) (rt, st *Token, err error) {
+// The logins of an account which failed too many times are delayed,
+// and then locked for a while.
+//
+// If the user enabled two-factor authentication, a challenge is returned
+// instead of the tokens, which is completed with LoginTwoFactor.
+func Login(opts LoginOptions) (rt, st *Token, challenge string, err error) {
 	switch {
 	case len(opts.Email) == 0:
-		return nil, nil, errs.ErrEmailRequired
+		return nil, nil, "", errs.ErrEmailRequired
 	case len(opts.Email) > 255:
-		return nil, nil, errs.ErrEmailTooLong
+		return nil, nil, "", errs.ErrEmailTooLong
 	case len(opts.RawPassword) == 0:
-		return nil, nil, errs.ErrPasswordRequired
+		return nil, nil, "", errs.ErrPasswordRequired
 	case len(opts.RawPassword) < 6:
-		return nil, nil, errs.ErrPasswordTooShort
+		return nil, nil, "", errs.ErrPasswordTooShort
 	case !isEmail(opts.Email):
-		return nil, nil, errs.ErrEmailInvalid
+		return nil, nil, "", errs.ErrEmailInvalid
+	}
+
+	failures, err := beginLogin(opts.Email, opts.Client)
+	if err != nil {
+		return nil, nil, "", err
 	}
 
 	u, err := GetUserByEmail(opts.Email)
 	if err != nil {
-		if err != errs.ErrUserNotFound {
+		if err == errs.ErrUserNotFound {
+			// Unknown emails are locked as well, so that
+			// the lockout doesn't tell whether they exist.
+			recordLoginFailure(opts.Email, nil, opts.Client, LoginFailureInvalidCredentials, failures)
+		} else {
 			log.Println(err)
+			uncountLogin(opts.Email)
 		}
-		return nil, nil, errs.ErrInvalidCredentials
+		return nil, nil, "", errs.ErrInvalidCredentials
 	}
 
 	if err := u.CheckPassword(opts.RawPassword); err != nil {
 		if err == bcrypt.ErrMismatchedHashAndPassword {
-			return nil, nil, errs.ErrInvalidCredentials
+			recordLoginFailure(opts.Email, u, opts.Client, LoginFailureInvalidCredentials, failures)
+			return nil, nil, "", errs.ErrInvalidCredentials
 		}
 		log.Println(err)
-		return nil, nil, errs.ErrUnknown
+		uncountLogin(opts.Email)
+		return nil, nil, "", errs.ErrUnknown
+	}
+
+	// The login still counts as failed until the challenge is completed.
+	if !u.TwoFactorEnabled {
+		if err := clearLoginFailures(opts.Email); err != nil {
+			log.Println(err)
+		}
+	}
+
+	if !u.EmailVerified {
+		if err := sendEmailVerification(u); err != nil {
+			return nil, nil, "", err
+		}
+		return nil, nil, "", errs.ErrEmailNotVerified
+	}
+
+	return issueLoginTokens(u, opts.Client)
+}
+
+// issueLoginTokens returns a new refresh and session token for the given user,
+// or a two-factor authentication challenge if the user enabled it.
+func issueLoginTokens(u *modext.User, client SessionClient) (rt, st *Token, challenge string, err error) {
+	if u.TwoFactorEnabled {
+		challenge, err = createTwoFactorChallenge(u.ID)
+		return nil, nil, challenge, err
 	}
 
-	rt, st, err = CreateToken(u.ID)
+	rt, st, err = CreateToken(u.ID, client)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
 	}
-	return rt, st, nil
+	return rt, st, "", nil
 }
 
-// Logout deletes the given session and refresh tokens.
+// Logout deletes the given session and refresh tokens,
+// and revokes their session.
 func Logout(st, rt string) error {
-	security := config.GetSecurity()
-	DeleteToken(st, security.JWTSessionSecret)
-	return DeleteToken(rt, security.JWTRefreshSecret)
+	if uid, sid, err := verifyToken(rt, tokenRefresh); err == nil {
+		revokeSession(uid, sid)
+	}
+
+	DeleteToken(st, tokenSession)
+	return DeleteToken(rt, tokenRefresh)
 }
 
 // Register creates a new user with the given registration options
-// and returns a new refresh and session token if successful,
-// or an error if user already exists.
-func Register(opts CreateUserOptions) (rt, st *Token, err error) {
-	u, err := CreateUser(opts)
+// and sends an email verification link, the user can log in once
+// the email is verified. Returns an error if user already exists.
+//
+// An invite code is required when the registration is disabled,
+// the user is then granted the role and permissions of the invite.
+func Register(opts CreateUserOptions, invite string) (*modext.User, error) {
+	opts.EmailVerified = false
+
+	var u *modext.User
+	var err error
+	if len(strings.TrimSpace(invite)) > 0 {
+		u, err = createInvitedUser(opts, invite)
+	} else if config.GetService().DisableRegistration {
+		return nil, errs.ErrInviteRequired
+	} else {
+		u, err = CreateUser(opts)
+	}
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	rt, st, err = CreateToken(u.ID)
-	if err != nil {
-		return nil, nil, err
+	if err := sendEmailVerification(u); err != nil {
+		return nil, err
 	}
-	return rt, st, nil
+	return u, nil
 }
 
-// RefreshToken refreshes session token using the given refresh token,
-// returns uid of the user and a new session token if successful,
-// or an error if the refresh token is invalid.
-func RefreshToken(rt string) (uid int64, st *Token, err error) {
-	uid, err = VerifyRefreshToken(rt)
+// RefreshToken refreshes session token using the given refresh token, which
+// is rotated. Returns uid of the user, the id of the session, a new refresh
+// and session token if successful, or an error if the refresh token is invalid.
+// The activity of the session is recorded along with the given ip.
+//
+// Refresh tokens can only be used once. If the refresh token has just been
+// used by a concurrent request, which was issued the new tokens, no token is
+// returned but the request is still authenticated. Otherwise, the whole
+// session is revoked as the refresh token has likely been stolen.
+func RefreshToken(rt, ip string) (uid int64, sid string, newRt, st *Token, err error) {
+	id, sid, err := parseTokenClaims(rt, tokenRefresh)
+	if err != nil {
+		log.Println(err)
+		return 0, "", nil, nil, errs.ErrInvalidToken
+	}
+
+	uid, newRt, err = rotateSession(sid, id, ip)
 	if err != nil {
-		return 0, nil, err
+		return 0, "", nil, nil, err
+	} else if newRt == nil {
+		return uid, sid, nil, nil, nil
 	}
 
-	st, err = createToken(uid, config.GetSecurity().JWTSessionSecret, SessionExpiration)
+	st, err = createToken(uid, sid, tokenSession)
 	if err != nil {
 		log.Println(err)
-		return 0, nil, errs.ErrUnknown
+		return 0, "", nil, nil, errs.ErrUnknown
 	}
-	return uid, st, nil
+	return uid, sid, newRt, st, nil
 }
@@ -177,12 +177,12 @@ func UpdateAuthorEx(e boil.Executor, id int64, name string) (*modext.Author, err
 }
 
 // This function simply calls DeleteAuthorEx with the global Write connection.
-func DeleteAuthor(id int64) error {
-	return DeleteAuthorEx(WriteDB, id)
+func DeleteAuthor(id int64, user *modext.User) error {
+	return DeleteAuthorEx(WriteDB, id, user)
 }
 
 // DeleteAuthorEx deletes an author.
-func DeleteAuthorEx(e boil.Executor, id int64) error {
+func DeleteAuthorEx(e boil.Executor, id int64, user *modext.User) error {
 	a, err := models.FindAuthor(e, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -197,16 +197,17 @@ func DeleteAuthorEx(e boil.Executor, id int64) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetAuthor, a.ID, modext.NewAuthor(a), nil)
 	return nil
 }
 
 // This function simply calls DeleteAuthorByNameEx with the global Write connection.
-func DeleteAuthorByName(name string) error {
-	return DeleteAuthorByNameEx(WriteDB, name)
+func DeleteAuthorByName(name string, user *modext.User) error {
+	return DeleteAuthorByNameEx(WriteDB, name, user)
 }
 
 // DeleteAuthorByNameEx deletes an author by name.
-func DeleteAuthorByNameEx(e boil.Executor, name string) error {
+func DeleteAuthorByNameEx(e boil.Executor, name string, user *modext.User) error {
 	a, err := models.Authors(Where("name ILIKE ?", name)).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -221,16 +222,17 @@ func DeleteAuthorByNameEx(e boil.Executor, name string) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetAuthor, a.ID, modext.NewAuthor(a), nil)
 	return nil
 }
 
 // This function simply calls DeleteAuthorBySlugEx with the global Write connection.
-func DeleteAuthorBySlug(slug string) error {
-	return DeleteAuthorBySlugEx(WriteDB, slug)
+func DeleteAuthorBySlug(slug string, user *modext.User) error {
+	return DeleteAuthorBySlugEx(WriteDB, slug, user)
 }
 
 // DeleteAuthorBySlugEx deletes an author by slug.
-func DeleteAuthorBySlugEx(e boil.Executor, slug string) error {
+func DeleteAuthorBySlugEx(e boil.Executor, slug string, user *modext.User) error {
 	a, err := models.Authors(Where("slug ILIKE ?", slug)).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -245,16 +247,17 @@ func DeleteAuthorBySlugEx(e boil.Executor, slug string) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetAuthor, a.ID, modext.NewAuthor(a), nil)
 	return nil
 }
 
 // This function simply calls DeleteAuthorBySlugOrNameEx with the global Write connection.
-func DeleteAuthorBySlugOrName(slugOrName string) error {
-	return DeleteAuthorBySlugOrNameEx(WriteDB, slugOrName)
+func DeleteAuthorBySlugOrName(slugOrName string, user *modext.User) error {
+	return DeleteAuthorBySlugOrNameEx(WriteDB, slugOrName, user)
 }
 
 // DeleteAuthorBySlugOrNameEx deletes an author by slug or name.
-func DeleteAuthorBySlugOrNameEx(e boil.Executor, slugOrName string) error {
+func DeleteAuthorBySlugOrNameEx(e boil.Executor, slugOrName string, user *modext.User) error {
 	a, err := models.Authors(Where("slug ILIKE ? OR name ILIKE ?", slugOrName, slugOrName)).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -269,5 +272,6 @@ func DeleteAuthorBySlugOrNameEx(e boil.Executor, slugOrName string) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetAuthor, a.ID, modext.NewAuthor(a), nil)
 	return nil
 }
@@ -7,12 +7,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/fs"
 	"log"
 	"net/http"
-	"os"
-	"path/filepath"
+	"path"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,56 +36,126 @@ func GetChapterCacheStats() *CacheStats {
 	return ChapterCache.GetStats()
 }
 
-// DownloadChapter downloads all pages of the given chapter as a zip.
-func DownloadChapter(cid int64, rw gin.ResponseWriter) error {
-	dir, err := getChapterDir(cid)
-	if err != nil {
-		return errors.Wrap(err, "Failed to get chapter dir")
+// Download formats of chapters.
+const (
+	DownloadFormatZip  = "zip"
+	DownloadFormatCBZ  = "cbz"
+	DownloadFormatEPUB = "epub"
+)
+
+// DownloadChapter downloads all pages of the given chapter as a zip,
+// as a CBZ containing a ComicInfo.xml generated from the chapter
+// and its project, or as a fixed-layout EPUB.
+func DownloadChapter(cid int64, format string, rw gin.ResponseWriter) error {
+	selectQueries := []QueryMod{Where("id = ?", cid), Load(ChapterRels.Project)}
+	switch format {
+	case DownloadFormatCBZ, DownloadFormatEPUB:
+		selectQueries = append(selectQueries,
+			Load(ChapterRels.ScanlationGroups),
+			Load(Rels(ChapterRels.Project, ProjectRels.Artists)),
+			Load(Rels(ChapterRels.Project, ProjectRels.Authors)),
+			Load(Rels(ChapterRels.Project, ProjectRels.Tags)))
+		if format == DownloadFormatEPUB {
+			selectQueries = append(selectQueries, Load(Rels(ChapterRels.Project, ProjectRels.Cover)))
+		}
+	default:
+		format = DownloadFormatZip
 	}
 
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		return errs.ErrChapterNotFound
+	c, err := models.Chapters(selectQueries...).One(ReadDB)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errs.ErrChapterNotFound
+		}
+		return errors.Wrap(err, "Failed to get chapter")
 	}
 
-	projectName := filepath.Base(filepath.Dir(filepath.Dir(dir)))
-	chapterName := filepath.Base(dir)
+	projectName := c.R.Project.Slug
+	chapterName := slug.Make(formatChapterModel(c))
+
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s_%s.%s", projectName, chapterName, format))
+	defer rw.Flush()
 
-	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s_%s.zip", projectName, chapterName))
+	if format == DownloadFormatEPUB {
+		title := fmt.Sprintf("%s - %s", c.R.Project.Title, formatChapterModel(c))
+		rw.Header().Set("Content-Type", "application/epub+zip")
+		return writeEPUB(rw, newEPUBBook(c.R.Project, title, []*models.Chapter{c}))
+	}
 
 	w := zip.NewWriter(rw)
 	defer w.Close()
-	defer rw.Flush()
 
-	walkFn := func(path string, info fs.DirEntry, err error) error {
-		if err != nil || info.IsDir() {
+	if format == DownloadFormatZip {
+		return writeChapterPages(w, c, "")
+	}
+	return writeChapterCBZ(w, c)
+}
+
+// writeChapterPages copies the pages of the given chapter
+// into the given directory of the zip.
+func writeChapterPages(w *zip.Writer, c *models.Chapter, dir string) error {
+	for _, fileName := range c.Pages {
+		if err := copyObjectToZip(w, chapterKey(c.ID, fileName), path.Join(dir, fileName)); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		fileName := filepath.Base(path)
-		if len(filepath.Ext(strings.TrimSuffix(fileName, filepath.Ext(fileName)))) > 0 {
-			return nil
-		}
+// writeChapterCBZ writes the ComicInfo.xml and the pages of
+// the given chapter into the given zip.
+func writeChapterCBZ(w *zip.Writer, c *models.Chapter) error {
+	buf, err := newComicInfo(c).Marshal()
+	if err != nil {
+		return errors.Wrap(err, "Failed to create ComicInfo.xml")
+	}
 
-		file, err := os.Open(path)
-		if err != nil {
-			return errors.Wrap(err, "Failed to open page file")
-		}
-		defer file.Close()
+	f, err := w.Create("ComicInfo.xml")
+	if err != nil {
+		return errors.Wrap(err, "Failed to create file in zip")
+	}
 
-		f, err := w.Create(fileName)
-		if err != nil {
-			return errors.Wrap(err, "Failed to create file in zip")
-		}
+	if _, err := f.Write(buf); err != nil {
+		return errors.Wrap(err, "Failed to write ComicInfo.xml")
+	}
 
-		_, err = io.Copy(f, file)
-		if err != nil {
-			return errors.Wrap(err, "Failed to copy file to zip")
+	// Readers order pages by their file names,
+	// so page numbers have to be zero-padded.
+	for i, fileName := range c.Pages {
+		name := fmt.Sprintf("%0*d%s", pageNumberWidth(len(c.Pages)), i+1, path.Ext(fileName))
+		if err := copyObjectToZip(w, chapterKey(c.ID, fileName), name); err != nil {
+			return err
 		}
+	}
+	return nil
+}
+
+// pageNumberWidth returns the number of digits of zero-padded
+// page numbers, which is at least 3.
+func pageNumberWidth(n int) int {
+	if w := len(strconv.Itoa(n)); w > 3 {
+		return w
+	}
+	return 3
+}
+
+// copyObjectToZip copies the object of the given key into a new file of the zip.
+func copyObjectToZip(w *zip.Writer, key, fileName string) error {
+	obj, err := store.Get(key)
+	if err != nil {
+		return errors.Wrap(err, "Failed to open page file")
+	}
+	defer obj.Close()
 
-		return nil
+	f, err := w.Create(fileName)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create file in zip")
 	}
 
-	return filepath.WalkDir(dir, walkFn)
+	if _, err = io.Copy(f, obj); err != nil {
+		return errors.Wrap(err, "Failed to copy file to zip")
+	}
+	return nil
 }
 
 var ChapterCols = models.ChapterColumns
@@ -131,7 +200,12 @@ func CreateChapter(pid int64, draft ChapterDraft, uploader *modext.User) (*modex
 	return CreateChapterEx(tx, pid, draft, uploader)
 }
 
-func refreshChapterRels(tx *sql.Tx, c *models.Chapter, draft *ChapterDraft) error {
+func refreshChapterRels(tx *sql.Tx, c *models.Chapter, draft *ChapterDraft, user *modext.User) error {
+	var prevScanlationGroups []*models.ScanlationGroup
+	if c.R != nil {
+		prevScanlationGroups = c.R.ScanlationGroups
+	}
+
 	var scanlationGroups []*models.ScanlationGroup
 	for _, g := range draft.ScanlationGroups {
 		g, err := CreateScanlationGroupEx(tx, g)
@@ -144,6 +218,10 @@ func refreshChapterRels(tx *sql.Tx, c *models.Chapter, draft *ChapterDraft) erro
 			Name: g.Name,
 		})
 	}
+	if err := checkScanlationGroupsCredit(tx, user, scanlationGroups, prevScanlationGroups); err != nil {
+		return err
+	}
+
 	if err := c.SetScanlationGroups(tx, false, scanlationGroups...); err != nil {
 		log.Println(err)
 		return errs.ErrUnknown
@@ -153,6 +231,8 @@ func refreshChapterRels(tx *sql.Tx, c *models.Chapter, draft *ChapterDraft) erro
 }
 
 // CreateChapterEx creates a chapter for the given project.
+//
+// This function will return an error if the uploader does not have the necessary permissions.
 func CreateChapterEx(tx *sql.Tx, pid int64, draft ChapterDraft, uploader *modext.User) (*modext.Chapter, error) {
 	if err := draft.validate(); err != nil {
 		return nil, err
@@ -167,6 +247,10 @@ func CreateChapterEx(tx *sql.Tx, pid int64, draft ChapterDraft, uploader *modext
 		return nil, errs.ErrUnknown
 	}
 
+	if uploader != nil && !uploader.HasProjectPermissions(p.ID, constants.PermCreateChapter) {
+		return nil, errs.ErrForbidden
+	}
+
 	c := &models.Chapter{
 		ProjectID: p.ID,
 		Chapter:   draft.Chapter,
@@ -183,7 +267,7 @@ func CreateChapterEx(tx *sql.Tx, pid int64, draft ChapterDraft, uploader *modext
 		return nil, errs.ErrUnknown
 	}
 
-	if err := refreshChapterRels(tx, c, &draft); err != nil {
+	if err := refreshChapterRels(tx, c, &draft, uploader); err != nil {
 		return nil, err
 	}
 
@@ -192,6 +276,8 @@ func CreateChapterEx(tx *sql.Tx, pid int64, draft ChapterDraft, uploader *modext
 		return nil, errs.ErrUnknown
 	}
 
+	recordAudit(uploader, AuditCreate, AuditTargetChapter, c.ID, nil, modext.NewChapter(c).LoadRels(c))
+
 	go refreshTemplatesCache()
 	go createChapterDir(c)
 	go func() {
@@ -624,7 +710,10 @@ func UpdateChapterEx(tx *sql.Tx, id int64, draft *ChapterDraft, user *modext.Use
 		return nil, err
 	}
 
-	c, err := models.FindChapter(tx, id)
+	c, err := models.Chapters(
+		Where("id = ?", id),
+		Load(ChapterRels.ScanlationGroups),
+	).One(tx)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errs.ErrChapterNotFound
@@ -637,12 +726,11 @@ func UpdateChapterEx(tx *sql.Tx, id int64, draft *ChapterDraft, user *modext.Use
 		return nil, errs.ErrChapterLocked
 	}
 
-	if !user.HasPermissions(constants.PermEditChapters) {
-		if c.UploaderID.Int64 != user.ID || !user.HasPermissions(constants.PermEditChapter) {
-			return nil, errs.ErrForbidden
-		}
+	if !hasChapterPermission(tx, user, c, constants.PermEditChapter, constants.PermEditChapters) {
+		return nil, errs.ErrForbidden
 	}
 
+	before := modext.NewChapter(c).LoadScanlationGroups(c)
 	prevChapter := c.Chapter
 	prevVolume := c.Volume.String
 	prevTitle := c.Title.String
@@ -661,9 +749,8 @@ func UpdateChapterEx(tx *sql.Tx, id int64, draft *ChapterDraft, user *modext.Use
 		return nil, errs.ErrUnknown
 	}
 
-	if err := refreshChapterRels(tx, c, draft); err != nil {
-		log.Println(err)
-		return nil, errs.ErrUnknown
+	if err := refreshChapterRels(tx, c, draft, user); err != nil {
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -671,6 +758,8 @@ func UpdateChapterEx(tx *sql.Tx, id int64, draft *ChapterDraft, user *modext.Use
 		return nil, errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditUpdate, AuditTargetChapter, c.ID, before, modext.NewChapter(c).LoadScanlationGroups(c))
+
 	if prevChapter != draft.Chapter || prevVolume != draft.Volume || prevTitle != draft.Title {
 		go renameChapterDir(c)
 	}
@@ -703,22 +792,25 @@ func PublishChapterEx(e boil.Executor, id int64, user *modext.User) (*modext.Cha
 		return nil, errs.ErrChapterLocked
 	}
 
-	if !user.HasPermissions(constants.PermPublishChapters) {
-		if c.UploaderID.Int64 != user.ID || !user.HasPermissions(constants.PermPublishChapter) {
-			return nil, errs.ErrForbidden
-		}
+	if !hasChapterPermission(e, user, c, constants.PermPublishChapter, constants.PermPublishChapters) {
+		return nil, errs.ErrForbidden
 	}
 
+	before := modext.NewChapter(c)
 	updatedAt := c.UpdatedAt
 	c.PublishedAt = null.TimeFrom(time.Now().UTC())
+	c.ScheduledAt.Valid = false
 
-	if err := c.Update(e, boil.Whitelist(ChapterCols.PublishedAt)); err != nil {
+	if err := c.Update(e, boil.Whitelist(ChapterCols.PublishedAt, ChapterCols.ScheduledAt)); err != nil {
 		log.Println(err)
 		return nil, errs.ErrUnknown
 	}
 
 	c.UpdatedAt = updatedAt
+	recordAudit(user, AuditPublish, AuditTargetChapter, c.ID, before, modext.NewChapter(c))
 	go chapterAfterUpdateHook(c)
+	go createPregenerateImagesJob(&PregenerateImagesPayload{ChapterID: c.ID})
+	go dispatchChapterWebhookEvent(WebhookChapterPublished, c.ID)
 	return modext.NewChapter(c), nil
 }
 
@@ -746,22 +838,24 @@ func UnpublishChapterEx(e boil.Executor, id int64, user *modext.User) (*modext.C
 		return nil, errs.ErrChapterLocked
 	}
 
-	if !user.HasPermissions(constants.PermUnpublishChapters) {
-		if c.UploaderID.Int64 != user.ID || !user.HasPermissions(constants.PermUnpublishChapter) {
-			return nil, errs.ErrForbidden
-		}
+	if !hasChapterPermission(e, user, c, constants.PermUnpublishChapter, constants.PermUnpublishChapters) {
+		return nil, errs.ErrForbidden
 	}
 
+	before := modext.NewChapter(c)
 	updatedAt := c.UpdatedAt
 	c.PublishedAt.Valid = false
+	c.ScheduledAt.Valid = false
 
-	if err := c.Update(e, boil.Whitelist(ChapterCols.PublishedAt)); err != nil {
+	if err := c.Update(e, boil.Whitelist(ChapterCols.PublishedAt, ChapterCols.ScheduledAt)); err != nil {
 		log.Println(err)
 		return nil, errs.ErrUnknown
 	}
 
 	c.UpdatedAt = updatedAt
+	recordAudit(user, AuditUnpublish, AuditTargetChapter, c.ID, before, modext.NewChapter(c))
 	go chapterAfterUpdateHook(c)
+	go dispatchChapterWebhookEvent(WebhookChapterUnpublished, c.ID)
 	return modext.NewChapter(c), nil
 }
 
@@ -784,12 +878,11 @@ func LockChapterEx(e boil.Executor, id int64, user *modext.User) (*modext.Chapte
 		return nil, errs.ErrUnknown
 	}
 
-	if !user.HasPermissions(constants.PermLockChapters) {
-		if c.UploaderID.Int64 != user.ID || !user.HasPermissions(constants.PermLockChapter) {
-			return nil, errs.ErrForbidden
-		}
+	if !hasChapterPermission(e, user, c, constants.PermLockChapter, constants.PermLockChapters) {
+		return nil, errs.ErrForbidden
 	}
 
+	before := modext.NewChapter(c)
 	updatedAt := c.UpdatedAt
 	c.Locked = null.BoolFrom(true)
 
@@ -799,6 +892,7 @@ func LockChapterEx(e boil.Executor, id int64, user *modext.User) (*modext.Chapte
 	}
 
 	c.UpdatedAt = updatedAt
+	recordAudit(user, AuditLock, AuditTargetChapter, c.ID, before, modext.NewChapter(c))
 	go chapterAfterUpdateHook(c)
 	return modext.NewChapter(c), nil
 }
@@ -822,12 +916,11 @@ func UnlockChapterEx(e boil.Executor, id int64, user *modext.User) (*modext.Chap
 		return nil, errs.ErrUnknown
 	}
 
-	if !user.HasPermissions(constants.PermUnlockChapters) {
-		if c.UploaderID.Int64 != user.ID || !user.HasPermissions(constants.PermUnlockChapter) {
-			return nil, errs.ErrForbidden
-		}
+	if !hasChapterPermission(e, user, c, constants.PermUnlockChapter, constants.PermUnlockChapters) {
+		return nil, errs.ErrForbidden
 	}
 
+	before := modext.NewChapter(c)
 	updatedAt := c.UpdatedAt
 	c.Locked = null.NewBool(false, false)
 
@@ -837,6 +930,7 @@ func UnlockChapterEx(e boil.Executor, id int64, user *modext.User) (*modext.Chap
 	}
 
 	c.UpdatedAt = updatedAt
+	recordAudit(user, AuditUnlock, AuditTargetChapter, c.ID, before, modext.NewChapter(c))
 	go chapterAfterUpdateHook(c)
 	return modext.NewChapter(c), nil
 }
@@ -846,7 +940,8 @@ func DeleteChapter(id int64, user *modext.User) error {
 	return DeleteChapterEx(WriteDB, id, user)
 }
 
-// DeleteChapterEx deletes a chapter.
+// DeleteChapterEx moves a chapter to the trash, it's purged
+// once the retention period is over.
 //
 // This function will return an error if the chapter is locked.
 // It will also return an error if the user does not have the necessary permissions.
@@ -864,22 +959,22 @@ func DeleteChapterEx(e boil.Executor, id int64, user *modext.User) error {
 		return errs.ErrChapterLocked
 	}
 
-	if !user.HasPermissions(constants.PermDeleteChapters) {
-		if c.UploaderID.Int64 != user.ID || !user.HasPermissions(constants.PermDeleteChapter) {
-			return errs.ErrForbidden
-		}
+	if !hasChapterPermission(e, user, c, constants.PermDeleteChapter, constants.PermDeleteChapters) {
+		return errs.ErrForbidden
 	}
 
-	if err := c.Delete(e); err != nil {
+	if err := c.Delete(e, false); err != nil {
 		log.Println(err)
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetChapter, c.ID, modext.NewChapter(c).LoadPages(c), nil)
+
 	ChapterCache.PurgeWithPrefix(c.ID)
 	PagesCache.RemoveWithInt64(c.ID)
 	go refreshTemplatesCache()
+	go invalidateArchives(c.ProjectID)
 
-	go removeChapterDir(c)
 	go func() {
 		refreshProjectChaptersCache(c.ProjectID)
 		refreshChaptersCache()
@@ -13,6 +13,7 @@ func chapterAfterUpdateHook(c *models.Chapter) {
 	refreshProjectChaptersCache(c.ProjectID)
 	refreshChapterCache(c.ID)
 	refreshChaptersCache()
+	invalidateArchives(c.ProjectID)
 }
 
 func init() {
@@ -1,18 +1,18 @@
 package services
 
 import (
-	"bytes"
+	"archive/zip"
 	"context"
 	"crypto/sha256"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/fs"
 	"log"
 	"mime/multipart"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -31,6 +31,7 @@ import (
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/pkg/errors"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 // GetCoverCacheStats gets the cache stats of the cover LRU cache.
@@ -40,32 +41,23 @@ func GetPagesCacheStats() *CacheStats {
 
 // ServePage serves the page file.
 func ServePage(id int64, fn string, width int, w http.ResponseWriter, r *http.Request) {
-	dir, err := getChapterDir(id)
-	if err != nil {
-		log.Println(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	// Files of deleted chapters are kept until they're purged
+	if GetPages(id).Err == errs.ErrChapterNotFound {
+		w.WriteHeader(http.StatusNotFound)
 		return
 	}
 
-	fp := filepath.Join(dir, fn)
-	if _, err := os.Stat(fp); os.IsNotExist(err) {
+	key := chapterKey(id, fn)
+	if ok, err := objectExists(key); err != nil {
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	} else if !ok {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
 
-	if width > 0 && width <= 1024 && width%64 == 0 {
-		original := fp
-		fp = fmt.Sprintf("%s.%d.jpg", fp, width)
-
-		if _, err := os.Stat(fp); os.IsNotExist(err) {
-			if err := resizeImage(original, fp, ResizeOptions{Width: width}); err != nil {
-				log.Println(err)
-				fp = original
-			}
-		}
-	}
-
-	http.ServeFile(w, r, fp)
+	serveImage(key, ResizeOptions{Width: width}, w, r)
 }
 
 // This function simply calls GetCoverEx with the global Write connection.
@@ -76,31 +68,9 @@ func UploadPage(cid int64, fileName string, f *os.File, uploader *modext.User) (
 // UploadPageEx uploads a page for the given chapter
 // and returns the updated chapter pages.
 func UploadPageEx(e boil.Executor, cid int64, fileName string, f *os.File, uploader *modext.User) ([]string, error) {
-	if f == nil {
-		return nil, errs.ErrPageInvalid
-	}
-
-	stat, err := f.Stat()
-	if err != nil {
-		log.Println(err)
-		return nil, errs.ErrUnknown
-	}
-
-	if sz := int(stat.Size()); sz <= 0 {
-		return nil, errs.ErrPageInvalid
-	} else if sz > config.GetService().PageMaxFileSize {
-		return nil, errs.ErrPageTooLarge
-	}
-
-	f.Seek(0, io.SeekStart)
-	mime, err := mimetype.DetectReader(f)
+	hash, ext, err := validatePage(f)
 	if err != nil {
-		log.Println(err)
-		return nil, errs.ErrUnknown
-	}
-
-	if !stringsContains(imageMimeTypes, mime.String()) {
-		return nil, errs.ErrPageUnsupportedFormat
+		return nil, err
 	}
 
 	c, err := models.FindChapter(e, cid)
@@ -116,15 +86,8 @@ func UploadPageEx(e boil.Executor, cid int64, fileName string, f *os.File, uploa
 		return nil, errs.ErrChapterLocked
 	}
 
-	if !uploader.HasPermissions(constants.PermEditChapters) {
-		if c.UploaderID.Int64 != uploader.ID || !uploader.HasPermissions(constants.PermEditChapter) {
-			return nil, errs.ErrForbidden
-		}
-	}
-
-	dir, err := getChapterDir(cid)
-	if err != nil {
-		return nil, err
+	if !hasChapterPermission(e, uploader, c, constants.PermEditChapter, constants.PermEditChapters) {
+		return nil, errs.ErrForbidden
 	}
 
 	pageNum := getPageNum(fileName)
@@ -132,48 +95,32 @@ func UploadPageEx(e boil.Executor, cid int64, fileName string, f *os.File, uploa
 		pageNum = len(c.Pages) + 1
 	}
 
-	hasher := sha256.New()
-	f.Seek(0, io.SeekStart)
-	if _, err := io.Copy(hasher, f); err != nil {
-		log.Println(err)
-		return nil, errs.ErrUnknown
-	}
-
-	hash := hasher.Sum(nil)
-	hasher.Reset()
-
-	ext := mime.Extension()
-
 	fn := fmt.Sprintf("%d-%x%s", pageNum, hash, ext)
-	fp := filepath.Join(dir, fn)
+	key := chapterKey(cid, fn)
 
-	if _, err := os.Stat(fp); os.IsNotExist(err) {
-		var buf bytes.Buffer
-		f.Seek(0, io.SeekStart)
-		if _, err = io.Copy(&buf, f); err != nil {
-			log.Println(err)
-			return nil, errs.ErrUnknown
-		}
-
-		if err := WriteFile(fp, buf.Bytes()); err != nil {
+	if ok, err := objectExists(key); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if !ok {
+		if err := putObject(key, f); err != nil {
 			log.Println(err)
 			return nil, errs.ErrUnknown
 		}
 	}
 
 	if !stringsContains(c.Pages, fn) {
+		prevPages := append([]string{}, c.Pages...)
 		c.Pages = append(c.Pages, fn)
-		sort.SliceStable(c.Pages, func(i, j int) bool {
-			prev, _ := strconv.ParseInt(rgx.FindString(c.Pages[i]), 10, 64)
-			next, _ := strconv.ParseInt(rgx.FindString(c.Pages[j]), 10, 64)
-			return prev < next
-		})
+		sortPages(c.Pages)
 
 		if err := c.Update(e, boil.Whitelist(ChapterCols.Pages, ChapterCols.UpdatedAt)); err != nil {
 			log.Println(err)
 			return nil, errs.ErrUnknown
 		}
 
+		recordChapterRevision(e, c, prevPages, RevisionUploadPages, uploader)
+		recordAudit(uploader, AuditUploadPages, AuditTargetChapter, c.ID,
+			map[string][]string{"pages": prevPages}, map[string][]string{"pages": c.Pages})
 		refreshPagesCache(cid, c.Pages)
 		go chapterAfterUpdateHook(c)
 	}
@@ -181,6 +128,55 @@ func UploadPageEx(e boil.Executor, cid int64, fileName string, f *os.File, uploa
 	return c.Pages, nil
 }
 
+// validatePage validates the size and the format of the given page file,
+// and returns the sha256 hash and the extension of the file.
+func validatePage(f *os.File) (hash []byte, ext string, err error) {
+	if f == nil {
+		return nil, "", errs.ErrPageInvalid
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		log.Println(err)
+		return nil, "", errs.ErrUnknown
+	}
+
+	if sz := int(stat.Size()); sz <= 0 {
+		return nil, "", errs.ErrPageInvalid
+	} else if sz > config.GetService().PageMaxFileSize {
+		return nil, "", errs.ErrPageTooLarge
+	}
+
+	f.Seek(0, io.SeekStart)
+	mime, err := mimetype.DetectReader(f)
+	if err != nil {
+		log.Println(err)
+		return nil, "", errs.ErrUnknown
+	}
+
+	if !stringsContains(imageMimeTypes, mime.String()) {
+		return nil, "", errs.ErrPageUnsupportedFormat
+	}
+
+	hasher := sha256.New()
+	f.Seek(0, io.SeekStart)
+	if _, err := io.Copy(hasher, f); err != nil {
+		log.Println(err)
+		return nil, "", errs.ErrUnknown
+	}
+
+	return hasher.Sum(nil), mime.Extension(), nil
+}
+
+// sortPages sorts the given pages by their page numbers.
+func sortPages(pages []string) {
+	sort.SliceStable(pages, func(i, j int) bool {
+		prev, _ := strconv.ParseInt(rgx.FindString(pages[i]), 10, 64)
+		next, _ := strconv.ParseInt(rgx.FindString(pages[j]), 10, 64)
+		return prev < next
+	})
+}
+
 // This function simply calls UploadPageMultipartEx with the global Write connection.
 func UploadPageMultipart(cid int64, fh *multipart.FileHeader, uploader *modext.User) ([]string, error) {
 	return UploadPageMultipartEx(WriteDB, cid, fh, uploader)
@@ -239,6 +235,292 @@ func UploadPageFromSourceEx(e boil.Executor, cid int64, source string, uploader
 	return UploadPageEx(WriteDB, cid, fn, tmp, uploader)
 }
 
+// This function simply calls UploadPagesArchiveEx with a new write transaction.
+func UploadPagesArchive(cid int64, f *os.File, uploader *modext.User) ([]string, error) {
+	tx, err := WriteDB.Begin()
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return UploadPagesArchiveEx(tx, cid, f, uploader)
+}
+
+// isArchiveEntryIgnored checks if the given archive entry is not a page,
+// such as directories, metadata and files created by the OS.
+func isArchiveEntryIgnored(zf *zip.File) bool {
+	name := path.Base(zf.Name)
+	return zf.FileInfo().IsDir() ||
+		strings.HasPrefix(zf.Name, "__MACOSX/") ||
+		strings.HasPrefix(name, ".") ||
+		strings.EqualFold(name, "ComicInfo.xml") ||
+		strings.EqualFold(name, "Thumbs.db")
+}
+
+// extractArchiveEntry extracts the given archive entry to a temporary file,
+// of at most the given remaining size of the archive, and returns its size.
+// The caller is responsible for closing and removing the file.
+func extractArchiveEntry(zf *zip.File, remaining int64) (*os.File, int64, error) {
+	maxSize := int64(config.GetService().PageMaxFileSize)
+	if zf.UncompressedSize64 > uint64(maxSize) {
+		return nil, 0, errs.ErrPageTooLarge
+	}
+
+	limitErr := errs.ErrPageTooLarge
+	if remaining < maxSize {
+		maxSize, limitErr = remaining, errs.ErrArchiveTooLarge
+	}
+
+	r, err := zf.Open()
+	if err != nil {
+		return nil, 0, errs.ErrPageInvalid
+	}
+	defer r.Close()
+
+	tmp, err := os.CreateTemp(GetTempDir(), "tmp-")
+	if err != nil {
+		log.Println(err)
+		return nil, 0, errs.ErrUnknown
+	}
+
+	// The uncompressed size in the header can not be trusted.
+	n, err := io.Copy(tmp, io.LimitReader(r, maxSize+1))
+	if err == nil && n > maxSize {
+		err = limitErr
+	} else if err != nil {
+		err = errs.ErrPageInvalid
+	}
+
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, 0, err
+	}
+	return tmp, n, nil
+}
+
+// archiveEntries returns the pages of the given archive in natural order,
+// archives with more pages or a larger declared size than allowed by the
+// given config are rejected.
+func archiveEntries(zr *zip.Reader, cfg config.Service) ([]*zip.File, error) {
+	var entries []*zip.File
+	var declaredSize uint64
+	for _, zf := range zr.File {
+		if !isArchiveEntryIgnored(zf) {
+			entries = append(entries, zf)
+			declaredSize += zf.UncompressedSize64
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, errs.ErrArchiveEmpty
+	} else if len(entries) > cfg.ArchiveMaxPages {
+		return nil, errs.ErrArchiveTooManyPages
+	} else if declaredSize > uint64(cfg.ArchiveMaxSize) {
+		return nil, errs.ErrArchiveTooLarge
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return naturalLess(entries[i].Name, entries[j].Name)
+	})
+	return entries, nil
+}
+
+// UploadPagesArchiveEx uploads the pages of a ZIP or CBZ archive for
+// the given chapter and returns the updated chapter pages.
+//
+// Every entry is validated with the same rules as UploadPageEx before
+// anything is stored. Entries are ordered naturally by their names and
+// appended to the pages of the chapter, either all of them or none.
+//
+// Archives with more pages or a larger uncompressed size than allowed
+// are rejected before anything is extracted.
+func UploadPagesArchiveEx(tx *sql.Tx, cid int64, f *os.File, uploader *modext.User) ([]string, error) {
+	defer tx.Rollback()
+
+	if f == nil {
+		return nil, errs.ErrArchiveInvalid
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	zr, err := zip.NewReader(f, stat.Size())
+	if err != nil {
+		return nil, errs.ErrArchiveInvalid
+	}
+
+	// The chapter is checked again once it's locked, after the extraction.
+	c, err := models.FindChapter(tx, cid)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrChapterNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if c.Locked.Bool {
+		return nil, errs.ErrChapterLocked
+	}
+
+	if !hasChapterPermission(tx, uploader, c, constants.PermEditChapter, constants.PermEditChapters) {
+		return nil, errs.ErrForbidden
+	}
+
+	cfg := config.GetService()
+	entries, err := archiveEntries(zr, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*os.File, 0, len(entries))
+	defer func() {
+		for _, tmp := range files {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	// The declared sizes can not be trusted either, so the
+	// extracted size is limited as well.
+	remaining := cfg.ArchiveMaxSize
+	hashes := make([]string, len(entries))
+	for i, zf := range entries {
+		tmp, n, err := extractArchiveEntry(zf, remaining)
+		if err != nil {
+			return nil, errors.Wrap(err, zf.Name)
+		}
+		files = append(files, tmp)
+		remaining -= n
+
+		hash, ext, err := validatePage(tmp)
+		if err != nil {
+			return nil, errors.Wrap(err, zf.Name)
+		}
+		hashes[i] = fmt.Sprintf("%x%s", hash, ext)
+	}
+
+	// The pages are numbered after the current ones, which can't
+	// change until the end of the transaction.
+	c, err = models.Chapters(Where("id = ?", cid), For("UPDATE")).One(tx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrChapterNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if c.Locked.Bool {
+		return nil, errs.ErrChapterLocked
+	}
+
+	fileNames := make([]string, len(entries))
+	for i, hash := range hashes {
+		fileNames[i] = fmt.Sprintf("%d-%s", len(c.Pages)+i+1, hash)
+	}
+
+	// Remove the files stored by this upload if anything fails,
+	// files which already existed are left untouched.
+	prevPages := append([]string{}, c.Pages...)
+	var stored []string
+	rollback := func() {
+		for _, key := range stored {
+			if err := store.Delete(key); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+
+	for i, fn := range fileNames {
+		key := chapterKey(cid, fn)
+		if ok, err := objectExists(key); err != nil {
+			log.Println(err)
+			rollback()
+			return nil, errs.ErrUnknown
+		} else if !ok {
+			if err := putObject(key, files[i]); err != nil {
+				log.Println(err)
+				rollback()
+				return nil, errs.ErrUnknown
+			}
+			stored = append(stored, key)
+		}
+
+		if !stringsContains(c.Pages, fn) {
+			c.Pages = append(c.Pages, fn)
+		}
+	}
+	sortPages(c.Pages)
+
+	if err := c.Update(tx, boil.Whitelist(ChapterCols.Pages, ChapterCols.UpdatedAt)); err != nil {
+		log.Println(err)
+		rollback()
+		return nil, errs.ErrUnknown
+	}
+
+	recordChapterRevision(tx, c, prevPages, RevisionUploadPages, uploader)
+
+	if err := tx.Commit(); err != nil {
+		log.Println(err)
+		rollback()
+		return nil, errs.ErrUnknown
+	}
+
+	recordAudit(uploader, AuditUploadPages, AuditTargetChapter, c.ID,
+		map[string][]string{"pages": prevPages}, map[string][]string{"pages": c.Pages})
+	refreshPagesCache(cid, c.Pages)
+	go chapterAfterUpdateHook(c)
+
+	return c.Pages, nil
+}
+
+// This function simply calls UploadPagesArchiveMultipartEx with a new write transaction.
+func UploadPagesArchiveMultipart(cid int64, fh *multipart.FileHeader, uploader *modext.User) ([]string, error) {
+	tx, err := WriteDB.Begin()
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return UploadPagesArchiveMultipartEx(tx, cid, fh, uploader)
+}
+
+// UploadPagesArchiveMultipartEx uploads the pages of an archive from multipart.FileHeader
+// for the given chapter and returns the updated chapter pages.
+//
+// This function simply copies the multipart.FileHeader to a temporary file and calls
+// UploadPagesArchiveEx.
+func UploadPagesArchiveMultipartEx(tx *sql.Tx, cid int64, fh *multipart.FileHeader, uploader *modext.User) ([]string, error) {
+	defer tx.Rollback()
+
+	tmp, err := os.CreateTemp(GetTempDir(), "tmp-")
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	defer tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	f, err := fh.Open()
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	_, err = io.Copy(tmp, f)
+	f.Close()
+
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	return UploadPagesArchiveEx(tx, cid, tmp, uploader)
+}
+
 // GetPagesResult represents the result of function GetPages.
 type GetPagesResult struct {
 	Pages []string `json:"data,omitempty"`
@@ -342,6 +624,8 @@ func DeletePage(cid int64, fileName string, user *modext.User) ([]string, error)
 
 // DeletePageEx deletes a page of the given chapter
 // and returns the updated chapter pages.
+//
+// The file of the page is kept until the revisions using it are purged.
 func DeletePageEx(e boil.Executor, cid int64, fileName string, user *modext.User) ([]string, error) {
 	c, err := models.FindChapter(e, cid)
 	if err != nil {
@@ -356,12 +640,11 @@ func DeletePageEx(e boil.Executor, cid int64, fileName string, user *modext.User
 		return nil, errs.ErrChapterLocked
 	}
 
-	if !user.HasPermissions(constants.PermEditChapters) {
-		if c.UploaderID.Int64 != user.ID || !user.HasPermissions(constants.PermEditChapter) {
-			return nil, errs.ErrForbidden
-		}
+	if !hasChapterPermission(e, user, c, constants.PermEditChapter, constants.PermEditChapters) {
+		return nil, errs.ErrForbidden
 	}
 
+	prevPages := append([]string{}, c.Pages...)
 	for i, fn := range c.Pages {
 		if strings.EqualFold(fn, fileName) {
 			c.Pages = append(c.Pages[:i], c.Pages[i+1:]...)
@@ -369,23 +652,6 @@ func DeletePageEx(e boil.Executor, cid int64, fileName string, user *modext.User
 		}
 	}
 
-	dir, err := getChapterDir(c.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	if _, err := os.Stat(dir); !os.IsNotExist(err) {
-		err := filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
-			if !info.IsDir() && strings.Contains(filepath.Base(path), fileName) {
-				os.Remove(path)
-			}
-			return err
-		})
-		if err != nil {
-			log.Println(err)
-		}
-	}
-
 	if err := c.Update(e, boil.Whitelist(
 		ChapterCols.Pages,
 		ChapterCols.UpdatedAt,
@@ -394,6 +660,9 @@ func DeletePageEx(e boil.Executor, cid int64, fileName string, user *modext.User
 		return nil, errs.ErrUnknown
 	}
 
+	recordChapterRevision(e, c, prevPages, RevisionDeletePage, user)
+	recordAudit(user, AuditDeletePage, AuditTargetChapter, c.ID,
+		map[string][]string{"pages": prevPages}, map[string][]string{"pages": c.Pages})
 	refreshPagesCache(cid, c.Pages)
 	go chapterAfterUpdateHook(c)
 	return c.Pages, nil
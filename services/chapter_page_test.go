@@ -0,0 +1,207 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kasen/config"
+	"kasen/errs"
+)
+
+// archiveFile represents a file of a test archive, whose declared
+// uncompressed size is the size of its data unless size is set.
+type archiveFile struct {
+	name string
+	data []byte
+	size uint64
+}
+
+func newTestArchive(t *testing.T, files ...archiveFile) *zip.Reader {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, f := range files {
+		size := uint64(len(f.data))
+		if f.size > 0 {
+			size = f.size
+		}
+
+		w, err := zw.CreateRaw(&zip.FileHeader{
+			Name:               f.name,
+			Method:             zip.Store,
+			CRC32:              crc32.ChecksumIEEE(f.data),
+			CompressedSize64:   uint64(len(f.data)),
+			UncompressedSize64: size,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(f.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return zr
+}
+
+// setTestService replaces the service config until the end of the test.
+func setTestService(t *testing.T, cfg config.Service) {
+	prev := config.GetService()
+	config.SetService(cfg)
+	t.Cleanup(func() { config.SetService(prev) })
+}
+
+// setTestDirectories creates the data directories in a temporary
+// directory which is used until the end of the test.
+func setTestDirectories(t *testing.T) {
+	prev := config.GetDirectories()
+	config.SetDirectories(config.Directories{Root: t.TempDir()})
+	t.Cleanup(func() { config.SetDirectories(prev) })
+
+	if err := os.MkdirAll(GetTempDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func archiveEntryNames(entries []*zip.File) []string {
+	names := make([]string, len(entries))
+	for i, zf := range entries {
+		names[i] = zf.Name
+	}
+	return names
+}
+
+func TestArchiveEntries(t *testing.T) {
+	cfg := config.Service{ArchiveMaxPages: 3, ArchiveMaxSize: 100}
+	page := []byte("page")
+
+	tests := []struct {
+		name  string
+		files []archiveFile
+		want  []string
+		err   error
+	}{
+		{
+			name: "natural order",
+			files: []archiveFile{
+				{name: "10.png", data: page},
+				{name: "2.png", data: page},
+				{name: "1.png", data: page},
+			},
+			want: []string{"1.png", "2.png", "10.png"},
+		},
+		{
+			name: "ignored entries",
+			files: []archiveFile{
+				{name: "chapter/", data: nil},
+				{name: "chapter/2.png", data: page},
+				{name: "__MACOSX/chapter/._1.png", data: page},
+				{name: "chapter/.DS_Store", data: page},
+				{name: "chapter/ComicInfo.xml", data: page},
+				{name: "chapter/Thumbs.db", data: page},
+				{name: "chapter/1.png", data: page},
+			},
+			want: []string{"chapter/1.png", "chapter/2.png"},
+		},
+		{
+			// The names are only used to order the pages,
+			// they are stored under the hash of their content.
+			name: "path traversal",
+			files: []archiveFile{
+				{name: "../../2.png", data: page},
+				{name: "/etc/1.png", data: page},
+			},
+			want: []string{"../../2.png", "/etc/1.png"},
+		},
+		{
+			name:  "empty",
+			files: []archiveFile{{name: "ComicInfo.xml", data: page}},
+			err:   errs.ErrArchiveEmpty,
+		},
+		{
+			name: "too many pages",
+			files: []archiveFile{
+				{name: "1.png", data: page},
+				{name: "2.png", data: page},
+				{name: "3.png", data: page},
+				{name: "4.png", data: page},
+			},
+			err: errs.ErrArchiveTooManyPages,
+		},
+		{
+			name: "too large",
+			files: []archiveFile{
+				{name: "1.png", data: page, size: 60},
+				{name: "2.png", data: page, size: 60},
+			},
+			err: errs.ErrArchiveTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := archiveEntries(newTestArchive(t, tt.files...), cfg)
+			if err != tt.err {
+				t.Fatalf("archiveEntries() error = %v, want %v", err, tt.err)
+			}
+
+			got := archiveEntryNames(entries)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("archiveEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractArchiveEntry(t *testing.T) {
+	setTestService(t, config.Service{PageMaxFileSize: 50})
+	setTestDirectories(t)
+
+	tests := []struct {
+		name      string
+		file      archiveFile
+		remaining int64
+		size      int64
+		err       error
+	}{
+		{"within limits", archiveFile{name: "1.png", data: make([]byte, 50)}, 100, 50, nil},
+		{"page too large", archiveFile{name: "1.png", data: make([]byte, 51)}, 100, 0, errs.ErrPageTooLarge},
+		{"archive too large", archiveFile{name: "1.png", data: make([]byte, 30)}, 20, 0, errs.ErrArchiveTooLarge},
+		{"larger than declared", archiveFile{name: "1.png", data: make([]byte, 30), size: 10}, 100, 0, errs.ErrPageInvalid},
+		{"path traversal", archiveFile{name: "../../1.png", data: make([]byte, 10)}, 100, 10, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zr := newTestArchive(t, tt.file)
+			tmp, n, err := extractArchiveEntry(zr.File[0], tt.remaining)
+			if err != tt.err {
+				t.Fatalf("extractArchiveEntry() error = %v, want %v", err, tt.err)
+			} else if err != nil {
+				return
+			}
+			defer os.Remove(tmp.Name())
+			defer tmp.Close()
+
+			if n != tt.size {
+				t.Errorf("extractArchiveEntry() size = %d, want %d", n, tt.size)
+			}
+			if dir := filepath.Dir(tmp.Name()); dir != GetTempDir() {
+				t.Errorf("extractArchiveEntry() extracted to %s, want %s", dir, GetTempDir())
+			}
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	. "kasen/database"
+
+	"kasen/config"
+	"kasen/constants"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+var ChapterRevisionCols = models.ChapterRevisionColumns
+
+// Chapter revision actions.
+const (
+	RevisionInitial     = "initial"
+	RevisionUploadPages = "upload_pages"
+	RevisionDeletePage  = "delete_page"
+	RevisionRollback    = "rollback"
+)
+
+// unusedPageMinAge is the minimum age of the files removed by removeUnusedPages,
+// as pages are stored before being added to their chapter.
+const unusedPageMinAge = time.Hour
+
+// recordChapterRevision records the pages of the given chapter after they
+// have been changed. The previous pages are recorded first if the chapter
+// doesn't have any revision yet, so that the change can be rolled back.
+// Failures are only logged, as the pages have already been changed.
+func recordChapterRevision(e boil.Executor, c *models.Chapter, prevPages []string, action string, user *modext.User) {
+	exists, err := models.ChapterRevisions(Where("chapter_id = ?", c.ID)).Exists(e)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	if !exists && len(prevPages) > 0 {
+		r := &models.ChapterRevision{
+			ChapterID: c.ID,
+			Action:    RevisionInitial,
+			Pages:     types.StringArray(prevPages),
+		}
+		if err := r.Insert(e, boil.Infer()); err != nil {
+			log.Println(err)
+			return
+		}
+	}
+
+	r := &models.ChapterRevision{
+		ChapterID: c.ID,
+		Action:    action,
+		Pages:     types.StringArray(append([]string{}, c.Pages...)),
+	}
+
+	if user != nil {
+		r.UserID = null.Int64From(user.ID)
+	}
+
+	if err := r.Insert(e, boil.Infer()); err != nil {
+		log.Println(err)
+	}
+}
+
+// GetChapterRevisionsOptions represents the options for getting the revisions of a chapter.
+type GetChapterRevisionsOptions struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+func (opts *GetChapterRevisionsOptions) validate() {
+	if opts.Limit <= 0 || opts.Limit > 100 {
+		opts.Limit = 100
+	}
+
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+}
+
+// GetChapterRevisionsResult represents the result of GetChapterRevisions.
+type GetChapterRevisionsResult struct {
+	Revisions []*modext.ChapterRevision `json:"data"`
+	Total     int64                     `json:"total"`
+	Err       error                     `json:"error,omitempty"`
+}
+
+// This function simply calls GetChapterRevisionsEx with the global Read connection.
+func GetChapterRevisions(cid int64, opts GetChapterRevisionsOptions, user *modext.User) *GetChapterRevisionsResult {
+	return GetChapterRevisionsEx(ReadDB, cid, opts, user)
+}
+
+// GetChapterRevisionsEx gets the revisions of the pages of a chapter,
+// ordered from the most recent.
+//
+// This function will return an error if the user does not have the necessary permissions.
+func GetChapterRevisionsEx(e boil.Executor, cid int64, opts GetChapterRevisionsOptions, user *modext.User) *GetChapterRevisionsResult {
+	opts.validate()
+
+	result := &GetChapterRevisionsResult{}
+
+	c, err := models.FindChapter(e, cid, ChapterCols.ID, ChapterCols.ProjectID, ChapterCols.UploaderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			result.Err = errs.ErrChapterNotFound
+		} else {
+			log.Println(err)
+			result.Err = errs.ErrUnknown
+		}
+		return result
+	}
+
+	if !hasChapterPermission(e, user, c, constants.PermEditChapter, constants.PermEditChapters) {
+		result.Err = errs.ErrForbidden
+		return result
+	}
+
+	total, err := models.ChapterRevisions(Where("chapter_id = ?", cid)).Count(e)
+	if err != nil {
+		log.Println(err)
+		result.Err = errs.ErrUnknown
+		return result
+	}
+
+	revisions, err := models.ChapterRevisions(
+		Where("chapter_id = ?", cid),
+		OrderBy(fmt.Sprintf("%s DESC", ChapterRevisionCols.ID)),
+		Limit(opts.Limit),
+		Offset(opts.Offset),
+		Load(models.ChapterRevisionRels.User),
+	).All(e)
+	if err != nil {
+		log.Println(err)
+		result.Err = errs.ErrUnknown
+		return result
+	}
+
+	result.Total = total
+	result.Revisions = make([]*modext.ChapterRevision, len(revisions))
+	for i, r := range revisions {
+		result.Revisions[i] = modext.NewChapterRevision(r).LoadUser(r)
+	}
+	return result
+}
+
+// findChapterRevision finds the revision of the given chapter.
+func findChapterRevision(e boil.Executor, cid, rid int64) (*models.ChapterRevision, error) {
+	r, err := models.ChapterRevisions(Where("id = ? AND chapter_id = ?", rid, cid)).One(e)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrRevisionNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return r, nil
+}
+
+// PageChange represents the files of a page number which differ
+// between two revisions. Before is empty if the page was added,
+// and After is empty if it was removed.
+type PageChange struct {
+	Page   int      `json:"page"`
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+}
+
+// ChapterRevisionDiff represents the difference between the pages of two revisions.
+type ChapterRevisionDiff struct {
+	From    int64         `json:"from"`
+	To      int64         `json:"to,omitempty"`
+	Changes []*PageChange `json:"changes"`
+}
+
+// This function simply calls DiffChapterRevisionsEx with the global Read connection.
+func DiffChapterRevisions(cid, from, to int64, user *modext.User) (*ChapterRevisionDiff, error) {
+	return DiffChapterRevisionsEx(ReadDB, cid, from, to, user)
+}
+
+// DiffChapterRevisionsEx compares the pages of two revisions of a chapter,
+// the pages of the revision from are compared to the current pages if to is 0.
+//
+// This function will return an error if the user does not have the necessary permissions.
+func DiffChapterRevisionsEx(e boil.Executor, cid, from, to int64, user *modext.User) (*ChapterRevisionDiff, error) {
+	c, err := models.FindChapter(e, cid, ChapterCols.ID, ChapterCols.ProjectID, ChapterCols.UploaderID, ChapterCols.Pages)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrChapterNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if !hasChapterPermission(e, user, c, constants.PermEditChapter, constants.PermEditChapters) {
+		return nil, errs.ErrForbidden
+	}
+
+	before, err := findChapterRevision(e, c.ID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	after := c.Pages
+	if to > 0 {
+		r, err := findChapterRevision(e, c.ID, to)
+		if err != nil {
+			return nil, err
+		}
+		after = r.Pages
+	}
+
+	return &ChapterRevisionDiff{
+		From:    from,
+		To:      to,
+		Changes: diffPages(before.Pages, after),
+	}, nil
+}
+
+// diffPages compares the given pages by their page numbers.
+func diffPages(before, after []string) []*PageChange {
+	group := func(pages []string) map[int][]string {
+		m := make(map[int][]string)
+		for _, fn := range pages {
+			n := getPageNum(fn)
+			m[n] = append(m[n], fn)
+		}
+		return m
+	}
+
+	b, a := group(before), group(after)
+
+	var nums []int
+	for n := range b {
+		nums = append(nums, n)
+	}
+	for n := range a {
+		if _, ok := b[n]; !ok {
+			nums = append(nums, n)
+		}
+	}
+	sort.Ints(nums)
+
+	changes := []*PageChange{}
+	for _, n := range nums {
+		if strings.Join(b[n], ",") != strings.Join(a[n], ",") {
+			changes = append(changes, &PageChange{Page: n, Before: b[n], After: a[n]})
+		}
+	}
+	return changes
+}
+
+// This function simply calls RollbackChapterEx with the global Write connection.
+func RollbackChapter(cid, rid int64, user *modext.User) ([]string, error) {
+	return RollbackChapterEx(WriteDB, cid, rid, user)
+}
+
+// RollbackChapterEx restores the pages of a chapter to the given revision
+// and returns the updated chapter pages, the rollback is recorded as a
+// new revision.
+//
+// This function will return an error if the chapter is locked.
+// It will also return an error if the user does not have the necessary permissions.
+func RollbackChapterEx(e boil.Executor, cid, rid int64, user *modext.User) ([]string, error) {
+	c, err := models.FindChapter(e, cid)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrChapterNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if c.Locked.Bool {
+		return nil, errs.ErrChapterLocked
+	}
+
+	if !hasChapterPermission(e, user, c, constants.PermEditChapter, constants.PermEditChapters) {
+		return nil, errs.ErrForbidden
+	}
+
+	r, err := findChapterRevision(e, c.ID, rid)
+	if err != nil {
+		return nil, err
+	}
+
+	prevPages := append([]string{}, c.Pages...)
+	c.Pages = append(types.StringArray{}, r.Pages...)
+
+	if err := c.Update(e, boil.Whitelist(ChapterCols.Pages, ChapterCols.UpdatedAt)); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	recordChapterRevision(e, c, prevPages, RevisionRollback, user)
+	recordAudit(user, AuditRollback, AuditTargetChapter, c.ID,
+		map[string][]string{"pages": prevPages}, map[string][]string{"pages": c.Pages})
+
+	refreshPagesCache(cid, c.Pages)
+	go chapterAfterUpdateHook(c)
+	return c.Pages, nil
+}
+
+// purgeRevisions removes the expired revisions of chapter pages, except the
+// latest revision of every chapter, along with the files which aren't used
+// by the chapters nor their remaining revisions anymore.
+func purgeRevisions() {
+	before := time.Now().UTC().Add(-config.GetTrash().RevisionRetention)
+
+	var expired []struct {
+		ChapterID int64 `boil:"chapter_id"`
+	}
+	err := queries.Raw(`
+		DELETE FROM chapter_revision
+		WHERE created_at < $1 AND id NOT IN
+			(SELECT MAX(id) FROM chapter_revision GROUP BY chapter_id)
+		RETURNING chapter_id`, before).Bind(nil, WriteDB, &expired)
+	if err != nil {
+		log.Println("Failed to purge expired revisions:", err)
+		return
+	}
+
+	purged := make(map[int64]bool)
+	for _, r := range expired {
+		if !purged[r.ChapterID] {
+			purged[r.ChapterID] = true
+			if err := removeUnusedPages(r.ChapterID); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// removeUnusedPages removes the files of the given chapter, and their
+// resized variants, which aren't used by its pages nor its revisions.
+// Recent files are kept, since they may belong to a page being uploaded.
+func removeUnusedPages(cid int64) error {
+	c, err := models.Chapters(
+		WithDeleted(),
+		Where("id = ?", cid),
+		Load(ChapterRels.ChapterRevisions),
+	).One(WriteDB)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	used := append([]string{}, c.Pages...)
+	for _, r := range c.R.ChapterRevisions {
+		used = append(used, r.Pages...)
+	}
+
+	objects, err := store.List(chapterKey(c.ID, ""))
+	if err != nil {
+		return err
+	}
+
+	for _, o := range objects {
+		if time.Since(o.ModTime) < unusedPageMinAge {
+			continue
+		}
+
+		name := path.Base(o.Key)
+
+		isUsed := false
+		for _, fn := range used {
+			if name == fn || strings.HasPrefix(name, fn+".") {
+				isUsed = true
+				break
+			}
+		}
+
+		if !isUsed {
+			if err := store.Delete(o.Key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
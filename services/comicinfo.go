@@ -0,0 +1,149 @@
+package services
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"kasen/config"
+	"kasen/models"
+)
+
+// ComicInfo represents the ComicInfo.xml of a CBZ archive,
+// as read by Komga, Kavita and Tachiyomi.
+//
+// See https://anansi-project.github.io/docs/comicinfo/schemas/v2.0
+type ComicInfo struct {
+	XMLName     xml.Name `xml:"ComicInfo"`
+	XMLNSXSI    string   `xml:"xmlns:xsi,attr"`
+	XMLNSXSD    string   `xml:"xmlns:xsd,attr"`
+	Title       string   `xml:"Title,omitempty"`
+	Series      string   `xml:"Series,omitempty"`
+	Number      string   `xml:"Number,omitempty"`
+	Volume      int      `xml:"Volume,omitempty"`
+	Summary     string   `xml:"Summary,omitempty"`
+	Year        int      `xml:"Year,omitempty"`
+	Month       int      `xml:"Month,omitempty"`
+	Day         int      `xml:"Day,omitempty"`
+	Writer      string   `xml:"Writer,omitempty"`
+	Penciller   string   `xml:"Penciller,omitempty"`
+	Translator  string   `xml:"Translator,omitempty"`
+	Genre       string   `xml:"Genre,omitempty"`
+	Web         string   `xml:"Web,omitempty"`
+	PageCount   int      `xml:"PageCount,omitempty"`
+	LanguageISO string   `xml:"LanguageISO,omitempty"`
+	Manga       string   `xml:"Manga,omitempty"`
+	AgeRating   string   `xml:"AgeRating,omitempty"`
+
+	Pages []*ComicInfoPage `xml:"Pages>Page,omitempty"`
+}
+
+// ComicInfoPage represents a page of ComicInfo.
+type ComicInfoPage struct {
+	Image int    `xml:"Image,attr"`
+	Type  string `xml:"Type,attr,omitempty"`
+}
+
+// comicInfoAgeRatings maps project ratings to ComicInfo age ratings.
+var comicInfoAgeRatings = map[string]string{
+	"safe":         "Everyone",
+	"suggestive":   "Teen",
+	"erotica":      "Mature 17+",
+	"pornographic": "Adults Only 18+",
+}
+
+// comicInfoMangas maps reading directions to ComicInfo manga values,
+// readers use "No" as left-to-right.
+var comicInfoMangas = map[string]string{
+	"ltr": "No",
+	"rtl": "YesAndRightToLeft",
+}
+
+// newComicInfo creates the ComicInfo of the given chapter.
+//
+// The chapter should be loaded with its scanlation groups, and
+// its project with the artists, authors and tags.
+func newComicInfo(c *models.Chapter) *ComicInfo {
+	info := &ComicInfo{
+		XMLNSXSI:  "http://www.w3.org/2001/XMLSchema-instance",
+		XMLNSXSD:  "http://www.w3.org/2001/XMLSchema",
+		Title:     c.Title.String,
+		Number:    c.Chapter,
+		PageCount: len(c.Pages),
+	}
+
+	meta := config.GetMeta()
+	info.Web = fmt.Sprintf("%s/chapters/%d", strings.TrimSuffix(meta.BaseURL, "/"), c.ID)
+	info.LanguageISO = strings.SplitN(meta.Language, "-", 2)[0]
+
+	if v, err := strconv.Atoi(c.Volume.String); err == nil {
+		info.Volume = v
+	}
+
+	if c.PublishedAt.Valid {
+		info.Year = c.PublishedAt.Time.Year()
+		info.Month = int(c.PublishedAt.Time.Month())
+		info.Day = c.PublishedAt.Time.Day()
+	}
+
+	for i := range c.Pages {
+		page := &ComicInfoPage{Image: i}
+		if i == 0 {
+			page.Type = "FrontCover"
+		}
+		info.Pages = append(info.Pages, page)
+	}
+
+	if c.R == nil {
+		return info
+	}
+
+	var groups []string
+	for _, g := range c.R.ScanlationGroups {
+		groups = append(groups, g.Name)
+	}
+	info.Translator = strings.Join(groups, ", ")
+
+	p := c.R.Project
+	if p == nil {
+		return info
+	}
+
+	info.Series = p.Title
+	info.Summary = p.Description.String
+	info.AgeRating = comicInfoAgeRatings[p.Rating.String]
+	info.Manga = comicInfoMangas[p.ReadingDirection.String]
+
+	if p.R == nil {
+		return info
+	}
+
+	var authors, artists, tags []string
+	for _, a := range p.R.Authors {
+		authors = append(authors, a.Name)
+	}
+
+	for _, a := range p.R.Artists {
+		artists = append(artists, a.Name)
+	}
+
+	for _, t := range p.R.Tags {
+		tags = append(tags, t.Name)
+	}
+
+	info.Writer = strings.Join(authors, ", ")
+	info.Penciller = strings.Join(artists, ", ")
+	info.Genre = strings.Join(tags, ", ")
+
+	return info
+}
+
+// Marshal encodes the ComicInfo as an XML document.
+func (info *ComicInfo) Marshal() ([]byte, error) {
+	buf, err := xml.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), buf...), nil
+}
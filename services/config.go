@@ -1,17 +1,45 @@
 package services
 
-import "kasen/config"
+import (
+	"kasen/config"
+	"kasen/modext"
+)
 
 func GetServiceConfig() config.Service {
 	return config.GetService()
 }
 
-func UpdateMeta(v *config.Meta) error {
+func UpdateMeta(v *config.Meta, user *modext.User) error {
+	before := config.GetMeta()
 	config.SetMeta(*v)
-	return config.Save()
+	if err := config.Save(); err != nil {
+		return err
+	}
+
+	recordAudit(user, AuditUpdate, AuditTargetConfig, 0, before, v)
+	return nil
 }
 
-func UpdateServiceConfig(v *config.Service) error {
+func UpdateServiceConfig(v *config.Service, user *modext.User) error {
+	perms, err := validatePermissions(v.TwoFactorPermissions)
+	if err != nil {
+		return err
+	}
+	v.TwoFactorPermissions = perms
+
+	// The archive limits can't be disabled, they keep their value if left out.
+	before := config.GetService()
+	if v.ArchiveMaxPages <= 0 {
+		v.ArchiveMaxPages = before.ArchiveMaxPages
+	}
+	if v.ArchiveMaxSize <= 0 {
+		v.ArchiveMaxSize = before.ArchiveMaxSize
+	}
 	config.SetService(*v)
-	return config.Save()
+	if err := config.Save(); err != nil {
+		return err
+	}
+
+	recordAudit(user, AuditUpdate, AuditTargetConfig, 0, before, v)
+	return nil
 }
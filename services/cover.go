@@ -1,7 +1,6 @@
 package services
 
 import (
-	"bytes"
 	"crypto/sha256"
 	"database/sql"
 	"fmt"
@@ -22,6 +21,7 @@ import (
 	"kasen/modext"
 
 	"github.com/gabriel-vasile/mimetype"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
@@ -36,33 +36,68 @@ func GetCoverCacheStats() *CacheStats {
 
 // ServeCover serves the cover file.
 func ServeCover(slug, fn string, width int, rw http.ResponseWriter, r *http.Request) {
-	dir := getProjectDir(slug)
-	fp := filepath.Join(dir, "covers", fn)
-	if _, err := os.Stat(fp); os.IsNotExist(err) {
+	pid, ok := getProjectIDBySlug(slug)
+	if !ok {
 		rw.WriteHeader(http.StatusNotFound)
 		return
 	}
 
-	if width > 0 && width <= 1024 && width%64 == 0 {
-		original := fp
-		fp = fmt.Sprintf("%s.%d.jpg", fp, width)
+	// Files of deleted covers are kept until they're purged
+	if !coverExists(pid, fn) {
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	key := coverKey(pid, fn)
+	if ok, err := objectExists(key); err != nil {
+		log.Println(err)
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	} else if !ok {
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
 
-		if _, err := os.Stat(fp); os.IsNotExist(err) {
-			o := ResizeOptions{
-				Width:  width,
-				Height: width * 3 / 2,
-				Crop:   true,
-			}
+	serveImage(key, coverResizeOptions(width), rw, r)
+}
 
-			err := resizeImage(original, fp, o)
-			if err != nil {
-				log.Println(err)
-				fp = original
-			}
+// coverExists checks if the given project has a cover of the given file
+// which is not in the trash, the covers are looked up from the cache.
+func coverExists(pid int64, fn string) bool {
+	for _, c := range GetCovers(pid).Covers {
+		if c.FileName == fn {
+			return true
 		}
 	}
+	return false
+}
+
+// coverResizeOptions returns the options of covers resized to the
+// given width, which are cropped to an aspect ratio of 2:3.
+func coverResizeOptions(width int) ResizeOptions {
+	return ResizeOptions{
+		Width:  width,
+		Height: width * 3 / 2,
+		Crop:   true,
+	}
+}
+
+// getProjectIDBySlug gets the id of the project with the given slug.
+//
+// The id is cached in the cover LRU cache, as covers are served by
+// the project's slug.
+func getProjectIDBySlug(slug string) (int64, bool) {
+	if v, err := CoverCache.GetWithPrefix("slug", slug); err == nil {
+		return v.(int64), true
+	}
+
+	id, _ := CheckProjectExistsBySlug(slug)
+	if id <= 0 {
+		return 0, false
+	}
 
-	http.ServeFile(rw, r, fp)
+	CoverCache.SetWithPrefix("slug", slug, id, 0)
+	return id, true
 }
 
 // This function simply calls UploadCoverEx with the global Write connection.
@@ -109,11 +144,6 @@ func UploadCoverEx(e boil.Executor, pid int64, fileName string, f *os.File, uplo
 		return nil, errs.ErrForbidden
 	}
 
-	dir, err := getProjectDirByID(pid)
-	if err != nil {
-		return nil, err
-	}
-
 	hasher := sha256.New()
 	f.Seek(0, io.SeekStart)
 	if _, err := io.Copy(hasher, f); err != nil {
@@ -127,17 +157,13 @@ func UploadCoverEx(e boil.Executor, pid int64, fileName string, f *os.File, uplo
 	ext := filepath.Ext(fileName)
 
 	fn := fmt.Sprintf("%x%s", hash, ext)
-	fp := filepath.Join(dir, "covers", fn)
+	key := coverKey(pid, fn)
 
-	if _, err := os.Stat(fp); os.IsNotExist(err) {
-		var buf bytes.Buffer
-		f.Seek(0, io.SeekStart)
-		if _, err = io.Copy(&buf, f); err != nil {
-			log.Println(err)
-			return nil, errs.ErrUnknown
-		}
-
-		if err := WriteFile(fp, buf.Bytes()); err != nil {
+	if ok, err := objectExists(key); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if !ok {
+		if err := putObject(key, f); err != nil {
 			log.Println(err)
 			return nil, errs.ErrUnknown
 		}
@@ -152,6 +178,7 @@ func UploadCoverEx(e boil.Executor, pid int64, fileName string, f *os.File, uplo
 		}
 
 		CoverCache.PurgeWithPrefix(c.ProjectID)
+		recordAudit(uploader, AuditCreate, AuditTargetCover, c.ID, nil, modext.NewCover(c))
 	} else if err != nil {
 		log.Println(err)
 		return nil, errs.ErrUnknown
@@ -318,12 +345,12 @@ func GetCoversEx(e boil.Executor, pid int64) (result *GetCoversResult) {
 }
 
 // This function simply calls SetCoverEx with the global Write connection.
-func SetCover(pid, cid int64) error {
-	return SetCoverEx(WriteDB, pid, cid)
+func SetCover(pid, cid int64, user *modext.User) error {
+	return SetCoverEx(WriteDB, pid, cid, user)
 }
 
 // SetCoverEx sets the main cover of the given project.
-func SetCoverEx(e boil.Executor, pid, cid int64) error {
+func SetCoverEx(e boil.Executor, pid, cid int64, user *modext.User) error {
 	p, err := models.Projects(Where("id = ?", pid), Load(ProjectRels.Covers)).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -349,6 +376,7 @@ func SetCoverEx(e boil.Executor, pid, cid int64) error {
 		return errs.ErrCoverNotFound
 	}
 
+	before := map[string]int64{"coverId": p.CoverID.Int64}
 	p.CoverID.Int64 = cid
 	p.CoverID.Valid = true
 
@@ -357,6 +385,8 @@ func SetCoverEx(e boil.Executor, pid, cid int64) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditSetCover, AuditTargetProject, pid, before, map[string]int64{"coverId": cid})
+
 	go func() {
 		refreshProjectCache(pid)
 		refreshProjectsCache()
@@ -364,17 +394,31 @@ func SetCoverEx(e boil.Executor, pid, cid int64) error {
 
 	refreshCoverCache(pid)
 
+	go createPregenerateImagesJob(&PregenerateImagesPayload{CoverID: cid})
+	go dispatchProjectWebhookEvent(WebhookCoverChanged, pid)
+
 	return nil
 }
 
-// This function simply calls DeleteCoverEx with the global Write connection.
-func DeleteCover(id int64) error {
-	return DeleteCoverEx(WriteDB, id)
+// This function simply calls DeleteCoverEx with a new write transaction.
+func DeleteCover(id int64, user *modext.User) error {
+	tx, err := WriteDB.Begin()
+	if err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+	return DeleteCoverEx(tx, id, user)
 }
 
-// DeleteCoverEx deletes a cover.
-func DeleteCoverEx(e boil.Executor, id int64) error {
-	c, err := models.FindCover(e, id)
+// DeleteCoverEx moves a cover to the trash, it's purged
+// once the retention period is over.
+//
+// The cover is no longer the main cover of its project, as the
+// foreign key is only cleared once the cover is purged.
+func DeleteCoverEx(tx *sql.Tx, id int64, user *modext.User) error {
+	defer tx.Rollback()
+
+	c, err := models.Covers(Where("id = ?", id), Load(models.CoverRels.Project)).One(tx)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return errs.ErrCoverNotFound
@@ -383,15 +427,41 @@ func DeleteCoverEx(e boil.Executor, id int64) error {
 		return errs.ErrUnknown
 	}
 
-	if err := c.Delete(e); err != nil {
+	if err := c.Delete(tx, false); err != nil {
 		log.Println(err)
 		return errs.ErrUnknown
 	}
 
+	p := c.R.Project
+	isMain := p != nil && p.CoverID.Int64 == c.ID
+	if isMain {
+		p.CoverID = null.Int64{}
+		if err := p.Update(tx, boil.Whitelist(ProjectCols.CoverID, ProjectCols.UpdatedAt)); err != nil {
+			log.Println(err)
+			return errs.ErrUnknown
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditDelete, AuditTargetCover, c.ID, modext.NewCover(c), nil)
+	if isMain {
+		recordAudit(user, AuditSetCover, AuditTargetProject, c.ProjectID,
+			map[string]int64{"coverId": c.ID}, map[string]int64{"coverId": 0})
+	}
+
 	CoverCache.PurgeWithPrefix(c.ProjectID)
 
 	go refreshTemplatesCache()
-	go removeCoverFiles(c)
+	if isMain {
+		go func() {
+			refreshProjectCache(c.ProjectID)
+			refreshProjectsCache()
+		}()
+	}
 
 	return nil
 }
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"kasen/config"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// epubBook represents the content of a fixed-layout EPUB,
+// which is either a chapter or a volume of a project.
+type epubBook struct {
+	ID       string
+	Title    string
+	Language string
+	Modified time.Time
+
+	Project  *modext.Project
+	Chapters []*models.Chapter
+}
+
+// newEPUBBook creates an EPUB of the given chapters.
+//
+// The project should be loaded with its artists, authors, tags and cover.
+func newEPUBBook(p *models.Project, title string, chapters []*models.Chapter) *epubBook {
+	book := &epubBook{
+		Title:    title,
+		Language: config.GetMeta().Language,
+		Project:  modext.NewProject(p).LoadRels(p),
+		Chapters: chapters,
+		Modified: p.UpdatedAt,
+	}
+
+	var ids []string
+	for _, c := range chapters {
+		ids = append(ids, fmt.Sprint(c.ID))
+		if c.UpdatedAt.After(book.Modified) {
+			book.Modified = c.UpdatedAt
+		}
+	}
+
+	// The identifier is stable as long as the chapters stay the same.
+	name := fmt.Sprintf("%s/projects/%d?chapters=%s", config.GetMeta().BaseURL, p.ID, strings.Join(ids, ","))
+	book.ID = "urn:uuid:" + uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+	return book
+}
+
+// epubPage represents a page of an EPUB.
+type epubPage struct {
+	ID     string
+	Image  string
+	Width  int
+	Height int
+}
+
+// epubNavPoint represents an entry of the table of contents.
+type epubNavPoint struct {
+	Title string
+	Href  string
+}
+
+// Fallback size of pages whose dimensions could not be determined.
+const (
+	epubDefaultWidth  = 1000
+	epubDefaultHeight = 1500
+)
+
+// epubMediaType gets the media type of the given image.
+func epubMediaType(fn string) string {
+	if strings.EqualFold(path.Ext(fn), ".png") {
+		return "image/png"
+	}
+	return "image/jpeg"
+}
+
+var epubTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+	"escape": func(s string) string {
+		var b strings.Builder
+		xml.EscapeText(&b, []byte(s))
+		return b.String()
+	},
+	"mediaType": epubMediaType,
+}).Parse(`
+{{- define "container" -}}
+<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+{{- end -}}
+
+{{- define "page" -}}
+<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head>
+  <title>{{ .Title | escape }}</title>
+  <meta name="viewport" content="width={{ .Page.Width }}, height={{ .Page.Height }}"/>
+  <style>html, body { margin: 0; padding: 0; } img { display: block; width: 100%; height: 100%; }</style>
+</head>
+<body>
+  <img src="{{ .Page.Image }}" width="{{ .Page.Width }}" height="{{ .Page.Height }}" alt=""/>
+</body>
+</html>
+{{- end -}}
+
+{{- define "nav" -}}
+<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head>
+  <title>{{ .Title | escape }}</title>
+</head>
+<body>
+  <nav epub:type="toc" id="toc">
+    <ol>
+      {{- range .NavPoints }}
+      <li><a href="{{ .Href }}">{{ .Title | escape }}</a></li>
+      {{- end }}
+    </ol>
+  </nav>
+</body>
+</html>
+{{- end -}}
+
+{{- define "opf" -}}
+<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" prefix="rendition: http://www.idpf.org/vocab/rendition/#">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">{{ .Book.ID }}</dc:identifier>
+    <dc:title>{{ .Book.Title | escape }}</dc:title>
+    <dc:language>{{ .Book.Language | escape }}</dc:language>
+    {{- range .Book.Project.Authors }}
+    <dc:creator>{{ .Name | escape }}</dc:creator>
+    {{- end }}
+    {{- range .Book.Project.Artists }}
+    <dc:contributor>{{ .Name | escape }}</dc:contributor>
+    {{- end }}
+    {{- range .Book.Project.Tags }}
+    <dc:subject>{{ .Name | escape }}</dc:subject>
+    {{- end }}
+    {{- with .Book.Project.Description }}
+    <dc:description>{{ . | escape }}</dc:description>
+    {{- end }}
+    <meta property="dcterms:modified">{{ .Modified }}</meta>
+    <meta property="rendition:layout">pre-paginated</meta>
+    <meta property="rendition:orientation">portrait</meta>
+    <meta property="rendition:spread">none</meta>
+    {{- if .CoverImage }}
+    <meta name="cover" content="cover-image"/>
+    {{- end }}
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    {{- with .CoverImage }}
+    <item id="cover-image" href="{{ . }}" media-type="{{ mediaType . }}" properties="cover-image"/>
+    {{- end }}
+    {{- range .Pages }}
+    {{- if ne .ID "cover" }}
+    <item id="{{ .ID }}-image" href="{{ .Image }}" media-type="{{ mediaType .Image }}"/>
+    {{- end }}
+    <item id="{{ .ID }}" href="{{ .ID }}.xhtml" media-type="application/xhtml+xml"/>
+    {{- end }}
+  </manifest>
+  <spine{{ with .Direction }} page-progression-direction="{{ . }}"{{ end }}>
+    {{- range .Pages }}
+    <itemref idref="{{ .ID }}"/>
+    {{- end }}
+  </spine>
+</package>
+{{- end -}}
+`))
+
+// writeEPUB writes the given book as an EPUB 3 fixed-layout.
+func writeEPUB(w io.Writer, book *epubBook) error {
+	zw := zip.NewWriter(w)
+
+	// The mimetype has to be the first file, stored without
+	// compression and without a data descriptor.
+	mimetype := []byte("application/epub+zip")
+	f, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "mimetype",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(mimetype),
+		CompressedSize64:   uint64(len(mimetype)),
+		UncompressedSize64: uint64(len(mimetype)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to create file in zip")
+	}
+
+	if _, err := f.Write(mimetype); err != nil {
+		return errors.Wrap(err, "Failed to write mimetype")
+	}
+
+	if err := writeEPUBTemplate(zw, "META-INF/container.xml", "container", nil); err != nil {
+		return err
+	}
+
+	var pages []*epubPage
+	var navPoints []*epubNavPoint
+	var coverImage string
+
+	if book.Project.Cover != nil {
+		fn := book.Project.Cover.FileName
+		coverImage = "images/cover" + path.Ext(fn)
+
+		page, err := copyEPUBImage(zw, coverKey(book.Project.ID, fn), coverImage)
+		if err != nil {
+			return err
+		}
+		page.ID = "cover"
+		pages = append(pages, page)
+	}
+
+	for _, c := range book.Chapters {
+		for i, fileName := range c.Pages {
+			n := len(pages) + 1
+			page, err := copyEPUBImage(zw, chapterKey(c.ID, fileName), fmt.Sprintf("images/%04d%s", n, path.Ext(fileName)))
+			if err != nil {
+				return err
+			}
+			page.ID = fmt.Sprintf("page-%04d", n)
+			pages = append(pages, page)
+
+			if i == 0 {
+				navPoints = append(navPoints, &epubNavPoint{
+					Title: formatChapterModel(c),
+					Href:  page.ID + ".xhtml",
+				})
+			}
+		}
+	}
+
+	if len(navPoints) == 0 && len(pages) > 0 {
+		navPoints = append(navPoints, &epubNavPoint{Title: book.Title, Href: pages[0].ID + ".xhtml"})
+	}
+
+	for _, page := range pages {
+		data := map[string]interface{}{"Title": book.Title, "Page": page}
+		if err := writeEPUBTemplate(zw, "OEBPS/"+page.ID+".xhtml", "page", data); err != nil {
+			return err
+		}
+	}
+
+	data := map[string]interface{}{"Title": book.Title, "NavPoints": navPoints}
+	if err := writeEPUBTemplate(zw, "OEBPS/nav.xhtml", "nav", data); err != nil {
+		return err
+	}
+
+	var direction string
+	switch book.Project.ReadingDirection {
+	case "ltr", "rtl":
+		direction = book.Project.ReadingDirection
+	}
+
+	data = map[string]interface{}{
+		"Book":       book,
+		"Pages":      pages,
+		"CoverImage": coverImage,
+		"Direction":  direction,
+		"Modified":   book.Modified.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if err := writeEPUBTemplate(zw, "OEBPS/content.opf", "opf", data); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// writeEPUBTemplate executes the given template into a new file of the zip.
+func writeEPUBTemplate(zw *zip.Writer, name, tmpl string, data interface{}) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create file in zip")
+	}
+
+	if err := epubTemplates.ExecuteTemplate(f, tmpl, data); err != nil {
+		return errors.Wrapf(err, "Failed to write %s", name)
+	}
+	return nil
+}
+
+// copyEPUBImage copies the image of the given key into the OEBPS
+// directory of the zip, and returns a page with its dimensions.
+func copyEPUBImage(zw *zip.Writer, key, fileName string) (*epubPage, error) {
+	obj, err := store.Get(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to open image file")
+	}
+	defer obj.Close()
+
+	// Images are read into memory as they're needed twice,
+	// they're limited by page_max_file_size anyways.
+	buf, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read image file")
+	}
+
+	page := &epubPage{Image: fileName, Width: epubDefaultWidth, Height: epubDefaultHeight}
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(buf)); err == nil && cfg.Width > 0 && cfg.Height > 0 {
+		page.Width, page.Height = cfg.Width, cfg.Height
+	}
+
+	f, err := zw.Create("OEBPS/" + fileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create file in zip")
+	}
+
+	if _, err := f.Write(buf); err != nil {
+		return nil, errors.Wrap(err, "Failed to copy image to zip")
+	}
+	return page, nil
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	. "kasen/database"
+
+	"kasen/config"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var InviteCols = models.InviteColumns
+
+// InviteMaxUses is the maximum number of uses of an invite.
+const InviteMaxUses = 1000
+
+// hashInviteCode hashes the given invite code, only the hashes are stored.
+func hashInviteCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// InviteDraft represents the draft of an invite.
+type InviteDraft struct {
+	MaxUses     int      `json:"maxUses"`
+	ExpiresAt   int64    `json:"expiresAt"`
+	RoleID      int64    `json:"roleId"`
+	Permissions []string `json:"permissions"`
+}
+
+func (draft *InviteDraft) validate() error {
+	if draft.MaxUses == 0 {
+		draft.MaxUses = 1
+	} else if draft.MaxUses < 0 || draft.MaxUses > InviteMaxUses {
+		return errs.ErrInviteMaxUsesInvalid
+	}
+
+	if draft.ExpiresAt > 0 && !time.Unix(draft.ExpiresAt, 0).After(time.Now()) {
+		return errs.ErrInviteExpirationInvalid
+	}
+
+	perms, err := validatePermissions(draft.Permissions)
+	if err != nil {
+		return err
+	}
+	draft.Permissions = perms
+
+	return nil
+}
+
+// This function simply calls CreateInviteEx with the global Write connection.
+func CreateInvite(draft InviteDraft, user *modext.User) (*modext.Invite, error) {
+	return CreateInviteEx(WriteDB, draft, user)
+}
+
+// CreateInviteEx creates an invite which can be used the given number of times
+// to register, the registered users are granted the role and permissions of the
+// invite. The code is only returned here, as only its hash is stored.
+func CreateInviteEx(e boil.Executor, draft InviteDraft, user *modext.User) (*modext.Invite, error) {
+	if err := draft.validate(); err != nil {
+		return nil, err
+	}
+
+	code, err := randomString(24)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	i := &models.Invite{
+		CreatorID:   null.Int64From(user.ID),
+		Hash:        hashInviteCode(code),
+		Prefix:      code[:6],
+		MaxUses:     draft.MaxUses,
+		Permissions: draft.Permissions,
+	}
+
+	var role *models.Role
+	if draft.RoleID > 0 {
+		if role, err = findRole(e, draft.RoleID); err != nil {
+			return nil, err
+		}
+		i.RoleID = null.Int64From(role.ID)
+	}
+
+	if draft.ExpiresAt > 0 {
+		i.ExpiresAt = null.TimeFrom(time.Unix(draft.ExpiresAt, 0).UTC())
+	}
+
+	if err := i.Insert(e, boil.Infer()); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	result := modext.NewInvite(i)
+	recordAudit(user, AuditCreate, AuditTargetInvite, i.ID, nil, result)
+
+	result.Role = modext.NewRole(role)
+	result.Code = code
+	result.URL = JoinURL(config.GetMeta().BaseURL, "/register") + "?invite=" + code
+	return result, nil
+}
+
+// This function simply calls GetInvitesEx with the global Read connection.
+func GetInvites() ([]*modext.Invite, error) {
+	return GetInvitesEx(ReadDB)
+}
+
+// GetInvitesEx gets all invites, including the expired and used up ones.
+// Results are sorted from the most recent.
+func GetInvitesEx(e boil.Executor) ([]*modext.Invite, error) {
+	invites, err := models.Invites(
+		OrderBy("id DESC"),
+		Load(models.InviteRels.Role),
+		Load(models.InviteRels.Creator),
+	).All(e)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	result := make([]*modext.Invite, len(invites))
+	for i, invite := range invites {
+		result[i] = modext.NewInvite(invite).LoadRole(invite).LoadCreator(invite)
+	}
+	return result, nil
+}
+
+// This function simply calls DeleteInviteEx with the global Write connection.
+func DeleteInvite(id int64, user *modext.User) error {
+	return DeleteInviteEx(WriteDB, id, user)
+}
+
+// DeleteInviteEx revokes an invite, the users who have already
+// registered with it are left untouched.
+func DeleteInviteEx(e boil.Executor, id int64, user *modext.User) error {
+	i, err := models.FindInvite(e, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errs.ErrInviteNotFound
+		}
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	if err := i.Delete(e); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditDelete, AuditTargetInvite, id, modext.NewInvite(i), nil)
+	return nil
+}
+
+// findUsableInvite finds the invite of the given code and locks it until
+// the end of the transaction, returns an error if the invite has expired
+// or has been used up.
+func findUsableInvite(tx *sql.Tx, code string) (*models.Invite, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == 0 {
+		return nil, errs.ErrInviteRequired
+	}
+
+	i, err := models.Invites(
+		Where("hash = ?", hashInviteCode(code)),
+		For("UPDATE"),
+	).One(tx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrInviteInvalid
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if i.Uses >= i.MaxUses || (i.ExpiresAt.Valid && !i.ExpiresAt.Time.After(time.Now())) {
+		return nil, errs.ErrInviteInvalid
+	}
+	return i, nil
+}
+
+// createInvitedUser creates a user with the given invite, the user is granted
+// the role and the permissions of the invite and the invite is used once.
+func createInvitedUser(opts CreateUserOptions, code string) (*modext.User, error) {
+	tx, err := WriteDB.Begin()
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	defer tx.Rollback()
+
+	i, err := findUsableInvite(tx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := CreateUserEx(tx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	u := user.ToModel()
+	if len(i.Permissions) > 0 {
+		for _, perm := range i.Permissions {
+			if !stringsContains(u.Permissions, perm) {
+				u.Permissions = append(u.Permissions, perm)
+			}
+		}
+
+		if err := u.Update(tx, boil.Whitelist(UserCols.Permissions)); err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+	}
+
+	// New users already have the uploader role, which can't be added twice.
+	hasRole := false
+	for _, r := range user.Roles {
+		if r.ID == i.RoleID.Int64 {
+			hasRole = true
+			break
+		}
+	}
+
+	if i.RoleID.Valid && !hasRole {
+		role, err := findRole(tx, i.RoleID.Int64)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := u.AddRoles(tx, false, role); err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+	}
+
+	i.Uses++
+	if err := i.Update(tx, boil.Whitelist(InviteCols.Uses)); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if user, err = GetUserEx(tx, user.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return user, nil
+}
@@ -0,0 +1,515 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	. "kasen/database"
+
+	"kasen/config"
+	"kasen/constants"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var JobCols = models.JobColumns
+
+// Job statuses.
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+var JobStatus = []string{
+	JobStatusPending,
+	JobStatusRunning,
+	JobStatusCompleted,
+	JobStatusFailed,
+	JobStatusCancelled,
+}
+
+// maxJobBackoff is the maximum delay between two attempts of a job.
+const maxJobBackoff = time.Hour
+
+// Job is a job being run by a worker.
+type Job struct {
+	*models.Job
+
+	// User is the user who created the job, if any, restricted
+	// to the permissions it had when the job was created.
+	User *modext.User
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Context returns the context of the job, which is done once the job
+// has been cancelled.
+func (j *Job) Context() context.Context {
+	return j.ctx
+}
+
+// Bind decodes the payload of the job into v.
+func (j *Job) Bind(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// SetProgress updates the progress of the job.
+//
+// The context of the job is cancelled if the job has been cancelled
+// in the meantime, handlers should stop as soon as possible then.
+func (j *Job) SetProgress(progress, total int) error {
+	j.Progress, j.Total = progress, total
+
+	res, err := WriteDB.Exec(
+		`UPDATE job SET progress = $1, total = $2, updated_at = NOW() WHERE id = $3 AND status = $4`,
+		progress, total, j.ID, JobStatusRunning)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		j.cancel()
+		return context.Canceled
+	}
+	return nil
+}
+
+// JobHandler handles a job of a kind, errors are retried
+// unless they're wrapped with permanentJobError.
+type JobHandler func(j *Job) error
+
+// permanentJobError represents an error which won't be fixed by retrying.
+type permanentJobError struct {
+	err error
+}
+
+func (e *permanentJobError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentJobError) Unwrap() error {
+	return e.err
+}
+
+// permanent marks the given error as permanent, so that the job
+// fails without being retried.
+func permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentJobError{err}
+}
+
+var jobHandlers = make(map[string]JobHandler)
+
+// registerJobHandler registers the handler of the given kind of jobs,
+// it must be called in init.
+func registerJobHandler(kind string, handler JobHandler) {
+	if _, ok := jobHandlers[kind]; ok {
+		panic("Job handler already registered: " + kind)
+	}
+	jobHandlers[kind] = handler
+}
+
+// runningJobs holds the jobs being run by this instance,
+// so that they can be cancelled right away.
+var runningJobs = struct {
+	sync.Mutex
+	jobs map[int64]*Job
+}{jobs: make(map[int64]*Job)}
+
+// wakeJobWorkers wakes up an idle worker after a job has been created.
+var wakeJobWorkers = make(chan struct{}, 1)
+
+// This function simply calls CreateJobEx with the global Write connection.
+func CreateJob(kind string, payload interface{}, user *modext.User) (*modext.Job, error) {
+	return CreateJobEx(WriteDB, kind, payload, user)
+}
+
+// CreateJobEx creates a new job of the given kind which will be run
+// by the first idle worker, with the current permissions of the user.
+func CreateJobEx(e boil.Executor, kind string, payload interface{}, user *modext.User) (*modext.Job, error) {
+	if _, ok := jobHandlers[kind]; !ok {
+		return nil, errs.ErrInvalidJobKind
+	}
+
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	j := &models.Job{
+		Kind:        kind,
+		Payload:     buf,
+		Status:      JobStatusPending,
+		MaxAttempts: config.GetJobs().MaxAttempts,
+		RunAt:       time.Now().UTC(),
+	}
+
+	if user != nil {
+		j.UserID = null.Int64From(user.ID)
+		j.Scopes = user.Scopes()
+	}
+
+	if err := j.Insert(e, boil.Infer()); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	select {
+	case wakeJobWorkers <- struct{}{}:
+	default:
+	}
+
+	return modext.NewJob(j), nil
+}
+
+// GetJobsOptions represents the options for getting jobs.
+type GetJobsOptions struct {
+	Kind   string `form:"kind"`
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+func (opts *GetJobsOptions) validate() {
+	opts.Kind = strings.ToLower(opts.Kind)
+	opts.Status = strings.ToLower(opts.Status)
+
+	if opts.Limit <= 0 || opts.Limit > 100 {
+		opts.Limit = 100
+	}
+
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+}
+
+// GetJobsResult represents the result of GetJobs.
+type GetJobsResult struct {
+	Jobs  []*modext.Job `json:"data"`
+	Total int64         `json:"total"`
+	Err   error         `json:"error,omitempty"`
+}
+
+// canViewJobs checks if the given user can view and cancel the jobs
+// of other users.
+func canViewJobs(user *modext.User) bool {
+	return user.HasPermissions(constants.PermManage)
+}
+
+// This function simply calls GetJobsEx with the global Read connection.
+func GetJobs(opts GetJobsOptions, user *modext.User) *GetJobsResult {
+	return GetJobsEx(ReadDB, opts, user)
+}
+
+// GetJobsEx gets the jobs of the given user, or all jobs if the user
+// is allowed to manage, ordered from the most recent.
+func GetJobsEx(e boil.Executor, opts GetJobsOptions, user *modext.User) *GetJobsResult {
+	opts.validate()
+
+	var queries []QueryMod
+	if !canViewJobs(user) {
+		queries = append(queries, Where("user_id = ?", user.ID))
+	}
+
+	if len(opts.Kind) > 0 {
+		queries = append(queries, Where("kind = ?", opts.Kind))
+	}
+
+	if len(opts.Status) > 0 {
+		queries = append(queries, Where("status = ?", opts.Status))
+	}
+
+	result := &GetJobsResult{}
+
+	total, err := models.Jobs(queries...).Count(e)
+	if err != nil {
+		log.Println(err)
+		result.Err = errs.ErrUnknown
+		return result
+	}
+
+	queries = append(queries,
+		OrderBy(fmt.Sprintf("%s DESC", JobCols.ID)),
+		Limit(opts.Limit),
+		Offset(opts.Offset))
+
+	jobs, err := models.Jobs(queries...).All(e)
+	if err != nil {
+		log.Println(err)
+		result.Err = errs.ErrUnknown
+		return result
+	}
+
+	result.Total = total
+	result.Jobs = make([]*modext.Job, len(jobs))
+	for i, j := range jobs {
+		result.Jobs[i] = modext.NewJob(j)
+	}
+	return result
+}
+
+// findJob finds the job of the given id which is visible to the given user.
+func findJob(e boil.Executor, id int64, user *modext.User) (*models.Job, error) {
+	j, err := models.FindJob(e, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrJobNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if !canViewJobs(user) && j.UserID.Int64 != user.ID {
+		return nil, errs.ErrJobNotFound
+	}
+	return j, nil
+}
+
+// This function simply calls GetJobEx with the global Read connection.
+func GetJob(id int64, user *modext.User) (*modext.Job, error) {
+	return GetJobEx(ReadDB, id, user)
+}
+
+// GetJobEx gets a job.
+func GetJobEx(e boil.Executor, id int64, user *modext.User) (*modext.Job, error) {
+	j, err := findJob(e, id, user)
+	if err != nil {
+		return nil, err
+	}
+	return modext.NewJob(j), nil
+}
+
+// This function simply calls CancelJobEx with the global Write connection.
+func CancelJob(id int64, user *modext.User) (*modext.Job, error) {
+	return CancelJobEx(WriteDB, id, user)
+}
+
+// CancelJobEx cancels a pending or running job.
+//
+// Running jobs are stopped at their next progress update.
+func CancelJobEx(e boil.Executor, id int64, user *modext.User) (*modext.Job, error) {
+	j, err := findJob(e, id, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.Status != JobStatusPending && j.Status != JobStatusRunning {
+		return nil, errs.ErrJobFinished
+	}
+
+	res, err := e.Exec(
+		`UPDATE job SET status = $1, finished_at = NOW(), updated_at = NOW() WHERE id = $2 AND status IN ($3, $4)`,
+		JobStatusCancelled, id, JobStatusPending, JobStatusRunning)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if n, _ := res.RowsAffected(); n == 0 {
+		return nil, errs.ErrJobFinished
+	}
+
+	runningJobs.Lock()
+	if running, ok := runningJobs.jobs[id]; ok {
+		running.cancel()
+	}
+	runningJobs.Unlock()
+
+	return GetJobEx(e, id, user)
+}
+
+var startJobWorkersOnce sync.Once
+
+// StartJobWorkers starts the workers which run the jobs.
+//
+// Jobs left running by a previous process are run again.
+func StartJobWorkers() {
+	startJobWorkersOnce.Do(func() {
+		err := models.Jobs(Where("status = ?", JobStatusRunning)).UpdateAll(WriteDB, models.M{
+			JobCols.Status: JobStatusPending,
+			JobCols.RunAt:  time.Now().UTC(),
+		})
+		if err != nil {
+			log.Println(err)
+		}
+
+		cfg := config.GetJobs()
+		if cfg.Workers <= 0 {
+			cfg.Workers = 1
+		}
+
+		for i := 0; i < cfg.Workers; i++ {
+			go runJobWorker()
+		}
+	})
+}
+
+func runJobWorker() {
+	for {
+		j, err := claimJob()
+		if err != nil {
+			log.Println("Failed to claim job:", err)
+		}
+
+		if j != nil {
+			runJob(j)
+			continue
+		}
+
+		select {
+		case <-wakeJobWorkers:
+		case <-time.After(config.GetJobs().PollInterval):
+		}
+	}
+}
+
+// claimJob claims the next job to run, if any.
+//
+// Rows locked by other workers are skipped, so that a job is never
+// claimed twice even with multiple instances.
+func claimJob() (*Job, error) {
+	tx, err := WriteDB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	j, err := models.Jobs(
+		Where("status = ?", JobStatusPending),
+		Where("run_at <= NOW()"),
+		OrderBy(fmt.Sprintf("%s ASC, %s ASC", JobCols.RunAt, JobCols.ID)),
+		Limit(1),
+		For("UPDATE SKIP LOCKED"),
+	).One(tx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	j.Status = JobStatusRunning
+	j.Attempts++
+	j.StartedAt = null.TimeFrom(time.Now().UTC())
+	j.Error = null.String{}
+
+	if err := j.Update(tx, boil.Whitelist(
+		JobCols.Status,
+		JobCols.Attempts,
+		JobCols.StartedAt,
+		JobCols.Error,
+		JobCols.UpdatedAt,
+	)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	job := &Job{Job: j}
+	job.ctx, job.cancel = context.WithCancel(context.Background())
+
+	if j.UserID.Valid {
+		if job.User, err = GetUser(j.UserID.Int64); err != nil {
+			job.User = nil
+		} else {
+			// The job must not be given more permissions than its user had
+			// when it was created, e.g. with an access token.
+			job.User.RestrictPermissions(j.Scopes)
+		}
+	}
+	return job, nil
+}
+
+func runJob(j *Job) {
+	runningJobs.Lock()
+	runningJobs.jobs[j.ID] = j
+	runningJobs.Unlock()
+
+	defer func() {
+		runningJobs.Lock()
+		delete(runningJobs.jobs, j.ID)
+		runningJobs.Unlock()
+		j.cancel()
+	}()
+
+	var err error
+	if handler, ok := jobHandlers[j.Kind]; !ok {
+		err = permanent(errs.ErrInvalidJobKind)
+	} else {
+		err = runJobHandler(handler, j)
+	}
+
+	if j.ctx.Err() != nil {
+		// The job has been cancelled, its status is already up to date.
+		return
+	}
+
+	now := time.Now().UTC()
+	cols := models.M{JobCols.UpdatedAt: now}
+
+	var perr *permanentJobError
+	switch {
+	case err == nil:
+		cols[JobCols.Status] = JobStatusCompleted
+		cols[JobCols.FinishedAt] = now
+	case errors.As(err, &perr) || j.Attempts >= j.MaxAttempts:
+		cols[JobCols.Status] = JobStatusFailed
+		cols[JobCols.FinishedAt] = now
+		cols[JobCols.Error] = err.Error()
+	default:
+		cols[JobCols.Status] = JobStatusPending
+		cols[JobCols.RunAt] = now.Add(jobBackoff(j.Attempts))
+		cols[JobCols.Error] = err.Error()
+	}
+
+	if err := models.Jobs(
+		Where("id = ?", j.ID),
+		Where("status = ?", JobStatusRunning),
+	).UpdateAll(WriteDB, cols); err != nil {
+		log.Println(err)
+	}
+}
+
+// runJobHandler runs the given handler, recovering from panics.
+func runJobHandler(handler JobHandler, j *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %d (%s) panicked: %v\n", j.ID, j.Kind, r)
+			err = fmt.Errorf("Job panicked: %v", r)
+		}
+	}()
+	return handler(j)
+}
+
+// jobBackoff returns the delay before the next attempt, which
+// is doubled after every attempt.
+func jobBackoff(attempts int) time.Duration {
+	d := config.GetJobs().Backoff
+	for i := 1; i < attempts && d < maxJobBackoff; i++ {
+		d *= 2
+	}
+
+	if d > maxJobBackoff {
+		d = maxJobBackoff
+	}
+	return d
+}
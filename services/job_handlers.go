@@ -0,0 +1,210 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	. "kasen/database"
+
+	"kasen/constants"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/pkg/errors"
+)
+
+// Job kinds.
+const (
+	JobRemapSymlinks     = "remap_symlinks"
+	JobImportPagesMd     = "import_pages_md"
+	JobPregenerateImages = "pregenerate_images"
+	JobDeliverWebhook    = "deliver_webhook"
+)
+
+// Widths of the resized variants requested by the templates,
+// see chapters.html and project.html.
+var (
+	thumbnailWidths = []int{64}
+	coverWidths     = []int{320, 512}
+)
+
+// ImportPagesMdPayload represents the payload of JobImportPagesMd.
+type ImportPagesMdPayload struct {
+	ChapterID   int64  `json:"chapterId"`
+	MdChapterID string `json:"mdChapterId"`
+}
+
+// PregenerateImagesPayload represents the payload of JobPregenerateImages,
+// either the thumbnail of a chapter or a cover is pre-generated.
+type PregenerateImagesPayload struct {
+	ChapterID int64 `json:"chapterId,omitempty"`
+	CoverID   int64 `json:"coverId,omitempty"`
+}
+
+func init() {
+	registerJobHandler(JobRemapSymlinks, func(j *Job) error {
+		return RemapSymlinks()
+	})
+	registerJobHandler(JobImportPagesMd, importPagesMd)
+	registerJobHandler(JobPregenerateImages, pregenerateImages)
+	registerJobHandler(JobDeliverWebhook, deliverWebhook)
+}
+
+// isPermanentError checks if the given error will be returned again
+// if the job is retried, others such as network errors are retried.
+func isPermanentError(err error) bool {
+	switch errors.Cause(err) {
+	case errs.ErrForbidden,
+		errs.ErrChapterNotFound,
+		errs.ErrChapterLocked,
+		errs.ErrPageInvalid,
+		errs.ErrPageTooLarge,
+		errs.ErrPageUnsupportedFormat:
+		return true
+	}
+	return false
+}
+
+// ImportPagesMd creates a JobImportPagesMd job importing the pages of the given
+// MangaDex chapter, the chapter is checked beforehand so that the job doesn't
+// fail on its first attempt.
+func ImportPagesMd(cid int64, mdChapterID string, user *modext.User) (*modext.Job, error) {
+	if len(mdChapterID) == 0 || strings.Contains(mdChapterID, "/") {
+		return nil, errs.ErrInvalidJobPayload
+	}
+
+	c, err := models.FindChapter(ReadDB, cid)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrChapterNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if c.Locked.Bool {
+		return nil, errs.ErrChapterLocked
+	}
+
+	if !hasChapterPermission(ReadDB, user, c, constants.PermEditChapter, constants.PermEditChapters) {
+		return nil, errs.ErrForbidden
+	}
+	return CreateJob(JobImportPagesMd, &ImportPagesMdPayload{cid, mdChapterID}, user)
+}
+
+// importPagesMd uploads the pages of a MangaDex chapter one by one,
+// the pages uploaded by a previous attempt are skipped.
+func importPagesMd(j *Job) error {
+	payload := &ImportPagesMdPayload{}
+	if err := j.Bind(payload); err != nil {
+		return permanent(errs.ErrInvalidJobPayload)
+	}
+
+	if j.User == nil {
+		return permanent(errs.ErrForbidden)
+	}
+
+	md, err := GetPagesMd(payload.MdChapterID)
+	if err != nil {
+		if isPermanentError(err) {
+			return permanent(err)
+		}
+		return err
+	}
+
+	total := len(md.Pages)
+	for i := j.Progress; i < total; i++ {
+		if err := j.Context().Err(); err != nil {
+			return err
+		}
+
+		source := fmt.Sprintf("%s/data/%s/%s", md.BaseURL, md.Hash, md.Pages[i])
+		if _, err := UploadPageFromSource(payload.ChapterID, source, j.User); err != nil {
+			if isPermanentError(err) {
+				return permanent(errors.Wrap(err, md.Pages[i]))
+			}
+			return err
+		}
+
+		if err := j.SetProgress(i+1, total); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pregenerateImages creates the resized variants of the thumbnail
+// of a chapter, or of a cover.
+func pregenerateImages(j *Job) error {
+	payload := &PregenerateImagesPayload{}
+	if err := j.Bind(payload); err != nil {
+		return permanent(errs.ErrInvalidJobPayload)
+	}
+
+	var key string
+	var options []ResizeOptions
+
+	switch {
+	case payload.ChapterID > 0:
+		c, err := models.FindChapter(ReadDB, payload.ChapterID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return permanent(errs.ErrChapterNotFound)
+			}
+			return err
+		}
+
+		chapter := modext.NewChapter(c)
+		chapter.GetThumbnail(c)
+		if !strings.HasPrefix(chapter.Thumbnail, "/pages/") {
+			return nil
+		}
+
+		key = chapterKey(c.ID, path.Base(chapter.Thumbnail))
+		for _, width := range thumbnailWidths {
+			options = append(options, ResizeOptions{Width: width})
+		}
+	case payload.CoverID > 0:
+		c, err := models.FindCover(ReadDB, payload.CoverID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return permanent(errs.ErrCoverNotFound)
+			}
+			return err
+		}
+
+		key = coverKey(c.ProjectID, c.FileName)
+		for _, width := range coverWidths {
+			options = append(options, coverResizeOptions(width))
+		}
+	default:
+		return permanent(errs.ErrInvalidJobPayload)
+	}
+
+	for i, o := range options {
+		if err := j.Context().Err(); err != nil {
+			return err
+		}
+
+		if err := pregenerateImage(key, o); err != nil {
+			return err
+		}
+
+		if err := j.SetProgress(i+1, len(options)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createPregenerateImagesJob creates a JobPregenerateImages job,
+// failures are only logged as images are still resized on demand.
+func createPregenerateImagesJob(payload *PregenerateImagesPayload) {
+	if _, err := CreateJob(JobPregenerateImages, payload, nil); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	. "kasen/cache"
+	. "kasen/database"
+
+	"kasen/config"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var LoginFailureCols = models.LoginFailureColumns
+
+// Reasons of the failed logins.
+const (
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	LoginFailureInvalidTwoFactor   = "invalid_two_factor"
+	LoginFailureThrottled          = "throttled"
+	LoginFailureLocked             = "locked"
+)
+
+// loginMaxDelay is the maximum delay between the logins of an account,
+// before it's locked.
+const loginMaxDelay = 5 * time.Minute
+
+// loginAttemptTimeout is how long a login of an account whose logins are
+// delayed is allowed to take, before another login can be attempted.
+const loginAttemptTimeout = time.Minute
+
+// States of the blocked logins of an account.
+const (
+	loginDelayed = "delayed"
+	loginLocked  = "locked"
+)
+
+// loginFailuresKey gets the key of the number of failed logins of the given
+// email, they are counted per email rather than per ip so that the logins of
+// an account are blocked even if they come from many addresses.
+func loginFailuresKey(email string) string {
+	return "login:failures:" + strings.ToLower(email)
+}
+
+func loginBlockedKey(email string) string {
+	return "login:blocked:" + strings.ToLower(email)
+}
+
+// incrLoginFailures increases the number of failed logins of the given email,
+// and returns it.
+func incrLoginFailures(email string) (int, error) {
+	ctx := context.Background()
+	key := loginFailuresKey(email)
+
+	// Failures are forgotten once the lockout is over.
+	window := config.GetSecurity().LoginLockout
+	if window < loginMaxDelay {
+		window = loginMaxDelay
+	}
+
+	pipe := Redis.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return int(incr.Val()), nil
+}
+
+// beginLogin counts a login of the given email as failed before its
+// credentials are checked, so that concurrent logins can't bypass the
+// delays and the lockout, and returns the number of failures.
+//
+// Once the logins are delayed, only one login is attempted at a time.
+// An error is returned if the logins are delayed or locked, the blocked
+// login is then recorded but not counted.
+func beginLogin(email string, client SessionClient) (int, error) {
+	failures, err := incrLoginFailures(email)
+	if err != nil {
+		log.Println(err)
+		return 0, errs.ErrUnknown
+	}
+
+	ctx := context.Background()
+	security := config.GetSecurity()
+
+	allowed := false
+	if (security.LoginDelayAfter > 0 && failures > security.LoginDelayAfter) ||
+		(security.LoginMaxAttempts > 0 && security.LoginLockout > 0 && failures > security.LoginMaxAttempts) {
+		allowed, err = Redis.SetNX(ctx, loginBlockedKey(email), loginDelayed, loginAttemptTimeout).Result()
+	} else {
+		var n int64
+		n, err = Redis.Exists(ctx, loginBlockedKey(email)).Result()
+		allowed = n == 0
+	}
+
+	if err == nil && allowed {
+		return failures, nil
+	}
+	uncountLogin(email)
+
+	if err != nil {
+		log.Println(err)
+		return 0, errs.ErrUnknown
+	}
+
+	state, err := Redis.Get(ctx, loginBlockedKey(email)).Result()
+	if err != nil && err != redis.Nil {
+		log.Println(err)
+		return 0, errs.ErrUnknown
+	}
+
+	if state == loginLocked {
+		insertLoginFailure(email, nil, client, LoginFailureLocked)
+		return 0, errs.ErrAccountLocked
+	}
+	insertLoginFailure(email, nil, client, LoginFailureThrottled)
+	return 0, errs.ErrLoginThrottled
+}
+
+// uncountLogin forgets a login counted by beginLogin which
+// has not been attempted.
+func uncountLogin(email string) {
+	if err := Redis.Decr(context.Background(), loginFailuresKey(email)).Err(); err != nil {
+		log.Println(err)
+	}
+}
+
+// isLoginLocked checks if the logins of the given email are locked.
+func isLoginLocked(email string) (bool, error) {
+	state, err := Redis.Get(context.Background(), loginBlockedKey(email)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return false, nil
+		}
+		return false, err
+	}
+	return state == loginLocked, nil
+}
+
+// recordLoginFailure records a login of the given email which failed for the
+// given reason, the user is nil if the email does not exist. The failure must
+// already be counted, failures is the number of failed logins.
+//
+// Once the account has failed too many times, its logins are delayed, twice
+// as long after each failure, and then it's locked. The user is notified by
+// email and the lockout is dispatched to the webhooks.
+func recordLoginFailure(email string, user *modext.User, client SessionClient, reason string, failures int) {
+	insertLoginFailure(email, user, client, reason)
+
+	security := config.GetSecurity()
+	ctx := context.Background()
+
+	log.Printf("Failed login of %s from %s, %d failures\n", email, client.IP, failures)
+
+	switch {
+	case security.LoginMaxAttempts > 0 && security.LoginLockout > 0 && failures >= security.LoginMaxAttempts:
+		if err := Redis.Set(ctx, loginBlockedKey(email), loginLocked, security.LoginLockout).Err(); err != nil {
+			log.Println(err)
+			return
+		}
+
+		// The blocked logins are not counted, so the lockout is only notified once.
+		if failures == security.LoginMaxAttempts {
+			log.Printf("Locked logins of %s after %d failures\n", email, failures)
+			if user != nil {
+				notifyAccountLocked(user, client, failures)
+			}
+		}
+	case security.LoginDelayAfter > 0 && failures >= security.LoginDelayAfter:
+		delay := loginMaxDelay
+		if n := failures - security.LoginDelayAfter; n < 16 && time.Second<<n < delay {
+			delay = time.Second << n
+		}
+
+		if err := Redis.Set(ctx, loginBlockedKey(email), loginDelayed, delay).Err(); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// notifyAccountLocked records the lockout of the given user, and notifies
+// the user by email and the webhooks.
+//
+// The webhooks only receive the id and name of the user, along with
+// the time of the lockout and the number of failures.
+func notifyAccountLocked(user *modext.User, client SessionClient, failures int) {
+	lockout := config.GetSecurity().LoginLockout
+	lockedAt := time.Now()
+	lockedUntil := lockedAt.Add(lockout).Unix()
+
+	recordAudit(nil, AuditLock, AuditTargetUser, user.ID, nil, map[string]interface{}{
+		"ip":          client.IP,
+		"failures":    failures,
+		"lockedUntil": lockedUntil,
+	})
+
+	go dispatchWebhookEvent(WebhookUserLocked, map[string]interface{}{
+		"id":          user.ID,
+		"name":        user.Name,
+		"lockedAt":    lockedAt.Unix(),
+		"lockedUntil": lockedUntil,
+		"failures":    failures,
+	})
+
+	sendMail("account_locked", user.Email, map[string]interface{}{
+		"Name":       user.Name,
+		"Failures":   failures,
+		"IP":         client.IP,
+		"Expiration": formatExpiration(lockout),
+		"URL":        JoinURL(config.GetMeta().BaseURL, "/forgot_password"),
+	})
+}
+
+// clearLoginFailures forgets the failed logins of the given email,
+// which lifts the delays and the lockout of its logins.
+func clearLoginFailures(email string) error {
+	return Redis.Del(context.Background(), loginFailuresKey(email), loginBlockedKey(email)).Err()
+}
+
+// insertLoginFailure records a failed login with the given reason,
+// errors are only logged.
+func insertLoginFailure(email string, user *modext.User, client SessionClient, reason string) {
+	if len(client.UserAgent) > 256 {
+		client.UserAgent = client.UserAgent[:256]
+	}
+
+	f := &models.LoginFailure{
+		Email:     strings.ToLower(email),
+		IP:        null.NewString(client.IP, len(client.IP) > 0),
+		UserAgent: null.NewString(client.UserAgent, len(client.UserAgent) > 0),
+		Reason:    reason,
+	}
+
+	if user != nil {
+		f.UserID = null.Int64From(user.ID)
+	}
+
+	if err := f.Insert(WriteDB, boil.Infer()); err != nil {
+		log.Println(err)
+	}
+}
+
+// UnlockUser lifts the delays and the lockout of the logins of the given user.
+func UnlockUser(user *modext.User, actor *modext.User) error {
+	if err := clearLoginFailures(user.Email); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	recordAudit(actor, AuditUnlock, AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
+
+// GetLoginFailuresOptions represents the options for getting the failed logins.
+type GetLoginFailuresOptions struct {
+	Email  string `form:"email"`
+	UserID int64  `form:"user"`
+	IP     string `form:"ip"`
+	Reason string `form:"reason"`
+	Since  int64  `form:"since"`
+	Until  int64  `form:"until"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+func (opts *GetLoginFailuresOptions) validate() {
+	opts.Email = strings.ToLower(strings.TrimSpace(opts.Email))
+	opts.IP = strings.TrimSpace(opts.IP)
+	opts.Reason = strings.ToLower(opts.Reason)
+
+	if opts.Limit <= 0 || opts.Limit > 100 {
+		opts.Limit = 100
+	}
+
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+}
+
+// GetLoginFailuresResult represents the result of GetLoginFailures.
+type GetLoginFailuresResult struct {
+	Failures []*modext.LoginFailure `json:"data"`
+	Total    int64                  `json:"total"`
+	Err      error                  `json:"error,omitempty"`
+}
+
+// This function simply calls GetLoginFailuresEx with the global Read connection.
+func GetLoginFailures(opts GetLoginFailuresOptions) *GetLoginFailuresResult {
+	return GetLoginFailuresEx(ReadDB, opts)
+}
+
+// GetLoginFailuresEx gets the failed logins with the given options,
+// ordered from the most recent.
+func GetLoginFailuresEx(e boil.Executor, opts GetLoginFailuresOptions) *GetLoginFailuresResult {
+	opts.validate()
+
+	var queries []QueryMod
+	if len(opts.Email) > 0 {
+		queries = append(queries, Where("email = ?", opts.Email))
+	}
+
+	if opts.UserID > 0 {
+		queries = append(queries, Where("user_id = ?", opts.UserID))
+	}
+
+	if len(opts.IP) > 0 {
+		queries = append(queries, Where("ip = ?", opts.IP))
+	}
+
+	if len(opts.Reason) > 0 {
+		queries = append(queries, Where("reason = ?", opts.Reason))
+	}
+
+	if opts.Since > 0 {
+		queries = append(queries, Where("created_at >= ?", time.Unix(opts.Since, 0).UTC()))
+	}
+
+	if opts.Until > 0 {
+		queries = append(queries, Where("created_at < ?", time.Unix(opts.Until, 0).UTC()))
+	}
+
+	result := &GetLoginFailuresResult{}
+
+	total, err := models.LoginFailures(queries...).Count(e)
+	if err != nil {
+		log.Println(err)
+		result.Err = errs.ErrUnknown
+		return result
+	}
+
+	queries = append(queries,
+		OrderBy(fmt.Sprintf("%s DESC", LoginFailureCols.ID)),
+		Limit(opts.Limit),
+		Offset(opts.Offset))
+
+	failures, err := models.LoginFailures(queries...).All(e)
+	if err != nil {
+		log.Println(err)
+		result.Err = errs.ErrUnknown
+		return result
+	}
+
+	result.Total = total
+	result.Failures = make([]*modext.LoginFailure, len(failures))
+	for i, f := range failures {
+		result.Failures[i] = modext.NewLoginFailure(f)
+	}
+	return result
+}
@@ -0,0 +1,443 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	. "kasen/cache"
+	. "kasen/database"
+
+	"kasen/config"
+	"kasen/errs"
+	"kasen/mail"
+	"kasen/modext"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Mail token kinds, tokens are sent by email to prove the ownership of an address.
+const (
+	mailTokenPasswordReset = "password_reset"
+	mailTokenVerifyEmail   = "verify_email"
+	mailTokenConfirmEmail  = "confirm_email"
+)
+
+const PasswordResetExpiration = time.Hour          // 1 hour
+const EmailVerificationExpiration = 24 * time.Hour // 24 hours
+
+// emailVerificationInterval is the minimum interval between
+// the email verification links sent to a user.
+const emailVerificationInterval = 5 * time.Minute
+
+// mailToken represents what a token sent by email was issued for.
+type mailToken struct {
+	UserID int64  `json:"userId"`
+	Email  string `json:"email"`
+
+	// NewEmail is the email confirmed by the token, if it's changed.
+	NewEmail string `json:"newEmail,omitempty"`
+}
+
+// mailTokenKey gets the Redis key of the given token, only the hashes are stored.
+func mailTokenKey(kind, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "mail:" + kind + ":" + hex.EncodeToString(sum[:])
+}
+
+// createMailToken creates a token of the given kind.
+func createMailToken(kind string, t *mailToken, expiration time.Duration) (string, error) {
+	token, err := randomString(32)
+	if err != nil {
+		return "", err
+	}
+
+	buf, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	if err := Redis.Set(context.Background(), mailTokenKey(kind, token), buf, expiration).Err(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// consumeMailToken gets what the given token was issued for,
+// a token can only be used once.
+func consumeMailToken(kind, token string) (*mailToken, error) {
+	if len(token) == 0 {
+		return nil, errs.ErrMailTokenInvalid
+	}
+
+	ctx := context.Background()
+	key := mailTokenKey(kind, token)
+
+	buf, err := Redis.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, errs.ErrMailTokenInvalid
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if n, err := Redis.Del(ctx, key).Result(); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if n == 0 {
+		return nil, errs.ErrMailTokenInvalid
+	}
+
+	t := &mailToken{}
+	if err := json.Unmarshal(buf, t); err != nil {
+		log.Println(err)
+		return nil, errs.ErrMailTokenInvalid
+	}
+	return t, nil
+}
+
+// findMailTokenUser gets the user the given token was issued for, tokens
+// are only valid as long as the user still has the same email.
+func findMailTokenUser(t *mailToken) (*modext.User, error) {
+	user, err := GetUser(t.UserID)
+	if err != nil {
+		if err == errs.ErrUserNotFound {
+			return nil, errs.ErrMailTokenInvalid
+		}
+		return nil, err
+	} else if !strings.EqualFold(user.Email, t.Email) {
+		return nil, errs.ErrMailTokenInvalid
+	}
+	return user, nil
+}
+
+// sendMail renders the message of the given template and sends it in the
+// background, failures are only logged.
+func sendMail(name, to string, data map[string]interface{}) {
+	data["Title"] = config.GetMeta().Title
+
+	msg, err := mail.Render(name, to, data)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	go func() {
+		if err := mail.Send(msg); err != nil {
+			log.Println(err)
+		}
+	}()
+}
+
+// mailURL gets the URL of the given path with the given token.
+func mailURL(path, token string) string {
+	return JoinURL(config.GetMeta().BaseURL, path) + "?token=" + token
+}
+
+// formatExpiration formats the given expiration for humans.
+func formatExpiration(d time.Duration) string {
+	switch {
+	case d == time.Hour:
+		return "1 hour"
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	}
+	return fmt.Sprintf("%d minutes", d/time.Minute)
+}
+
+// RequestPasswordReset sends a password reset link to the given email,
+// if it belongs to a user. Nothing tells whether it does.
+func RequestPasswordReset(email string) error {
+	email = strings.TrimSpace(email)
+	if len(email) == 0 {
+		return errs.ErrEmailRequired
+	} else if len(email) > 255 {
+		return errs.ErrEmailTooLong
+	} else if !isEmail(email) {
+		return errs.ErrEmailInvalid
+	}
+
+	user, err := GetUserByEmail(email)
+	if err != nil {
+		if err == errs.ErrUserNotFound {
+			return nil
+		}
+		return err
+	}
+
+	token, err := createMailToken(mailTokenPasswordReset, &mailToken{UserID: user.ID, Email: user.Email}, PasswordResetExpiration)
+	if err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	sendMail("password_reset", user.Email, map[string]interface{}{
+		"Name":       user.Name,
+		"URL":        mailURL("/reset_password", token),
+		"Expiration": formatExpiration(PasswordResetExpiration),
+	})
+	return nil
+}
+
+// ResetPassword sets the password of the user the given
+// password reset token was sent to.
+func ResetPassword(token, rawPassword string) error {
+	if len(rawPassword) == 0 {
+		return errs.ErrNewPasswordRequired
+	} else if len(rawPassword) < 6 {
+		return errs.ErrNewPasswordTooShort
+	}
+
+	t, err := consumeMailToken(mailTokenPasswordReset, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := findMailTokenUser(t)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := hashPassword(rawPassword)
+	if err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	u := user.ToModel()
+	u.Password = hashedPassword
+	cols := []string{UserCols.Password, UserCols.UpdatedAt}
+
+	// The user received the link, so the email is verified.
+	if !user.EmailVerified {
+		u.EmailVerifiedAt = null.TimeFrom(time.Now().UTC())
+		cols = append(cols, UserCols.EmailVerifiedAt)
+	}
+
+	if err := u.Update(WriteDB, boil.Whitelist(cols...)); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	// The user proved the ownership of the email, so the lockout is lifted.
+	if err := clearLoginFailures(user.Email); err != nil {
+		log.Println(err)
+	}
+
+	// Sessions started with the previous password are no longer trusted.
+	if err := revokeUserSessions(user.ID); err != nil {
+		log.Println(err)
+	}
+
+	recordAudit(user, AuditUpdatePassword, AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
+
+// emailVerificationSentKey gets the Redis key which is set
+// while the email verification links of the given user are throttled.
+func emailVerificationSentKey(uid int64) string {
+	return fmt.Sprintf("mail:%s:sent:%d", mailTokenVerifyEmail, uid)
+}
+
+// emailVerificationPendingKey gets the Redis key of the last email
+// verification token sent to the given user, so that it's sent
+// again rather than issuing a new one.
+func emailVerificationPendingKey(uid int64) string {
+	return fmt.Sprintf("mail:%s:pending:%d", mailTokenVerifyEmail, uid)
+}
+
+// sendEmailVerification sends an email verification link to the given user.
+//
+// Links are sent at most once every few minutes, the pending token
+// is sent again as long as it's valid and issued for the same email.
+func sendEmailVerification(user *modext.User) error {
+	ctx := context.Background()
+
+	ok, err := Redis.SetNX(ctx, emailVerificationSentKey(user.ID), 1, emailVerificationInterval).Result()
+	if err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	} else if !ok {
+		return nil
+	}
+
+	token, expiration, err := getPendingEmailVerification(user)
+	if err != nil {
+		log.Println(err)
+	}
+
+	if len(token) == 0 {
+		token, err = createMailToken(mailTokenVerifyEmail, &mailToken{UserID: user.ID, Email: user.Email}, EmailVerificationExpiration)
+		if err != nil {
+			log.Println(err)
+			return errs.ErrUnknown
+		}
+		expiration = EmailVerificationExpiration
+
+		if err := Redis.Set(ctx, emailVerificationPendingKey(user.ID), token, expiration).Err(); err != nil {
+			log.Println(err)
+		}
+	}
+
+	sendMail("verify_email", user.Email, map[string]interface{}{
+		"Name":       user.Name,
+		"URL":        mailURL("/verify_email", token),
+		"Expiration": formatExpiration(expiration),
+	})
+	return nil
+}
+
+// getPendingEmailVerification gets the pending email verification token
+// of the given user and how long it's still valid for, the token is empty
+// if it has been used, has expired or was issued for another email.
+func getPendingEmailVerification(user *modext.User) (string, time.Duration, error) {
+	ctx := context.Background()
+
+	token, err := Redis.Get(ctx, emailVerificationPendingKey(user.ID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", 0, nil
+		}
+		return "", 0, err
+	}
+
+	key := mailTokenKey(mailTokenVerifyEmail, token)
+	buf, err := Redis.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return "", 0, nil
+		}
+		return "", 0, err
+	}
+
+	t := &mailToken{}
+	if err := json.Unmarshal(buf, t); err != nil {
+		return "", 0, err
+	} else if t.UserID != user.ID || !strings.EqualFold(t.Email, user.Email) {
+		return "", 0, nil
+	}
+
+	ttl, err := Redis.TTL(ctx, key).Result()
+	if err != nil {
+		return "", 0, err
+	}
+
+	// Tokens about to expire are not worth sending again.
+	if ttl < time.Hour {
+		return "", 0, nil
+	}
+	return token, ttl.Truncate(time.Hour), nil
+}
+
+// VerifyEmail verifies the email of the user the given
+// verification token was sent to.
+func VerifyEmail(token string) error {
+	t, err := consumeMailToken(mailTokenVerifyEmail, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := findMailTokenUser(t)
+	if err != nil {
+		return err
+	} else if user.EmailVerified {
+		return nil
+	}
+
+	u := user.ToModel()
+	u.EmailVerifiedAt = null.TimeFrom(time.Now().UTC())
+
+	if err := u.Update(WriteDB, boil.Whitelist(UserCols.EmailVerifiedAt, UserCols.UpdatedAt)); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditUpdate, AuditTargetUser, user.ID,
+		map[string]bool{"emailVerified": false}, map[string]bool{"emailVerified": true})
+	return nil
+}
+
+// RequestEmailChangeOptions represents the parameters for changing the email of a user.
+type RequestEmailChangeOptions struct {
+	Email       string `json:"email"`
+	RawPassword string `json:"password"`
+}
+
+// RequestEmailChange sends a confirmation link to the new email of the given user,
+// the email is only changed once confirmed with ConfirmEmailChange.
+func RequestEmailChange(user *modext.User, opts RequestEmailChangeOptions) error {
+	email := strings.TrimSpace(opts.Email)
+
+	switch {
+	case len(email) == 0:
+		return errs.ErrEmailRequired
+	case len(email) > 255:
+		return errs.ErrEmailTooLong
+	case !isEmail(email):
+		return errs.ErrEmailInvalid
+	case strings.EqualFold(email, user.Email):
+		return errs.ErrEmailUnchanged
+	case len(opts.RawPassword) == 0:
+		return errs.ErrPasswordRequired
+	}
+
+	if err := user.CheckPassword(opts.RawPassword); err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return errs.ErrInvalidCredentials
+		}
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	if CheckUserExistsByEmail(email) {
+		return errs.ErrEmailTaken
+	}
+
+	t := &mailToken{UserID: user.ID, Email: user.Email, NewEmail: email}
+	token, err := createMailToken(mailTokenConfirmEmail, t, EmailVerificationExpiration)
+	if err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	sendMail("confirm_email", email, map[string]interface{}{
+		"Name":       user.Name,
+		"Email":      email,
+		"URL":        mailURL("/confirm_email", token),
+		"Expiration": formatExpiration(EmailVerificationExpiration),
+	})
+	return nil
+}
+
+// ConfirmEmailChange changes the email of the user the given confirmation
+// token was sent to, and notifies the previous email of the change.
+func ConfirmEmailChange(token string) error {
+	t, err := consumeMailToken(mailTokenConfirmEmail, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := findMailTokenUser(t)
+	if err != nil {
+		return err
+	}
+
+	if err := UpdateUserEmail(user, t.NewEmail, user); err != nil {
+		return err
+	}
+
+	sendMail("email_changed", t.Email, map[string]interface{}{
+		"Name":  user.Name,
+		"Email": t.NewEmail,
+	})
+	return nil
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"os"
+	"testing"
+
+	"kasen/cache"
+	"kasen/database"
+)
+
+// backendsErr is the error returned while connecting to Postgres and Redis,
+// which are configured by the file given by KASEN_CONFIG.
+var backendsErr error
+
+func TestMain(m *testing.M) {
+	if backendsErr = database.ConnectDB(); backendsErr == nil {
+		backendsErr = cache.ConnectRedis()
+	}
+	os.Exit(m.Run())
+}
+
+// requireBackends skips the test if Postgres or Redis are not available.
+func requireBackends(t *testing.T) {
+	t.Helper()
+	if backendsErr != nil {
+		t.Skip("Postgres and Redis are required:", backendsErr)
+	}
+}
@@ -0,0 +1,739 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	. "kasen/cache"
+	. "kasen/database"
+
+	"kasen/config"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var UserIdentityCols = models.UserIdentityColumns
+
+// OIDCCallbackPath is the path the identity provider redirects to,
+// it has to be allowed as a redirect URI by the provider.
+const OIDCCallbackPath = "/login/oidc/callback"
+
+const OIDCLoginExpiration = 10 * time.Minute // 10 minutes
+const oidcDiscoveryExpiration = time.Hour    // 1 hour
+
+// oidcKeysInterval is the minimum interval between two fetches of the
+// provider keys, which are refetched when a token is signed by an unknown key.
+const oidcKeysInterval = time.Minute
+
+var oidcClient = &http.Client{Timeout: 10 * time.Second}
+
+// oidcProvider represents the discovered configuration of the identity provider.
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+
+	discoveredAt  time.Time
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+var oidcCache struct {
+	sync.Mutex
+	provider *oidcProvider
+}
+
+// oidcLogin represents a login in progress, stored until the user
+// is redirected back from the identity provider.
+type oidcLogin struct {
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+
+	// UserID is the id of the logged in user which links
+	// the identity to its account, if any.
+	UserID int64 `json:"userId,omitempty"`
+}
+
+// oidcClaims represents the claims of the identity used by Kasen.
+type oidcClaims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// oidcRedirectURL gets the URL the identity provider redirects to.
+func oidcRedirectURL() string {
+	return strings.TrimSuffix(config.GetMeta().BaseURL, "/") + OIDCCallbackPath
+}
+
+// oidcScopes gets the configured scopes, openid is always included.
+func oidcScopes(cfg config.OIDC) string {
+	scopes := []string{"openid"}
+	for _, scope := range cfg.Scopes {
+		if scope = strings.TrimSpace(scope); len(scope) > 0 && !stringsContains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 1 {
+		scopes = append(scopes, "profile", "email")
+	}
+	return strings.Join(scopes, " ")
+}
+
+// fetchOIDC fetches the given URL and decodes its JSON body into v.
+func fetchOIDC(req *http.Request, v interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	res, err := oidcClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s: %s", req.Method, req.URL, res.Status, body)
+	}
+	return json.Unmarshal(body, v)
+}
+
+// getOIDCProvider gets the configuration of the identity provider,
+// which is discovered from the issuer and cached.
+func getOIDCProvider(cfg config.OIDC) (*oidcProvider, error) {
+	oidcCache.Lock()
+	defer oidcCache.Unlock()
+
+	issuer := strings.TrimSuffix(cfg.Issuer, "/")
+	if p := oidcCache.provider; p != nil && strings.TrimSuffix(p.Issuer, "/") == issuer &&
+		time.Since(p.discoveredAt) < oidcDiscoveryExpiration {
+		return p, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &oidcProvider{}
+	if err := fetchOIDC(req, p); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSuffix(p.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("issuer %q does not match the configured issuer %q", p.Issuer, cfg.Issuer)
+	} else if len(p.AuthorizationEndpoint) == 0 || len(p.TokenEndpoint) == 0 || len(p.JWKSURI) == 0 {
+		return nil, errors.New("provider configuration is incomplete")
+	}
+
+	// Keep the keys if the provider hasn't changed.
+	if prev := oidcCache.provider; prev != nil && prev.Issuer == p.Issuer && prev.JWKSURI == p.JWKSURI {
+		p.keys, p.keysFetchedAt = prev.keys, prev.keysFetchedAt
+	}
+
+	p.discoveredAt = time.Now()
+	oidcCache.provider = p
+	return p, nil
+}
+
+// jwk represents a JSON web key, only signing keys are used.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// publicKey gets the public key of the JSON web key.
+func (k *jwk) publicKey() (interface{}, error) {
+	decode := func(s string) (*big.Int, error) {
+		buf, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetBytes(buf), nil
+	}
+
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// getOIDCKey gets the key of the given id used by the identity provider
+// to sign its tokens, the keys are refetched if the key is unknown.
+func getOIDCKey(p *oidcProvider, kid string) (interface{}, error) {
+	oidcCache.Lock()
+	defer oidcCache.Unlock()
+
+	find := func() interface{} {
+		if len(kid) == 0 && len(p.keys) == 1 {
+			for _, key := range p.keys {
+				return key
+			}
+		}
+		return p.keys[kid]
+	}
+
+	if key := find(); key != nil {
+		return key, nil
+	} else if time.Since(p.keysFetchedAt) < oidcKeysInterval {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, p.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []*jwk `json:"keys"`
+	}
+	if err := fetchOIDC(req, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{})
+	for _, k := range set.Keys {
+		if len(k.Use) > 0 && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	p.keys, p.keysFetchedAt = keys, time.Now()
+	if key := find(); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
+
+// verifyIDToken verifies the ID token issued by the identity provider
+// for the given login, and returns its claims if the token is valid.
+func verifyIDToken(p *oidcProvider, cfg config.OIDC, raw string, login *oidcLogin) (jwt.MapClaims, error) {
+	t, err := jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+		default:
+			return nil, fmt.Errorf("unexpected signing method %q", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		return getOIDCKey(p, kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := t.Claims.(jwt.MapClaims)
+	if !ok || !t.Valid {
+		return nil, errors.New("invalid ID token")
+	}
+
+	if !claims.VerifyIssuer(p.Issuer, true) {
+		return nil, errors.New("ID token issuer does not match")
+	} else if !claims.VerifyAudience(cfg.ClientID, true) {
+		return nil, errors.New("ID token audience does not match")
+	} else if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("ID token has expired")
+	} else if azp, ok := claims["azp"].(string); ok && azp != cfg.ClientID {
+		return nil, errors.New("ID token authorized party does not match")
+	} else if nonce, _ := claims["nonce"].(string); nonce != login.Nonce {
+		return nil, errors.New("ID token nonce does not match")
+	} else if sub, _ := claims["sub"].(string); len(sub) == 0 {
+		return nil, errors.New("ID token subject is missing")
+	}
+	return claims, nil
+}
+
+// parseOIDCClaims gets the claims of the identity from the given claims,
+// from either the ID token or the userinfo endpoint.
+func parseOIDCClaims(claims map[string]interface{}) *oidcClaims {
+	c := &oidcClaims{}
+	c.Subject, _ = claims["sub"].(string)
+	c.Email, _ = claims["email"].(string)
+	c.Name, _ = claims["name"].(string)
+	c.PreferredUsername, _ = claims["preferred_username"].(string)
+
+	// Some providers return the claim as a string.
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		c.EmailVerified = v
+	case string:
+		c.EmailVerified = v == "true"
+	}
+
+	c.Email = strings.TrimSpace(c.Email)
+	return c
+}
+
+// fetchOIDCUserinfo fetches the claims of the identity from the userinfo
+// endpoint, for the providers which leave the email out of the ID token.
+func fetchOIDCUserinfo(p *oidcProvider, accessToken, subject string) (*oidcClaims, error) {
+	req, err := http.NewRequest(http.MethodGet, p.UserinfoEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	claims := make(map[string]interface{})
+	if err := fetchOIDC(req, &claims); err != nil {
+		return nil, err
+	}
+
+	c := parseOIDCClaims(claims)
+	if c.Subject != subject {
+		return nil, errors.New("userinfo subject does not match")
+	}
+	return c, nil
+}
+
+// oidcBinding gets the value which binds a login to the browser which
+// started it, so that a callback can't be replayed in another browser.
+func oidcBinding(state, nonce string) string {
+	return state + "." + nonce
+}
+
+// OIDCAuthURL starts a login with the identity provider, and returns the URL
+// the user has to be redirected to. If the user is logged in, the identity
+// is linked to its account instead.
+//
+// The returned binding has to be kept by the browser, e.g. in a cookie which
+// lasts OIDCLoginExpiration, and given back to OIDCCallback.
+func OIDCAuthURL(user *modext.User) (authURL, binding string, err error) {
+	cfg := config.GetOIDC()
+	if !cfg.Enabled {
+		return "", "", errs.ErrOIDCDisabled
+	}
+
+	p, err := getOIDCProvider(cfg)
+	if err != nil {
+		log.Println(err)
+		return "", "", errs.ErrOIDCProvider
+	}
+
+	var state string
+	login := &oidcLogin{}
+	for _, s := range []*string{&state, &login.Verifier, &login.Nonce} {
+		if *s, err = randomString(32); err != nil {
+			log.Println(err)
+			return "", "", errs.ErrUnknown
+		}
+	}
+
+	if user != nil {
+		login.UserID = user.ID
+	}
+
+	buf, err := json.Marshal(login)
+	if err != nil {
+		log.Println(err)
+		return "", "", errs.ErrUnknown
+	}
+
+	if err := Redis.Set(context.Background(), "oidc:"+state, buf, OIDCLoginExpiration).Err(); err != nil {
+		log.Println(err)
+		return "", "", errs.ErrUnknown
+	}
+
+	challenge := sha256.Sum256([]byte(login.Verifier))
+
+	u, err := url.Parse(p.AuthorizationEndpoint)
+	if err != nil {
+		log.Println(err)
+		return "", "", errs.ErrOIDCProvider
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", cfg.ClientID)
+	q.Set("redirect_uri", oidcRedirectURL())
+	q.Set("scope", oidcScopes(cfg))
+	q.Set("state", state)
+	q.Set("nonce", login.Nonce)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), oidcBinding(state, login.Nonce), nil
+}
+
+// consumeOIDCLogin gets the login of the given state,
+// a login can only be used once.
+func consumeOIDCLogin(state string) (*oidcLogin, error) {
+	if len(state) == 0 {
+		return nil, errs.ErrOIDCStateInvalid
+	}
+
+	key := "oidc:" + state
+	buf, err := Redis.Get(context.Background(), key).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+		return nil, errs.ErrOIDCStateInvalid
+	}
+
+	if n, err := Redis.Del(context.Background(), key).Result(); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if n == 0 {
+		// Already consumed by a concurrent request.
+		return nil, errs.ErrOIDCStateInvalid
+	}
+
+	login := &oidcLogin{}
+	if err := json.Unmarshal(buf, login); err != nil {
+		log.Println(err)
+		return nil, errs.ErrOIDCStateInvalid
+	}
+	return login, nil
+}
+
+// exchangeOIDCCode exchanges the authorization code for the tokens
+// of the identity, and returns the claims of the identity.
+func exchangeOIDCCode(p *oidcProvider, cfg config.OIDC, code string, login *oidcLogin) (*oidcClaims, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", oidcRedirectURL())
+	form.Set("client_id", cfg.ClientID)
+	form.Set("code_verifier", login.Verifier)
+
+	req, err := http.NewRequest(http.MethodPost, p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if len(cfg.ClientSecret) > 0 {
+		req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+	}
+
+	var res struct {
+		AccessToken string `json:"access_token"`
+		IDToken     string `json:"id_token"`
+	}
+	if err := fetchOIDC(req, &res); err != nil {
+		return nil, err
+	} else if len(res.IDToken) == 0 {
+		return nil, errors.New("token response has no ID token")
+	}
+
+	claims, err := verifyIDToken(p, cfg, res.IDToken, login)
+	if err != nil {
+		return nil, err
+	}
+
+	c := parseOIDCClaims(claims)
+	if len(c.Email) == 0 && len(p.UserinfoEndpoint) > 0 && len(res.AccessToken) > 0 {
+		info, err := fetchOIDCUserinfo(p, res.AccessToken, c.Subject)
+		if err != nil {
+			return nil, err
+		}
+		info.Name = firstNonEmpty(c.Name, info.Name)
+		info.PreferredUsername = firstNonEmpty(c.PreferredUsername, info.PreferredUsername)
+		c = info
+	}
+	return c, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if len(v) > 0 {
+			return v
+		}
+	}
+	return ""
+}
+
+// oidcUserName gets the name of a user created from the given identity,
+// names are truncated to the maximum length of user names.
+func oidcUserName(c *oidcClaims) string {
+	local := c.Email
+	if i := strings.Index(local, "@"); i >= 0 {
+		local = local[:i]
+	}
+
+	for _, name := range []string{c.PreferredUsername, c.Name, local} {
+		name = strings.TrimSpace(name)
+		if utf8.RuneCountInString(name) > 32 {
+			name = strings.TrimSpace(string([]rune(name)[:32]))
+		}
+		if utf8.RuneCountInString(name) >= 3 {
+			return name
+		}
+	}
+	return local
+}
+
+// OIDCCallback completes a login with the identity provider, and returns
+// a new refresh and session token if successful, or a two-factor
+// authentication challenge if the user enabled it.
+//
+// Identities are linked to the logged in user which started the login, or to
+// the user with the same verified email if enabled. Otherwise, a new user is
+// created, unless the registrations are disabled.
+//
+// The given binding is the one returned by OIDCAuthURL to the browser which
+// started the login, otherwise the login is rejected.
+func OIDCCallback(state, binding, code string, client SessionClient) (rt, st *Token, challenge string, err error) {
+	cfg := config.GetOIDC()
+	if !cfg.Enabled {
+		return nil, nil, "", errs.ErrOIDCDisabled
+	}
+
+	login, err := consumeOIDCLogin(state)
+	if err != nil {
+		return nil, nil, "", err
+	} else if len(code) == 0 {
+		return nil, nil, "", errs.ErrOIDCStateInvalid
+	}
+
+	// The login was started by another browser, whose callback URL could
+	// have been sent to log the user in as someone else.
+	if subtle.ConstantTimeCompare([]byte(binding), []byte(oidcBinding(state, login.Nonce))) != 1 {
+		return nil, nil, "", errs.ErrOIDCStateInvalid
+	}
+
+	p, err := getOIDCProvider(cfg)
+	if err != nil {
+		log.Println(err)
+		return nil, nil, "", errs.ErrOIDCProvider
+	}
+
+	claims, err := exchangeOIDCCode(p, cfg, code, login)
+	if err != nil {
+		log.Println(err)
+		return nil, nil, "", errs.ErrInvalidCredentials
+	}
+
+	tx, err := WriteDB.Begin()
+	if err != nil {
+		log.Println(err)
+		return nil, nil, "", errs.ErrUnknown
+	}
+	defer tx.Rollback()
+
+	user, err := linkOIDCIdentityEx(tx, p.Issuer, claims, login, cfg)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println(err)
+		return nil, nil, "", errs.ErrUnknown
+	}
+
+	// Locked accounts can't log in with the identity provider either.
+	if locked, err := isLoginLocked(user.Email); err != nil {
+		log.Println(err)
+		return nil, nil, "", errs.ErrUnknown
+	} else if locked {
+		insertLoginFailure(user.Email, user, client, LoginFailureLocked)
+		return nil, nil, "", errs.ErrAccountLocked
+	}
+
+	return issueLoginTokens(user, client)
+}
+
+// linkOIDCIdentityEx finds the user of the given identity,
+// linking the identity to a user if it is new.
+func linkOIDCIdentityEx(tx *sql.Tx, issuer string, claims *oidcClaims, login *oidcLogin, cfg config.OIDC) (*modext.User, error) {
+	now := time.Now().UTC()
+	email := null.NewString(claims.Email, len(claims.Email) > 0)
+
+	identity, err := models.UserIdentities(Where("issuer = ? AND subject = ?", issuer, claims.Subject)).One(tx)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if identity != nil {
+		if login.UserID > 0 && identity.UserID != login.UserID {
+			return nil, errs.ErrIdentityAlreadyLinked
+		}
+
+		user, err := GetUserEx(tx, identity.UserID)
+		if err != nil {
+			if err == errs.ErrUserNotFound {
+				return nil, errs.ErrInvalidCredentials
+			}
+			return nil, err
+		}
+
+		identity.Email = email
+		identity.LastLoginAt = null.TimeFrom(now)
+		if err := identity.Update(tx, boil.Whitelist(UserIdentityCols.Email, UserIdentityCols.LastLoginAt)); err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+		return user, nil
+	}
+
+	var user *modext.User
+	switch {
+	case login.UserID > 0:
+		user, err = GetUserEx(tx, login.UserID)
+	case len(claims.Email) > 0 && claims.EmailVerified && cfg.LinkByEmail && CheckUserExistsByEmailEx(tx, claims.Email):
+		user, err = GetUserByEmailEx(tx, claims.Email)
+	case config.GetService().DisableRegistration:
+		return nil, errs.ErrRegistrationDisabled
+	case len(claims.Email) == 0 || !claims.EmailVerified:
+		return nil, errs.ErrOIDCEmailRequired
+	default:
+		// Users created from an identity log in with the identity provider,
+		// their password is random until they change it.
+		var password string
+		if password, err = randomString(32); err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+		user, err = CreateUserEx(tx, CreateUserOptions{
+			Name:          oidcUserName(claims),
+			Email:         claims.Email,
+			RawPassword:   password,
+			EmailVerified: true,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	identity = &models.UserIdentity{
+		UserID:      user.ID,
+		Issuer:      issuer,
+		Subject:     claims.Subject,
+		Email:       email,
+		LastLoginAt: null.TimeFrom(now),
+	}
+
+	if err := identity.Insert(tx, boil.Infer()); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditLinkIdentity, AuditTargetUser, user.ID, nil, modext.NewUserIdentity(identity))
+	return user, nil
+}
+
+// This function simply calls GetUserIdentitiesEx with the global Read connection.
+func GetUserIdentities(user *modext.User) ([]*modext.UserIdentity, error) {
+	return GetUserIdentitiesEx(ReadDB, user)
+}
+
+// GetUserIdentitiesEx gets the external identities linked to the given user,
+// results are sorted from the oldest.
+func GetUserIdentitiesEx(e boil.Executor, user *modext.User) ([]*modext.UserIdentity, error) {
+	identities, err := models.UserIdentities(
+		Where("user_id = ?", user.ID),
+		OrderBy("id ASC"),
+	).All(e)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	result := make([]*modext.UserIdentity, len(identities))
+	for i, identity := range identities {
+		result[i] = modext.NewUserIdentity(identity)
+	}
+	return result, nil
+}
+
+// This function simply calls DeleteUserIdentityEx with the global Write connection.
+func DeleteUserIdentity(user *modext.User, id int64) error {
+	return DeleteUserIdentityEx(WriteDB, user, id)
+}
+
+// DeleteUserIdentityEx unlinks an external identity from the given user,
+// the user has to log in with its password afterwards.
+func DeleteUserIdentityEx(e boil.Executor, user *modext.User, id int64) error {
+	identity, err := models.UserIdentities(Where("id = ? AND user_id = ?", id, user.ID)).One(e)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errs.ErrIdentityNotFound
+		}
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	if err := identity.Delete(e); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditUnlinkIdentity, AuditTargetUser, user.ID, modext.NewUserIdentity(identity), nil)
+	return nil
+}
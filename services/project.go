@@ -27,10 +27,11 @@ import (
 )
 
 var (
-	Demographic   = []string{"none", "shounen", "shoujo", "josei", "seinen"}
-	ProjectStatus = []string{"ongoing", "finished", "dropped"}
-	Rating        = []string{"none", "safe", "suggestive", "erotica", "pornographic"}
-	SeriesStatus  = []string{"ongoing", "completed", "hiatus", "cancelled"}
+	Demographic      = []string{"none", "shounen", "shoujo", "josei", "seinen"}
+	ProjectStatus    = []string{"ongoing", "finished", "dropped"}
+	Rating           = []string{"none", "safe", "suggestive", "erotica", "pornographic"}
+	ReadingDirection = []string{"ltr", "rtl"}
+	SeriesStatus     = []string{"ongoing", "completed", "hiatus", "cancelled"}
 )
 
 // GetProjectCacheStats gets the cache stats of the project LRU cache.
@@ -43,16 +44,17 @@ var ProjectCols = models.ProjectColumns
 
 // ProjectDraft represents a project draft.
 type ProjectDraft struct {
-	Title         string   `json:"title"`
-	Description   string   `json:"description,omitempty"`
-	CoverURL      string   `json:"coverUrl,omitempty"`
-	ProjectStatus string   `json:"projectStatus,omitempty"`
-	SeriesStatus  string   `json:"seriesStatus,omitempty"`
-	Demographic   string   `json:"demographic,omitempty"`
-	Rating        string   `json:"rating,omitempty"`
-	Artists       []string `json:"artists,omitempty"`
-	Authors       []string `json:"authors,omitempty"`
-	Tags          []string `json:"tags,omitempty"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description,omitempty"`
+	CoverURL         string   `json:"coverUrl,omitempty"`
+	ProjectStatus    string   `json:"projectStatus,omitempty"`
+	SeriesStatus     string   `json:"seriesStatus,omitempty"`
+	Demographic      string   `json:"demographic,omitempty"`
+	Rating           string   `json:"rating,omitempty"`
+	ReadingDirection string   `json:"readingDirection,omitempty"`
+	Artists          []string `json:"artists,omitempty"`
+	Authors          []string `json:"authors,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
 }
 
 func (draft *ProjectDraft) validate() error {
@@ -63,6 +65,7 @@ func (draft *ProjectDraft) validate() error {
 	draft.SeriesStatus = strings.TrimSpace(draft.SeriesStatus)
 	draft.Demographic = strings.TrimSpace(draft.Demographic)
 	draft.Rating = strings.TrimSpace(draft.Rating)
+	draft.ReadingDirection = strings.TrimSpace(draft.ReadingDirection)
 
 	for i := range draft.Artists {
 		draft.Artists[i] = strings.TrimSpace(draft.Artists[i])
@@ -95,19 +98,21 @@ func (draft *ProjectDraft) validate() error {
 		return errs.ErrInvalidDemographic
 	case len(draft.Rating) > 0 && !stringsContains(Rating, draft.Rating):
 		return errs.ErrInvalidRating
+	case len(draft.ReadingDirection) > 0 && !stringsContains(ReadingDirection, draft.ReadingDirection):
+		return errs.ErrInvalidReadingDirection
 	}
 	return nil
 }
 
 // This function simply calls CreateProjectEx with a new write transaction.
-func CreateProject(draft *ProjectDraft) (*modext.Project, error) {
+func CreateProject(draft *ProjectDraft, user *modext.User) (*modext.Project, error) {
 	tx, err := WriteDB.Begin()
 	if err != nil {
 		log.Println(err)
 		return nil, errs.ErrUnknown
 	}
 
-	return CreateProjectEx(tx, draft)
+	return CreateProjectEx(tx, draft, user)
 }
 
 func refreshProjectRels(tx *sql.Tx, p *models.Project, draft *ProjectDraft) error {
@@ -166,22 +171,23 @@ func refreshProjectRels(tx *sql.Tx, p *models.Project, draft *ProjectDraft) erro
 }
 
 // CreateProjectEx creates a new project.
-func CreateProjectEx(tx *sql.Tx, draft *ProjectDraft) (*modext.Project, error) {
+func CreateProjectEx(tx *sql.Tx, draft *ProjectDraft, user *modext.User) (*modext.Project, error) {
 
 	if err := draft.validate(); err != nil {
 		return nil, err
 	}
 
 	p := &models.Project{
-		Title:         draft.Title,
-		Description:   null.StringFrom(draft.Description),
-		ProjectStatus: draft.ProjectStatus,
-		SeriesStatus:  draft.SeriesStatus,
-		Demographic:   null.StringFrom(draft.Demographic),
-		Rating:        null.StringFrom(draft.Rating),
+		Title:            draft.Title,
+		Description:      null.StringFrom(draft.Description),
+		ProjectStatus:    draft.ProjectStatus,
+		SeriesStatus:     draft.SeriesStatus,
+		Demographic:      null.StringFrom(draft.Demographic),
+		Rating:           null.StringFrom(draft.Rating),
+		ReadingDirection: null.StringFrom(draft.ReadingDirection),
 	}
 	if err := p.Insert(tx, boil.Infer()); err != nil {
-		if strings.Contains(err.Error(), `unique constraint "project_slug"`) {
+		if isProjectConflict(err) {
 			return nil, errs.ErrProjectAlreadyExists
 		}
 		log.Println(err)
@@ -197,8 +203,11 @@ func CreateProjectEx(tx *sql.Tx, draft *ProjectDraft) (*modext.Project, error) {
 		return nil, errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditCreate, AuditTargetProject, p.ID, nil, modext.NewProject(p).LoadRels(p))
+
 	go createProjectDir(p)
 	go refreshProjectsCache()
+	go dispatchProjectWebhookEvent(WebhookProjectCreated, p.ID)
 
 	return modext.NewProject(p).LoadRels(p), nil
 }
@@ -718,23 +727,28 @@ func GetProjectsEx(e boil.Executor, opts GetProjectsOptions) (result *GetProject
 }
 
 // This function simply creates a new write transaction and calls UpdateProjectEx.
-func UpdateProject(id int64, draft *ProjectDraft) (*modext.Project, error) {
+func UpdateProject(id int64, draft *ProjectDraft, user *modext.User) (*modext.Project, error) {
 	tx, err := WriteDB.Begin()
 	if err != nil {
 		log.Println(err)
 		return nil, errs.ErrUnknown
 	}
-	return UpdateProjectEx(tx, id, draft)
+	return UpdateProjectEx(tx, id, draft, user)
 }
 
 // UpdateProjectEx updates a project.
 // Returns the updated project if successful.
-func UpdateProjectEx(tx *sql.Tx, id int64, draft *ProjectDraft) (*modext.Project, error) {
+func UpdateProjectEx(tx *sql.Tx, id int64, draft *ProjectDraft, user *modext.User) (*modext.Project, error) {
 	if err := draft.validate(); err != nil {
 		return nil, err
 	}
 
-	p, err := models.FindProject(tx, id)
+	p, err := models.Projects(
+		Where("id = ?", id),
+		Load(ProjectRels.Artists),
+		Load(ProjectRels.Authors),
+		Load(ProjectRels.Tags),
+	).One(tx)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errs.ErrProjectNotFound
@@ -747,6 +761,7 @@ func UpdateProjectEx(tx *sql.Tx, id int64, draft *ProjectDraft) (*modext.Project
 		return nil, errs.ErrProjectLocked
 	}
 
+	before := modext.NewProject(p).LoadArtists(p).LoadAuthors(p).LoadTags(p)
 	prevTitle := p.Title
 
 	p.Title = draft.Title
@@ -755,6 +770,7 @@ func UpdateProjectEx(tx *sql.Tx, id int64, draft *ProjectDraft) (*modext.Project
 	p.SeriesStatus = draft.SeriesStatus
 	p.Demographic = null.StringFrom(draft.Demographic)
 	p.Rating = null.StringFrom(draft.Rating)
+	p.ReadingDirection = null.StringFrom(draft.ReadingDirection)
 
 	if err := p.Update(tx, boil.Whitelist(
 		ProjectCols.Title,
@@ -764,8 +780,12 @@ func UpdateProjectEx(tx *sql.Tx, id int64, draft *ProjectDraft) (*modext.Project
 		ProjectCols.SeriesStatus,
 		ProjectCols.Demographic,
 		ProjectCols.Rating,
+		ProjectCols.ReadingDirection,
 		ProjectCols.UpdatedAt,
 	)); err != nil {
+		if isProjectConflict(err) {
+			return nil, errs.ErrProjectAlreadyExists
+		}
 		log.Println(err)
 		return nil, errs.ErrUnknown
 	}
@@ -780,22 +800,25 @@ func UpdateProjectEx(tx *sql.Tx, id int64, draft *ProjectDraft) (*modext.Project
 		return nil, errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditUpdate, AuditTargetProject, p.ID, before, modext.NewProject(p).LoadArtists(p).LoadAuthors(p).LoadTags(p))
+
 	if prevTitle != p.Title {
 		go renameProjectDir(p)
 	}
 
 	go projectAfterUpdateHook(p)
+	go dispatchProjectWebhookEvent(WebhookProjectUpdated, p.ID)
 	return modext.NewProject(p).LoadRels(p), nil
 }
 
 // This function simply calls PublishProjectEx with the global Write connection.
-func PublishProject(id int64) (*modext.Project, error) {
-	return PublishProjectEx(WriteDB, id)
+func PublishProject(id int64, user *modext.User) (*modext.Project, error) {
+	return PublishProjectEx(WriteDB, id, user)
 }
 
 // PublishProjectEx publishes a project.
 // Returns the updated project if successful.
-func PublishProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
+func PublishProjectEx(e boil.Executor, id int64, user *modext.User) (*modext.Project, error) {
 	p, err := models.FindProject(e, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -809,15 +832,18 @@ func PublishProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
 		return nil, errs.ErrProjectLocked
 	}
 
+	before := modext.NewProject(p)
 	updatedAt := p.UpdatedAt
 	p.PublishedAt = null.TimeFrom(time.Now().UTC())
+	p.ScheduledAt.Valid = false
 
-	if err := p.Update(e, boil.Whitelist(ProjectCols.PublishedAt)); err != nil {
+	if err := p.Update(e, boil.Whitelist(ProjectCols.PublishedAt, ProjectCols.ScheduledAt)); err != nil {
 		log.Println(err)
 		return nil, errs.ErrUnknown
 	}
 
 	p.UpdatedAt = updatedAt
+	recordAudit(user, AuditPublish, AuditTargetProject, p.ID, before, modext.NewProject(p))
 	go func() {
 		projectAfterUpdateHook(p)
 		projectAfterPublishStateUpdateHook(p)
@@ -826,13 +852,13 @@ func PublishProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
 }
 
 // This function simply calls UnpublishProjectEx with the global Write connection.
-func UnpublishProject(id int64) (*modext.Project, error) {
-	return UnpublishProjectEx(WriteDB, id)
+func UnpublishProject(id int64, user *modext.User) (*modext.Project, error) {
+	return UnpublishProjectEx(WriteDB, id, user)
 }
 
 // UnpublishProjectEx unpublishes a project.
 // Returns the updated project if successful.
-func UnpublishProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
+func UnpublishProjectEx(e boil.Executor, id int64, user *modext.User) (*modext.Project, error) {
 	p, err := models.FindProject(e, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -846,15 +872,18 @@ func UnpublishProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
 		return nil, errs.ErrProjectLocked
 	}
 
+	before := modext.NewProject(p)
 	updatedAt := p.UpdatedAt
 	p.PublishedAt.Valid = false
+	p.ScheduledAt.Valid = false
 
-	if err := p.Update(e, boil.Whitelist(ProjectCols.PublishedAt)); err != nil {
+	if err := p.Update(e, boil.Whitelist(ProjectCols.PublishedAt, ProjectCols.ScheduledAt)); err != nil {
 		log.Println(err)
 		return nil, errs.ErrUnknown
 	}
 
 	p.UpdatedAt = updatedAt
+	recordAudit(user, AuditUnpublish, AuditTargetProject, p.ID, before, modext.NewProject(p))
 	go func() {
 		projectAfterUpdateHook(p)
 		projectAfterPublishStateUpdateHook(p)
@@ -863,13 +892,13 @@ func UnpublishProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
 }
 
 // This function simply calls LockProjectEx with the global Write connection.
-func LockProject(id int64) (*modext.Project, error) {
-	return LockProjectEx(WriteDB, id)
+func LockProject(id int64, user *modext.User) (*modext.Project, error) {
+	return LockProjectEx(WriteDB, id, user)
 }
 
 // LockProjectEx locks a project.
 // Returns the updated project if successful.
-func LockProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
+func LockProjectEx(e boil.Executor, id int64, user *modext.User) (*modext.Project, error) {
 	p, err := models.FindProject(e, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -879,6 +908,7 @@ func LockProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
 		return nil, errs.ErrUnknown
 	}
 
+	before := modext.NewProject(p)
 	updatedAt := p.UpdatedAt
 	p.Locked = null.BoolFrom(true)
 
@@ -888,18 +918,19 @@ func LockProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
 	}
 
 	p.UpdatedAt = updatedAt
+	recordAudit(user, AuditLock, AuditTargetProject, p.ID, before, modext.NewProject(p))
 	go projectAfterUpdateHook(p)
 	return modext.NewProject(p), nil
 }
 
 // This function simply calls UnlockProjectEx with the global Write connection.
-func UnlockProject(id int64) (*modext.Project, error) {
-	return UnlockProjectEx(WriteDB, id)
+func UnlockProject(id int64, user *modext.User) (*modext.Project, error) {
+	return UnlockProjectEx(WriteDB, id, user)
 }
 
 // UnlockProjectEx unlocks a project.
 // Returns the updated project if successful.
-func UnlockProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
+func UnlockProjectEx(e boil.Executor, id int64, user *modext.User) (*modext.Project, error) {
 	p, err := models.FindProject(e, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -909,6 +940,7 @@ func UnlockProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
 		return nil, errs.ErrUnknown
 	}
 
+	before := modext.NewProject(p)
 	updatedAt := p.UpdatedAt
 	p.Locked = null.NewBool(false, false)
 
@@ -918,18 +950,27 @@ func UnlockProjectEx(e boil.Executor, id int64) (*modext.Project, error) {
 	}
 
 	p.UpdatedAt = updatedAt
+	recordAudit(user, AuditUnlock, AuditTargetProject, p.ID, before, modext.NewProject(p))
 	go projectAfterUpdateHook(p)
 	return modext.NewProject(p), nil
 }
 
-// This function simply calls DeleteProjectEx with the global Write connection.
-func DeleteProject(id int64) error {
-	return DeleteProjectEx(WriteDB, id)
+// This function simply calls DeleteProjectEx with a new write transaction.
+func DeleteProject(id int64, user *modext.User) error {
+	tx, err := WriteDB.Begin()
+	if err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+	return DeleteProjectEx(tx, id, user)
 }
 
-// DeleteProjectEx deletes a project.
-func DeleteProjectEx(e boil.Executor, id int64) error {
-	p, err := models.FindProject(e, id)
+// DeleteProjectEx moves a project to the trash along with its chapters
+// and covers, which are purged once the retention period is over.
+func DeleteProjectEx(tx *sql.Tx, id int64, user *modext.User) error {
+	defer tx.Rollback()
+
+	p, err := models.Projects(Where("id = ?", id), Load(ProjectRels.Chapters)).One(tx)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return errs.ErrProjectNotFound
@@ -942,17 +983,50 @@ func DeleteProjectEx(e boil.Executor, id int64) error {
 		return errs.ErrProjectLocked
 	}
 
-	if err := p.Delete(e); err != nil {
+	// Chapters and covers share the deletion time of the project,
+	// so that only these are restored along with it.
+	now := time.Now().UTC()
+
+	err = models.Chapters(Where("project_id = ?", p.ID)).
+		UpdateAll(tx, models.M{ChapterCols.DeletedAt: now})
+	if err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	err = models.Covers(Where("project_id = ?", p.ID)).
+		UpdateAll(tx, models.M{CoverCols.DeletedAt: now})
+	if err != nil {
 		log.Println(err)
 		return errs.ErrUnknown
 	}
 
+	updatedAt := p.UpdatedAt
+	p.DeletedAt = null.TimeFrom(now)
+
+	if err := p.Update(tx, boil.Whitelist(ProjectCols.DeletedAt)); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	p.UpdatedAt = updatedAt
+	recordAudit(user, AuditDelete, AuditTargetProject, p.ID, modext.NewProject(p), nil)
+
 	ProjectCache.PurgeWithPrefix(p.ID)
 	CoverCache.PurgeWithPrefix(p.ID)
 	ChapterCache.PurgeWithPrefix(p.ID)
+	for _, c := range p.R.Chapters {
+		ChapterCache.PurgeWithPrefix(c.ID)
+		PagesCache.RemoveWithInt64(c.ID)
+	}
 
 	go refreshTemplatesCache()
-	go removeProjectDir(p)
+	go invalidateArchives(p.ID)
 	go func() {
 		refreshProjectsCache()
 		refreshChaptersCache()
@@ -980,6 +1054,13 @@ func CheckProjectExistsEx(e boil.Executor, id int64) (int64, string) {
 	return p.ID, p.Slug
 }
 
+// isProjectConflict checks if the given error is caused by another project
+// with the same slug or title, projects in the trash are not considered.
+func isProjectConflict(err error) bool {
+	return strings.Contains(err.Error(), `unique constraint "project_slug`) ||
+		strings.Contains(err.Error(), `unique constraint "project_title`)
+}
+
 // This function simply calls CheckProjectExistsBySlugEx with the global Read connection.
 func CheckProjectExistsBySlug(slug string) (int64, string) {
 	return CheckProjectExistsBySlugEx(ReadDB, slug)
@@ -1,6 +1,8 @@
 package services
 
 import (
+	. "kasen/cache"
+
 	"kasen/models"
 
 	"github.com/gosimple/slug"
@@ -13,6 +15,7 @@ func projectAfterUpdateHook(p *models.Project) {
 
 	refreshProjectCache(p.ID)
 	refreshProjectsCache()
+	invalidateArchives(p.ID)
 }
 
 func projectAfterPublishStateUpdateHook(p *models.Project) {
@@ -33,6 +36,16 @@ func init() {
 	models.AddProjectHook(BeforeUpdateHook, makeSlug)
 	models.AddProjectHook(BeforeUpsertHook, makeSlug)
 
+	// Forget the project ids of cached slugs after update or delete,
+	// as they're used to look up covers
+	purgeSlugs := func(e Executor, p *models.Project) error {
+		CoverCache.PurgeWithPrefix("slug")
+		return nil
+	}
+
+	models.AddProjectHook(AfterUpdateHook, purgeSlugs)
+	models.AddProjectHook(AfterDeleteHook, purgeSlugs)
+
 	// Create statistics after insert
 	models.AddProjectHook(AfterInsertHook, func(e Executor, p *models.Project) error {
 		if p.ID <= 0 {
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	. "kasen/database"
+
+	"kasen/constants"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var ProjectMemberCols = models.ProjectMemberColumns
+
+// hasChapterPermission checks if the user has the permission over every
+// chapter of the project of the given chapter, or the permission over
+// the chapter itself if the user uploaded it or is a member of one of
+// its scanlation groups.
+func hasChapterPermission(e boil.Executor, user *modext.User, c *models.Chapter, own, all string) bool {
+	if user.HasProjectPermissions(c.ProjectID, all) {
+		return true
+	}
+
+	if !user.HasProjectPermissions(c.ProjectID, own) {
+		return false
+	}
+	return c.UploaderID.Int64 == user.ID || isChapterScanlationGroupMember(e, user, c)
+}
+
+// validateMemberPermissions checks if the given permissions can be given to
+// the members of a project, which only manage the chapters of the project.
+//
+// Users can only give the permissions they have, either globally or over
+// the project, unless they have the manage permission.
+func validateMemberPermissions(permissions []string, pid int64, user *modext.User) ([]string, error) {
+	perms, err := validatePermissions(permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, perm := range perms {
+		if !stringsContains(constants.PermsChapter, perm) {
+			return nil, errs.ErrPermissionInvalid
+		}
+	}
+
+	if user.HasPermissions(constants.PermManage) {
+		return perms, nil
+	}
+
+	for _, perm := range perms {
+		if !user.HasProjectPermissions(pid, perm) {
+			return nil, errs.ErrForbidden
+		}
+	}
+	return perms, nil
+}
+
+// This function simply calls GetProjectMembersEx with the global Read connection.
+func GetProjectMembers(pid int64) ([]*modext.ProjectMember, error) {
+	return GetProjectMembersEx(ReadDB, pid)
+}
+
+// GetProjectMembersEx gets the members of a project,
+// results are sorted from the oldest member.
+func GetProjectMembersEx(e boil.Executor, pid int64) ([]*modext.ProjectMember, error) {
+	if exists, err := models.ProjectExists(e, pid); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if !exists {
+		return nil, errs.ErrProjectNotFound
+	}
+
+	members, err := models.ProjectMembers(
+		Where("project_id = ?", pid),
+		OrderBy("created_at ASC"),
+		Load(models.ProjectMemberRels.User),
+	).All(e)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	result := make([]*modext.ProjectMember, len(members))
+	for i, m := range members {
+		result[i] = modext.NewProjectMember(m).LoadUser(m)
+	}
+	return result, nil
+}
+
+// This function simply calls SetProjectMemberEx with the global Write connection.
+func SetProjectMember(pid, uid int64, permissions []string, user *modext.User) (*modext.ProjectMember, error) {
+	return SetProjectMemberEx(WriteDB, pid, uid, permissions, user)
+}
+
+// SetProjectMemberEx adds a user to the members of a project with the given
+// permissions, or updates its permissions if the user is already a member.
+// Returns the member if successful.
+//
+// The permissions are limited to the chapter permissions the user has,
+// and apply to the chapters of the project only.
+func SetProjectMemberEx(e boil.Executor, pid, uid int64, permissions []string, user *modext.User) (*modext.ProjectMember, error) {
+	perms, err := validateMemberPermissions(permissions, pid, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists, err := models.ProjectExists(e, pid); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if !exists {
+		return nil, errs.ErrProjectNotFound
+	}
+
+	u, err := models.FindUser(e, uid)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrUserNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	var before *modext.ProjectMember
+	m, err := models.FindProjectMember(e, pid, uid)
+	if err == sql.ErrNoRows {
+		m = &models.ProjectMember{ProjectID: pid, UserID: uid, Permissions: perms}
+		if err = m.Insert(e, boil.Infer()); err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+	} else if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else {
+		before = modext.NewProjectMember(m)
+		m.Permissions = perms
+		m.UpdatedAt = time.Now().UTC()
+
+		if err := m.Update(e, boil.Whitelist(ProjectMemberCols.Permissions, ProjectMemberCols.UpdatedAt)); err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+	}
+
+	recordAudit(user, AuditUpdateMember, AuditTargetProject, pid, before, modext.NewProjectMember(m))
+
+	member := modext.NewProjectMember(m)
+	member.User = modext.NewUser(u)
+	return member, nil
+}
+
+// This function simply calls RemoveProjectMemberEx with the global Write connection.
+func RemoveProjectMember(pid, uid int64, user *modext.User) error {
+	return RemoveProjectMemberEx(WriteDB, pid, uid, user)
+}
+
+// RemoveProjectMemberEx removes a user from the members of a project.
+func RemoveProjectMemberEx(e boil.Executor, pid, uid int64, user *modext.User) error {
+	m, err := models.FindProjectMember(e, pid, uid)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errs.ErrProjectMemberNotFound
+		}
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	if err := m.Delete(e); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditRemoveMember, AuditTargetProject, pid, modext.NewProjectMember(m), nil)
+	return nil
+}
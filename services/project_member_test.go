@@ -0,0 +1,50 @@
+package services
+
+import (
+	"testing"
+
+	"kasen/constants"
+	"kasen/errs"
+	"kasen/modext"
+)
+
+func TestValidateMemberPermissions(t *testing.T) {
+	editor := &modext.User{
+		Permissions: []string{constants.PermEditProject, constants.PermCreateChapter, constants.PermEditChapters},
+	}
+	member := &modext.User{
+		Permissions: []string{constants.PermEditProject},
+		Memberships: []*modext.ProjectMember{
+			{ProjectID: 1, Permissions: []string{constants.PermPublishChapters}},
+		},
+	}
+	manager := &modext.User{
+		Permissions: []string{constants.PermManage},
+	}
+
+	tests := []struct {
+		name        string
+		user        *modext.User
+		pid         int64
+		permissions []string
+		err         error
+	}{
+		{"editor grants its permissions", editor, 1, []string{constants.PermCreateChapter, constants.PermEditChapters}, nil},
+		{"editor grants nothing", editor, 1, nil, nil},
+		{"editor grants a permission it lacks", editor, 1, []string{constants.PermEditChapters, constants.PermDeleteChapters}, errs.ErrForbidden},
+		{"editor grants unlocking", editor, 1, []string{constants.PermUnlockChapters}, errs.ErrForbidden},
+		{"member grants its project permissions", member, 1, []string{constants.PermPublishChapters}, nil},
+		{"member grants its permissions on another project", member, 2, []string{constants.PermPublishChapters}, errs.ErrForbidden},
+		{"manager grants any chapter permission", manager, 1, []string{constants.PermDeleteChapters, constants.PermUnlockChapters}, nil},
+		{"manager grants a project permission", manager, 1, []string{constants.PermEditProject}, errs.ErrPermissionInvalid},
+		{"unknown permission", manager, 1, []string{"unknown"}, errs.ErrPermissionInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := validateMemberPermissions(tt.permissions, tt.pid, tt.user); err != tt.err {
+				t.Errorf("validateMemberPermissions(%v) = %v, want %v", tt.permissions, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	. "kasen/database"
+
+	"kasen/constants"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var RoleCols = models.RoleColumns
+
+// validatePermissions checks if the given permissions exist,
+// and returns them lowercased without duplicates.
+func validatePermissions(permissions []string) ([]string, error) {
+	perms := []string{}
+	for _, perm := range permissions {
+		perm = strings.ToLower(strings.TrimSpace(perm))
+		if !stringsContains(constants.Perms, perm) {
+			return nil, errs.ErrPermissionInvalid
+		}
+		if !stringsContains(perms, perm) {
+			perms = append(perms, perm)
+		}
+	}
+	return perms, nil
+}
+
+// removePermission returns the given permissions without the given permission.
+func removePermission(permissions []string, permission string) []string {
+	perms := []string{}
+	for _, perm := range permissions {
+		if perm != permission {
+			perms = append(perms, perm)
+		}
+	}
+	return perms
+}
+
+// RoleDraft represents the draft of a role.
+type RoleDraft struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+func (draft *RoleDraft) validate() error {
+	draft.Name = strings.TrimSpace(draft.Name)
+
+	if len(draft.Name) == 0 {
+		return errs.ErrRoleNameRequired
+	} else if len(draft.Name) > 32 {
+		return errs.ErrRoleNameTooLong
+	}
+
+	perms, err := validatePermissions(draft.Permissions)
+	if err != nil {
+		return err
+	}
+	draft.Permissions = perms
+
+	return nil
+}
+
+// CreateDefaultRoles creates the default roles which don't exist yet,
+// the roles which have been edited are left untouched.
+func CreateDefaultRoles() {
+	for _, name := range constants.Roles {
+		exists, err := models.Roles(Where("name ILIKE ?", name)).Exists(WriteDB)
+		if err != nil {
+			log.Fatalln(err)
+		} else if exists {
+			continue
+		}
+
+		r := &models.Role{Name: name, Permissions: constants.DefaultRoles[name]}
+		if err := r.Insert(WriteDB, boil.Infer()); err != nil {
+			log.Fatalln(err)
+		}
+	}
+}
+
+// This function simply calls CreateRoleEx with the global Write connection.
+func CreateRole(draft RoleDraft, user *modext.User) (*modext.Role, error) {
+	return CreateRoleEx(WriteDB, draft, user)
+}
+
+// CreateRoleEx creates a new role with the given draft.
+// Returns the created role if successful, or an error if the role already exists.
+func CreateRoleEx(e boil.Executor, draft RoleDraft, user *modext.User) (*modext.Role, error) {
+	if err := draft.validate(); err != nil {
+		return nil, err
+	}
+
+	if exists, err := models.Roles(Where("name ILIKE ?", draft.Name)).Exists(e); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if exists {
+		return nil, errs.ErrRoleAlreadyExists
+	}
+
+	r := &models.Role{
+		Name:        draft.Name,
+		Permissions: draft.Permissions,
+	}
+
+	if err := r.Insert(e, boil.Infer()); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditCreate, AuditTargetRole, r.ID, nil, modext.NewRole(r))
+	return modext.NewRole(r), nil
+}
+
+// This function simply calls GetRolesEx with the global Read connection.
+func GetRoles() ([]*modext.Role, error) {
+	return GetRolesEx(ReadDB)
+}
+
+// GetRolesEx gets all roles, results are sorted by id in ascending order.
+func GetRolesEx(e boil.Executor) ([]*modext.Role, error) {
+	roles, err := models.Roles(OrderBy("id ASC")).All(e)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	result := make([]*modext.Role, len(roles))
+	for i, r := range roles {
+		result[i] = modext.NewRole(r)
+	}
+	return result, nil
+}
+
+// findRole finds the role of the given id.
+func findRole(e boil.Executor, id int64) (*models.Role, error) {
+	r, err := models.FindRole(e, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrRoleNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return r, nil
+}
+
+// This function simply calls GetRoleEx with the global Read connection.
+func GetRole(id int64) (*modext.Role, error) {
+	return GetRoleEx(ReadDB, id)
+}
+
+// GetRoleEx gets a role by id.
+func GetRoleEx(e boil.Executor, id int64) (*modext.Role, error) {
+	r, err := findRole(e, id)
+	if err != nil {
+		return nil, err
+	}
+	return modext.NewRole(r), nil
+}
+
+// This function simply calls GetRoleByNameEx with the global Read connection.
+func GetRoleByName(name string) (*modext.Role, error) {
+	return GetRoleByNameEx(ReadDB, name)
+}
+
+// GetRoleByNameEx gets a role by name.
+func GetRoleByNameEx(e boil.Executor, name string) (*modext.Role, error) {
+	r, err := models.Roles(Where("name ILIKE ?", name)).One(e)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrRoleNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return modext.NewRole(r), nil
+}
+
+// This function simply calls UpdateRoleEx with the global Write connection.
+func UpdateRole(id int64, draft RoleDraft, user *modext.User) (*modext.Role, error) {
+	return UpdateRoleEx(WriteDB, id, draft, user)
+}
+
+// UpdateRoleEx updates the name and permissions of a role, the permissions
+// of every user of the role are updated along with it.
+// Returns the updated role if successful.
+func UpdateRoleEx(e boil.Executor, id int64, draft RoleDraft, user *modext.User) (*modext.Role, error) {
+	if err := draft.validate(); err != nil {
+		return nil, err
+	}
+
+	r, err := findRole(e, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists, err := models.Roles(Where("id <> ? AND name ILIKE ?", r.ID, draft.Name)).Exists(e); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if exists {
+		return nil, errs.ErrRoleAlreadyExists
+	}
+
+	before := modext.NewRole(r)
+	r.Name = draft.Name
+	r.Permissions = draft.Permissions
+	r.UpdatedAt = time.Now().UTC()
+
+	if err := r.Update(e, boil.Whitelist(RoleCols.Name, RoleCols.Permissions, RoleCols.UpdatedAt)); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditUpdate, AuditTargetRole, r.ID, before, modext.NewRole(r))
+	return modext.NewRole(r), nil
+}
+
+// This function simply calls DeleteRoleEx with the global Write connection.
+func DeleteRole(id int64, user *modext.User) error {
+	return DeleteRoleEx(WriteDB, id, user)
+}
+
+// DeleteRoleEx deletes a role, its users lose the permissions
+// which aren't granted to them otherwise.
+func DeleteRoleEx(e boil.Executor, id int64, user *modext.User) error {
+	r, err := findRole(e, id)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Delete(e); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditDelete, AuditTargetRole, r.ID, modext.NewRole(r), nil)
+	return nil
+}
+
+// This function simply calls UpdateUserRolesEx with the global Write connection.
+func UpdateUserRoles(user *modext.User, roleIDs []int64, actor *modext.User) ([]*modext.Role, error) {
+	return UpdateUserRolesEx(WriteDB, user, roleIDs, actor)
+}
+
+// UpdateUserRolesEx replaces the roles of the given user.
+// Returns the updated roles of the user.
+func UpdateUserRolesEx(e boil.Executor, user *modext.User, roleIDs []int64, actor *modext.User) ([]*modext.Role, error) {
+	roles := models.RoleSlice{}
+	seen := make(map[int64]bool)
+	for _, id := range roleIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		r, err := findRole(e, id)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, r)
+	}
+
+	before := map[string]interface{}{"roles": user.Roles, "permissions": user.Permissions}
+
+	u := user.ToModel()
+	if err := u.SetRoles(e, false, roles...); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	user.Roles = make([]*modext.Role, len(roles))
+	for i, r := range roles {
+		user.Roles[i] = modext.NewRole(r)
+	}
+	user.ResolvePermissions()
+
+	// The user has to log in again for the new permissions to apply.
+	if err := revokeUserSessions(user.ID); err != nil {
+		log.Println(err)
+	}
+
+	recordAudit(actor, AuditUpdateRoles, AuditTargetUser, user.ID, before,
+		map[string]interface{}{"roles": user.Roles, "permissions": user.Permissions})
+	return user.Roles, nil
+}
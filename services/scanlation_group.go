@@ -179,12 +179,12 @@ func UpdateScanlationGroupEx(e boil.Executor, id int64, name string) (*modext.Sc
 }
 
 // This function simply calls DeleteScanlationGroupEx with the global Write connection.
-func DeleteScanlationGroup(id int64) error {
-	return DeleteScanlationGroupEx(WriteDB, id)
+func DeleteScanlationGroup(id int64, user *modext.User) error {
+	return DeleteScanlationGroupEx(WriteDB, id, user)
 }
 
 // DeleteScanlationGroupEx deletes a scanlation group.
-func DeleteScanlationGroupEx(e boil.Executor, id int64) error {
+func DeleteScanlationGroupEx(e boil.Executor, id int64, user *modext.User) error {
 	g, err := models.FindScanlationGroup(e, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -199,16 +199,17 @@ func DeleteScanlationGroupEx(e boil.Executor, id int64) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetScanlationGroup, g.ID, modext.NewScanlationGroup(g), nil)
 	return nil
 }
 
 // This function simply calls DeleteScanlationGroupBySlugEx with the global Write connection.
-func DeleteScanlationGroupBySlug(slug string) error {
-	return DeleteScanlationGroupBySlugEx(WriteDB, slug)
+func DeleteScanlationGroupBySlug(slug string, user *modext.User) error {
+	return DeleteScanlationGroupBySlugEx(WriteDB, slug, user)
 }
 
 // DeleteScanlationGroupBySlugEx deletes a scanlation group by slug.
-func DeleteScanlationGroupBySlugEx(e boil.Executor, slug string) error {
+func DeleteScanlationGroupBySlugEx(e boil.Executor, slug string, user *modext.User) error {
 	g, err := models.ScanlationGroups(Where("slug ILIKE ?", slug)).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -223,16 +224,17 @@ func DeleteScanlationGroupBySlugEx(e boil.Executor, slug string) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetScanlationGroup, g.ID, modext.NewScanlationGroup(g), nil)
 	return nil
 }
 
 // This function simply calls DeleteScanlationGroupByNameEx with the global Write connection.
-func DeleteScanlationGroupByName(name string) error {
-	return DeleteScanlationGroupByNameEx(WriteDB, name)
+func DeleteScanlationGroupByName(name string, user *modext.User) error {
+	return DeleteScanlationGroupByNameEx(WriteDB, name, user)
 }
 
 // DeleteScanlationGroupByNameEx deletes a scanlation group by name.
-func DeleteScanlationGroupByNameEx(e boil.Executor, name string) error {
+func DeleteScanlationGroupByNameEx(e boil.Executor, name string, user *modext.User) error {
 	g, err := models.ScanlationGroups(Where("name ILIKE ?", name)).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -247,16 +249,17 @@ func DeleteScanlationGroupByNameEx(e boil.Executor, name string) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetScanlationGroup, g.ID, modext.NewScanlationGroup(g), nil)
 	return nil
 }
 
 // This function simply calls DeleteScanlationGroupBySlugOrNameEx with the global Write connection.
-func DeleteScanlationGroupBySlugOrName(slugOrName string) error {
-	return DeleteScanlationGroupBySlugOrNameEx(WriteDB, slugOrName)
+func DeleteScanlationGroupBySlugOrName(slugOrName string, user *modext.User) error {
+	return DeleteScanlationGroupBySlugOrNameEx(WriteDB, slugOrName, user)
 }
 
 // DeleteScanlationGroupBySlugOrNameEx deletes a scanlation group by slug or name.
-func DeleteScanlationGroupBySlugOrNameEx(e boil.Executor, slugOrName string) error {
+func DeleteScanlationGroupBySlugOrNameEx(e boil.Executor, slugOrName string, user *modext.User) error {
 	g, err := models.ScanlationGroups(Where("slug ILIKE ? OR name ILIKE ?", slugOrName, slugOrName)).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -271,5 +274,6 @@ func DeleteScanlationGroupBySlugOrNameEx(e boil.Executor, slugOrName string) err
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetScanlationGroup, g.ID, modext.NewScanlationGroup(g), nil)
 	return nil
 }
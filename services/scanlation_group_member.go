@@ -0,0 +1,258 @@
+package services
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	. "kasen/database"
+
+	"kasen/constants"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var ScanlationGroupMemberCols = models.ScanlationGroupMemberColumns
+
+// isChapterScanlationGroupMember checks if the user is a member of
+// one of the scanlation groups credited on the given chapter.
+func isChapterScanlationGroupMember(e boil.Executor, user *modext.User, c *models.Chapter) bool {
+	if len(user.ScanlationGroups) == 0 {
+		return false
+	}
+
+	ids := make([]interface{}, len(user.ScanlationGroups))
+	for i, member := range user.ScanlationGroups {
+		ids[i] = member.ScanlationGroupID
+	}
+
+	exists, err := models.ScanlationGroups(
+		InnerJoin("chapter_scanlation_groups csg ON csg.scanlation_group_id = scanlation_group.id"),
+		Where("csg.chapter_id = ?", c.ID),
+		WhereIn("scanlation_group.id IN ?", ids...),
+	).Exists(e)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+	return exists
+}
+
+// checkScanlationGroupsCredit checks if the user can credit the given
+// scanlation groups on a chapter. Groups with members can only be credited
+// by their members, the groups which were already credited are left out.
+func checkScanlationGroupsCredit(e boil.Executor, user *modext.User, groups, prevGroups []*models.ScanlationGroup) error {
+	if user == nil || user.HasPermissions(constants.PermManage) {
+		return nil
+	}
+
+	for _, g := range groups {
+		credited := false
+		for _, prev := range prevGroups {
+			if prev.ID == g.ID {
+				credited = true
+				break
+			}
+		}
+
+		if credited || user.IsScanlationGroupMember(g.ID, false) {
+			continue
+		}
+
+		exists, err := models.ScanlationGroupMembers(Where("scanlation_group_id = ?", g.ID)).Exists(e)
+		if err != nil {
+			log.Println(err)
+			return errs.ErrUnknown
+		} else if exists {
+			return errs.ErrScanlationGroupMembersOnly
+		}
+	}
+	return nil
+}
+
+// This function simply calls GetScanlationGroupMembersEx with the global Read connection.
+func GetScanlationGroupMembers(gid int64) ([]*modext.ScanlationGroupMember, error) {
+	return GetScanlationGroupMembersEx(ReadDB, gid)
+}
+
+// GetScanlationGroupMembersEx gets the members of a scanlation group,
+// results are sorted from the oldest member.
+func GetScanlationGroupMembersEx(e boil.Executor, gid int64) ([]*modext.ScanlationGroupMember, error) {
+	members, err := models.ScanlationGroupMembers(
+		Where("scanlation_group_id = ?", gid),
+		OrderBy("created_at ASC"),
+		Load(models.ScanlationGroupMemberRels.User),
+	).All(e)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	result := make([]*modext.ScanlationGroupMember, len(members))
+	for i, m := range members {
+		result[i] = modext.NewScanlationGroupMember(m).LoadUser(m)
+	}
+	return result, nil
+}
+
+// canManageScanlationGroup checks if the user can manage the members of
+// the given scanlation group, which is limited to its leaders.
+func canManageScanlationGroup(user *modext.User, gid int64) bool {
+	return user.HasPermissions(constants.PermManage) || user.IsScanlationGroupMember(gid, true)
+}
+
+// checkScanlationGroupLeaders checks if the scanlation group would still
+// have a leader without the given member, unless it has no member left.
+func checkScanlationGroupLeaders(e boil.Executor, gid, uid int64) error {
+	leaders, err := models.ScanlationGroupMembers(
+		Where("scanlation_group_id = ? AND user_id <> ? AND role = ?", gid, uid, constants.ScanlationGroupLeader),
+	).Count(e)
+	if err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	} else if leaders > 0 {
+		return nil
+	}
+
+	members, err := models.ScanlationGroupMembers(
+		Where("scanlation_group_id = ? AND user_id <> ?", gid, uid),
+	).Count(e)
+	if err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	} else if members > 0 {
+		return errs.ErrScanlationGroupLeaderRequired
+	}
+	return nil
+}
+
+// This function simply calls SetScanlationGroupMemberEx with the global Write connection.
+func SetScanlationGroupMember(gid, uid int64, role string, user *modext.User) (*modext.ScanlationGroupMember, error) {
+	return SetScanlationGroupMemberEx(WriteDB, gid, uid, role, user)
+}
+
+// SetScanlationGroupMemberEx adds a user to the members of a scanlation group
+// with the given role, or updates its role if the user is already a member.
+// Returns the member if successful.
+//
+// This function will return an error if the user is neither a leader of the group nor a manager,
+// or if the group would be left without a leader.
+func SetScanlationGroupMemberEx(e boil.Executor, gid, uid int64, role string, user *modext.User) (*modext.ScanlationGroupMember, error) {
+	role = strings.ToLower(strings.TrimSpace(role))
+	if len(role) == 0 {
+		role = constants.ScanlationGroupMember
+	} else if role != constants.ScanlationGroupLeader && role != constants.ScanlationGroupMember {
+		return nil, errs.ErrScanlationGroupRoleInvalid
+	}
+
+	if exists, err := models.ScanlationGroupExists(e, gid); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if !exists {
+		return nil, errs.ErrScanlationGroupNotFound
+	}
+
+	if !canManageScanlationGroup(user, gid) {
+		return nil, errs.ErrForbidden
+	}
+
+	u, err := models.FindUser(e, uid)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrUserNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	var before *modext.ScanlationGroupMember
+	m, err := models.FindScanlationGroupMember(e, gid, uid)
+	if err == sql.ErrNoRows {
+		// The first member of a group has to be its leader.
+		if role != constants.ScanlationGroupLeader {
+			leaders, err := models.ScanlationGroupMembers(
+				Where("scanlation_group_id = ? AND role = ?", gid, constants.ScanlationGroupLeader),
+			).Count(e)
+			if err != nil {
+				log.Println(err)
+				return nil, errs.ErrUnknown
+			} else if leaders == 0 {
+				return nil, errs.ErrScanlationGroupLeaderRequired
+			}
+		}
+
+		m = &models.ScanlationGroupMember{ScanlationGroupID: gid, UserID: uid, Role: role}
+		if err = m.Insert(e, boil.Infer()); err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+	} else if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else {
+		if m.Role == constants.ScanlationGroupLeader && role != constants.ScanlationGroupLeader {
+			if err := checkScanlationGroupLeaders(e, gid, uid); err != nil {
+				return nil, err
+			}
+		}
+
+		before = modext.NewScanlationGroupMember(m)
+		m.Role = role
+		m.UpdatedAt = time.Now().UTC()
+
+		if err := m.Update(e, boil.Whitelist(ScanlationGroupMemberCols.Role, ScanlationGroupMemberCols.UpdatedAt)); err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+	}
+
+	recordAudit(user, AuditUpdateMember, AuditTargetScanlationGroup, gid, before, modext.NewScanlationGroupMember(m))
+
+	member := modext.NewScanlationGroupMember(m)
+	member.User = modext.NewUser(u)
+	return member, nil
+}
+
+// This function simply calls RemoveScanlationGroupMemberEx with the global Write connection.
+func RemoveScanlationGroupMember(gid, uid int64, user *modext.User) error {
+	return RemoveScanlationGroupMemberEx(WriteDB, gid, uid, user)
+}
+
+// RemoveScanlationGroupMemberEx removes a user from the members of a scanlation group,
+// members can leave the group by themselves.
+//
+// This function will return an error if the user is neither a leader of the group nor a manager,
+// or if the group would be left without a leader.
+func RemoveScanlationGroupMemberEx(e boil.Executor, gid, uid int64, user *modext.User) error {
+	if uid != user.ID && !canManageScanlationGroup(user, gid) {
+		return errs.ErrForbidden
+	}
+
+	m, err := models.FindScanlationGroupMember(e, gid, uid)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errs.ErrScanlationGroupMemberNotFound
+		}
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	if m.Role == constants.ScanlationGroupLeader {
+		if err := checkScanlationGroupLeaders(e, gid, uid); err != nil {
+			return err
+		}
+	}
+
+	if err := m.Delete(e); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditRemoveMember, AuditTargetScanlationGroup, gid, modext.NewScanlationGroupMember(m), nil)
+	return nil
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+
+	. "kasen/database"
+
+	"kasen/config"
+	"kasen/constants"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// This function simply calls ScheduleChapterEx with the global Write connection.
+func ScheduleChapter(id int64, at time.Time, user *modext.User) (*modext.Chapter, error) {
+	return ScheduleChapterEx(WriteDB, id, at, user)
+}
+
+// ScheduleChapterEx schedules a chapter to be published at the given time
+// and returns the updated chapter if successful.
+//
+// This function will return an error if the chapter is locked or already published.
+// It will also return an error if the user does not have the necessary permissions.
+func ScheduleChapterEx(e boil.Executor, id int64, at time.Time, user *modext.User) (*modext.Chapter, error) {
+	c, err := models.FindChapter(e, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrChapterNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if c.Locked.Bool {
+		return nil, errs.ErrChapterLocked
+	}
+
+	if !hasChapterPermission(e, user, c, constants.PermPublishChapter, constants.PermPublishChapters) {
+		return nil, errs.ErrForbidden
+	}
+
+	if c.PublishedAt.Valid {
+		return nil, errs.ErrChapterPublished
+	}
+
+	if !at.After(time.Now()) {
+		return nil, errs.ErrInvalidScheduleTime
+	}
+
+	before := modext.NewChapter(c)
+	updatedAt := c.UpdatedAt
+	c.ScheduledAt = null.TimeFrom(at.UTC())
+
+	if err := c.Update(e, boil.Whitelist(ChapterCols.ScheduledAt)); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	c.UpdatedAt = updatedAt
+	recordAudit(user, AuditSchedule, AuditTargetChapter, c.ID, before, modext.NewChapter(c))
+	go chapterAfterUpdateHook(c)
+	return modext.NewChapter(c), nil
+}
+
+// This function simply calls UnscheduleChapterEx with the global Write connection.
+func UnscheduleChapter(id int64, user *modext.User) (*modext.Chapter, error) {
+	return UnscheduleChapterEx(WriteDB, id, user)
+}
+
+// UnscheduleChapterEx cancels the scheduled publication of a chapter
+// and returns the updated chapter if successful.
+//
+// This function will return an error if the chapter is locked.
+// It will also return an error if the user does not have the necessary permissions.
+func UnscheduleChapterEx(e boil.Executor, id int64, user *modext.User) (*modext.Chapter, error) {
+	c, err := models.FindChapter(e, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrChapterNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if c.Locked.Bool {
+		return nil, errs.ErrChapterLocked
+	}
+
+	if !hasChapterPermission(e, user, c, constants.PermPublishChapter, constants.PermPublishChapters) {
+		return nil, errs.ErrForbidden
+	}
+
+	before := modext.NewChapter(c)
+	updatedAt := c.UpdatedAt
+	c.ScheduledAt.Valid = false
+
+	if err := c.Update(e, boil.Whitelist(ChapterCols.ScheduledAt)); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	c.UpdatedAt = updatedAt
+	recordAudit(user, AuditUnschedule, AuditTargetChapter, c.ID, before, modext.NewChapter(c))
+	go chapterAfterUpdateHook(c)
+	return modext.NewChapter(c), nil
+}
+
+// This function simply calls ScheduleProjectEx with the global Write connection.
+func ScheduleProject(id int64, at time.Time, user *modext.User) (*modext.Project, error) {
+	return ScheduleProjectEx(WriteDB, id, at, user)
+}
+
+// ScheduleProjectEx schedules a project to be published at the given time.
+// Returns the updated project if successful.
+func ScheduleProjectEx(e boil.Executor, id int64, at time.Time, user *modext.User) (*modext.Project, error) {
+	p, err := models.FindProject(e, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrProjectNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if p.Locked.Bool {
+		return nil, errs.ErrProjectLocked
+	}
+
+	if p.PublishedAt.Valid {
+		return nil, errs.ErrProjectPublished
+	}
+
+	if !at.After(time.Now()) {
+		return nil, errs.ErrInvalidScheduleTime
+	}
+
+	before := modext.NewProject(p)
+	updatedAt := p.UpdatedAt
+	p.ScheduledAt = null.TimeFrom(at.UTC())
+
+	if err := p.Update(e, boil.Whitelist(ProjectCols.ScheduledAt)); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	p.UpdatedAt = updatedAt
+	recordAudit(user, AuditSchedule, AuditTargetProject, p.ID, before, modext.NewProject(p))
+	go projectAfterUpdateHook(p)
+	return modext.NewProject(p), nil
+}
+
+// This function simply calls UnscheduleProjectEx with the global Write connection.
+func UnscheduleProject(id int64, user *modext.User) (*modext.Project, error) {
+	return UnscheduleProjectEx(WriteDB, id, user)
+}
+
+// UnscheduleProjectEx cancels the scheduled publication of a project.
+// Returns the updated project if successful.
+func UnscheduleProjectEx(e boil.Executor, id int64, user *modext.User) (*modext.Project, error) {
+	p, err := models.FindProject(e, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrProjectNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if p.Locked.Bool {
+		return nil, errs.ErrProjectLocked
+	}
+
+	before := modext.NewProject(p)
+	updatedAt := p.UpdatedAt
+	p.ScheduledAt.Valid = false
+
+	if err := p.Update(e, boil.Whitelist(ProjectCols.ScheduledAt)); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	p.UpdatedAt = updatedAt
+	recordAudit(user, AuditUnschedule, AuditTargetProject, p.ID, before, modext.NewProject(p))
+	go projectAfterUpdateHook(p)
+	return modext.NewProject(p), nil
+}
+
+var startSchedulerOnce sync.Once
+
+// StartScheduler starts publishing the scheduled chapters and projects
+// once their time has come, it's safe to run on multiple instances.
+func StartScheduler() {
+	startSchedulerOnce.Do(func() {
+		go func() {
+			for {
+				publishScheduled()
+				time.Sleep(config.GetJobs().ScheduleInterval)
+			}
+		}()
+	})
+}
+
+// publishScheduled publishes the chapters and projects whose scheduled
+// time has passed, and runs the same hooks as publishing them manually.
+//
+// Locked chapters and projects can't be published, so their schedule
+// is cleared instead.
+func publishScheduled() {
+	now := time.Now().UTC()
+
+	lockedProjects, err := models.Projects(
+		Where("scheduled_at <= ?", now),
+		Where("published_at IS NULL"),
+		Where("locked = TRUE"),
+	).All(WriteDB)
+	if err != nil {
+		log.Println("Failed to get locked scheduled projects:", err)
+	}
+
+	for _, p := range lockedProjects {
+		before := modext.NewProject(p)
+		updatedAt := p.UpdatedAt
+		p.ScheduledAt.Valid = false
+
+		if err := p.Update(WriteDB, boil.Whitelist(ProjectCols.ScheduledAt)); err != nil {
+			log.Println(err)
+			continue
+		}
+		log.Printf("Unscheduled project %d because it's locked\n", p.ID)
+
+		p.UpdatedAt = updatedAt
+		recordAudit(nil, AuditUnschedule, AuditTargetProject, p.ID, before, modext.NewProject(p))
+		projectAfterUpdateHook(p)
+	}
+
+	var projects models.ProjectSlice
+	err = queries.Raw(`
+		UPDATE project SET published_at = scheduled_at, scheduled_at = NULL
+		WHERE scheduled_at <= $1 AND published_at IS NULL AND deleted_at IS NULL
+		AND (locked IS NULL OR locked = FALSE)
+		RETURNING *`, now).Bind(nil, WriteDB, &projects)
+	if err != nil {
+		log.Println("Failed to publish scheduled projects:", err)
+	}
+
+	for _, p := range projects {
+		after := modext.NewProject(p)
+		before := *after
+		before.PublishedAt, before.ScheduledAt = 0, after.PublishedAt
+		recordAudit(nil, AuditPublish, AuditTargetProject, p.ID, &before, after)
+
+		projectAfterUpdateHook(p)
+		projectAfterPublishStateUpdateHook(p)
+	}
+
+	lockedChapters, err := models.Chapters(
+		Where("scheduled_at <= ?", now),
+		Where("published_at IS NULL"),
+		Where("locked = TRUE"),
+	).All(WriteDB)
+	if err != nil {
+		log.Println("Failed to get locked scheduled chapters:", err)
+	}
+
+	for _, c := range lockedChapters {
+		before := modext.NewChapter(c)
+		updatedAt := c.UpdatedAt
+		c.ScheduledAt.Valid = false
+
+		if err := c.Update(WriteDB, boil.Whitelist(ChapterCols.ScheduledAt)); err != nil {
+			log.Println(err)
+			continue
+		}
+		log.Printf("Unscheduled chapter %d because it's locked\n", c.ID)
+
+		c.UpdatedAt = updatedAt
+		recordAudit(nil, AuditUnschedule, AuditTargetChapter, c.ID, before, modext.NewChapter(c))
+		chapterAfterUpdateHook(c)
+	}
+
+	var chapters models.ChapterSlice
+	err = queries.Raw(`
+		UPDATE chapter SET published_at = scheduled_at, scheduled_at = NULL
+		WHERE scheduled_at <= $1 AND published_at IS NULL AND deleted_at IS NULL
+		AND (locked IS NULL OR locked = FALSE)
+		RETURNING *`, now).Bind(nil, WriteDB, &chapters)
+	if err != nil {
+		log.Println("Failed to publish scheduled chapters:", err)
+	}
+
+	for _, c := range chapters {
+		after := modext.NewChapter(c)
+		before := *after
+		before.PublishedAt, before.ScheduledAt = 0, after.PublishedAt
+		recordAudit(nil, AuditPublish, AuditTargetChapter, c.ID, &before, after)
+
+		chapterAfterUpdateHook(c)
+		go createPregenerateImagesJob(&PregenerateImagesPayload{ChapterID: c.ID})
+		go dispatchChapterWebhookEvent(WebhookChapterPublished, c.ID)
+	}
+}
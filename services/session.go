@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	. "kasen/cache"
+
+	"kasen/errs"
+	"kasen/modext"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// SessionClient represents the client which started a session.
+type SessionClient struct {
+	UserAgent string
+	IP        string
+}
+
+// Session represents a login of a user, which lasts as long as its refresh
+// token. Every token issued for the session carries its id, and is no
+// longer valid once the session has been revoked.
+type Session struct {
+	ID         string `json:"id"`
+	CreatedAt  int64  `json:"createdAt"`
+	LastSeenAt int64  `json:"lastSeenAt"`
+	UserAgent  string `json:"userAgent,omitempty"`
+	IP         string `json:"ip,omitempty"`
+
+	// Current is true if the session is the one of the request.
+	Current bool `json:"current"`
+}
+
+// storedSession represents a session as stored in Redis.
+type storedSession struct {
+	Session
+	UserID int64 `json:"userId"`
+
+	// RefreshID is the id of the current refresh token of the session,
+	// the session is the family of the refresh tokens rotated from the
+	// first one.
+	RefreshID string `json:"refreshId"`
+
+	// PreviousRefreshID is the id of the refresh token rotated at RotatedAt,
+	// which is still accepted for a short while.
+	PreviousRefreshID string `json:"previousRefreshId,omitempty"`
+	RotatedAt         int64  `json:"rotatedAt,omitempty"`
+}
+
+// refreshRotationGrace is the duration during which a rotated refresh token
+// is still accepted, as concurrent requests can refresh a session at once.
+const refreshRotationGrace = 30 * time.Second
+
+// errRefreshTokenReused is returned when a rotated refresh token is used again.
+var errRefreshTokenReused = errors.New("Refresh token reused")
+
+func sessionKey(sid string) string {
+	return "session:" + sid
+}
+
+func userSessionsKey(uid int64) string {
+	return "sessions:" + strconv.FormatInt(uid, 10)
+}
+
+// createSession creates a new session of the given id for the given user,
+// with the given refresh token.
+func createSession(sid string, uid int64, refreshID string, client SessionClient) error {
+	if len(client.UserAgent) > 256 {
+		client.UserAgent = client.UserAgent[:256]
+	}
+
+	now := time.Now().Unix()
+	s := &storedSession{
+		Session: Session{
+			ID:         sid,
+			CreatedAt:  now,
+			LastSeenAt: now,
+			UserAgent:  client.UserAgent,
+			IP:         client.IP,
+		},
+		UserID:    uid,
+		RefreshID: refreshID,
+	}
+
+	buf, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	key := userSessionsKey(uid)
+
+	pipe := Redis.TxPipeline()
+	pipe.Set(ctx, sessionKey(sid), buf, RefreshExpiration)
+	pipe.SAdd(ctx, key, sid)
+	pipe.Expire(ctx, key, RefreshExpiration)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// getSession gets the session of the given id,
+// returns redis.Nil if the session does not exist.
+func getSession(sid string) (*storedSession, error) {
+	buf, err := Redis.Get(context.Background(), sessionKey(sid)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &storedSession{}
+	if err := json.Unmarshal(buf, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// sessionExists checks if the session of the given id has not been revoked.
+func sessionExists(sid string) (bool, error) {
+	n, err := Redis.Exists(context.Background(), sessionKey(sid)).Result()
+	return n > 0, err
+}
+
+// rotateSession replaces the given refresh token of the given session with a
+// new one, and records the activity of the session along with the given ip.
+// Returns the uid of the user of the session and the new refresh token, which
+// is nil if the refresh token has just been rotated by a concurrent request,
+// as the replacement has already been issued to it.
+//
+// The session is revoked if the refresh token has already been rotated,
+// as either the token or its replacement has likely been stolen.
+func rotateSession(sid, refreshID, ip string) (uid int64, rt *Token, err error) {
+	ctx := context.Background()
+	key := sessionKey(sid)
+
+	rotate := func(tx *redis.Tx) error {
+		buf, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			return err
+		}
+
+		s := &storedSession{}
+		if err := json.Unmarshal(buf, s); err != nil {
+			return err
+		}
+		uid = s.UserID
+
+		now := time.Now()
+		switch {
+		case len(s.RefreshID) > 0 && refreshID == s.RefreshID:
+		case refreshID == s.PreviousRefreshID && now.Sub(time.Unix(s.RotatedAt, 0)) < refreshRotationGrace:
+			rt = nil
+			return nil
+		default:
+			return errRefreshTokenReused
+		}
+
+		// The new token is only stored if the transaction succeeds,
+		// so that retries don't leave tokens which no session tracks.
+		if rt, err = newToken(uid, sid, tokenRefresh); err != nil {
+			return err
+		}
+
+		s.PreviousRefreshID = s.RefreshID
+		s.RefreshID = rt.ID
+		s.RotatedAt = now.Unix()
+		s.LastSeenAt = now.Unix()
+		if len(ip) > 0 {
+			s.IP = ip
+		}
+
+		if buf, err = json.Marshal(s); err != nil {
+			return err
+		}
+
+		// The session lasts as long as its new refresh token.
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, rt.ID, uid, rt.ExprDate.Sub(now))
+			pipe.Set(ctx, key, buf, RefreshExpiration)
+			pipe.Expire(ctx, userSessionsKey(uid), RefreshExpiration)
+			pipe.Del(ctx, refreshID)
+			return nil
+		})
+		return err
+	}
+
+	// The session is watched so that concurrent rotations
+	// are retried, and then use the grace period.
+	for i := 0; i < 3; i++ {
+		if err = Redis.Watch(ctx, rotate, key); err != redis.TxFailedErr {
+			break
+		}
+	}
+
+	switch err {
+	case nil:
+		return uid, rt, nil
+	case redis.Nil:
+		return 0, nil, errs.ErrInvalidToken
+	case errRefreshTokenReused:
+		revokeReusedSession(uid, sid, ip)
+		return 0, nil, errs.ErrInvalidToken
+	default:
+		log.Println(err)
+		return 0, nil, errs.ErrUnknown
+	}
+}
+
+// revokeReusedSession revokes the given session after one of its rotated
+// refresh tokens has been used again from the given ip, which is recorded
+// as a security event.
+func revokeReusedSession(uid int64, sid, ip string) {
+	log.Printf("Refresh token of session %s of user %d reused from %s, revoking the session\n", sid, uid, ip)
+
+	if err := revokeSession(uid, sid); err != nil && err != errs.ErrSessionNotFound {
+		log.Println(err)
+	}
+
+	recordAudit(nil, AuditReuseRefreshToken, AuditTargetUser, uid, nil,
+		map[string]interface{}{"session": sid, "ip": ip})
+}
+
+// GetSessions gets the active sessions of the given user, the given
+// session is marked as current. Results are sorted from the most
+// recently seen.
+func GetSessions(user *modext.User, current string) ([]*Session, error) {
+	ctx := context.Background()
+	key := userSessionsKey(user.ID)
+
+	ids, err := Redis.SMembers(ctx, key).Result()
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	sessions := []*Session{}
+	for _, id := range ids {
+		s, err := getSession(id)
+		if err == redis.Nil {
+			// The session has expired.
+			Redis.SRem(ctx, key, id)
+			continue
+		} else if err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+
+		s.Current = s.ID == current
+		sessions = append(sessions, &s.Session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt > sessions[j].LastSeenAt
+	})
+	return sessions, nil
+}
+
+// RevokeSession revokes a session of the given user,
+// the tokens of the session are no longer valid.
+func RevokeSession(user *modext.User, sid string) error {
+	return revokeSession(user.ID, sid)
+}
+
+func revokeSession(uid int64, sid string) error {
+	ctx := context.Background()
+
+	s, err := getSession(sid)
+	if err != nil {
+		if err == redis.Nil {
+			return errs.ErrSessionNotFound
+		}
+		log.Println(err)
+		return errs.ErrUnknown
+	} else if s.UserID != uid {
+		return errs.ErrSessionNotFound
+	}
+
+	pipe := Redis.TxPipeline()
+	pipe.Del(ctx, sessionKey(sid))
+	pipe.SRem(ctx, userSessionsKey(uid), sid)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+	return nil
+}
+
+// RevokeSessions revokes every session of the given user, which logs
+// the user out everywhere.
+func RevokeSessions(user *modext.User, actor *modext.User) error {
+	if err := revokeUserSessions(user.ID); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	recordAudit(actor, AuditRevokeSessions, AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
+
+// revokeUserSessions revokes every session of the user of the given id.
+func revokeUserSessions(uid int64) error {
+	ctx := context.Background()
+	key := userSessionsKey(uid)
+
+	ids, err := Redis.SMembers(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{key}
+	for _, id := range ids {
+		keys = append(keys, sessionKey(id))
+	}
+	return Redis.Del(ctx, keys...).Err()
+}
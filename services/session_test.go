@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "kasen/cache"
+
+	"kasen/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestRotateSession(t *testing.T) {
+	requireBackends(t)
+
+	if err := RotateSigningKeys(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	uid := time.Now().UnixNano()
+	t.Cleanup(func() { Redis.Del(ctx, userSessionsKey(uid)) })
+
+	tests := []struct {
+		name      string
+		stored    *storedSession
+		refreshID string
+		err       error
+		rotated   bool
+		revoked   bool
+	}{
+		{
+			name:      "current token",
+			stored:    &storedSession{RefreshID: "b", PreviousRefreshID: "a", RotatedAt: time.Now().Add(-time.Hour).Unix()},
+			refreshID: "b",
+			rotated:   true,
+		},
+		{
+			name:      "previous token within the grace period",
+			stored:    &storedSession{RefreshID: "b", PreviousRefreshID: "a", RotatedAt: time.Now().Add(-refreshRotationGrace / 2).Unix()},
+			refreshID: "a",
+		},
+		{
+			name:      "previous token after the grace period",
+			stored:    &storedSession{RefreshID: "b", PreviousRefreshID: "a", RotatedAt: time.Now().Add(-2 * refreshRotationGrace).Unix()},
+			refreshID: "a",
+			err:       errs.ErrInvalidToken,
+			revoked:   true,
+		},
+		{
+			name:      "older token",
+			stored:    &storedSession{RefreshID: "c", PreviousRefreshID: "b", RotatedAt: time.Now().Unix()},
+			refreshID: "a",
+			err:       errs.ErrInvalidToken,
+			revoked:   true,
+		},
+		{
+			name:      "session without refresh token",
+			stored:    &storedSession{},
+			refreshID: "",
+			err:       errs.ErrInvalidToken,
+			revoked:   true,
+		},
+		{
+			name:      "unknown session",
+			refreshID: "a",
+			err:       errs.ErrInvalidToken,
+			revoked:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sid := uuid.NewString()
+			t.Cleanup(func() { Redis.Del(ctx, sessionKey(sid)) })
+
+			if tt.stored != nil {
+				tt.stored.ID = sid
+				tt.stored.UserID = uid
+				buf, err := json.Marshal(tt.stored)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := Redis.Set(ctx, sessionKey(sid), buf, time.Minute).Err(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, rt, err := rotateSession(sid, tt.refreshID, "127.0.0.1")
+			if err != tt.err {
+				t.Fatalf("rotateSession() error = %v, want %v", err, tt.err)
+			}
+			if rt != nil {
+				t.Cleanup(func() { Redis.Del(ctx, rt.ID) })
+			}
+			if (rt != nil) != tt.rotated {
+				t.Errorf("rotateSession() rotated = %v, want %v", rt != nil, tt.rotated)
+			}
+
+			s, err := getSession(sid)
+			if (err != nil) != tt.revoked {
+				t.Fatalf("getSession() error = %v, want revoked = %v", err, tt.revoked)
+			}
+			if tt.rotated && (s.RefreshID != rt.ID || s.PreviousRefreshID != tt.refreshID) {
+				t.Errorf("rotated session refresh ids = %s, %s, want %s, %s",
+					s.RefreshID, s.PreviousRefreshID, rt.ID, tt.refreshID)
+			}
+		})
+	}
+}
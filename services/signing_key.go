@@ -0,0 +1,459 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	. "kasen/database"
+
+	"kasen/config"
+	"kasen/models"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Purposes of the tokens, which are signed with different keys.
+const (
+	tokenSession = "session"
+	tokenRefresh = "refresh"
+)
+
+// Algorithms of the signing keys.
+const (
+	SigningHS256 = "HS256"
+	SigningEdDSA = "EdDSA"
+	SigningRS256 = "RS256"
+)
+
+// signingLegacy is the algorithm of the keys which stand for the legacy
+// secrets, they have no key material and only record until when the
+// tokens without key id are accepted.
+const signingLegacy = "legacy"
+
+var errNoSigningKey = errors.New("No signing key")
+
+// signingKeysRefreshInterval is the interval at which the signing keys are
+// reloaded, to pick up the keys rotated by other instances.
+const signingKeysRefreshInterval = time.Minute
+
+// signingKeysLock is the id of the advisory lock held while rotating keys.
+const signingKeysLock = 0x6b6579
+
+// signingKey represents a signing key, along with its parsed key material.
+type signingKey struct {
+	*models.SigningKey
+
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+var signingKeys struct {
+	sync.RWMutex
+	keys     map[string]*signingKey
+	active   map[string]*signingKey
+	legacy   map[string]time.Time
+	loadedAt time.Time
+}
+
+// tokenExpiration gets the expiration of the tokens of the given purpose.
+func tokenExpiration(purpose string) time.Duration {
+	if purpose == tokenRefresh {
+		return RefreshExpiration
+	}
+	return SessionExpiration
+}
+
+// legacySecret gets the secret which signed the tokens of the given purpose
+// before the signing keys were rotated, these tokens have no key id.
+func legacySecret(purpose string) []byte {
+	if purpose == tokenRefresh {
+		return config.GetSecurity().JWTRefreshSecret
+	}
+	return config.GetSecurity().JWTSessionSecret
+}
+
+// signingAlgorithm gets the algorithm of the keys of the given purpose,
+// refresh tokens are only verified by Kasen itself.
+func signingAlgorithm(purpose string) string {
+	if purpose == tokenRefresh {
+		return SigningHS256
+	}
+	return config.GetSecurity().JWTAlgorithm
+}
+
+// generateSigningKey generates the key material of the given algorithm,
+// encoded as it is stored.
+func generateSigningKey(algorithm string) (string, error) {
+	var key interface{}
+	var err error
+
+	switch algorithm {
+	case SigningHS256:
+		buf, err := randomBytes(32)
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(buf), nil
+	case SigningEdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case SigningRS256:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		return "", fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// parseSigningKey parses the key material of the given signing key.
+func parseSigningKey(k *models.SigningKey) (*signingKey, error) {
+	key := &signingKey{SigningKey: k}
+
+	if k.Algorithm == SigningHS256 {
+		secret, err := base64.StdEncoding.DecodeString(k.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		key.signKey, key.verifyKey = secret, secret
+		return key, nil
+	}
+
+	block, _ := pem.Decode([]byte(k.PrivateKey))
+	if block == nil {
+		return nil, errors.New("invalid private key")
+	}
+
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch private := private.(type) {
+	case ed25519.PrivateKey:
+		key.verifyKey = private.Public()
+	case *rsa.PrivateKey:
+		key.verifyKey = &private.PublicKey
+	default:
+		return nil, fmt.Errorf("unsupported private key of signing key %s", k.Kid)
+	}
+	key.signKey = private
+	return key, nil
+}
+
+// loadSigningKeys loads the signing keys which have not expired.
+func loadSigningKeys() error {
+	keys, err := models.SigningKeys(
+		Where("expires_at IS NULL OR expires_at > ?", time.Now().UTC()),
+		OrderBy("created_at ASC"),
+	).All(ReadDB)
+	if err != nil {
+		return err
+	}
+
+	loaded := make(map[string]*signingKey)
+	active := make(map[string]*signingKey)
+	legacy := make(map[string]time.Time)
+	for _, k := range keys {
+		if k.Algorithm == signingLegacy {
+			legacy[k.Purpose] = k.ExpiresAt.Time
+			continue
+		}
+
+		key, err := parseSigningKey(k)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		loaded[k.Kid] = key
+		if !k.RetiredAt.Valid {
+			active[k.Purpose] = key
+		}
+	}
+
+	signingKeys.Lock()
+	defer signingKeys.Unlock()
+
+	signingKeys.keys = loaded
+	signingKeys.active = active
+	signingKeys.legacy = legacy
+	signingKeys.loadedAt = time.Now()
+	return nil
+}
+
+// refreshSigningKeys reloads the signing keys if they were loaded
+// more than the given duration ago.
+func refreshSigningKeys(maxAge time.Duration) {
+	signingKeys.RLock()
+	stale := time.Since(signingKeys.loadedAt) > maxAge
+	signingKeys.RUnlock()
+
+	if stale {
+		if err := loadSigningKeys(); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// getActiveSigningKey gets the key which signs the new tokens of the given
+// purpose, which is nil if no key has been created yet.
+func getActiveSigningKey(purpose string) *signingKey {
+	refreshSigningKeys(signingKeysRefreshInterval)
+
+	signingKeys.RLock()
+	defer signingKeys.RUnlock()
+	return signingKeys.active[purpose]
+}
+
+// getSigningKey gets the signing key of the given id, the keys are
+// reloaded if the key is unknown as it may have just been rotated.
+func getSigningKey(kid string) *signingKey {
+	refreshSigningKeys(signingKeysRefreshInterval)
+
+	signingKeys.RLock()
+	key, ok := signingKeys.keys[kid]
+	signingKeys.RUnlock()
+
+	if !ok {
+		refreshSigningKeys(5 * time.Second)
+
+		signingKeys.RLock()
+		key = signingKeys.keys[kid]
+		signingKeys.RUnlock()
+	}
+
+	if key != nil && key.ExpiresAt.Valid && !key.ExpiresAt.Time.After(time.Now()) {
+		return nil
+	}
+	return key
+}
+
+// acceptsLegacyTokens checks if the tokens of the given purpose without
+// key id are still accepted, which is only the case during the grace
+// period following the first rotation.
+func acceptsLegacyTokens(purpose string) bool {
+	refreshSigningKeys(signingKeysRefreshInterval)
+
+	signingKeys.RLock()
+	defer signingKeys.RUnlock()
+
+	expiresAt, ok := signingKeys.legacy[purpose]
+	return ok && time.Now().Before(expiresAt)
+}
+
+// signToken signs the given claims for the given purpose, with the active key.
+// An error is returned if there is no key, as they failed to be created
+// or loaded.
+func signToken(purpose string, claims jwt.MapClaims) (string, error) {
+	claims["typ"] = purpose
+
+	key := getActiveSigningKey(purpose)
+	if key == nil {
+		return "", errNoSigningKey
+	}
+
+	t := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	t.Header["kid"] = key.Kid
+	return t.SignedString(key.signKey)
+}
+
+// tokenKeyFunc gets the key which verifies the tokens of the given purpose,
+// the tokens without key id are verified with the legacy secret until
+// the grace period following the first rotation is over.
+func tokenKeyFunc(purpose string) jwt.Keyfunc {
+	return func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if len(kid) == 0 {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok || !acceptsLegacyTokens(purpose) {
+				return nil, errors.New("Failed to parse token")
+			}
+			return legacySecret(purpose), nil
+		}
+
+		key := getSigningKey(kid)
+		if key == nil || key.Purpose != purpose || t.Method.Alg() != key.Algorithm {
+			return nil, errors.New("Failed to parse token")
+		}
+		return key.verifyKey, nil
+	}
+}
+
+// RotateSigningKeys creates a new signing key for the tokens of each
+// purpose, if there is none or if the active one is due for rotation.
+// The previous keys still verify tokens during the grace period, and the
+// expired keys are deleted. It's safe to run on multiple instances.
+func RotateSigningKeys() error {
+	security := config.GetSecurity()
+	now := time.Now().UTC()
+
+	tx, err := WriteDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := queries.Raw("SELECT pg_advisory_xact_lock($1)", signingKeysLock).Exec(tx); err != nil {
+		return err
+	}
+
+	for _, purpose := range []string{tokenSession, tokenRefresh} {
+		algorithm := signingAlgorithm(purpose)
+
+		active, err := models.SigningKeys(
+			Where("purpose = ? AND retired_at IS NULL", purpose),
+			OrderBy("created_at DESC"),
+		).One(tx)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		if active != nil && active.Algorithm == algorithm &&
+			(security.KeyRotationInterval <= 0 || now.Sub(active.CreatedAt) < security.KeyRotationInterval) {
+			continue
+		}
+
+		// Tokens signed with the previous keys stay valid until they expire.
+		grace := security.KeyGracePeriod
+		if grace < tokenExpiration(purpose) {
+			grace = tokenExpiration(purpose)
+		}
+
+		// The legacy secret is retired by the first rotation.
+		if active == nil {
+			exists, err := models.SigningKeys(Where("purpose = ?", purpose)).Exists(tx)
+			if err != nil {
+				return err
+			} else if !exists {
+				legacy := &models.SigningKey{
+					Kid:       "legacy-" + purpose,
+					Purpose:   purpose,
+					Algorithm: signingLegacy,
+					RetiredAt: null.TimeFrom(now),
+					ExpiresAt: null.TimeFrom(now.Add(grace)),
+				}
+				if err := legacy.Insert(tx, boil.Infer()); err != nil {
+					return err
+				}
+			}
+		}
+
+		privateKey, err := generateSigningKey(algorithm)
+		if err != nil {
+			return err
+		}
+
+		kid, err := randomString(12)
+		if err != nil {
+			return err
+		}
+
+		k := &models.SigningKey{
+			Kid:        kid,
+			Purpose:    purpose,
+			Algorithm:  algorithm,
+			PrivateKey: privateKey,
+		}
+		if err := k.Insert(tx, boil.Infer()); err != nil {
+			return err
+		}
+
+		if err := models.SigningKeys(
+			Where("purpose = ? AND retired_at IS NULL AND id <> ?", purpose, k.ID),
+		).UpdateAll(tx, models.M{
+			models.SigningKeyColumns.RetiredAt: null.TimeFrom(now),
+			models.SigningKeyColumns.ExpiresAt: null.TimeFrom(now.Add(grace)),
+		}); err != nil {
+			return err
+		}
+
+		log.Printf("Rotated %s signing key, new key %s\n", purpose, kid)
+	}
+
+	if err := models.SigningKeys(Where("expires_at <= ?", now)).DeleteAll(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return loadSigningKeys()
+}
+
+var startKeyRotationOnce sync.Once
+
+// StartKeyRotation creates the signing keys if needed, and starts
+// rotating them once they are due for rotation.
+func StartKeyRotation() {
+	startKeyRotationOnce.Do(func() {
+		if err := RotateSigningKeys(); err != nil {
+			log.Println("Failed to rotate signing keys:", err)
+		}
+
+		go func() {
+			for {
+				interval := time.Hour
+				if i := config.GetSecurity().KeyRotationInterval; i > 0 && i < interval {
+					interval = i
+				}
+				time.Sleep(interval)
+
+				if err := RotateSigningKeys(); err != nil {
+					log.Println("Failed to rotate signing keys:", err)
+				}
+			}
+		}()
+	})
+}
+
+// GetJWKS gets the public keys which verify the session tokens, as a JSON web
+// key set. Only asymmetric keys are published, including the rotated ones.
+func GetJWKS() map[string]interface{} {
+	refreshSigningKeys(signingKeysRefreshInterval)
+
+	signingKeys.RLock()
+	defer signingKeys.RUnlock()
+
+	keys := []*jwk{}
+	for _, key := range signingKeys.keys {
+		if key.Purpose != tokenSession {
+			continue
+		}
+
+		k := &jwk{Kid: key.Kid, Use: "sig", Alg: key.Algorithm}
+		switch public := key.verifyKey.(type) {
+		case ed25519.PublicKey:
+			k.Kty, k.Crv = "OKP", "Ed25519"
+			k.X = base64.RawURLEncoding.EncodeToString(public)
+		case *rsa.PublicKey:
+			k.Kty = "RSA"
+			k.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			k.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		default:
+			continue
+		}
+		keys = append(keys, k)
+	}
+	return map[string]interface{}{"keys": keys}
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+
+	"kasen/config"
+	"kasen/storage"
+
+	"github.com/pkg/errors"
+)
+
+// store is the storage of pages and covers.
+//
+// The local storage is rooted at the <data>/symlinks directory,
+// so pages are stored under "chapters/<chapter id>/" and covers
+// under "<project id>/covers/", the same keys are used by
+// the other storages.
+var store storage.Storage
+
+func init() {
+	cfg := config.GetStorage()
+	s3 := storage.S3Options{
+		Endpoint:  cfg.Endpoint,
+		Region:    cfg.Region,
+		Bucket:    cfg.Bucket,
+		AccessKey: cfg.AccessKey,
+		SecretKey: cfg.SecretKey,
+		PathStyle: cfg.PathStyle,
+	}
+
+	var err error
+	if store, err = storage.New(cfg.Driver, s3, GetSymlinksDir()); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// isLocalStorage checks if pages and covers are stored in the local filesystem,
+// in which case project and chapter directories have to be maintained.
+func isLocalStorage() bool {
+	_, ok := store.(*storage.Local)
+	return ok
+}
+
+// chapterKey gets the storage key of the given chapter file,
+// or the prefix of the chapter files if the file name is empty.
+func chapterKey(cid int64, fn string) string {
+	return fmt.Sprintf("chapters/%d/%s", cid, fn)
+}
+
+// coverKey gets the storage key of the given cover file,
+// or the prefix of the project covers if the file name is empty.
+func coverKey(pid int64, fn string) string {
+	return fmt.Sprintf("%d/covers/%s", pid, fn)
+}
+
+// objectExists checks if the object of the given key exists.
+func objectExists(key string) (bool, error) {
+	if _, err := store.Stat(key); err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// putObject stores the given file under the given key.
+func putObject(key string, f *os.File) error {
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return store.Put(key, f, stat.Size())
+}
+
+// removeObjects removes all objects whose keys start with the given prefix.
+func removeObjects(prefix string) error {
+	objects, err := store.List(prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range objects {
+		if err := store.Delete(o.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openObjectFile opens the object of the given key as a file.
+//
+// Objects of the local storage are opened directly, others are
+// downloaded into a temporary file which will be removed by
+// the returned cleanup function.
+func openObjectFile(key string) (f *os.File, cleanup func(), err error) {
+	r, err := store.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if f, ok := r.(*os.File); ok {
+		return f, func() { f.Close() }, nil
+	}
+	defer r.Close()
+
+	tmp, err := os.CreateTemp(GetTempDir(), "tmp-")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup = func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return tmp, cleanup, nil
+}
+
+// serveObject serves the object of the given key.
+//
+// Clients will be redirected to a presigned URL if the storage
+// supports it and presign_redirect is enabled, otherwise the
+// object is proxied.
+func serveObject(key string, w http.ResponseWriter, r *http.Request) {
+	cfg := config.GetStorage()
+	if cfg.PresignRedirect {
+		if u, err := store.Presign(key, cfg.PresignExpiry); err == nil {
+			http.Redirect(w, r, u, http.StatusFound)
+			return
+		} else if err != storage.ErrNotSupported {
+			log.Println(err)
+		}
+	}
+
+	info, err := store.Stat(key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	proxyObject(key, info, w, r)
+}
+
+// proxyObject serves the object of the given key and info
+// through the server, without redirecting the client.
+func proxyObject(key string, info *storage.ObjectInfo, w http.ResponseWriter, r *http.Request) {
+	obj, err := store.Get(key)
+	if err != nil {
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer obj.Close()
+
+	if rs, ok := obj.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, path.Base(key), info.ModTime, rs)
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	w.Header().Set("Last-Modified", info.ModTime.UTC().Format(http.TimeFormat))
+	if ct := mime.TypeByExtension(path.Ext(key)); len(ct) > 0 {
+		w.Header().Set("Content-Type", ct)
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method != http.MethodHead {
+		io.Copy(w, obj)
+	}
+}
@@ -175,12 +175,12 @@ func UpdateTagEx(e boil.Executor, id int64, name string) (*modext.Tag, error) {
 }
 
 // This function simply calls DeleteTagEx with the global Write connection.
-func DeleteTag(id int64) error {
-	return DeleteTagEx(WriteDB, id)
+func DeleteTag(id int64, user *modext.User) error {
+	return DeleteTagEx(WriteDB, id, user)
 }
 
 // DeleteTagEx deletes a tag.
-func DeleteTagEx(e boil.Executor, id int64) error {
+func DeleteTagEx(e boil.Executor, id int64, user *modext.User) error {
 	t, err := models.FindTag(e, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -195,16 +195,17 @@ func DeleteTagEx(e boil.Executor, id int64) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetTag, t.ID, modext.NewTag(t), nil)
 	return nil
 }
 
 // This function simply calls DeleteTagByNameEx with the global Write connection.
-func DeleteTagByName(name string) error {
-	return DeleteTagByNameEx(WriteDB, name)
+func DeleteTagByName(name string, user *modext.User) error {
+	return DeleteTagByNameEx(WriteDB, name, user)
 }
 
 // DeleteTagByNameEx deletes a tag by name.
-func DeleteTagByNameEx(e boil.Executor, name string) error {
+func DeleteTagByNameEx(e boil.Executor, name string, user *modext.User) error {
 	t, err := models.Tags(Where("name ILIKE ?", name)).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -219,16 +220,17 @@ func DeleteTagByNameEx(e boil.Executor, name string) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetTag, t.ID, modext.NewTag(t), nil)
 	return nil
 }
 
 // This function simply calls DeleteTagBySlugEx with the global Write connection.
-func DeleteTagBySlug(slug string) error {
-	return DeleteTagBySlugEx(WriteDB, slug)
+func DeleteTagBySlug(slug string, user *modext.User) error {
+	return DeleteTagBySlugEx(WriteDB, slug, user)
 }
 
 // DeleteTagBySlugEx deletes a tag by slug.
-func DeleteTagBySlugEx(e boil.Executor, slug string) error {
+func DeleteTagBySlugEx(e boil.Executor, slug string, user *modext.User) error {
 	t, err := models.Tags(Where("slug ILIKE ?", slug)).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -243,16 +245,17 @@ func DeleteTagBySlugEx(e boil.Executor, slug string) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetTag, t.ID, modext.NewTag(t), nil)
 	return nil
 }
 
 // This function simply calls DeleteTagBySlugOrNameEx with the global Write connection.
-func DeleteTagBySlugOrName(slugOrName string) error {
-	return DeleteTagBySlugOrNameEx(WriteDB, slugOrName)
+func DeleteTagBySlugOrName(slugOrName string, user *modext.User) error {
+	return DeleteTagBySlugOrNameEx(WriteDB, slugOrName, user)
 }
 
 // DeleteTagBySlugOrNameEx deletes a tag by slug or name.
-func DeleteTagBySlugOrNameEx(e boil.Executor, slugOrName string) error {
+func DeleteTagBySlugOrNameEx(e boil.Executor, slugOrName string, user *modext.User) error {
 	t, err := models.Tags(Where("slug ILIKE ? OR name ILIKE ?", slugOrName, slugOrName)).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -267,5 +270,6 @@ func DeleteTagBySlugOrNameEx(e boil.Executor, slugOrName string) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(user, AuditDelete, AuditTargetTag, t.ID, modext.NewTag(t), nil)
 	return nil
 }
@@ -26,51 +26,81 @@ type Token struct {
 const SessionExpiration = 15 * time.Minute    // 15 minutes
 const RefreshExpiration = 24 * 30 * time.Hour // 30 days
 
-func createToken(uid int64, secret []byte, exprDur time.Duration) (*Token, error) {
-	expr := time.Now().Add(exprDur)
+// newToken creates a token of the given purpose for the given session,
+// signed with the active signing key of the purpose. The token is only
+// valid once its id has been stored along with the uid, until it expires.
+func newToken(uid int64, sid string, purpose string) (*Token, error) {
+	now := time.Now()
+	expr := now.Add(tokenExpiration(purpose))
 	t := &Token{ID: uuid.NewString(), Expr: expr.Unix(), ExprDate: &expr}
-	tRaw := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+
+	var err error
+	if t.String, err = signToken(purpose, jwt.MapClaims{
 		"id":  t.ID,
+		"sid": sid,
+		"sub": strconv.FormatInt(uid, 10),
+		"iss": config.GetMeta().BaseURL,
+		"iat": now.Unix(),
 		"exp": t.Expr,
-	})
+	}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
 
-	var err error
-	if t.String, err = tRaw.SignedString(secret); err != nil {
+// createToken creates and stores a token of the given purpose for the given session.
+func createToken(uid int64, sid string, purpose string) (*Token, error) {
+	t, err := newToken(uid, sid, purpose)
+	if err != nil {
 		return nil, err
-	} else if err = Redis.Set(context.Background(), t.ID, uid, expr.Sub(time.Now())).Err(); err != nil {
+	} else if err = Redis.Set(context.Background(), t.ID, uid, t.ExprDate.Sub(time.Now())).Err(); err != nil {
 		return nil, err
 	}
 	return t, nil
 }
 
-func CreateToken(uid int64) (rt *Token, st *Token, err error) {
-	security := config.GetSecurity()
+// CreateToken starts a new session for the given user from the given client,
+// and returns its refresh and session token.
+func CreateToken(uid int64, client SessionClient) (rt *Token, st *Token, err error) {
+	sid := uuid.NewString()
 
-	rt, err = createToken(uid, security.JWTRefreshSecret, RefreshExpiration)
+	rt, err = createToken(uid, sid, tokenRefresh)
 	if err != nil {
 		log.Println(err)
 		return nil, nil, errs.ErrUnknown
 	}
 
-	st, err = createToken(uid, security.JWTSessionSecret, SessionExpiration)
+	st, err = createToken(uid, sid, tokenSession)
 	if err != nil {
 		log.Println(err)
 		return nil, nil, errs.ErrUnknown
 	}
+
+	if err = createSession(sid, uid, rt.ID, client); err != nil {
+		log.Println(err)
+		return nil, nil, errs.ErrUnknown
+	}
 	return
 }
 
-func parseToken(t string, secret []byte) (*jwt.Token, error) {
-	return jwt.Parse(t, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+// parseToken parses the given token of the given purpose, tokens issued
+// before the signing keys were rotated have neither key id nor purpose.
+func parseToken(tStr string, purpose string) (*jwt.Token, error) {
+	t, err := jwt.Parse(tStr, tokenKeyFunc(purpose))
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := t.Claims.(jwt.MapClaims); ok {
+		if typ, ok := claims["typ"]; ok && typ != purpose {
 			return nil, errors.New("Failed to parse token")
 		}
-		return secret, nil
-	})
+	}
+	return t, nil
 }
 
-func DeleteToken(rt string, secret []byte) error {
-	t, err := parseToken(rt, secret)
+func DeleteToken(rt string, purpose string) error {
+	t, err := parseToken(rt, purpose)
 	if err != nil {
 		log.Println(err)
 		return errs.ErrUnknown
@@ -93,41 +123,67 @@ func DeleteToken(rt string, secret []byte) error {
 	return nil
 }
 
-func verifyToken(tStr string, secret []byte) (uid int64, err error) {
-	t, err := parseToken(tStr, secret)
+// parseTokenClaims parses the given token, and returns its id and the id of
+// its session. Tokens issued before the sessions were tracked have no
+// session, and are no longer valid.
+func parseTokenClaims(tStr string, purpose string) (id, sid string, err error) {
+	t, err := parseToken(tStr, purpose)
 	if err != nil {
-		return 0, err
+		return "", "", err
 	}
 
 	claims, ok := t.Claims.(jwt.MapClaims)
 	if !ok || !t.Valid {
-		return 0, errs.ErrInvalidToken
+		return "", "", errs.ErrInvalidToken
 	}
 
-	id, ok := claims["id"].(string)
+	id, ok = claims["id"].(string)
 	if !ok {
-		return 0, errs.ErrInvalidToken
+		return "", "", errs.ErrInvalidToken
+	}
+
+	sid, ok = claims["sid"].(string)
+	if !ok || len(sid) == 0 {
+		return "", "", errs.ErrInvalidToken
+	}
+	return id, sid, nil
+}
+
+// verifyToken verifies the given token, and returns the uid of its user and
+// the id of its session.
+func verifyToken(tStr string, purpose string) (uid int64, sid string, err error) {
+	id, sid, err := parseTokenClaims(tStr, purpose)
+	if err != nil {
+		return 0, "", err
 	}
 
 	uidStr, err := Redis.Get(context.Background(), id).Result()
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
-	return strconv.ParseInt(uidStr, 10, 64)
+
+	if exists, err := sessionExists(sid); err != nil {
+		return 0, "", err
+	} else if !exists {
+		return 0, "", errs.ErrInvalidToken
+	}
+
+	uid, err = strconv.ParseInt(uidStr, 10, 64)
+	return uid, sid, err
 }
 
-func VerifySessionToken(st string) (uid int64, err error) {
-	if uid, err = verifyToken(st, config.GetSecurity().JWTSessionSecret); err != nil {
+func VerifySessionToken(st string) (uid int64, sid string, err error) {
+	if uid, sid, err = verifyToken(st, tokenSession); err != nil {
 		log.Println(err)
-		return 0, errs.ErrUnknown
+		return 0, "", errs.ErrUnknown
 	}
 	return
 }
 
-func VerifyRefreshToken(rt string) (uid int64, err error) {
-	if uid, err = verifyToken(rt, config.GetSecurity().JWTRefreshSecret); err != nil {
+func VerifyRefreshToken(rt string) (uid int64, sid string, err error) {
+	if uid, sid, err = verifyToken(rt, tokenRefresh); err != nil {
 		log.Println(err)
-		return 0, errs.ErrUnknown
+		return 0, "", errs.ErrUnknown
 	}
 	return
 }
@@ -0,0 +1,394 @@
+package services
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	. "kasen/cache"
+	. "kasen/database"
+
+	"kasen/config"
+	"kasen/constants"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Trash represents the deleted projects, chapters and covers which
+// haven't been purged yet. Chapters and covers of deleted projects
+// are left out, as they're restored along with their project.
+type Trash struct {
+	Projects []*modext.Project `json:"projects"`
+	Chapters []*modext.Chapter `json:"chapters"`
+	Covers   []*modext.Cover   `json:"covers"`
+}
+
+// This function simply calls GetTrashEx with the global Read connection.
+func GetTrash() (*Trash, error) {
+	return GetTrashEx(ReadDB)
+}
+
+// GetTrashEx gets the trash, results are sorted from the most recently deleted.
+func GetTrashEx(e boil.Executor) (*Trash, error) {
+	projects, err := models.Projects(
+		WithDeleted(),
+		Where("deleted_at IS NOT NULL"),
+		OrderBy("deleted_at DESC"),
+	).All(e)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	chapters, err := models.Chapters(
+		WithDeleted(),
+		Where(`deleted_at IS NOT NULL AND project_id NOT IN
+					(SELECT id FROM project WHERE deleted_at IS NOT NULL)`),
+		OrderBy("deleted_at DESC"),
+		Load(ChapterRels.Project),
+	).All(e)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	covers, err := models.Covers(
+		WithDeleted(),
+		Where(`deleted_at IS NOT NULL AND project_id NOT IN
+					(SELECT id FROM project WHERE deleted_at IS NOT NULL)`),
+		OrderBy("deleted_at DESC"),
+		Load(models.CoverRels.Project),
+	).All(e)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	trash := &Trash{
+		Projects: make([]*modext.Project, len(projects)),
+		Chapters: make([]*modext.Chapter, len(chapters)),
+		Covers:   make([]*modext.Cover, len(covers)),
+	}
+
+	for i, p := range projects {
+		trash.Projects[i] = modext.NewProject(p)
+	}
+
+	for i, c := range chapters {
+		trash.Chapters[i] = modext.NewChapter(c).LoadProject(c)
+	}
+
+	for i, c := range covers {
+		trash.Covers[i] = modext.NewCover(c).LoadProject(c)
+	}
+
+	return trash, nil
+}
+
+// This function simply calls RestoreProjectEx with a new write transaction.
+func RestoreProject(id int64, user *modext.User) (*modext.Project, error) {
+	tx, err := WriteDB.Begin()
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return RestoreProjectEx(tx, id, user)
+}
+
+// RestoreProjectEx restores a project from the trash, along with
+// the chapters and covers which were deleted with it.
+// Returns the restored project if successful.
+func RestoreProjectEx(tx *sql.Tx, id int64, user *modext.User) (*modext.Project, error) {
+	defer tx.Rollback()
+
+	p, err := models.Projects(WithDeleted(), Where("id = ? AND deleted_at IS NOT NULL", id)).One(tx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrProjectNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	chapters, err := models.Chapters(
+		WithDeleted(),
+		Where("project_id = ? AND deleted_at = ?", p.ID, p.DeletedAt.Time),
+	).All(tx)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if err := chapters.UpdateAll(tx, models.M{ChapterCols.DeletedAt: nil}); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	err = models.Covers(
+		WithDeleted(),
+		Where("project_id = ? AND deleted_at = ?", p.ID, p.DeletedAt.Time),
+	).UpdateAll(tx, models.M{CoverCols.DeletedAt: nil})
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	before := modext.NewProject(p)
+	updatedAt := p.UpdatedAt
+	p.DeletedAt.Valid = false
+
+	if err := p.Update(tx, boil.Whitelist(ProjectCols.DeletedAt)); err != nil {
+		// Another project has taken its title since it was deleted.
+		if isProjectConflict(err) {
+			return nil, errs.ErrProjectAlreadyExists
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	p.UpdatedAt = updatedAt
+	recordAudit(user, AuditRestore, AuditTargetProject, p.ID, before, modext.NewProject(p))
+
+	CoverCache.PurgeWithPrefix(p.ID)
+	for _, c := range chapters {
+		ChapterCache.PurgeWithPrefix(c.ID)
+		PagesCache.RemoveWithInt64(c.ID)
+	}
+
+	go func() {
+		projectAfterUpdateHook(p)
+		projectAfterPublishStateUpdateHook(p)
+	}()
+	return modext.NewProject(p), nil
+}
+
+// This function simply calls RestoreChapterEx with the global Write connection.
+func RestoreChapter(id int64, user *modext.User) (*modext.Chapter, error) {
+	return RestoreChapterEx(WriteDB, id, user)
+}
+
+// RestoreChapterEx restores a chapter from the trash
+// and returns the restored chapter if successful.
+//
+// This function will return an error if the project of the chapter is deleted,
+// as it has to be restored instead.
+// It will also return an error if the user does not have the necessary permissions.
+func RestoreChapterEx(e boil.Executor, id int64, user *modext.User) (*modext.Chapter, error) {
+	c, err := models.Chapters(WithDeleted(), Where("id = ? AND deleted_at IS NOT NULL", id)).One(e)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrChapterNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	if !hasChapterPermission(e, user, c, constants.PermDeleteChapter, constants.PermDeleteChapters) {
+		return nil, errs.ErrForbidden
+	}
+
+	if exists, err := models.ProjectExists(e, c.ProjectID); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if !exists {
+		return nil, errs.ErrProjectNotFound
+	}
+
+	before := modext.NewChapter(c)
+	updatedAt := c.UpdatedAt
+	c.DeletedAt.Valid = false
+
+	if err := c.Update(e, boil.Whitelist(ChapterCols.DeletedAt)); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	c.UpdatedAt = updatedAt
+	recordAudit(user, AuditRestore, AuditTargetChapter, c.ID, before, modext.NewChapter(c))
+
+	PagesCache.RemoveWithInt64(c.ID)
+	go chapterAfterUpdateHook(c)
+	return modext.NewChapter(c), nil
+}
+
+// This function simply calls RestoreCoverEx with a new write transaction.
+func RestoreCover(id int64, user *modext.User) (*modext.Cover, error) {
+	tx, err := WriteDB.Begin()
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return RestoreCoverEx(tx, id, user)
+}
+
+// RestoreCoverEx restores a cover from the trash
+// and returns the restored cover if successful.
+// It's the main cover of its project again if the project has none,
+// as it was likely unset when the cover was deleted.
+//
+// This function will return an error if the project of the cover is deleted,
+// as it has to be restored instead.
+func RestoreCoverEx(tx *sql.Tx, id int64, user *modext.User) (*modext.Cover, error) {
+	defer tx.Rollback()
+
+	c, err := models.Covers(WithDeleted(), Where("id = ? AND deleted_at IS NOT NULL", id)).One(tx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrCoverNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	p, err := models.FindProject(tx, c.ProjectID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrProjectNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	before := modext.NewCover(c)
+	c.DeletedAt.Valid = false
+
+	if err := c.Update(tx, boil.Whitelist(CoverCols.DeletedAt)); err != nil {
+		// The same file has been uploaded again since it was deleted.
+		if strings.Contains(err.Error(), `unique constraint "cover_pid_fn`) {
+			return nil, errs.ErrCoverAlreadyExists
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	isMain := !p.CoverID.Valid
+	if isMain {
+		p.CoverID = null.Int64From(c.ID)
+		if err := p.Update(tx, boil.Whitelist(ProjectCols.CoverID, ProjectCols.UpdatedAt)); err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditRestore, AuditTargetCover, c.ID, before, modext.NewCover(c))
+	if isMain {
+		recordAudit(user, AuditSetCover, AuditTargetProject, p.ID,
+			map[string]int64{"coverId": 0}, map[string]int64{"coverId": c.ID})
+	}
+
+	CoverCache.PurgeWithPrefix(c.ProjectID)
+	go func() {
+		refreshProjectCache(c.ProjectID)
+		if isMain {
+			refreshProjectsCache()
+		}
+	}()
+	return modext.NewCover(c), nil
+}
+
+var startTrashPurgerOnce sync.Once
+
+// StartTrashPurger starts purging the projects, chapters and covers
+// which have been in the trash for longer than the retention period,
+// along with the expired revisions of chapter pages.
+func StartTrashPurger() {
+	startTrashPurgerOnce.Do(func() {
+		go func() {
+			for {
+				purgeTrash()
+				purgeRevisions()
+				time.Sleep(config.GetTrash().PurgeInterval)
+			}
+		}()
+	})
+}
+
+// purgeTrash permanently deletes the expired projects, chapters and
+// covers of the trash, along with their files.
+func purgeTrash() {
+	before := time.Now().UTC().Add(-config.GetTrash().Retention)
+
+	projects, err := models.Projects(
+		WithDeleted(),
+		Where("deleted_at < ?", before),
+		Load(ProjectRels.Chapters, WithDeleted()),
+	).All(WriteDB)
+	if err != nil {
+		log.Println("Failed to get expired projects:", err)
+	}
+
+	for _, p := range projects {
+		if err := p.Delete(WriteDB, true); err != nil {
+			log.Println(err)
+			continue
+		}
+
+		recordAudit(nil, AuditPurge, AuditTargetProject, p.ID, modext.NewProject(p), nil)
+		if err := removeProjectDir(p); err != nil {
+			log.Println(err)
+		}
+	}
+
+	chapters, err := models.Chapters(WithDeleted(), Where("deleted_at < ?", before)).All(WriteDB)
+	if err != nil {
+		log.Println("Failed to get expired chapters:", err)
+	}
+
+	for _, c := range chapters {
+		if err := c.Delete(WriteDB, true); err != nil {
+			log.Println(err)
+			continue
+		}
+
+		recordAudit(nil, AuditPurge, AuditTargetChapter, c.ID, modext.NewChapter(c), nil)
+		if err := removeChapterDir(c); err != nil {
+			log.Println(err)
+		}
+	}
+
+	covers, err := models.Covers(WithDeleted(), Where("deleted_at < ?", before)).All(WriteDB)
+	if err != nil {
+		log.Println("Failed to get expired covers:", err)
+	}
+
+	for _, c := range covers {
+		if err := c.Delete(WriteDB, true); err != nil {
+			log.Println(err)
+			continue
+		}
+
+		recordAudit(nil, AuditPurge, AuditTargetCover, c.ID, modext.NewCover(c), nil)
+
+		// The files are shared with the cover of the same file uploaded again.
+		if exists, err := models.Covers(
+			WithDeleted(),
+			Where("project_id = ? AND file_name = ?", c.ProjectID, c.FileName),
+		).Exists(WriteDB); err != nil {
+			log.Println(err)
+			continue
+		} else if exists {
+			continue
+		}
+
+		if err := removeCoverFiles(c); err != nil {
+			log.Println(err)
+		}
+	}
+}
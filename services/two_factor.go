@@ -0,0 +1,451 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	. "kasen/cache"
+	. "kasen/database"
+
+	"kasen/config"
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var RecoveryCodeCols = models.RecoveryCodeColumns
+
+const totpPeriod = 30 // 30 seconds
+const totpDigits = 6
+
+// totpSkew is the number of periods before and after the current one
+// whose codes are accepted, to allow for clock drift.
+const totpSkew = 1
+
+const recoveryCodeCount = 10
+
+const TwoFactorChallengeExpiration = 5 * time.Minute // 5 minutes
+
+// twoFactorChallengeAttempts is the number of codes which can be tried
+// for a challenge, the user has to log in again afterwards.
+const twoFactorChallengeAttempts = 5
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// totpCode computes the TOTP code of the given secret and counter (RFC 6238).
+func totpCode(secret []byte, counter uint64) string {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, counter)
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(buf)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+// normalizeTwoFactorCode removes the spaces and dashes users may type.
+func normalizeTwoFactorCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+// isTOTPCode checks if the given normalized code is a TOTP code,
+// instead of a recovery code.
+func isTOTPCode(code string) bool {
+	if len(code) != totpDigits {
+		return false
+	}
+	_, err := strconv.ParseUint(code, 10, 64)
+	return err == nil
+}
+
+// checkTOTP checks the given code against the secret of the given user.
+// A code can only be used once.
+func checkTOTP(uid int64, secret, code string) (bool, error) {
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		return false, err
+	}
+
+	now := uint64(time.Now().Unix() / totpPeriod)
+	for i := -totpSkew; i <= totpSkew; i++ {
+		counter := now + uint64(i)
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, counter)), []byte(code)) != 1 {
+			continue
+		}
+
+		used := fmt.Sprintf("totp:%d:%d", uid, counter)
+		ok, err := Redis.SetNX(context.Background(), used, 1, time.Duration(2*totpSkew+1)*totpPeriod*time.Second).Result()
+		if err != nil {
+			return false, err
+		}
+		return ok, nil
+	}
+	return false, nil
+}
+
+// hashRecoveryCode hashes the given normalized recovery code,
+// only the hashes are stored.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// useRecoveryCode checks the given normalized recovery code,
+// and marks it as used if it's valid.
+func useRecoveryCode(e boil.Executor, uid int64, code string) (bool, error) {
+	rc, err := models.RecoveryCodes(
+		Where("user_id = ? AND hash = ? AND used_at IS NULL", uid, hashRecoveryCode(code)),
+	).One(e)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	rc.UsedAt = null.TimeFrom(time.Now().UTC())
+	if err := rc.Update(e, boil.Whitelist(RecoveryCodeCols.UsedAt)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// generateRecoveryCodes replaces the recovery codes of the given user.
+// Returns the new codes, which are only returned here.
+func generateRecoveryCodes(e boil.Executor, uid int64) ([]string, error) {
+	if err := models.RecoveryCodes(Where("user_id = ?", uid)).DeleteAll(e); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		buf, err := randomBytes(5)
+		if err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(buf)
+		codes[i] = code[:5] + "-" + code[5:]
+
+		rc := &models.RecoveryCode{UserID: uid, Hash: hashRecoveryCode(code)}
+		if err := rc.Insert(e, boil.Infer()); err != nil {
+			return nil, err
+		}
+	}
+	return codes, nil
+}
+
+// verifyTwoFactorCode checks the given TOTP or recovery code of the given user.
+func verifyTwoFactorCode(e boil.Executor, u *models.User, code string) error {
+	code = normalizeTwoFactorCode(code)
+	if len(code) == 0 {
+		return errs.ErrTwoFactorCodeRequired
+	}
+
+	var ok bool
+	var err error
+	if isTOTPCode(code) {
+		ok, err = checkTOTP(u.ID, u.TotpSecret.String, code)
+	} else {
+		ok, err = useRecoveryCode(e, u.ID, code)
+	}
+
+	if err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	} else if !ok {
+		return errs.ErrTwoFactorCodeInvalid
+	}
+	return nil
+}
+
+// findTwoFactorUser finds the user of the given id, along with its TOTP secret.
+func findTwoFactorUser(e boil.Executor, uid int64) (*models.User, error) {
+	u, err := models.FindUser(e, uid)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrUserNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return u, nil
+}
+
+// TOTPEnrollment represents a pending TOTP enrollment.
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+
+	// URI is the provisioning URI shown as a QR code
+	// to be scanned by authenticator apps.
+	URI string `json:"uri"`
+}
+
+// This function simply calls EnrollTOTPEx with the global Write connection.
+func EnrollTOTP(user *modext.User) (*TOTPEnrollment, error) {
+	return EnrollTOTPEx(WriteDB, user)
+}
+
+// EnrollTOTPEx generates a new TOTP secret for the given user, which has to be
+// confirmed with EnableTOTP before two-factor authentication is enabled.
+func EnrollTOTPEx(e boil.Executor, user *modext.User) (*TOTPEnrollment, error) {
+	u, err := findTwoFactorUser(e, user.ID)
+	if err != nil {
+		return nil, err
+	} else if u.TotpEnabledAt.Valid {
+		return nil, errs.ErrTwoFactorAlreadyEnabled
+	}
+
+	buf, err := randomBytes(20)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	u.TotpSecret = null.StringFrom(totpEncoding.EncodeToString(buf))
+	if err := u.Update(e, boil.Whitelist(UserCols.TotpSecret, UserCols.UpdatedAt)); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	issuer := config.GetMeta().Title
+	q := url.Values{}
+	q.Set("secret", u.TotpSecret.String)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", strconv.Itoa(totpDigits))
+	q.Set("period", strconv.Itoa(totpPeriod))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + u.Email,
+		RawQuery: q.Encode(),
+	}
+
+	return &TOTPEnrollment{Secret: u.TotpSecret.String, URI: uri.String()}, nil
+}
+
+// This function simply calls EnableTOTPEx with the global Write connection.
+func EnableTOTP(user *modext.User, code string) ([]string, error) {
+	return EnableTOTPEx(WriteDB, user, code)
+}
+
+// EnableTOTPEx enables two-factor authentication for the given user,
+// once the code of its pending enrollment is confirmed.
+// Returns the recovery codes of the user, which are only returned here.
+func EnableTOTPEx(e boil.Executor, user *modext.User, code string) ([]string, error) {
+	u, err := findTwoFactorUser(e, user.ID)
+	if err != nil {
+		return nil, err
+	} else if u.TotpEnabledAt.Valid {
+		return nil, errs.ErrTwoFactorAlreadyEnabled
+	} else if !u.TotpSecret.Valid {
+		return nil, errs.ErrTwoFactorNotEnrolled
+	}
+
+	code = normalizeTwoFactorCode(code)
+	if len(code) == 0 {
+		return nil, errs.ErrTwoFactorCodeRequired
+	} else if ok, err := checkTOTP(u.ID, u.TotpSecret.String, code); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if !ok {
+		return nil, errs.ErrTwoFactorCodeInvalid
+	}
+
+	codes, err := generateRecoveryCodes(e, u.ID)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	u.TotpEnabledAt = null.TimeFrom(time.Now().UTC())
+	if err := u.Update(e, boil.Whitelist(UserCols.TotpEnabledAt, UserCols.UpdatedAt)); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	user.TwoFactorEnabled = true
+	recordAudit(user, AuditEnableTwoFactor, AuditTargetUser, user.ID, nil, nil)
+	return codes, nil
+}
+
+// This function simply calls DisableTOTPEx with the global Write connection.
+func DisableTOTP(user *modext.User, code string, actor *modext.User) error {
+	return DisableTOTPEx(WriteDB, user, code, actor)
+}
+
+// DisableTOTPEx disables two-factor authentication for the given user,
+// and deletes its recovery codes. Users have to confirm with a TOTP or
+// recovery code, unless it's disabled by someone else.
+func DisableTOTPEx(e boil.Executor, user *modext.User, code string, actor *modext.User) error {
+	u, err := findTwoFactorUser(e, user.ID)
+	if err != nil {
+		return err
+	} else if !u.TotpSecret.Valid {
+		return errs.ErrTwoFactorNotEnabled
+	}
+
+	if actor.ID == user.ID && u.TotpEnabledAt.Valid {
+		if err := verifyTwoFactorCode(e, u, code); err != nil {
+			return err
+		}
+	}
+
+	if err := models.RecoveryCodes(Where("user_id = ?", u.ID)).DeleteAll(e); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	u.TotpSecret = null.String{}
+	u.TotpEnabledAt = null.Time{}
+	if err := u.Update(e, boil.Whitelist(UserCols.TotpSecret, UserCols.TotpEnabledAt, UserCols.UpdatedAt)); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	user.TwoFactorEnabled = false
+	recordAudit(actor, AuditDisableTwoFactor, AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
+
+// This function simply calls RegenerateRecoveryCodesEx with the global Write connection.
+func RegenerateRecoveryCodes(user *modext.User, code string) ([]string, error) {
+	return RegenerateRecoveryCodesEx(WriteDB, user, code)
+}
+
+// RegenerateRecoveryCodesEx replaces the recovery codes of the given user,
+// once confirmed with a TOTP or recovery code.
+// Returns the new recovery codes, which are only returned here.
+func RegenerateRecoveryCodesEx(e boil.Executor, user *modext.User, code string) ([]string, error) {
+	u, err := findTwoFactorUser(e, user.ID)
+	if err != nil {
+		return nil, err
+	} else if !u.TotpEnabledAt.Valid {
+		return nil, errs.ErrTwoFactorNotEnabled
+	}
+
+	if err := verifyTwoFactorCode(e, u, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := generateRecoveryCodes(e, u.ID)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditRecoveryCodes, AuditTargetUser, user.ID, nil, nil)
+	return codes, nil
+}
+
+// createTwoFactorChallenge creates a challenge for the given user, which
+// is completed with a TOTP or recovery code to finish logging in.
+func createTwoFactorChallenge(uid int64) (string, error) {
+	challenge := uuid.NewString()
+	if err := Redis.Set(context.Background(), "2fa:"+challenge, uid, TwoFactorChallengeExpiration).Err(); err != nil {
+		log.Println(err)
+		return "", errs.ErrUnknown
+	}
+	return challenge, nil
+}
+
+// LoginTwoFactor completes the given challenge with a TOTP or recovery code,
+// and returns a new refresh and session token if successful.
+func LoginTwoFactor(challenge, code string, client SessionClient) (rt, st *Token, err error) {
+	if len(challenge) == 0 {
+		return nil, nil, errs.ErrTwoFactorChallengeInvalid
+	}
+
+	ctx := context.Background()
+	key := "2fa:" + challenge
+
+	uid, err := Redis.Get(ctx, key).Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil, errs.ErrTwoFactorChallengeInvalid
+		}
+		log.Println(err)
+		return nil, nil, errs.ErrUnknown
+	}
+
+	attempts, err := Redis.Incr(ctx, key+":attempts").Result()
+	if err != nil {
+		log.Println(err)
+		return nil, nil, errs.ErrUnknown
+	}
+	Redis.Expire(ctx, key+":attempts", TwoFactorChallengeExpiration)
+
+	if attempts > twoFactorChallengeAttempts {
+		Redis.Del(ctx, key, key+":attempts")
+		return nil, nil, errs.ErrTwoFactorChallengeInvalid
+	}
+
+	u, err := findTwoFactorUser(WriteDB, uid)
+	if err != nil {
+		return nil, nil, errs.ErrTwoFactorChallengeInvalid
+	} else if !u.TotpEnabledAt.Valid {
+		// Two-factor authentication has been disabled in the meantime.
+		Redis.Del(ctx, key, key+":attempts")
+		return nil, nil, errs.ErrTwoFactorChallengeInvalid
+	}
+
+	if locked, err := isLoginLocked(u.Email); err != nil {
+		log.Println(err)
+		return nil, nil, errs.ErrUnknown
+	} else if locked {
+		Redis.Del(ctx, key, key+":attempts")
+		insertLoginFailure(u.Email, modext.NewUser(u), client, LoginFailureLocked)
+		return nil, nil, errs.ErrAccountLocked
+	}
+
+	if err := verifyTwoFactorCode(WriteDB, u, code); err != nil {
+		// Invalid codes count as failed logins.
+		if err == errs.ErrTwoFactorCodeInvalid {
+			if failures, err := incrLoginFailures(u.Email); err != nil {
+				log.Println(err)
+			} else {
+				recordLoginFailure(u.Email, modext.NewUser(u), client, LoginFailureInvalidTwoFactor, failures)
+			}
+		}
+		return nil, nil, err
+	}
+
+	// A challenge can only be completed once.
+	if n, err := Redis.Del(ctx, key).Result(); err != nil {
+		log.Println(err)
+		return nil, nil, errs.ErrUnknown
+	} else if n == 0 {
+		return nil, nil, errs.ErrTwoFactorChallengeInvalid
+	}
+	Redis.Del(ctx, key+":attempts")
+
+	// The login succeeded, so its failures are forgotten.
+	if err := clearLoginFailures(u.Email); err != nil {
+		log.Println(err)
+	}
+
+	return CreateToken(uid, client)
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "kasen/cache"
+)
+
+// totpTestSecret is the SHA1 secret of the test vectors of RFC 6238.
+var totpTestSecret = []byte("12345678901234567890")
+
+func TestTOTPCode(t *testing.T) {
+	// The codes of RFC 6238 have 8 digits, the last 6 digits are used here.
+	tests := []struct {
+		time int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		if got := totpCode(totpTestSecret, uint64(tt.time/totpPeriod)); got != tt.want {
+			t.Errorf("totpCode(%d) = %s, want %s", tt.time, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTOTP(t *testing.T) {
+	requireBackends(t)
+
+	// The codes are computed before being checked, within the same period.
+	if rem := totpPeriod - time.Now().Unix()%totpPeriod; rem < 2 {
+		time.Sleep(time.Duration(rem) * time.Second)
+	}
+
+	uid := time.Now().UnixNano()
+	secret := totpEncoding.EncodeToString(totpTestSecret)
+	now := uint64(time.Now().Unix() / totpPeriod)
+	t.Cleanup(func() {
+		for i := now - 2; i <= now+2; i++ {
+			Redis.Del(context.Background(), fmt.Sprintf("totp:%d:%d", uid, i))
+		}
+	})
+
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{"current code", totpCode(totpTestSecret, now), true},
+		{"replayed code", totpCode(totpTestSecret, now), false},
+		{"previous code", totpCode(totpTestSecret, now-1), true},
+		{"replayed previous code", totpCode(totpTestSecret, now-1), false},
+		{"expired code", totpCode(totpTestSecret, now-3), false},
+		{"invalid code", "abcdef", false},
+	}
+
+	for _, tt := range tests {
+		ok, err := checkTOTP(uid, secret, tt.code)
+		if err != nil {
+			t.Fatalf("%s: checkTOTP() error = %v", tt.name, err)
+		} else if ok != tt.want {
+			t.Errorf("%s: checkTOTP() = %v, want %v", tt.name, ok, tt.want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"log"
 	"strings"
+	"time"
 
 	. "kasen/database"
 
@@ -12,6 +13,7 @@ import (
 	"kasen/models"
 	"kasen/modext"
 
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"golang.org/x/crypto/bcrypt"
@@ -31,6 +33,10 @@ type CreateUserOptions struct {
 	Name        string `validate:"required,min=3,max=32"`
 	Email       string `validate:"required,email,max=255"`
 	RawPassword string `validate:"required,min=8"`
+
+	// EmailVerified is true if the email is known to be owned by the user,
+	// otherwise the user has to verify it before logging in.
+	EmailVerified bool
 }
 
 func (opts *CreateUserOptions) validate() error {
@@ -78,23 +84,25 @@ func CreateUserEx(e boil.Executor, opts CreateUserOptions) (*modext.User, error)
 	}
 
 	user := &models.User{
-		Name:     opts.Name,
-		Email:    opts.Email,
-		Password: hashedPassword,
-		Permissions: []string{
-			constants.PermCreateProject,
-			constants.PermUploadCover,
-			constants.PermSetCover,
-
-			constants.PermEditUser,
-			constants.PermDeleteUser,
-			constants.PermCreateChapter,
-			constants.PermEditChapter,
-			constants.PermLockChapter,
-			constants.PermPublishChapter,
-			constants.PermUnlockChapter,
-			constants.PermUnpublishChapter,
-		},
+		Name:               opts.Name,
+		Email:              opts.Email,
+		Password:           hashedPassword,
+		Permissions:        []string{},
+		RevokedPermissions: []string{},
+	}
+
+	if opts.EmailVerified {
+		user.EmailVerifiedAt = null.TimeFrom(time.Now().UTC())
+	}
+
+	// New users are uploaders, they are granted the default permissions
+	// of the role instead if it has been deleted.
+	role, err := models.Roles(Where("name = ?", constants.RoleUploader)).One(e)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	} else if role == nil {
+		user.Permissions = constants.DefaultRoles[constants.RoleUploader]
 	}
 
 	if err := user.Insert(e, boil.Infer()); err != nil {
@@ -102,7 +110,14 @@ func CreateUserEx(e boil.Executor, opts CreateUserOptions) (*modext.User, error)
 		return nil, errs.ErrUnknown
 	}
 
-	return modext.NewUser(user), nil
+	if role != nil {
+		if err := user.AddRoles(e, false, role); err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+	}
+
+	return modext.NewUser(user).LoadRoles(user), nil
 }
 
 // This function simply calls GetUserEx with the global Read connection.
@@ -113,7 +128,12 @@ func GetUser(id int64) (*modext.User, error) {
 // GetUserEx gets a user by ID.
 // Returns the user if found, or an error if the user does not exist.
 func GetUserEx(e boil.Executor, id int64) (*modext.User, error) {
-	user, err := models.FindUser(e, id)
+	user, err := models.Users(
+		Where("id = ?", id),
+		Load(UserRels.Roles),
+		Load(UserRels.ProjectMembers),
+		Load(UserRels.ScanlationGroupMembers),
+	).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errs.ErrUserNotFound
@@ -123,7 +143,7 @@ func GetUserEx(e boil.Executor, id int64) (*modext.User, error) {
 		return nil, errs.ErrUnknown
 	}
 
-	return modext.NewUser(user), nil
+	return modext.NewUser(user).LoadRoles(user).LoadMemberships(user).LoadScanlationGroups(user), nil
 }
 
 // This function simply calls GetUserByEmailEx with the global Read connection.
@@ -134,7 +154,12 @@ func GetUserByEmail(email string) (*modext.User, error) {
 // GetUserByEmailEx gets a user by email.
 // Returns the user if found, or an error if the user does not exist.
 func GetUserByEmailEx(e boil.Executor, email string) (*modext.User, error) {
-	user, err := models.Users(Where("email ILIKE ?", email)).One(e)
+	user, err := models.Users(
+		Where("email ILIKE ?", email),
+		Load(UserRels.Roles),
+		Load(UserRels.ProjectMembers),
+		Load(UserRels.ScanlationGroupMembers),
+	).One(e)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errs.ErrUserNotFound
@@ -144,7 +169,7 @@ func GetUserByEmailEx(e boil.Executor, email string) (*modext.User, error) {
 		return nil, errs.ErrUnknown
 	}
 
-	return modext.NewUser(user), nil
+	return modext.NewUser(user).LoadRoles(user).LoadMemberships(user).LoadScanlationGroups(user), nil
 }
 
 // This function simply calls GetUsersEx with the global Read connection.
@@ -154,7 +179,11 @@ func GetUsers() ([]*modext.User, error) {
 
 // GetUsersEx gets all users.
 func GetUsersEx(e boil.Executor) ([]*modext.User, error) {
-	users, err := models.Users().All(e)
+	users, err := models.Users(
+		Load(UserRels.Roles),
+		Load(UserRels.ProjectMembers),
+		Load(UserRels.ScanlationGroupMembers),
+	).All(e)
 	if err != nil {
 		log.Println(err)
 		return nil, errs.ErrUnknown
@@ -162,19 +191,19 @@ func GetUsersEx(e boil.Executor) ([]*modext.User, error) {
 
 	results := make([]*modext.User, len(users))
 	for i, user := range users {
-		results[i] = modext.NewUser(user)
+		results[i] = modext.NewUser(user).LoadRoles(user).LoadMemberships(user).LoadScanlationGroups(user)
 	}
 
 	return results, nil
 }
 
 // This function simply calls UpdateUserNameEx with the global Write connection.
-func UpdateUserName(user *modext.User, name string) error {
-	return UpdateUserNameEx(WriteDB, user, name)
+func UpdateUserName(user *modext.User, name string, actor *modext.User) error {
+	return UpdateUserNameEx(WriteDB, user, name, actor)
 }
 
 // UpdateUserNameEx updates the name of the given user.
-func UpdateUserNameEx(e boil.Executor, user *modext.User, name string) error {
+func UpdateUserNameEx(e boil.Executor, user *modext.User, name string, actor *modext.User) error {
 	name = strings.TrimSpace(name)
 
 	if len(name) == 0 {
@@ -185,6 +214,7 @@ func UpdateUserNameEx(e boil.Executor, user *modext.User, name string) error {
 		return errs.ErrUserNameTooLong
 	}
 
+	before := map[string]string{"name": user.Name}
 	u := user.ToModel()
 	u.Name = name
 	user.Name = name
@@ -194,17 +224,18 @@ func UpdateUserNameEx(e boil.Executor, user *modext.User, name string) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(actor, AuditUpdate, AuditTargetUser, user.ID, before, map[string]string{"name": name})
 	return nil
 }
 
 // This function simply calls UpdateUserEmailEx with the global Write connection.
-func UpdateUserEmail(user *modext.User, email string) error {
-	return UpdateUserEmailEx(WriteDB, user, email)
+func UpdateUserEmail(user *modext.User, email string, actor *modext.User) error {
+	return UpdateUserEmailEx(WriteDB, user, email, actor)
 }
 
 // UpdateUserEmailEx updates the email of the given user.
 // Returns an error if the email is already in use.
-func UpdateUserEmailEx(e boil.Executor, user *modext.User, email string) error {
+func UpdateUserEmailEx(e boil.Executor, user *modext.User, email string, actor *modext.User) error {
 	email = strings.TrimSpace(email)
 
 	if len(email) == 0 {
@@ -219,6 +250,7 @@ func UpdateUserEmailEx(e boil.Executor, user *modext.User, email string) error {
 		return errs.ErrEmailTaken
 	}
 
+	before := map[string]string{"email": user.Email}
 	u := user.ToModel()
 	u.Email = email
 
@@ -227,6 +259,7 @@ func UpdateUserEmailEx(e boil.Executor, user *modext.User, email string) error {
 		return errs.ErrUnknown
 	}
 
+	recordAudit(actor, AuditUpdate, AuditTargetUser, user.ID, before, map[string]string{"email": email})
 	return nil
 }
 
@@ -237,13 +270,13 @@ type UpdateUserPasswordOptions struct {
 }
 
 // This function simply calls UpdateUserPasswordEx with the global Write connection.
-func UpdateUserPassword(user *modext.User, opts UpdateUserPasswordOptions) error {
-	return UpdateUserPasswordEx(WriteDB, user, opts)
+func UpdateUserPassword(user *modext.User, opts UpdateUserPasswordOptions, actor *modext.User) error {
+	return UpdateUserPasswordEx(WriteDB, user, opts, actor)
 }
 
 // UpdateUserPasswordEx updates the password of the given user with the given options.
 // Returns an error if the current password is incorrect.
-func UpdateUserPasswordEx(e boil.Executor, user *modext.User, opts UpdateUserPasswordOptions) error {
+func UpdateUserPasswordEx(e boil.Executor, user *modext.User, opts UpdateUserPasswordOptions, actor *modext.User) error {
 	if len(opts.CurrentRawPassword) == 0 {
 		return errs.ErrCurrentPasswordRequired
 	} else if len(opts.CurrentRawPassword) < 6 {
@@ -276,6 +309,12 @@ func UpdateUserPasswordEx(e boil.Executor, user *modext.User, opts UpdateUserPas
 		return errs.ErrUnknown
 	}
 
+	// Sessions started with the previous password are no longer trusted.
+	if err := revokeUserSessions(user.ID); err != nil {
+		log.Println(err)
+	}
+
+	recordAudit(actor, AuditUpdatePassword, AuditTargetUser, user.ID, nil, nil)
 	return nil
 }
 
@@ -284,7 +323,7 @@ func AddUserPermission(user *modext.User, permission string) ([]string, error) {
 	return AddUserPermissionEx(WriteDB, user, permission)
 }
 
-// AddUserPermissionEx adds the given permission to the given user.
+// AddUserPermissionEx grants the given permission to the given user.
 // Returns the updated permissions of the user.
 func AddUserPermissionEx(e boil.Executor, user *modext.User, permission string) ([]string, error) {
 	if len(permission) == 0 {
@@ -292,11 +331,12 @@ func AddUserPermissionEx(e boil.Executor, user *modext.User, permission string)
 	}
 
 	if !user.HasPermissions(permission) {
-		u := user.ToModel()
-		u.Permissions = append(u.Permissions, permission)
-		user.Permissions = u.Permissions
+		user.GrantedPermissions = append(append([]string{}, user.GrantedPermissions...), permission)
+		user.RevokedPermissions = removePermission(user.RevokedPermissions, permission)
+		user.ResolvePermissions()
 
-		if err := u.Update(e, boil.Whitelist(UserCols.Permissions, UserCols.UpdatedAt)); err != nil {
+		u := user.ToModel()
+		if err := u.Update(e, boil.Whitelist(UserCols.Permissions, UserCols.RevokedPermissions, UserCols.UpdatedAt)); err != nil {
 			log.Println(err)
 			return nil, errs.ErrUnknown
 		}
@@ -305,38 +345,98 @@ func AddUserPermissionEx(e boil.Executor, user *modext.User, permission string)
 	return user.Permissions, nil
 }
 
+// UpdateUserPermissionsOptions represents the permissions which override
+// the permissions of the roles of a user.
+type UpdateUserPermissionsOptions struct {
+	Granted []string `json:"permissions"`
+	Revoked []string `json:"revokedPermissions"`
+}
+
+func (opts *UpdateUserPermissionsOptions) validate() error {
+	granted, err := validatePermissions(opts.Granted)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := validatePermissions(opts.Revoked)
+	if err != nil {
+		return err
+	}
+
+	for _, perm := range granted {
+		revoked = removePermission(revoked, perm)
+	}
+
+	opts.Granted, opts.Revoked = granted, revoked
+	return nil
+}
+
 // This function simply calls UpdateUserPermissionsEx with the global Write connection.
-func UpdateUserPermissions(user *modext.User, permissions []string) ([]string, error) {
-	return UpdateUserPermissionsEx(WriteDB, user, permissions)
+func UpdateUserPermissions(user *modext.User, opts UpdateUserPermissionsOptions, actor *modext.User) ([]string, error) {
+	return UpdateUserPermissionsEx(WriteDB, user, opts, actor)
 }
 
-// UpdateUserPermissionsEx updates the permissions of the given user.
-// Returns the updated permissions of the user.
-func UpdateUserPermissionsEx(e boil.Executor, user *modext.User, permissions []string) ([]string, error) {
-	u := user.ToModel()
-	u.Permissions = permissions
-	user.Permissions = u.Permissions
+// UpdateUserPermissionsEx updates the permissions granted to and revoked from
+// the given user, on top of the permissions of its roles.
+// Returns the updated effective permissions of the user.
+func UpdateUserPermissionsEx(e boil.Executor, user *modext.User, opts UpdateUserPermissionsOptions, actor *modext.User) ([]string, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	before := userPermissionsAudit(user)
+	user.GrantedPermissions = opts.Granted
+	user.RevokedPermissions = opts.Revoked
+	user.ResolvePermissions()
 
-	if err := u.Update(e, boil.Whitelist(UserCols.Permissions, UserCols.UpdatedAt)); err != nil {
+	u := user.ToModel()
+	if err := u.Update(e, boil.Whitelist(UserCols.Permissions, UserCols.RevokedPermissions, UserCols.UpdatedAt)); err != nil {
 		log.Println(err)
 		return nil, errs.ErrUnknown
 	}
 
-	return permissions, nil
+	// The user has to log in again for the new permissions to apply.
+	if err := revokeUserSessions(user.ID); err != nil {
+		log.Println(err)
+	}
+
+	recordAudit(actor, AuditUpdatePermissions, AuditTargetUser, user.ID, before, userPermissionsAudit(user))
+	return user.Permissions, nil
+}
+
+// userPermissionsAudit gets the permissions of the given user recorded in the audit log.
+func userPermissionsAudit(user *modext.User) map[string][]string {
+	return map[string][]string{
+		"permissions":        user.Permissions,
+		"grantedPermissions": user.GrantedPermissions,
+		"revokedPermissions": user.RevokedPermissions,
+	}
 }
 
 // This function simply calls DeleteUserEx with the global Write connection.
-func DeleteUser(user *modext.User) error {
-	return DeleteUserEx(WriteDB, user)
+func DeleteUser(user *modext.User, actor *modext.User) error {
+	return DeleteUserEx(WriteDB, user, actor)
 }
 
 // DeleteUserEx deletes the given user.
-func DeleteUserEx(e boil.Executor, user *modext.User) error {
-	if err := user.ToModel().Delete(e); err != nil {
+func DeleteUserEx(e boil.Executor, user *modext.User, actor *modext.User) error {
+	if err := user.ToModel().Delete(e, true); err != nil {
 		log.Println(err)
 		return errs.ErrUnknown
 	}
 
+	// The actor no longer exists if users deleted themselves.
+	if actor != nil && actor.ID == user.ID {
+		a := *actor
+		a.ID = 0
+		actor = &a
+	}
+
+	if err := revokeUserSessions(user.ID); err != nil {
+		log.Println(err)
+	}
+
+	recordAudit(actor, AuditDelete, AuditTargetUser, user.ID, user, nil)
 	return nil
 }
 
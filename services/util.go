@@ -2,6 +2,8 @@ package services
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,7 +13,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -19,12 +21,14 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	. "kasen/cache"
 	. "kasen/database"
 
 	"kasen/config"
 	"kasen/errs"
+	"kasen/internal/resize"
 	"kasen/models"
 	"kasen/modext"
 
@@ -101,6 +105,49 @@ func getPageNum(fileName string) int {
 	return n
 }
 
+// naturalLess compares the given strings in natural order,
+// sequences of digits are compared by their numeric values.
+//
+// e.g. "page2.jpg" < "page10.jpg"
+func naturalLess(a, b string) bool {
+	for len(a) > 0 && len(b) > 0 {
+		da, db := isDigit(a[0]), isDigit(b[0])
+		if da && db {
+			i, j := digitsLen(a), digitsLen(b)
+			na, nb := strings.TrimLeft(a[:i], "0"), strings.TrimLeft(b[:j], "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			} else if na != nb {
+				return na < nb
+			} else if i != j {
+				return i < j
+			}
+			a, b = a[i:], b[j:]
+			continue
+		}
+
+		ca, cb := unicode.ToLower(rune(a[0])), unicode.ToLower(rune(b[0]))
+		if ca != cb {
+			return ca < cb
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// digitsLen returns the length of the leading sequence of digits.
+func digitsLen(s string) int {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
 // makeCacheKey creates a cache key.
 //
 // This function simply calls json.Marshal on the given object
@@ -127,10 +174,29 @@ func stringsContains(slice []string, search string) bool {
 	return false
 }
 
+// randomBytes generates n random bytes.
+func randomBytes(n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// randomString generates a random URL safe string of n bytes.
+func randomString(n int) (string, error) {
+	buf, err := randomBytes(n)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
 type ResizeOptions struct {
 	Width  int
 	Height int
 	Crop   bool
+	Format string
 }
 
 var resizer struct {
@@ -139,16 +205,49 @@ var resizer struct {
 	sync.Once
 }
 
+// imagePool is the pool of image workers which do the actual resizing,
+// it's nil until StartImageWorkers is called.
+var imagePool *resize.Pool
+
+var errImageWorkersNotStarted = errors.New("Image workers are not started")
+
 func init() {
 	resizer.Map = make(map[string]*sync.Mutex)
 }
 
-func resizeImage(filepath, outputPath string, o ResizeOptions) error {
+var startImageWorkersOnce sync.Once
+
+// StartImageWorkers starts the worker processes which resize the images.
+func StartImageWorkers() {
+	startImageWorkersOnce.Do(func() {
+		cfg := config.GetImage()
+		imagePool = resize.NewPool(resize.PoolOptions{
+			Path:      getImageBinPath(),
+			Workers:   cfg.Workers,
+			QueueSize: cfg.QueueSize,
+			MaxRSS:    cfg.MaxRSS,
+			Timeout:   cfg.Timeout,
+		})
+	})
+}
+
+// imageFormats gets the output formats supported by the image workers,
+// only JPEG is supported until they're started.
+func imageFormats() []string {
+	if imagePool == nil {
+		return []string{resize.FormatJPEG}
+	}
+	return imagePool.Formats()
+}
+
+// resizeImage resizes the image stored under the given key
+// and stores the result under the output key.
+func resizeImage(key, outputKey string, o ResizeOptions) error {
 	resizer.Lock()
-	mu, ok := resizer.Map[outputPath]
+	mu, ok := resizer.Map[outputKey]
 	if !ok {
 		mu = &sync.Mutex{}
-		resizer.Map[outputPath] = mu
+		resizer.Map[outputKey] = mu
 	}
 	resizer.Unlock()
 
@@ -157,31 +256,131 @@ func resizeImage(filepath, outputPath string, o ResizeOptions) error {
 		mu.Unlock()
 
 		resizer.Lock()
-		delete(resizer.Map, outputPath)
+		delete(resizer.Map, outputKey)
 		resizer.Unlock()
 	}()
 
 	if ok {
 		return nil
+	} else if imagePool == nil {
+		return errImageWorkersNotStarted
 	}
 
-	w := strconv.Itoa(o.Width)
-	h := strconv.Itoa(o.Height)
-	crop := strconv.FormatBool(o.Crop)
+	f, cleanup, err := openObjectFile(key)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
 
-	// This is a workaround for vips consuming too much memory.
-	//
-	// Another todo is to use grpc or websocket to communicate with
-	// the vips process instead of spawning a new process for every
-	// resize, and restart the process if it consumes too much memory.
+	// Resizing is done by long-lived worker processes instead of vips
+	// in this process, as vips consumes too much memory and never gives
+	// it back. Workers are restarted once they exceed max_rss.
 	//
 	// Using another library such as imaging is not an option
 	// because they're too slow.
-	buf, err := runCommand(getImageBinPath(), filepath, w, h, crop)
+	buf, err := imagePool.Do(&resize.Request{
+		Input:  f.Name(),
+		Width:  o.Width,
+		Height: o.Height,
+		Crop:   o.Crop,
+		Format: o.Format,
+	})
 	if err != nil {
 		return err
 	}
-	return WriteFile(outputPath, buf.Bytes())
+	return store.Put(outputKey, bytes.NewReader(buf), int64(len(buf)))
+}
+
+// negotiateImageFormat picks the output format of resized images
+// from the given Accept header.
+//
+// AVIF is preferred over WebP, and JPEG is used if neither of them
+// is accepted by the client or supported by the image workers.
+func negotiateImageFormat(accept string) string {
+	return pickImageFormat(accept, imageFormats())
+}
+
+// pickImageFormat picks the preferred format among the given formats
+// which is explicitly accepted by the given Accept header. Wildcards
+// are ignored, since clients which send them may not support AVIF or
+// WebP.
+func pickImageFormat(accept string, formats []string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+
+		q := 1.0
+		for _, param := range params[1:] {
+			if kv := strings.SplitN(strings.TrimSpace(param), "=", 2); len(kv) == 2 && kv[0] == "q" {
+				q, _ = strconv.ParseFloat(kv[1], 64)
+			}
+		}
+		accepted[mediaType] = q > 0
+	}
+
+	for _, format := range []string{resize.FormatAVIF, resize.FormatWebP} {
+		if accepted["image/"+format] && stringsContains(formats, format) {
+			return format
+		}
+	}
+	return resize.FormatJPEG
+}
+
+// isValidImageWidth checks if images can be resized to the given width.
+func isValidImageWidth(width int) bool {
+	return width > 0 && width <= 1024 && width%64 == 0
+}
+
+// imageVariantKey gets the storage key of the resized variant of the given image.
+func imageVariantKey(key string, o ResizeOptions) string {
+	return fmt.Sprintf("%s.%d.%s", key, o.Width, resize.Extensions[o.Format])
+}
+
+// pregenerateImage creates the resized variants of the given image
+// in every output format, so that they don't have to be created
+// when they're requested for the first time.
+func pregenerateImage(key string, o ResizeOptions) error {
+	for _, format := range imageFormats() {
+		o.Format = format
+		variant := imageVariantKey(key, o)
+
+		if ok, err := objectExists(variant); err != nil {
+			return err
+		} else if ok {
+			continue
+		}
+
+		if err := resizeImage(key, variant, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serveImage serves the image stored under the given key.
+//
+// If the width is valid, a resized variant in the format negotiated
+// from the Accept header is served instead. Variants are created on
+// the first request and stored next to the image, under the key
+// "<key>.<width>.<extension>".
+func serveImage(key string, o ResizeOptions, w http.ResponseWriter, r *http.Request) {
+	if isValidImageWidth(o.Width) {
+		o.Format = negotiateImageFormat(r.Header.Get("Accept"))
+		w.Header().Add("Vary", "Accept")
+
+		original := key
+		key = imageVariantKey(key, o)
+
+		if ok, _ := objectExists(key); !ok {
+			if err := resizeImage(original, key, o); err != nil {
+				log.Println(err)
+				key = original
+			}
+		}
+	}
+
+	serveObject(key, w, r)
 }
 
 // sanitizeOrder sanitizes the given order.
@@ -322,7 +521,13 @@ func formatChapterModel(c *models.Chapter, short ...interface{}) string {
 //
 // The project's directory and its subdirectories, and the symlink
 // which points to it, will be removed when the project is deleted.
+//
+// Directories are only maintained for the local storage.
 func createProjectDir(p *models.Project) error {
+	if !isLocalStorage() {
+		return nil
+	}
+
 	dir := getProjectDir(p.Slug)
 	if err := MkdirAll(filepath.Join(dir, "chapters")); err != nil {
 		return errors.Wrap(err, "failed to create chapters directory")
@@ -342,7 +547,21 @@ func createProjectDir(p *models.Project) error {
 
 // removeProjectDir removes the project directory and its
 // subdirectories. It also removes the symlink which points to it.
+//
+// For other storages, the covers and the pages of the chapters
+// loaded in p.R.Chapters are removed instead.
 func removeProjectDir(p *models.Project) error {
+	if !isLocalStorage() {
+		if p.R != nil {
+			for _, c := range p.R.Chapters {
+				if err := removeObjects(chapterKey(c.ID, "")); err != nil {
+					return errors.Wrap(err, "failed to remove chapter files")
+				}
+			}
+		}
+		return errors.Wrap(removeObjects(coverKey(p.ID, "")), "failed to remove cover files")
+	}
+
 	dir := getProjectDir(p.Slug)
 	sDir := GetChaptersSymlinksDir()
 
@@ -389,7 +608,7 @@ func removeProjectDir(p *models.Project) error {
 // The chapter's directory and the symlink which points to it will
 // be removed when the chapter is deleted.
 func createChapterDir(c *models.Chapter) error {
-	if c.ID <= 0 {
+	if c.ID <= 0 || !isLocalStorage() {
 		return nil
 	}
 
@@ -427,7 +646,7 @@ func createChapterDir(c *models.Chapter) error {
 // The chapter's directory and the symlink which points to it will
 // be removed when the chapter is deleted.
 func renameChapterDir(c *models.Chapter) error {
-	if c.ID <= 0 {
+	if c.ID <= 0 || !isLocalStorage() {
 		return nil
 	}
 
@@ -465,6 +684,10 @@ func renameChapterDir(c *models.Chapter) error {
 // removeChapterDir removes the directory of the given chapter.
 // It also removes the symlink which points to it.
 func removeChapterDir(c *models.Chapter) error {
+	if !isLocalStorage() {
+		return removeObjects(chapterKey(c.ID, ""))
+	}
+
 	symlink := getChapterSymlink(c.ID)
 
 	dir, err := os.Readlink(symlink)
@@ -487,6 +710,10 @@ func removeChapterDir(c *models.Chapter) error {
 // The project's directory and the symlink which points to it will
 // be removed when the project is deleted.
 func renameProjectDir(p *models.Project) error {
+	if !isLocalStorage() {
+		return nil
+	}
+
 	oldDir, err := getProjectDirByID(p.ID)
 	if err != nil {
 		return err
@@ -594,20 +821,17 @@ func removeCoverFiles(c *models.Cover) error {
 		return nil
 	}
 
-	dir, err := getProjectDirByID(c.ProjectID)
+	objects, err := store.List(coverKey(c.ProjectID, ""))
 	if err != nil {
 		return err
-	} else if _, err := os.Stat(dir); os.IsNotExist(err) {
-		return err
 	}
 
-	walkFn := func(p string, d fs.DirEntry, e error) error {
-		if !d.IsDir() && strings.Contains(p, c.FileName) {
-			os.Remove(p)
+	for _, o := range objects {
+		if strings.Contains(path.Base(o.Key), c.FileName) {
+			store.Delete(o.Key)
 		}
-		return e
 	}
-	return filepath.WalkDir(filepath.Join(dir, "covers"), walkFn)
+	return nil
 }
 
 func refreshTemplatesCache() {
@@ -748,6 +972,10 @@ var remapSymlinksState bool
 // Use this when symbolic links are broken or when the working
 // directory or data directory has been changed.
 func RemapSymlinks() error {
+	if !isLocalStorage() {
+		return nil
+	}
+
 	remapSymlinksMutex.Lock()
 	state := remapSymlinksState
 	remapSymlinksMutex.Unlock()
@@ -822,28 +1050,6 @@ func RemapSymlinks() error {
 	return nil
 }
 
-// runCommand runs the given command and returns the output.
-func runCommand(path string, args ...string) (*bytes.Buffer, error) {
-	cmd := exec.Command(path, args...)
-	cmd.Env = os.Environ()
-
-	var buf bytes.Buffer
-	var stderr bytes.Buffer
-
-	cmd.Stdout = &buf
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return nil, err
-	}
-
-	if err := stderr.String(); len(err) > 0 {
-		return nil, errors.New(err)
-	}
-
-	return &buf, nil
-}
-
 type Pagination struct {
 	CurrentPage int
 	Pages       []int
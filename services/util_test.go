@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"kasen/internal/resize"
+)
+
+func TestPickImageFormat(t *testing.T) {
+	all := []string{resize.FormatJPEG, resize.FormatWebP, resize.FormatAVIF}
+	noAVIF := []string{resize.FormatJPEG, resize.FormatWebP}
+
+	tests := []struct {
+		name    string
+		accept  string
+		formats []string
+		want    string
+	}{
+		{"empty", "", all, resize.FormatJPEG},
+		{"browser", "image/avif,image/webp,image/apng,image/*,*/*;q=0.8", all, resize.FormatAVIF},
+		{"webp only", "image/webp,*/*", all, resize.FormatWebP},
+		{"image wildcard", "image/*", all, resize.FormatJPEG},
+		{"any wildcard", "*/*", all, resize.FormatJPEG},
+		{"avif unsupported", "image/avif,image/webp", noAVIF, resize.FormatWebP},
+		{"jpeg only supported", "image/avif,image/webp", []string{resize.FormatJPEG}, resize.FormatJPEG},
+		{"avif preferred over q-values", "image/avif;q=0.5,image/webp;q=1", all, resize.FormatAVIF},
+		{"avif refused", "image/avif;q=0,image/webp", all, resize.FormatWebP},
+		{"avif refused with spaces", "image/avif ; q=0 , image/webp", all, resize.FormatWebP},
+		{"both refused", "image/avif;q=0,image/webp;q=0.0,image/*", all, resize.FormatJPEG},
+		{"invalid q-value refuses", "image/avif;q=abc,image/webp", all, resize.FormatWebP},
+		{"case insensitive", "Image/AVIF", all, resize.FormatAVIF},
+		{"other parameters", "image/webp;charset=utf-8", all, resize.FormatWebP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickImageFormat(tt.accept, tt.formats); got != tt.want {
+				t.Errorf("pickImageFormat(%q, %v) = %q, want %q", tt.accept, tt.formats, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegotiateImageFormatWithoutWorkers(t *testing.T) {
+	if imagePool != nil {
+		t.Skip("image workers are started")
+	}
+	if got := negotiateImageFormat("image/avif,image/webp"); got != resize.FormatJPEG {
+		t.Errorf("negotiateImageFormat() = %q, want %q", got, resize.FormatJPEG)
+	}
+}
+
+func TestNaturalLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"page2.jpg", "page10.jpg", true},
+		{"page10.jpg", "page2.jpg", false},
+		{"1.png", "1.png", false},
+		{"2.png", "10.png", true},
+		{"02.png", "10.png", true},
+		{"01.png", "1.png", false},
+		{"1.png", "01.png", true},
+		{"a.png", "B.png", true},
+		{"B.png", "a.png", false},
+		{"chapter 1/10.png", "chapter 2/1.png", true},
+		{"1", "1.png", true},
+		{"", "1.png", true},
+		{"99999999999999999999.png", "100000000000000000000.png", true},
+	}
+
+	for _, tt := range tests {
+		if got := naturalLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("naturalLess(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,578 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	. "kasen/database"
+
+	"kasen/errs"
+	"kasen/models"
+	"kasen/modext"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var WebhookCols = models.WebhookColumns
+var WebhookDeliveryCols = models.WebhookDeliveryColumns
+
+// Webhook events.
+const (
+	WebhookChapterPublished   = "chapter.published"
+	WebhookChapterUnpublished = "chapter.unpublished"
+	WebhookProjectCreated     = "project.created"
+	WebhookProjectUpdated     = "project.updated"
+	WebhookCoverChanged       = "cover.changed"
+	WebhookUserLocked         = "user.locked"
+)
+
+var WebhookEvents = []string{
+	WebhookChapterPublished,
+	WebhookChapterUnpublished,
+	WebhookProjectCreated,
+	WebhookProjectUpdated,
+	WebhookCoverChanged,
+	WebhookUserLocked,
+}
+
+// Webhook delivery statuses.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Headers sent with every delivery, the signature is the HMAC-SHA256
+// of the body using the secret of the webhook, as "sha256=<hex>".
+const (
+	WebhookEventHeader     = "X-Kasen-Event"
+	WebhookDeliveryHeader  = "X-Kasen-Delivery"
+	WebhookSignatureHeader = "X-Kasen-Signature"
+)
+
+// webhookResponseMaxLength is the number of bytes of the response
+// body kept in the delivery log.
+const webhookResponseMaxLength = 4096
+
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// WebhookDraft represents the draft of a webhook.
+type WebhookDraft struct {
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret"`
+	Events      []string `json:"events"`
+	Active      *bool    `json:"active"`
+	Description string   `json:"description"`
+}
+
+func (draft *WebhookDraft) validate() error {
+	draft.URL = strings.TrimSpace(draft.URL)
+	draft.Secret = strings.TrimSpace(draft.Secret)
+	draft.Description = strings.TrimSpace(draft.Description)
+
+	u, err := url.Parse(draft.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 || len(draft.URL) > 2048 {
+		return errs.ErrWebhookURLInvalid
+	}
+
+	if len(draft.Secret) > 128 {
+		return errs.ErrWebhookSecretTooLong
+	}
+
+	if utf8.RuneCountInString(draft.Description) > 256 {
+		return errs.ErrWebhookDescriptionTooLong
+	}
+
+	if len(draft.Events) == 0 {
+		return errs.ErrWebhookEventsRequired
+	}
+
+	var events []string
+	for _, event := range draft.Events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !stringsContains(WebhookEvents, event) {
+			return errs.ErrWebhookEventInvalid
+		}
+		if !stringsContains(events, event) {
+			events = append(events, event)
+		}
+	}
+	draft.Events = events
+
+	return nil
+}
+
+// newWebhookSecret generates a random secret.
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// This function simply calls CreateWebhookEx with the global Write connection.
+func CreateWebhook(draft WebhookDraft, user *modext.User) (*modext.Webhook, error) {
+	return CreateWebhookEx(WriteDB, draft, user)
+}
+
+// CreateWebhookEx creates a new webhook, a secret is generated
+// if none is given. The secret is only returned here and after
+// it has been updated.
+func CreateWebhookEx(e boil.Executor, draft WebhookDraft, user *modext.User) (*modext.Webhook, error) {
+	if err := draft.validate(); err != nil {
+		return nil, err
+	}
+
+	if len(draft.Secret) == 0 {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			log.Println(err)
+			return nil, errs.ErrUnknown
+		}
+		draft.Secret = secret
+	}
+
+	w := &models.Webhook{
+		URL:         draft.URL,
+		Secret:      draft.Secret,
+		Events:      draft.Events,
+		Active:      draft.Active == nil || *draft.Active,
+		Description: null.NewString(draft.Description, len(draft.Description) > 0),
+	}
+
+	if err := w.Insert(e, boil.Infer()); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditCreate, AuditTargetWebhook, w.ID, nil, modext.NewWebhook(w))
+	return modext.NewWebhook(w).LoadSecret(w), nil
+}
+
+// This function simply calls GetWebhooksEx with the global Read connection.
+func GetWebhooks() ([]*modext.Webhook, error) {
+	return GetWebhooksEx(ReadDB)
+}
+
+// GetWebhooksEx gets all webhooks, results are sorted by id in ascending order.
+func GetWebhooksEx(e boil.Executor) ([]*modext.Webhook, error) {
+	webhooks, err := models.Webhooks(OrderBy("id ASC")).All(e)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	result := make([]*modext.Webhook, len(webhooks))
+	for i, w := range webhooks {
+		result[i] = modext.NewWebhook(w)
+	}
+	return result, nil
+}
+
+// findWebhook finds the webhook of the given id.
+func findWebhook(e boil.Executor, id int64) (*models.Webhook, error) {
+	w, err := models.FindWebhook(e, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrWebhookNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return w, nil
+}
+
+// This function simply calls GetWebhookEx with the global Read connection.
+func GetWebhook(id int64) (*modext.Webhook, error) {
+	return GetWebhookEx(ReadDB, id)
+}
+
+// GetWebhookEx gets a webhook.
+func GetWebhookEx(e boil.Executor, id int64) (*modext.Webhook, error) {
+	w, err := findWebhook(e, id)
+	if err != nil {
+		return nil, err
+	}
+	return modext.NewWebhook(w), nil
+}
+
+// This function simply calls UpdateWebhookEx with the global Write connection.
+func UpdateWebhook(id int64, draft WebhookDraft, user *modext.User) (*modext.Webhook, error) {
+	return UpdateWebhookEx(WriteDB, id, draft, user)
+}
+
+// UpdateWebhookEx updates a webhook, the secret is kept if none is given.
+func UpdateWebhookEx(e boil.Executor, id int64, draft WebhookDraft, user *modext.User) (*modext.Webhook, error) {
+	if err := draft.validate(); err != nil {
+		return nil, err
+	}
+
+	w, err := findWebhook(e, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := modext.NewWebhook(w)
+	w.URL = draft.URL
+	w.Events = draft.Events
+	w.Description = null.NewString(draft.Description, len(draft.Description) > 0)
+
+	if draft.Active != nil {
+		w.Active = *draft.Active
+	}
+
+	if len(draft.Secret) > 0 {
+		w.Secret = draft.Secret
+	}
+
+	if err := w.Update(e, boil.Infer()); err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	webhook := modext.NewWebhook(w)
+	recordAudit(user, AuditUpdate, AuditTargetWebhook, w.ID, before, webhook)
+
+	if len(draft.Secret) > 0 {
+		webhook.LoadSecret(w)
+	}
+	return webhook, nil
+}
+
+// This function simply calls DeleteWebhookEx with the global Write connection.
+func DeleteWebhook(id int64, user *modext.User) error {
+	return DeleteWebhookEx(WriteDB, id, user)
+}
+
+// DeleteWebhookEx deletes a webhook and its delivery log.
+func DeleteWebhookEx(e boil.Executor, id int64, user *modext.User) error {
+	w, err := findWebhook(e, id)
+	if err != nil {
+		return err
+	}
+
+	if err := w.Delete(e); err != nil {
+		log.Println(err)
+		return errs.ErrUnknown
+	}
+
+	recordAudit(user, AuditDelete, AuditTargetWebhook, w.ID, modext.NewWebhook(w), nil)
+	return nil
+}
+
+// GetWebhookDeliveriesOptions represents the options for getting
+// the deliveries of a webhook.
+type GetWebhookDeliveriesOptions struct {
+	Event  string `form:"event"`
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+func (opts *GetWebhookDeliveriesOptions) validate() {
+	opts.Event = strings.ToLower(opts.Event)
+	opts.Status = strings.ToLower(opts.Status)
+
+	if opts.Limit <= 0 || opts.Limit > 100 {
+		opts.Limit = 100
+	}
+
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+}
+
+// GetWebhookDeliveriesResult represents the result of GetWebhookDeliveries.
+type GetWebhookDeliveriesResult struct {
+	Deliveries []*modext.WebhookDelivery `json:"data"`
+	Total      int64                     `json:"total"`
+	Err        error                     `json:"error,omitempty"`
+}
+
+// This function simply calls GetWebhookDeliveriesEx with the global Read connection.
+func GetWebhookDeliveries(id int64, opts GetWebhookDeliveriesOptions) *GetWebhookDeliveriesResult {
+	return GetWebhookDeliveriesEx(ReadDB, id, opts)
+}
+
+// GetWebhookDeliveriesEx gets the deliveries of a webhook,
+// ordered from the most recent.
+func GetWebhookDeliveriesEx(e boil.Executor, id int64, opts GetWebhookDeliveriesOptions) *GetWebhookDeliveriesResult {
+	opts.validate()
+
+	result := &GetWebhookDeliveriesResult{}
+	if _, err := findWebhook(e, id); err != nil {
+		result.Err = err
+		return result
+	}
+
+	queries := []QueryMod{Where("webhook_id = ?", id)}
+	if len(opts.Event) > 0 {
+		queries = append(queries, Where("event = ?", opts.Event))
+	}
+
+	if len(opts.Status) > 0 {
+		queries = append(queries, Where("status = ?", opts.Status))
+	}
+
+	total, err := models.WebhookDeliveries(queries...).Count(e)
+	if err != nil {
+		log.Println(err)
+		result.Err = errs.ErrUnknown
+		return result
+	}
+
+	queries = append(queries,
+		OrderBy(fmt.Sprintf("%s DESC", WebhookDeliveryCols.ID)),
+		Limit(opts.Limit),
+		Offset(opts.Offset))
+
+	deliveries, err := models.WebhookDeliveries(queries...).All(e)
+	if err != nil {
+		log.Println(err)
+		result.Err = errs.ErrUnknown
+		return result
+	}
+
+	result.Total = total
+	result.Deliveries = make([]*modext.WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		result.Deliveries[i] = modext.NewWebhookDelivery(d)
+	}
+	return result
+}
+
+// This function simply calls RedeliverWebhookEx with the global Write connection.
+func RedeliverWebhook(id, deliveryID int64) (*modext.WebhookDelivery, error) {
+	return RedeliverWebhookEx(WriteDB, id, deliveryID)
+}
+
+// RedeliverWebhookEx sends the payload of a previous delivery again,
+// as a new delivery.
+func RedeliverWebhookEx(e boil.Executor, id, deliveryID int64) (*modext.WebhookDelivery, error) {
+	d, err := models.WebhookDeliveries(
+		Where("id = ?", deliveryID),
+		Where("webhook_id = ?", id),
+	).One(e)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.ErrWebhookDeliveryNotFound
+		}
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+
+	delivery, err := createWebhookDelivery(e, id, d.Event, d.Payload)
+	if err != nil {
+		log.Println(err)
+		return nil, errs.ErrUnknown
+	}
+	return modext.NewWebhookDelivery(delivery), nil
+}
+
+// WebhookPayload represents the body of a delivery.
+type WebhookPayload struct {
+	Event     string      `json:"event"`
+	CreatedAt int64       `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
+
+// DeliverWebhookPayload represents the payload of JobDeliverWebhook.
+type DeliverWebhookPayload struct {
+	DeliveryID int64 `json:"deliveryId"`
+}
+
+// createWebhookDelivery logs a new delivery and creates the job
+// which delivers it.
+func createWebhookDelivery(e boil.Executor, id int64, event string, payload []byte) (*models.WebhookDelivery, error) {
+	d := &models.WebhookDelivery{
+		WebhookID: id,
+		Event:     event,
+		Payload:   payload,
+		Status:    WebhookDeliveryPending,
+	}
+
+	if err := d.Insert(e, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	if _, err := CreateJobEx(e, JobDeliverWebhook, &DeliverWebhookPayload{d.ID}, nil); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// dispatchWebhookEvent delivers the given event to the active webhooks
+// subscribed to it, failures are only logged.
+func dispatchWebhookEvent(event string, data interface{}) {
+	webhooks, err := models.Webhooks(
+		Where("active = TRUE"),
+		Where("? = ANY(events)", event),
+	).All(ReadDB)
+	if err != nil {
+		log.Println(err)
+		return
+	} else if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(&WebhookPayload{
+		Event:     event,
+		CreatedAt: time.Now().Unix(),
+		Data:      data,
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, w := range webhooks {
+		if _, err := createWebhookDelivery(WriteDB, w.ID, event, payload); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// dispatchChapterWebhookEvent delivers the given event with the chapter,
+// including its project and scanlation groups.
+func dispatchChapterWebhookEvent(event string, id int64) {
+	c, err := models.Chapters(
+		Where("id = ?", id),
+		Load(ChapterRels.Project),
+		Load(ChapterRels.ScanlationGroups),
+	).One(ReadDB)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	chapter := modext.NewChapter(c)
+	chapter.LoadProject(c)
+	chapter.LoadScanlationGroups(c)
+	dispatchWebhookEvent(event, chapter)
+}
+
+// dispatchProjectWebhookEvent delivers the given event with the project,
+// including its artists, authors, tags and cover.
+func dispatchProjectWebhookEvent(event string, id int64) {
+	p, err := models.Projects(
+		Where("id = ?", id),
+		Load(ProjectRels.Artists),
+		Load(ProjectRels.Authors),
+		Load(ProjectRels.Tags),
+		Load(ProjectRels.Cover),
+	).One(ReadDB)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	dispatchWebhookEvent(event, modext.NewProject(p).LoadRels(p))
+}
+
+// signWebhookPayload signs the given payload with the given secret.
+func signWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliverWebhook sends a delivery to its webhook, server errors
+// and network errors are retried.
+func deliverWebhook(j *Job) error {
+	payload := &DeliverWebhookPayload{}
+	if err := j.Bind(payload); err != nil {
+		return permanent(errs.ErrInvalidJobPayload)
+	}
+
+	d, err := models.WebhookDeliveries(
+		Where("id = ?", payload.DeliveryID),
+		Load(models.WebhookDeliveryRels.Webhook),
+	).One(ReadDB)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return permanent(errs.ErrWebhookDeliveryNotFound)
+		}
+		return err
+	}
+
+	w := d.R.Webhook
+	if !w.Active {
+		d.Status = WebhookDeliveryFailed
+		d.Error = null.StringFrom("Webhook is inactive")
+		if err := d.Update(WriteDB, boil.Whitelist(WebhookDeliveryCols.Status, WebhookDeliveryCols.Error, WebhookDeliveryCols.UpdatedAt)); err != nil {
+			log.Println(err)
+		}
+		return nil
+	}
+
+	d.Attempts++
+	d.ResponseStatus = null.Int{}
+	d.ResponseBody = null.String{}
+	d.Error = null.String{}
+
+	deliveryErr := sendWebhookDelivery(j, w, d)
+	if deliveryErr == nil {
+		d.Status = WebhookDeliveryDelivered
+		d.DeliveredAt = null.TimeFrom(time.Now().UTC())
+	} else {
+		d.Status = WebhookDeliveryFailed
+		d.Error = null.StringFrom(deliveryErr.Error())
+	}
+
+	if err := d.Update(WriteDB, boil.Infer()); err != nil {
+		log.Println(err)
+	}
+	return deliveryErr
+}
+
+// sendWebhookDelivery posts the payload of the delivery, and records
+// the response into it.
+func sendWebhookDelivery(j *Job, w *models.Webhook, d *models.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(j.Context(), http.MethodPost, w.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return permanent(err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Kasen-Webhook")
+	req.Header.Set(WebhookEventHeader, d.Event)
+	req.Header.Set(WebhookDeliveryHeader, fmt.Sprint(d.ID))
+	req.Header.Set(WebhookSignatureHeader, signWebhookPayload(w.Secret, d.Payload))
+
+	res, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, webhookResponseMaxLength))
+	d.ResponseStatus = null.IntFrom(res.StatusCode)
+	d.ResponseBody = null.StringFrom(strings.ToValidUTF8(string(body), ""))
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	err = errors.Errorf("Unexpected status code %d", res.StatusCode)
+	if res.StatusCode >= 400 && res.StatusCode < 500 &&
+		res.StatusCode != http.StatusRequestTimeout && res.StatusCode != http.StatusTooManyRequests {
+		return permanent(err)
+	}
+	return err
+}
@@ -66,13 +66,19 @@ func setupUser() {
 			params.RawPassword = defaultPassword
 		}
 
+		params.EmailVerified = true
 		user, err := services.CreateUser(params)
 		if err != nil {
 			log.Println("unable to create account:", err.Error())
 			continue
 		}
 
-		if _, err := services.UpdateUserPermissions(user, constants.Perms); err != nil {
+		role, err := services.GetRoleByName(constants.RoleAdmin)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		if _, err := services.UpdateUserRoles(user, []int64{role.ID}, nil); err != nil {
 			log.Fatalln(err)
 		}
 		break
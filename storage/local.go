@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Local is a storage backed by the local filesystem.
+type Local struct {
+	Root string
+}
+
+// NewLocal creates a local storage rooted at the given directory.
+func NewLocal(root string) *Local {
+	return &Local{Root: root}
+}
+
+func (s *Local) path(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.Root, filepath.FromSlash(key)), nil
+}
+
+// Put writes the content of r to a temporary file and then
+// renames it, so that readers never see a partially written file.
+func (s *Local) Put(key string, r io.Reader, _ int64) error {
+	fp, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(fp)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	} else if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return err
+	} else if err := os.Chown(tmp.Name(), os.Getuid(), os.Getgid()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), fp)
+}
+
+// Get opens the file of the given key.
+//
+// The returned reader is an *os.File, so it can be seeked
+// and its name can be used to access the file directly.
+func (s *Local) Get(key string) (io.ReadCloser, error) {
+	fp, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(fp)
+}
+
+func (s *Local) Stat(key string) (*ObjectInfo, error) {
+	fp, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := os.Stat(fp)
+	if err != nil {
+		return nil, err
+	} else if stat.IsDir() {
+		return nil, ErrNotExist
+	}
+
+	key, _ = cleanKey(key)
+	return &ObjectInfo{Key: key, Size: stat.Size(), ModTime: stat.ModTime()}, nil
+}
+
+func (s *Local) Delete(key string) error {
+	fp, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(fp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// List walks the directory of the given prefix.
+//
+// Symbolic links are resolved before walking, as chapters and projects
+// are linked into the root by their ids.
+func (s *Local) List(prefix string) ([]*ObjectInfo, error) {
+	prefix = strings.TrimPrefix(prefix, "/")
+
+	dirKey := prefix
+	if !strings.HasSuffix(prefix, "/") {
+		dirKey = filepath.ToSlash(filepath.Dir(prefix))
+		if dirKey == "." {
+			dirKey = ""
+		}
+	}
+
+	dir, err := filepath.EvalSymlinks(filepath.Join(s.Root, filepath.FromSlash(dirKey)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var result []*ObjectInfo
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		key := strings.TrimPrefix(filepath.ToSlash(filepath.Join(dirKey, rel)), "/")
+		if !strings.HasPrefix(key, prefix) || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		result = append(result, &ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result, nil
+}
+
+// Presign is not supported by the local storage,
+// files are served directly instead.
+func (s *Local) Presign(string, time.Duration) (string, error) {
+	return "", ErrNotSupported
+}
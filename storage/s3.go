@@ -0,0 +1,422 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// S3Options represents the options of an S3-compatible storage.
+type S3Options struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+
+	// PathStyle addresses the bucket as part of the path
+	// (http://host/bucket/key) instead of the host name
+	// (http://bucket.host/key), as required by MinIO and
+	// most self-hosted S3-compatible servers.
+	PathStyle bool
+}
+
+// S3 is a storage backed by an S3-compatible object storage.
+//
+// Requests are signed with AWS Signature Version 4.
+type S3 struct {
+	opts     S3Options
+	endpoint *url.URL
+	client   *http.Client
+}
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+	s3DateFormat      = "20060102"
+)
+
+// NewS3 creates an S3-compatible storage.
+func NewS3(opts S3Options) (*S3, error) {
+	if len(opts.Endpoint) == 0 || len(opts.Bucket) == 0 {
+		return nil, errors.New("S3 endpoint and bucket are required")
+	}
+
+	endpoint, err := url.Parse(opts.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid S3 endpoint")
+	} else if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, errors.New("S3 endpoint must be an http or https URL")
+	}
+
+	if len(opts.Region) == 0 {
+		opts.Region = "us-east-1"
+	}
+
+	return &S3{
+		opts:     opts,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+// objectURL returns the URL of the given key, or the URL
+// of the bucket if the key is empty.
+func (s *S3) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	if s.opts.PathStyle {
+		u.Path = "/" + path.Join(strings.Trim(u.Path, "/"), s.opts.Bucket, key)
+	} else {
+		u.Host = s.opts.Bucket + "." + u.Host
+		u.Path = "/" + path.Join(strings.Trim(u.Path, "/"), key)
+	}
+	if len(key) == 0 && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	u.RawPath = uriEncode(u.Path, false)
+	return &u
+}
+
+func (s *S3) do(method, key string, query url.Values, body io.Reader, size int64) (*http.Response, error) {
+	u := s.objectURL(key)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		// A zero content length with a body is sent as chunked,
+		// which is not supported by S3.
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+		if t := mime.TypeByExtension(path.Ext(key)); len(t) > 0 {
+			req.Header.Set("Content-Type", t)
+		}
+	}
+
+	s.sign(req, time.Now().UTC())
+	return s.client.Do(req)
+}
+
+// sign signs the given request by adding the Authorization header.
+//
+// The payload is not signed so that bodies can be streamed,
+// unless its hash is already set in the X-Amz-Content-Sha256 header.
+func (s *S3) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format(s3TimeFormat)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if len(req.Header.Get("X-Amz-Content-Sha256")) == 0 {
+		req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+	}
+
+	canonicalRequest, signedHeaders := canonicalRequest(req)
+
+	scope := s.scope(now)
+	signature := s.signature(now, s.stringToSign(amzDate, scope, canonicalRequest))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.opts.AccessKey, scope, signedHeaders, signature))
+}
+
+// canonicalRequest returns the canonical request of the given request
+// and the list of its signed headers, which are the host and every
+// header already set but the signature itself.
+func canonicalRequest(req *http.Request) (string, string) {
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		if k = strings.ToLower(k); k != "authorization" {
+			headers[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		headers["x-amz-content-sha256"],
+	}, "\n")
+	return canonicalRequest, signedHeaders
+}
+
+func (s *S3) scope(now time.Time) string {
+	return strings.Join([]string{now.Format(s3DateFormat), s.opts.Region, "s3", "aws4_request"}, "/")
+}
+
+func (s *S3) stringToSign(amzDate, scope, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	return strings.Join([]string{s3Algorithm, amzDate, scope, hex.EncodeToString(hash[:])}, "\n")
+}
+
+func (s *S3) signature(now time.Time, stringToSign string) string {
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretKey), now.Format(s3DateFormat))
+	key = hmacSHA256(key, s.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// uriEncode encodes the given string as specified by AWS,
+// every byte except the unreserved characters is percent-encoded.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// canonicalQuery encodes the given query sorted by key.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Error represents the error returned by an S3-compatible server.
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// readError reads the error from the given response.
+func readError(res *http.Response) error {
+	if res.StatusCode == http.StatusNotFound {
+		return ErrNotExist
+	}
+
+	e := &s3Error{}
+	buf, _ := io.ReadAll(io.LimitReader(res.Body, 64*1024))
+	if err := xml.Unmarshal(buf, e); err != nil || len(e.Code) == 0 {
+		return fmt.Errorf("S3 request failed with status %d", res.StatusCode)
+	}
+	return fmt.Errorf("S3 request failed with status %d: %s: %s", res.StatusCode, e.Code, e.Message)
+}
+
+func (s *S3) Put(key string, r io.Reader, size int64) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.do(http.MethodPut, key, nil, r, size)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return readError(res)
+	}
+	return nil
+}
+
+func (s *S3) Get(key string) (io.ReadCloser, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.do(http.MethodGet, key, nil, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, readError(res)
+	}
+	return res.Body, nil
+}
+
+func (s *S3) Stat(key string) (*ObjectInfo, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.do(http.MethodHead, key, nil, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, readError(res)
+	}
+
+	info := &ObjectInfo{Key: key}
+	info.Size, _ = strconv.ParseInt(res.Header.Get("Content-Length"), 10, 64)
+	info.ModTime, _ = http.ParseTime(res.Header.Get("Last-Modified"))
+	return info, nil
+}
+
+func (s *S3) Delete(key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.do(http.MethodDelete, key, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return readError(res)
+	}
+}
+
+// listResult represents the result of ListObjectsV2.
+type listResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3) List(prefix string) ([]*ObjectInfo, error) {
+	prefix = strings.TrimPrefix(prefix, "/")
+
+	var result []*ObjectInfo
+	var token string
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if len(token) > 0 {
+			query.Set("continuation-token", token)
+		}
+
+		res, err := s.do(http.MethodGet, "", query, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			err := readError(res)
+			res.Body.Close()
+			return nil, err
+		}
+
+		list := &listResult{}
+		err = xml.NewDecoder(res.Body).Decode(list)
+		res.Body.Close()
+
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to decode S3 list result")
+		}
+
+		for _, c := range list.Contents {
+			result = append(result, &ObjectInfo{Key: c.Key, Size: c.Size, ModTime: c.LastModified})
+		}
+
+		if !list.IsTruncated || len(list.NextContinuationToken) == 0 {
+			break
+		}
+		token = list.NextContinuationToken
+	}
+	return result, nil
+}
+
+// Presign creates a presigned GET URL of the given key.
+func (s *S3) Presign(key string, expires time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return s.presign(key, expires, time.Now().UTC()), nil
+}
+
+func (s *S3) presign(key string, expires time.Duration, now time.Time) string {
+	amzDate := now.Format(s3TimeFormat)
+	scope := s.scope(now)
+
+	u := s.objectURL(key)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", s.opts.AccessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		uriEncode(u.Path, false),
+		canonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.signature(now, s.stringToSign(amzDate, scope, canonicalRequest)))
+	u.RawQuery = canonicalQuery(query)
+	return u.String()
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+)
+
+// Storage is the interface that wraps the basic operations
+// of an object storage.
+//
+// Keys are slash-separated paths relative to the root of the storage,
+// e.g. "chapters/1/1-<hash>.jpg".
+type Storage interface {
+	// Put stores the content of r under the given key,
+	// replacing the existing object if any.
+	Put(key string, r io.Reader, size int64) error
+
+	// Get opens the object stored under the given key.
+	// The caller is responsible for closing the returned reader.
+	Get(key string) (io.ReadCloser, error)
+
+	// Stat returns the info of the object stored under the given key.
+	Stat(key string) (*ObjectInfo, error)
+
+	// Delete deletes the object stored under the given key.
+	// Deleting a non-existent object is not an error.
+	Delete(key string) error
+
+	// List lists the objects whose keys start with the given prefix.
+	List(prefix string) ([]*ObjectInfo, error)
+
+	// Presign returns a URL which can be used to download the object
+	// without credentials until the given duration has elapsed.
+	Presign(key string, expires time.Duration) (string, error)
+}
+
+// ObjectInfo represents the info of a stored object.
+type ObjectInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+var (
+	ErrNotExist     = fs.ErrNotExist
+	ErrNotSupported = errors.New("Operation not supported")
+	ErrInvalidKey   = errors.New("Invalid key")
+)
+
+// New creates a storage of the given driver, either "local" or "s3".
+//
+// The local storage will be rooted at the given directory,
+// the S3 options are only used by the S3 storage.
+func New(driver string, s3 S3Options, root string) (Storage, error) {
+	switch strings.ToLower(driver) {
+	case "", "local":
+		return NewLocal(root), nil
+	case "s3":
+		return NewS3(s3)
+	default:
+		return nil, errors.New("Unknown storage driver")
+	}
+}
+
+// cleanKey cleans the given key and makes sure
+// it does not escape the root of the storage.
+func cleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(path.Clean("/"+key), "/")
+	if len(key) == 0 {
+		return "", ErrInvalidKey
+	}
+	return key, nil
+}
@@ -53,12 +53,14 @@ declare interface Project {
   createdAt: number;
   updatedAt: number;
   publishedAt?: number;
+  scheduledAt?: number;
   title: string;
   description?: string;
   projectStatus: string;
   seriesStatus: string;
   demographic?: string;
   rating?: string;
+  readingDirection?: string;
   artists?: Author[];
   authors?: Author[];
   tags?: Tag[];
@@ -76,6 +78,7 @@ declare interface ProjectDraft {
   seriesStatus: string;
   demographic?: string;
   rating?: string;
+  readingDirection?: string;
   artists?: string[];
   authors?: string[];
   tags?: string[];
@@ -94,6 +97,7 @@ declare interface Chapter {
   createdAt: number;
   updatedAt: number;
   publishedAt?: number;
+  scheduledAt?: number;
   chapter: string;
   volume?: string;
   title?: string;
@@ -1,7 +1,15 @@
-import React, { memo, useContext, useRef } from "react";
-import { Book, Check, Edit, ExternalLink, Eye, EyeOff, Lock, Trash, Unlock, X } from "react-feather";
+import React, { memo, useContext, useRef, useState } from "react";
+import { Book, Check, Clock, Edit, ExternalLink, Eye, EyeOff, Lock, Trash, Unlock, X } from "react-feather";
 import { Link, useHistory } from "react-router-dom";
-import { DeleteChapter, LockChapter, PublishChapter, UnlockChapter, UnpublishChapter } from "../../../api";
+import {
+  DeleteChapter,
+  LockChapter,
+  PublishChapter,
+  ScheduleChapter,
+  UnlockChapter,
+  UnpublishChapter,
+  UnscheduleChapter
+} from "../../../api";
 import { Permission } from "../../../constants";
 import { FormatChapter, FormatUnix, HasPerms } from "../../../utils/utils";
 import { useMounted } from "../../Hooks";
@@ -28,8 +36,10 @@ const PublishStateModal = ({ entriesRef, isDeletedRef, chapter, render }: ModalP
   const mountedRef = useMounted();
   const mutex = useRef(false);
 
+  const [scheduledAt, setScheduledAt] = useState("");
+
   const setIsChangingPublishStateRef = useRef<Dispatcher<boolean>>();
-  const changePublishStateCallback = async () => {
+  const changePublishStateCallback = async (unschedule = false) => {
     if (!mountedRef.current || mutex.current || chapter.locked || isDeletedRef.current) {
       return;
     }
@@ -37,15 +47,32 @@ const PublishStateModal = ({ entriesRef, isDeletedRef, chapter, render }: ModalP
     mutex.current = true;
 
     let result: ApiResult<Chapter>;
-    if (chapter.publishedAt) result = await UnpublishChapter(chapter.id);
-    else result = await PublishChapter(chapter.id);
+    let action: string;
+    if (unschedule) {
+      result = await UnscheduleChapter(chapter.id);
+      action = "unscheduled";
+    } else if (chapter.publishedAt) {
+      result = await UnpublishChapter(chapter.id);
+      action = "unpublished";
+    } else if (scheduledAt) {
+      const unix = Math.floor(new Date(scheduledAt).getTime() / 1000);
+      result = await ScheduleChapter(chapter.id, unix);
+      action = `scheduled for ${FormatUnix(unix)}`;
+    } else {
+      result = await PublishChapter(chapter.id);
+      action = "published";
+    }
     if (!mountedRef.current) return;
 
     const { response, error } = result;
     if (response) {
       const idx = entriesRef.current.findIndex(e => e.id === chapter.id);
       if (idx >= 0) {
-        entriesRef.current[idx] = { ...entriesRef.current[idx], publishedAt: response.publishedAt };
+        entriesRef.current[idx] = {
+          ...entriesRef.current[idx],
+          publishedAt: response.publishedAt,
+          scheduledAt: response.scheduledAt
+        };
         Object.assign(entriesRef.current[idx], response);
         render();
       }
@@ -53,7 +80,7 @@ const PublishStateModal = ({ entriesRef, isDeletedRef, chapter, render }: ModalP
       toast.show(
         <>
           &apos;<b>{FormatChapter(chapter)}</b>&apos; of project &apos;<b>{chapter.project.title}</b>&apos; has been{" "}
-          {chapter.publishedAt ? "unpublished" : "published"}.
+          {action}.
         </>
       );
     }
@@ -70,16 +97,32 @@ const PublishStateModal = ({ entriesRef, isDeletedRef, chapter, render }: ModalP
         Are you sure you want to {chapter.publishedAt ? "unpublish" : "publish"} &apos;<b>{FormatChapter(chapter)}</b>
         &apos; of project &apos;<b>{chapter.project.title}</b>&apos;?
       </p>
+      {!chapter.publishedAt && (
+        <label className="schedule">
+          <small>
+            {chapter.scheduledAt
+              ? `Scheduled for ${FormatUnix(chapter.scheduledAt)}. Pick another time to reschedule, or leave empty to publish now.`
+              : "Optionally pick a time to publish it later, or leave empty to publish now."}
+          </small>
+          <input type="datetime-local" value={scheduledAt} onChange={e => setScheduledAt(e.target.value)} />
+        </label>
+      )}
       <div className="actions">
         <button className="cancel" type="button" onClick={modal.hide}>
           <X width="16" height="16" strokeWidth="3" />
           <strong>Cancel</strong>
         </button>
-        <button className="confirm" type="button" onClick={changePublishStateCallback}>
+        {!chapter.publishedAt && chapter.scheduledAt && (
+          <button className="cancel" type="button" onClick={() => changePublishStateCallback(true)}>
+            <Clock width="16" height="16" strokeWidth="3" />
+            <strong>Unschedule</strong>
+          </button>
+        )}
+        <button className="confirm" type="button" onClick={() => changePublishStateCallback()}>
           <WithSpinner width="16" height="16" dispatcherRef={setIsChangingPublishStateRef}>
             <Check width="16" height="16" strokeWidth="3" />
           </WithSpinner>
-          <strong>Confirm</strong>
+          <strong>{scheduledAt ? "Schedule" : "Confirm"}</strong>
         </button>
       </div>
     </div>
@@ -203,7 +246,7 @@ const DeleteModal = ({ isDeletedRef, chapter }: ModalProps) => {
         Are you sure you want to delete &apos;<b>{FormatChapter(chapter)}</b>
         &apos; of project &apos;<b>{chapter.project.title}</b>&apos;?
       </p>
-      <small>This chapter will be removed permanently. This action cannot be undone.</small>
+      <small>This chapter will be moved to the trash, and removed permanently once the retention period is over.</small>
       <div className="actions">
         <button className="cancel" type="button" onClick={modal.hide}>
           <X width="16" height="16" strokeWidth="3" />
@@ -233,7 +276,7 @@ export const Entry = ({
 
   const { user } = useContext(ManageContext);
   const { project, uploader, scanlationGroups } = chapter;
-  const { id, locked, createdAt, updatedAt, publishedAt } = chapter;
+  const { id, locked, createdAt, updatedAt, publishedAt, scheduledAt } = chapter;
 
   const isDeletedRef = useRef(false);
   const modalProps = { entriesRef, isDeletedRef, chapter, render };
@@ -282,7 +325,7 @@ export const Entry = ({
           </Link>
         </div>
         <h3 className="title">
-          {!publishedAt && <span className="status">Draft</span>}
+          {!publishedAt && <span className="status">{scheduledAt ? "Scheduled" : "Draft"}</span>}
           {locked && <span className="status">Locked</span>}
           {FormatChapter(chapter)}
         </h3>
@@ -305,6 +348,15 @@ export const Entry = ({
               </span>
             </>
           )}
+          {!publishedAt && scheduledAt && (
+            <>
+              <span className="separator">&#xB7;</span>
+              <span className="scheduledAt">
+                <strong>{"Scheduled: "}</strong>
+                <span>{FormatUnix(scheduledAt)}</span>
+              </span>
+            </>
+          )}
         </div>
         <div className="meta-line-2">
           <span className="uploader">
@@ -418,6 +470,7 @@ export const MemoizedEntry = memo(
   Entry,
   (prev, next) =>
     prev.chapter.publishedAt === next.chapter.publishedAt &&
+    prev.chapter.scheduledAt === next.chapter.scheduledAt &&
     prev.chapter.updatedAt === next.chapter.updatedAt &&
     prev.chapter.locked === next.chapter.locked
 );
@@ -19,6 +19,7 @@ import {
   ProjectCols as ProjectCol,
   ProjectStatus,
   Rating,
+  ReadingDirection,
   SeriesStatus
 } from "../../../constants";
 import { GetCoverURL, HasPerms } from "../../../utils/utils";
@@ -42,6 +43,7 @@ const createDraft = (data: Project): ProjectDraft => ({
   seriesStatus: data.seriesStatus || SeriesStatus.Ongoing,
   demographic: data.demographic || Demographic.None,
   rating: data.rating || Rating.None,
+  readingDirection: data.readingDirection || ReadingDirection.RightToLeft,
   artists: data.artists?.map(e => e.name) || [],
   authors: data.authors?.map(e => e.name) || [],
   tags: data.tags?.map(e => e.name) || []
@@ -280,7 +282,7 @@ const Editor = ({ initialData: project, isNew, ...props }: EditorProps) => {
   const { description } = draftRef.current;
 
   const { projectStatus, seriesStatus, demographic } = draftRef.current;
-  const { rating, artists, authors, tags } = draftRef.current;
+  const { rating, readingDirection, artists, authors, tags } = draftRef.current;
 
   const { markdown, Markdown } = useMarkdown({
     placeholder: "Series description",
@@ -782,6 +784,22 @@ const Editor = ({ initialData: project, isNew, ...props }: EditorProps) => {
               ))}
             </div>
           </div>
+          <div className="readingDirection">
+            <b>Reading Direction</b>
+            <div className="buttonGroups">
+              {Object.keys(ReadingDirection).map(k => (
+                <button
+                  className="button"
+                  type="button"
+                  data-active={readingDirection === ReadingDirection[k] || undefined}
+                  onClick={() => setColumn(ProjectCol.ReadingDirection, ReadingDirection[k])}
+                  key={`readingDirection-${k}`}
+                >
+                  <strong>{k}</strong>
+                </button>
+              ))}
+            </div>
+          </div>
         </section>
       </div>
     </div>
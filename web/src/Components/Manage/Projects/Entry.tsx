@@ -1,7 +1,15 @@
-import React, { memo, useContext, useRef } from "react";
-import { Check, Edit, ExternalLink, Eye, EyeOff, Lock, Trash, Unlock, Upload, X } from "react-feather";
+import React, { memo, useContext, useRef, useState } from "react";
+import { Check, Clock, Edit, ExternalLink, Eye, EyeOff, Lock, Trash, Unlock, Upload, X } from "react-feather";
 import { Link, useHistory } from "react-router-dom";
-import { DeleteProject, LockProject, PublishProject, UnlockProject, UnpublishProject } from "../../../api";
+import {
+  DeleteProject,
+  LockProject,
+  PublishProject,
+  ScheduleProject,
+  UnlockProject,
+  UnpublishProject,
+  UnscheduleProject
+} from "../../../api";
 import { Permission } from "../../../constants";
 import { FormatUnix, GetCoverURL, HasPerms } from "../../../utils/utils";
 import { useMounted } from "../../Hooks";
@@ -28,8 +36,10 @@ const PublishStateModal = ({ entriesRef, isDeletedRef, project, render }: ModalP
   const mountedRef = useMounted();
   const mutex = useRef(false);
 
+  const [scheduledAt, setScheduledAt] = useState("");
+
   const setIsChangingPublishStateRef = useRef<Dispatcher<boolean>>();
-  const changePublishStateCallback = async () => {
+  const changePublishStateCallback = async (unschedule = false) => {
     if (!mountedRef.current || mutex.current || project.locked || isDeletedRef.current) {
       return;
     }
@@ -37,22 +47,39 @@ const PublishStateModal = ({ entriesRef, isDeletedRef, project, render }: ModalP
     mutex.current = true;
 
     let result: ApiResult<Project>;
-    if (project.publishedAt) result = await UnpublishProject(project.id);
-    else result = await PublishProject(project.id);
+    let action: string;
+    if (unschedule) {
+      result = await UnscheduleProject(project.id);
+      action = "unscheduled";
+    } else if (project.publishedAt) {
+      result = await UnpublishProject(project.id);
+      action = "unpublished";
+    } else if (scheduledAt) {
+      const unix = Math.floor(new Date(scheduledAt).getTime() / 1000);
+      result = await ScheduleProject(project.id, unix);
+      action = `scheduled for ${FormatUnix(unix)}`;
+    } else {
+      result = await PublishProject(project.id);
+      action = "published";
+    }
     if (!mountedRef.current) return;
 
     const { response, error } = result;
     if (response) {
       const idx = entriesRef.current.findIndex(e => e.id === project.id);
       if (idx >= 0) {
-        entriesRef.current[idx] = { ...entriesRef.current[idx], publishedAt: response.publishedAt };
+        entriesRef.current[idx] = {
+          ...entriesRef.current[idx],
+          publishedAt: response.publishedAt,
+          scheduledAt: response.scheduledAt
+        };
         Object.assign(entriesRef.current[idx], response);
         render();
       }
 
       toast.show(
         <>
-          Project &apos;<b>{project.title}</b>&apos; has been {project.publishedAt ? "unpublished" : "published"}.
+          Project &apos;<b>{project.title}</b>&apos; has been {action}.
         </>
       );
     }
@@ -71,16 +98,32 @@ const PublishStateModal = ({ entriesRef, isDeletedRef, project, render }: ModalP
       {project.publishedAt && (
         <small>Chapters will still be visible to the public. This action can be undone anytime.</small>
       )}
+      {!project.publishedAt && (
+        <label className="schedule">
+          <small>
+            {project.scheduledAt
+              ? `Scheduled for ${FormatUnix(project.scheduledAt)}. Pick another time to reschedule, or leave empty to publish now.`
+              : "Optionally pick a time to publish it later, or leave empty to publish now."}
+          </small>
+          <input type="datetime-local" value={scheduledAt} onChange={e => setScheduledAt(e.target.value)} />
+        </label>
+      )}
       <div className="actions">
         <button className="cancel" type="button" onClick={modal.hide}>
           <X width="16" height="16" strokeWidth="3" />
           <strong>Cancel</strong>
         </button>
-        <button className="confirm" type="button" onClick={changePublishStateCallback}>
+        {!project.publishedAt && project.scheduledAt && (
+          <button className="cancel" type="button" onClick={() => changePublishStateCallback(true)}>
+            <Clock width="16" height="16" strokeWidth="3" />
+            <strong>Unschedule</strong>
+          </button>
+        )}
+        <button className="confirm" type="button" onClick={() => changePublishStateCallback()}>
           <WithSpinner width="16" height="16" dispatcherRef={setIsChangingPublishStateRef}>
             <Check width="16" height="16" strokeWidth="3" />
           </WithSpinner>
-          <strong>Confirm</strong>
+          <strong>{scheduledAt ? "Schedule" : "Confirm"}</strong>
         </button>
       </div>
     </div>
@@ -202,7 +245,8 @@ const DeleteModal = ({ isDeletedRef, project, render }: ModalProps) => {
         Are you sure you want to delete &apos;<b>{project.title}</b>&apos;?
       </p>
       <small>
-        This project will be removed permanently, including its covers and chapters. This action cannot be undone.
+        This project will be moved to the trash, including its covers and chapters, and removed permanently once the
+        retention period is over.
       </small>
       <div className="actions">
         <button className="cancel" type="button" onClick={modal.hide}>
@@ -232,7 +276,8 @@ export const Entry = ({
   const modal = useModal();
 
   const { user } = useContext(ManageContext);
-  const { id, slug, title, locked, cover, artists, authors, createdAt, updatedAt, publishedAt } = project;
+  const { id, slug, title, locked, cover, artists, authors } = project;
+  const { createdAt, updatedAt, publishedAt, scheduledAt } = project;
 
   const isDeletedRef = useRef(false);
   const modalProps = { entriesRef, isDeletedRef, project, render };
@@ -284,7 +329,7 @@ export const Entry = ({
       </div>
       <div className="metadata">
         <h3 className="title">
-          {!publishedAt && <span className="status">Draft</span>}
+          {!publishedAt && <span className="status">{scheduledAt ? "Scheduled" : "Draft"}</span>}
           {locked && <span className="status">Locked</span>}
           <Link to={`/chapters?project=${id}`}>{title}</Link>
         </h3>
@@ -307,6 +352,15 @@ export const Entry = ({
               </span>
             </>
           )}
+          {!publishedAt && scheduledAt && (
+            <>
+              <span className="separator">&#xB7;</span>
+              <span className="scheduledAt">
+                <strong>{"Scheduled: "}</strong>
+                <span>{FormatUnix(scheduledAt)}</span>
+              </span>
+            </>
+          )}
         </div>
         <div className="meta-line-2">
           <span className="artists">
@@ -433,6 +487,7 @@ export const MemoizedEntry = memo(
   Entry,
   (prev, next) =>
     prev.project.publishedAt === next.project.publishedAt &&
+    prev.project.scheduledAt === next.project.scheduledAt &&
     prev.project.updatedAt === next.project.updatedAt &&
     prev.project.locked === next.project.locked
 );
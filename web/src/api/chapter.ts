@@ -82,9 +82,14 @@ export const LockChapter = (id: number) => SendRequest<Chapter>("PATCH", `/api/c
 
 export const PublishChapter = (id: number) => SendRequest<Chapter>("PATCH", `/api/chapter/${id}/publish`);
 
+export const ScheduleChapter = (id: number, scheduledAt: number) =>
+  SendRequest<Chapter>("PATCH", `/api/chapter/${id}/schedule`, JSON.stringify({ scheduledAt }));
+
 export const UnlockChapter = (id: number) => SendRequest<Chapter>("PATCH", `/api/chapter/${id}/unlock`);
 
 export const UnpublishChapter = (id: number) => SendRequest<Chapter>("PATCH", `/api/chapter/${id}/unpublish`);
 
+export const UnscheduleChapter = (id: number) => SendRequest<Chapter>("PATCH", `/api/chapter/${id}/unschedule`);
+
 export const UpdateChapter = (id: number, draft: ChapterDraft) =>
   SendRequest<Chapter>("PATCH", `/api/chapter/${id}`, JSON.stringify(draft));
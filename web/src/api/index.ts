@@ -8,8 +8,10 @@ export {
   GetChaptersByProject,
   LockChapter,
   PublishChapter,
+  ScheduleChapter,
   UnlockChapter,
   UnpublishChapter,
+  UnscheduleChapter,
   UpdateChapter
 } from "./chapter";
 export { DeletePage, GetPages, GetPagesMd, UploadPage } from "./chapter_page";
@@ -36,8 +38,10 @@ export {
   GetProjects,
   LockProject,
   PublishProject,
+  ScheduleProject,
   UnlockProject,
   UnpublishProject,
+  UnscheduleProject,
   UpdateProject
 } from "./project";
 export { DeleteCover, GetCover, GetCovers, SetCover, UploadCover } from "./project_cover";
@@ -107,9 +107,14 @@ export const LockProject = (id: number) => SendRequest<Project>("PATCH", `/api/p
 
 export const PublishProject = (id: number) => SendRequest<Project>("PATCH", `/api/project/${id}/publish`);
 
+export const ScheduleProject = (id: number, scheduledAt: number) =>
+  SendRequest<Project>("PATCH", `/api/project/${id}/schedule`, JSON.stringify({ scheduledAt }));
+
 export const UnlockProject = (id: number) => SendRequest<Project>("PATCH", `/api/project/${id}/unlock`);
 
 export const UnpublishProject = (id: number) => SendRequest<Project>("PATCH", `/api/project/${id}/unpublish`);
 
+export const UnscheduleProject = (id: number) => SendRequest<Project>("PATCH", `/api/project/${id}/unschedule`);
+
 export const UpdateProject = (id: number, draft: ProjectDraft) =>
   SendRequest<Project>("PATCH", `/api/project/${id}`, JSON.stringify(draft));
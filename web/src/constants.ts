@@ -5,7 +5,8 @@ export enum ProjectCols {
   ProjectStatus = "projectStatus",
   SeriesStatus = "seriesStatus",
   Demographic = "demographic",
-  Rating = "rating"
+  Rating = "rating",
+  ReadingDirection = "readingDirection"
 }
 
 export const ProjectColsKeys = Object.keys(ProjectCols);
@@ -80,6 +81,14 @@ export enum Rating {
 export const RatingKeys = Object.keys(Rating);
 export const RatingValues = Object.values(Rating);
 
+export enum ReadingDirection {
+  LeftToRight = "ltr",
+  RightToLeft = "rtl"
+}
+
+export const ReadingDirectionKeys = Object.keys(ReadingDirection);
+export const ReadingDirectionValues = Object.values(ReadingDirection);
+
 export enum SeriesStatus {
   Ongoing = "ongoing",
   Completed = "completed",